@@ -0,0 +1,463 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/quintilesims/layer0/api/backend"
+	"github.com/quintilesims/layer0/api/backend/ecs/id"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/waitutils"
+)
+
+const (
+	certificateCategory  = "certificate"
+	deployCategory       = "deploy"
+	environmentCategory  = "environment"
+	loadBalancerCategory = "load_balancer"
+	serviceCategory      = "service"
+	taskCategory         = "task"
+)
+
+var categories = []string{
+	certificateCategory,
+	deployCategory,
+	environmentCategory,
+	loadBalancerCategory,
+	serviceCategory,
+	taskCategory,
+}
+
+// page is how the results of List*Page calls are cached
+type page struct {
+	Items     interface{}
+	NextToken string
+}
+
+type entry struct {
+	category string
+	data     []byte
+	expires  time.Time
+}
+
+// CachedBackend caches the results of read calls to a backend.Backend for TTL.
+// Mutating calls invalidate the cached results of every read in the categories they affect, so
+// changes made through the same backend are visible right away; changes made elsewhere
+// (e.g. by job runners or in the AWS console) are visible once the cached results expire.
+// Results are stored as json so callers can modify the models they receive.
+type CachedBackend struct {
+	backend.Backend
+	TTL         time.Duration
+	Clock       waitutils.Clock
+	mutex       sync.Mutex
+	entries     map[string]entry
+	generations map[string]int64
+	stats       models.BackendCacheStats
+}
+
+func NewCachedBackend(inner backend.Backend, ttl time.Duration) *CachedBackend {
+	return &CachedBackend{
+		Backend:     inner,
+		TTL:         ttl,
+		Clock:       waitutils.RealClock{},
+		entries:     map[string]entry{},
+		generations: map[string]int64{},
+		stats: models.BackendCacheStats{
+			Enabled: true,
+			TTL:     ttl.String(),
+			Methods: map[string]models.BackendCacheMethodStats{},
+		},
+	}
+}
+
+// Stats returns the hit, miss, and invalidation counts of the cache
+func (c *CachedBackend) Stats() models.BackendCacheStats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.removeExpired()
+
+	stats := c.stats
+	stats.Entries = len(c.entries)
+	stats.Methods = make(map[string]models.BackendCacheMethodStats, len(c.stats.Methods))
+	for method, methodStats := range c.stats.Methods {
+		stats.Methods[method] = methodStats
+	}
+
+	return stats
+}
+
+// Flush removes every cached result
+func (c *CachedBackend) Flush() {
+	c.invalidate(categories...)
+}
+
+// read stores the cached result of method with args in receive,
+// or calls fn to get the result and caches it if no unexpired result exists
+func (c *CachedBackend) read(category, method string, receive interface{}, fn func() (interface{}, error), args ...string) error {
+	key := strings.Join(append([]string{method}, args...), "/")
+
+	c.mutex.Lock()
+	methodStats := c.stats.Methods[method]
+	if e, ok := c.entries[key]; ok && c.Clock.Now().Before(e.expires) {
+		methodStats.Hits++
+		c.stats.Hits++
+		c.stats.Methods[method] = methodStats
+		c.mutex.Unlock()
+
+		return json.Unmarshal(e.data, receive)
+	}
+
+	methodStats.Misses++
+	c.stats.Misses++
+	c.stats.Methods[method] = methodStats
+	generation := c.generations[category]
+	c.mutex.Unlock()
+
+	result, err := fn()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("Failed to cache result of %s: %v", method, err)
+	}
+
+	c.mutex.Lock()
+	// don't cache the result if the category was invalidated while fn was running, since it may be stale
+	if c.generations[category] == generation {
+		c.entries[key] = entry{
+			category: category,
+			data:     data,
+			expires:  c.Clock.Now().Add(c.TTL),
+		}
+	}
+	c.mutex.Unlock()
+
+	return json.Unmarshal(data, receive)
+}
+
+func (c *CachedBackend) invalidate(categories ...string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, category := range categories {
+		c.generations[category]++
+		c.stats.Invalidations++
+
+		for key, e := range c.entries {
+			if e.category == category {
+				delete(c.entries, key)
+			}
+		}
+	}
+}
+
+func (c *CachedBackend) removeExpired() {
+	now := c.Clock.Now()
+	for key, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func (c *CachedBackend) CreateEnvironment(environmentName, instanceSize, operatingSystem, amiID string, minClusterCount int, userData []byte, spotPrice string, mixedInstancesPolicy *models.MixedInstancesPolicy) (*models.Environment, error) {
+	defer c.invalidate(environmentCategory)
+	return c.Backend.CreateEnvironment(environmentName, instanceSize, operatingSystem, amiID, minClusterCount, userData, spotPrice, mixedInstancesPolicy)
+}
+
+func (c *CachedBackend) UpdateEnvironment(environmentID string, minClusterCount int) (*models.Environment, error) {
+	defer c.invalidate(environmentCategory)
+	return c.Backend.UpdateEnvironment(environmentID, minClusterCount)
+}
+
+func (c *CachedBackend) DeleteEnvironment(environmentID string) error {
+	defer c.invalidate(environmentCategory, loadBalancerCategory, serviceCategory, taskCategory)
+	return c.Backend.DeleteEnvironment(environmentID)
+}
+
+func (c *CachedBackend) GetEnvironment(environmentID string) (*models.Environment, error) {
+	var environment *models.Environment
+	fn := func() (interface{}, error) { return c.Backend.GetEnvironment(environmentID) }
+	if err := c.read(environmentCategory, "GetEnvironment", &environment, fn, environmentID); err != nil {
+		return nil, err
+	}
+
+	return environment, nil
+}
+
+func (c *CachedBackend) ListEnvironments() ([]id.ECSEnvironmentID, error) {
+	var environmentIDs []id.ECSEnvironmentID
+	fn := func() (interface{}, error) { return c.Backend.ListEnvironments() }
+	if err := c.read(environmentCategory, "ListEnvironments", &environmentIDs, fn); err != nil {
+		return nil, err
+	}
+
+	return environmentIDs, nil
+}
+
+func (c *CachedBackend) ListEnvironmentsPage(limit int, nextToken string) ([]id.ECSEnvironmentID, string, error) {
+	var environmentIDs []id.ECSEnvironmentID
+	result := page{Items: &environmentIDs}
+	fn := func() (interface{}, error) {
+		items, next, err := c.Backend.ListEnvironmentsPage(limit, nextToken)
+		return page{Items: items, NextToken: next}, err
+	}
+
+	if err := c.read(environmentCategory, "ListEnvironmentsPage", &result, fn, strconv.Itoa(limit), nextToken); err != nil {
+		return nil, "", err
+	}
+
+	return environmentIDs, result.NextToken, nil
+}
+
+func (c *CachedBackend) CreateEnvironmentLink(link models.EnvironmentLink) error {
+	defer c.invalidate(environmentCategory)
+	return c.Backend.CreateEnvironmentLink(link)
+}
+
+func (c *CachedBackend) DeleteEnvironmentLink(link models.EnvironmentLink) error {
+	defer c.invalidate(environmentCategory)
+	return c.Backend.DeleteEnvironmentLink(link)
+}
+
+func (c *CachedBackend) AuthorizeEnvironmentIngress(environmentID string, rule models.EnvironmentIngressRule) error {
+	defer c.invalidate(environmentCategory)
+	return c.Backend.AuthorizeEnvironmentIngress(environmentID, rule)
+}
+
+func (c *CachedBackend) RevokeEnvironmentIngress(environmentID string, rule models.EnvironmentIngressRule) error {
+	defer c.invalidate(environmentCategory)
+	return c.Backend.RevokeEnvironmentIngress(environmentID, rule)
+}
+
+func (c *CachedBackend) ListDeploys() ([]*models.Deploy, error) {
+	var deploys []*models.Deploy
+	fn := func() (interface{}, error) { return c.Backend.ListDeploys() }
+	if err := c.read(deployCategory, "ListDeploys", &deploys, fn); err != nil {
+		return nil, err
+	}
+
+	return deploys, nil
+}
+
+func (c *CachedBackend) GetDeploy(deployID string) (*models.Deploy, error) {
+	var deploy *models.Deploy
+	fn := func() (interface{}, error) { return c.Backend.GetDeploy(deployID) }
+	if err := c.read(deployCategory, "GetDeploy", &deploy, fn, deployID); err != nil {
+		return nil, err
+	}
+
+	return deploy, nil
+}
+
+func (c *CachedBackend) CreateDeploy(name string, body []byte) (*models.Deploy, error) {
+	defer c.invalidate(deployCategory)
+	return c.Backend.CreateDeploy(name, body)
+}
+
+func (c *CachedBackend) DeleteDeploy(deployID string) error {
+	defer c.invalidate(deployCategory)
+	return c.Backend.DeleteDeploy(deployID)
+}
+
+func (c *CachedBackend) ListServices() ([]id.ECSServiceID, error) {
+	var serviceIDs []id.ECSServiceID
+	fn := func() (interface{}, error) { return c.Backend.ListServices() }
+	if err := c.read(serviceCategory, "ListServices", &serviceIDs, fn); err != nil {
+		return nil, err
+	}
+
+	return serviceIDs, nil
+}
+
+func (c *CachedBackend) ListServicesPage(limit int, nextToken string) ([]id.ECSServiceID, string, error) {
+	var serviceIDs []id.ECSServiceID
+	result := page{Items: &serviceIDs}
+	fn := func() (interface{}, error) {
+		items, next, err := c.Backend.ListServicesPage(limit, nextToken)
+		return page{Items: items, NextToken: next}, err
+	}
+
+	if err := c.read(serviceCategory, "ListServicesPage", &result, fn, strconv.Itoa(limit), nextToken); err != nil {
+		return nil, "", err
+	}
+
+	return serviceIDs, result.NextToken, nil
+}
+
+func (c *CachedBackend) GetService(environmentID, serviceID string) (*models.Service, error) {
+	var service *models.Service
+	fn := func() (interface{}, error) { return c.Backend.GetService(environmentID, serviceID) }
+	if err := c.read(serviceCategory, "GetService", &service, fn, environmentID, serviceID); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
+func (c *CachedBackend) GetEnvironmentServices(environmentID string) ([]*models.Service, error) {
+	var services []*models.Service
+	fn := func() (interface{}, error) { return c.Backend.GetEnvironmentServices(environmentID) }
+	if err := c.read(serviceCategory, "GetEnvironmentServices", &services, fn, environmentID); err != nil {
+		return nil, err
+	}
+
+	return services, nil
+}
+
+func (c *CachedBackend) CreateService(serviceName, environmentID, deployID, loadBalancerID string) (*models.Service, error) {
+	defer c.invalidate(serviceCategory, loadBalancerCategory)
+	return c.Backend.CreateService(serviceName, environmentID, deployID, loadBalancerID)
+}
+
+func (c *CachedBackend) DeleteService(environmentID, serviceID string) error {
+	defer c.invalidate(serviceCategory, loadBalancerCategory)
+	return c.Backend.DeleteService(environmentID, serviceID)
+}
+
+func (c *CachedBackend) ScaleService(environmentID, serviceID string, count int) (*models.Service, error) {
+	defer c.invalidate(serviceCategory)
+	return c.Backend.ScaleService(environmentID, serviceID, count)
+}
+
+func (c *CachedBackend) UpdateService(environmentID, serviceID, deployID string) (*models.Service, error) {
+	defer c.invalidate(serviceCategory)
+	return c.Backend.UpdateService(environmentID, serviceID, deployID)
+}
+
+func (c *CachedBackend) CreateTask(environmentID, deployID string, overrides []models.ContainerOverride) (string, error) {
+	defer c.invalidate(taskCategory)
+	return c.Backend.CreateTask(environmentID, deployID, overrides)
+}
+
+func (c *CachedBackend) ListTasks() ([]string, error) {
+	var taskARNs []string
+	fn := func() (interface{}, error) { return c.Backend.ListTasks() }
+	if err := c.read(taskCategory, "ListTasks", &taskARNs, fn); err != nil {
+		return nil, err
+	}
+
+	return taskARNs, nil
+}
+
+func (c *CachedBackend) ListTasksPage(limit int, nextToken string) ([]string, string, error) {
+	var taskARNs []string
+	result := page{Items: &taskARNs}
+	fn := func() (interface{}, error) {
+		items, next, err := c.Backend.ListTasksPage(limit, nextToken)
+		return page{Items: items, NextToken: next}, err
+	}
+
+	if err := c.read(taskCategory, "ListTasksPage", &result, fn, strconv.Itoa(limit), nextToken); err != nil {
+		return nil, "", err
+	}
+
+	return taskARNs, result.NextToken, nil
+}
+
+func (c *CachedBackend) GetTask(environmentID, taskARN string) (*models.Task, error) {
+	var task *models.Task
+	fn := func() (interface{}, error) { return c.Backend.GetTask(environmentID, taskARN) }
+	if err := c.read(taskCategory, "GetTask", &task, fn, environmentID, taskARN); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+func (c *CachedBackend) GetEnvironmentTasks(environmentID string) (map[string]*models.Task, error) {
+	var tasks map[string]*models.Task
+	fn := func() (interface{}, error) { return c.Backend.GetEnvironmentTasks(environmentID) }
+	if err := c.read(taskCategory, "GetEnvironmentTasks", &tasks, fn, environmentID); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+func (c *CachedBackend) DeleteTask(environmentID, taskARN string) error {
+	defer c.invalidate(taskCategory)
+	return c.Backend.DeleteTask(environmentID, taskARN)
+}
+
+func (c *CachedBackend) ListLoadBalancers() ([]*models.LoadBalancer, error) {
+	var loadBalancers []*models.LoadBalancer
+	fn := func() (interface{}, error) { return c.Backend.ListLoadBalancers() }
+	if err := c.read(loadBalancerCategory, "ListLoadBalancers", &loadBalancers, fn); err != nil {
+		return nil, err
+	}
+
+	return loadBalancers, nil
+}
+
+func (c *CachedBackend) GetLoadBalancer(loadBalancerID string) (*models.LoadBalancer, error) {
+	var loadBalancer *models.LoadBalancer
+	fn := func() (interface{}, error) { return c.Backend.GetLoadBalancer(loadBalancerID) }
+	if err := c.read(loadBalancerCategory, "GetLoadBalancer", &loadBalancer, fn, loadBalancerID); err != nil {
+		return nil, err
+	}
+
+	return loadBalancer, nil
+}
+
+func (c *CachedBackend) DeleteLoadBalancer(loadBalancerID string) error {
+	defer c.invalidate(loadBalancerCategory)
+	return c.Backend.DeleteLoadBalancer(loadBalancerID)
+}
+
+func (c *CachedBackend) CreateLoadBalancer(loadBalancerName, environmentID string, isPublic bool, ports []models.Port, healthCheck models.HealthCheck, idleTimeout int, crossZone bool) (*models.LoadBalancer, error) {
+	defer c.invalidate(loadBalancerCategory)
+	return c.Backend.CreateLoadBalancer(loadBalancerName, environmentID, isPublic, ports, healthCheck, idleTimeout, crossZone)
+}
+
+func (c *CachedBackend) UpdateLoadBalancerPorts(loadBalancerID string, ports []models.Port) (*models.LoadBalancer, error) {
+	defer c.invalidate(loadBalancerCategory)
+	return c.Backend.UpdateLoadBalancerPorts(loadBalancerID, ports)
+}
+
+func (c *CachedBackend) UpdateLoadBalancerHealthCheck(loadBalancerID string, healthCheck models.HealthCheck) (*models.LoadBalancer, error) {
+	defer c.invalidate(loadBalancerCategory)
+	return c.Backend.UpdateLoadBalancerHealthCheck(loadBalancerID, healthCheck)
+}
+
+func (c *CachedBackend) UpdateLoadBalancerIdleTimeout(loadBalancerID string, idleTimeout int) (*models.LoadBalancer, error) {
+	defer c.invalidate(loadBalancerCategory)
+	return c.Backend.UpdateLoadBalancerIdleTimeout(loadBalancerID, idleTimeout)
+}
+
+func (c *CachedBackend) UpdateLoadBalancerCrossZone(loadBalancerID string, crossZone bool) (*models.LoadBalancer, error) {
+	defer c.invalidate(loadBalancerCategory)
+	return c.Backend.UpdateLoadBalancerCrossZone(loadBalancerID, crossZone)
+}
+
+func (c *CachedBackend) UpdateLoadBalancerAccessLog(loadBalancerID string, accessLog models.AccessLog) (*models.LoadBalancer, error) {
+	defer c.invalidate(loadBalancerCategory)
+	return c.Backend.UpdateLoadBalancerAccessLog(loadBalancerID, accessLog)
+}
+
+func (c *CachedBackend) CreateLoadBalancerDNSRecord(loadBalancerID, dnsName string) error {
+	defer c.invalidate(loadBalancerCategory)
+	return c.Backend.CreateLoadBalancerDNSRecord(loadBalancerID, dnsName)
+}
+
+func (c *CachedBackend) DeleteLoadBalancerDNSRecord(loadBalancerID, dnsName string) error {
+	defer c.invalidate(loadBalancerCategory)
+	return c.Backend.DeleteLoadBalancerDNSRecord(loadBalancerID, dnsName)
+}
+
+func (c *CachedBackend) ListCertificates() ([]*models.Certificate, error) {
+	var certificates []*models.Certificate
+	fn := func() (interface{}, error) { return c.Backend.ListCertificates() }
+	if err := c.read(certificateCategory, "ListCertificates", &certificates, fn); err != nil {
+		return nil, err
+	}
+
+	return certificates, nil
+}
@@ -0,0 +1,206 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/quintilesims/layer0/api/backend/mock_backend"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func newTestCachedBackend(t *testing.T) (*CachedBackend, *mock_backend.MockBackend, *gomock.Controller) {
+	ctrl := gomock.NewController(t)
+	mockBackend := mock_backend.NewMockBackend(ctrl)
+
+	cached := NewCachedBackend(mockBackend, time.Minute)
+	cached.Clock = &testutils.StubClock{}
+
+	return cached, mockBackend, ctrl
+}
+
+func TestCachedBackend_hit(t *testing.T) {
+	cached, mockBackend, ctrl := newTestCachedBackend(t)
+	defer ctrl.Finish()
+
+	mockBackend.EXPECT().
+		GetService("e1", "s1").
+		Return(&models.Service{ServiceID: "s1", DesiredCount: 1}, nil).
+		Times(1)
+
+	for i := 0; i < 3; i++ {
+		service, err := cached.GetService("e1", "s1")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testutils.AssertEqual(t, service.ServiceID, "s1")
+		testutils.AssertEqual(t, service.DesiredCount, int64(1))
+
+		// callers may modify the models they receive without changing the cached result
+		service.DesiredCount = 5
+	}
+
+	stats := cached.Stats()
+	testutils.AssertEqual(t, stats.Hits, int64(2))
+	testutils.AssertEqual(t, stats.Misses, int64(1))
+	testutils.AssertEqual(t, stats.Entries, 1)
+	testutils.AssertEqual(t, stats.Methods["GetService"], models.BackendCacheMethodStats{Hits: 2, Misses: 1})
+}
+
+func TestCachedBackend_keysIncludeArgs(t *testing.T) {
+	cached, mockBackend, ctrl := newTestCachedBackend(t)
+	defer ctrl.Finish()
+
+	mockBackend.EXPECT().
+		GetLoadBalancer("l1").
+		Return(&models.LoadBalancer{LoadBalancerID: "l1"}, nil)
+
+	mockBackend.EXPECT().
+		GetLoadBalancer("l2").
+		Return(&models.LoadBalancer{LoadBalancerID: "l2"}, nil)
+
+	for _, id := range []string{"l1", "l2", "l1", "l2"} {
+		loadBalancer, err := cached.GetLoadBalancer(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testutils.AssertEqual(t, loadBalancer.LoadBalancerID, id)
+	}
+}
+
+func TestCachedBackend_expires(t *testing.T) {
+	cached, mockBackend, ctrl := newTestCachedBackend(t)
+	defer ctrl.Finish()
+
+	mockBackend.EXPECT().
+		ListTasks().
+		Return([]string{"arn1"}, nil).
+		Times(2)
+
+	if _, err := cached.ListTasks(); err != nil {
+		t.Fatal(err)
+	}
+
+	cached.Clock.Sleep(time.Minute)
+
+	if _, err := cached.ListTasks(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCachedBackend_errorsAreNotCached(t *testing.T) {
+	cached, mockBackend, ctrl := newTestCachedBackend(t)
+	defer ctrl.Finish()
+
+	mockBackend.EXPECT().
+		ListCertificates().
+		Return(nil, fmt.Errorf("some error"))
+
+	mockBackend.EXPECT().
+		ListCertificates().
+		Return([]*models.Certificate{{CertificateID: "c1"}}, nil)
+
+	if _, err := cached.ListCertificates(); err == nil {
+		t.Fatal("error was unexpectedly nil")
+	}
+
+	certificates, err := cached.ListCertificates()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, len(certificates), 1)
+}
+
+func TestCachedBackend_mutationsInvalidate(t *testing.T) {
+	cached, mockBackend, ctrl := newTestCachedBackend(t)
+	defer ctrl.Finish()
+
+	mockBackend.EXPECT().
+		GetService("e1", "s1").
+		Return(&models.Service{ServiceID: "s1", DesiredCount: 1}, nil)
+
+	mockBackend.EXPECT().
+		GetDeploy("d1").
+		Return(&models.Deploy{DeployID: "d1"}, nil)
+
+	mockBackend.EXPECT().
+		ScaleService("e1", "s1", 2).
+		Return(&models.Service{ServiceID: "s1", DesiredCount: 2}, nil)
+
+	mockBackend.EXPECT().
+		GetService("e1", "s1").
+		Return(&models.Service{ServiceID: "s1", DesiredCount: 2}, nil)
+
+	if _, err := cached.GetService("e1", "s1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := cached.GetDeploy("d1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := cached.ScaleService("e1", "s1", 2); err != nil {
+		t.Fatal(err)
+	}
+
+	service, err := cached.GetService("e1", "s1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, service.DesiredCount, int64(2))
+
+	// results in other categories are still cached
+	if _, err := cached.GetDeploy("d1"); err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, cached.Stats().Invalidations, int64(1))
+}
+
+func TestCachedBackend_staleReadIsNotCached(t *testing.T) {
+	cached, mockBackend, ctrl := newTestCachedBackend(t)
+	defer ctrl.Finish()
+
+	// the environment is deleted while the first read is in flight
+	mockBackend.EXPECT().
+		ListEnvironments().
+		Do(func() { cached.invalidate(environmentCategory) }).
+		Return(nil, nil)
+
+	mockBackend.EXPECT().
+		ListEnvironments().
+		Return(nil, nil)
+
+	for i := 0; i < 2; i++ {
+		if _, err := cached.ListEnvironments(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCachedBackend_flush(t *testing.T) {
+	cached, mockBackend, ctrl := newTestCachedBackend(t)
+	defer ctrl.Finish()
+
+	mockBackend.EXPECT().
+		ListDeploys().
+		Return([]*models.Deploy{{DeployID: "d1"}}, nil).
+		Times(2)
+
+	if _, err := cached.ListDeploys(); err != nil {
+		t.Fatal(err)
+	}
+
+	cached.Flush()
+	testutils.AssertEqual(t, cached.Stats().Entries, 0)
+
+	if _, err := cached.ListDeploys(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,12 +1,15 @@
 package ecsbackend
 
 import (
+	"github.com/quintilesims/layer0/common/aws/acm"
 	"github.com/quintilesims/layer0/common/aws/autoscaling"
+	"github.com/quintilesims/layer0/common/aws/cloudwatch"
 	"github.com/quintilesims/layer0/common/aws/cloudwatchlogs"
 	"github.com/quintilesims/layer0/common/aws/ec2"
 	"github.com/quintilesims/layer0/common/aws/ecs"
 	"github.com/quintilesims/layer0/common/aws/elb"
 	"github.com/quintilesims/layer0/common/aws/iam"
+	"github.com/quintilesims/layer0/common/aws/route53"
 	"github.com/quintilesims/layer0/common/aws/s3"
 	"github.com/quintilesims/layer0/common/db/tag_store"
 )
@@ -20,6 +23,7 @@ type ECSBackend struct {
 	*ECSDeployManager
 	*ECSLoadBalancerManager
 	*ECSTaskManager
+	*ECSCertificateManager
 }
 
 func NewBackend(
@@ -31,15 +35,19 @@ func NewBackend(
 	elb elb.Provider,
 	autoscaling autoscaling.Provider,
 	cloudWatchLogs cloudwatchlogs.Provider,
+	cloudWatch cloudwatch.Provider,
+	route53 route53.Provider,
+	acm acm.Provider,
 ) *ECSBackend {
 
 	backend := &ECSBackend{}
 
 	backend.ECSEnvironmentManager = NewECSEnvironmentManager(ecs, ec2, autoscaling, backend)
 	backend.ECSServiceManager = NewECSServiceManager(ecs, ec2, cloudWatchLogs, backend)
-	backend.ECSLoadBalancerManager = NewECSLoadBalancerManager(ec2, elb, iam, backend)
+	backend.ECSLoadBalancerManager = NewECSLoadBalancerManager(ec2, elb, iam, acm, cloudWatch, route53, backend)
 	backend.ECSDeployManager = NewECSDeployManager(ecs)
 	backend.ECSTaskManager = NewECSTaskManager(ecs, cloudWatchLogs, backend)
+	backend.ECSCertificateManager = NewECSCertificateManager(iam, acm)
 
 	return backend
 }
@@ -0,0 +1,66 @@
+package ecsbackend
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/quintilesims/layer0/api/backend/ecs/id"
+	"github.com/quintilesims/layer0/common/aws/acm"
+	"github.com/quintilesims/layer0/common/aws/iam"
+	"github.com/quintilesims/layer0/common/models"
+)
+
+const (
+	CERTIFICATE_SOURCE_IAM = "iam"
+	CERTIFICATE_SOURCE_ACM = "acm"
+)
+
+type ECSCertificateManager struct {
+	IAM iam.Provider
+	ACM acm.Provider
+}
+
+func NewECSCertificateManager(iam iam.Provider, acm acm.Provider) *ECSCertificateManager {
+	return &ECSCertificateManager{
+		IAM: iam,
+		ACM: acm,
+	}
+}
+
+func (c *ECSCertificateManager) ListCertificates() ([]*models.Certificate, error) {
+	serverCertificates, err := c.IAM.ListCertificates()
+	if err != nil {
+		return nil, err
+	}
+
+	certificates := []*models.Certificate{}
+	for _, metadata := range serverCertificates {
+		certificate := &models.Certificate{
+			CertificateARN:  aws.StringValue(metadata.Arn),
+			CertificateID:   aws.StringValue(metadata.ServerCertificateId),
+			CertificateName: aws.StringValue(metadata.ServerCertificateName),
+			Expiration:      aws.TimeValue(metadata.Expiration),
+			Source:          CERTIFICATE_SOURCE_IAM,
+		}
+
+		certificates = append(certificates, certificate)
+	}
+
+	summaries, err := c.ACM.ListCertificates()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, summary := range summaries {
+		certificate := &models.Certificate{
+			CertificateARN: aws.StringValue(summary.CertificateArn),
+			CertificateID:  id.CertificateARNToName(aws.StringValue(summary.CertificateArn)),
+			DomainName:     aws.StringValue(summary.DomainName),
+			Expiration:     aws.TimeValue(summary.NotAfter),
+			Source:         CERTIFICATE_SOURCE_ACM,
+			Status:         aws.StringValue(summary.Status),
+		}
+
+		certificates = append(certificates, certificate)
+	}
+
+	return certificates, nil
+}
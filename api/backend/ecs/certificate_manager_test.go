@@ -0,0 +1,90 @@
+package ecsbackend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/golang/mock/gomock"
+	"github.com/quintilesims/layer0/common/aws/acm"
+	"github.com/quintilesims/layer0/common/aws/acm/mock_acm"
+	"github.com/quintilesims/layer0/common/aws/iam"
+	"github.com/quintilesims/layer0/common/aws/iam/mock_iam"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+type MockECSCertificateManager struct {
+	IAM *mock_iam.MockProvider
+	ACM *mock_acm.MockProvider
+}
+
+func NewMockECSCertificateManager(ctrl *gomock.Controller) *MockECSCertificateManager {
+	return &MockECSCertificateManager{
+		IAM: mock_iam.NewMockProvider(ctrl),
+		ACM: mock_acm.NewMockProvider(ctrl),
+	}
+}
+
+func (this *MockECSCertificateManager) Certificate() *ECSCertificateManager {
+	return NewECSCertificateManager(this.IAM, this.ACM)
+}
+
+func TestListCertificates(t *testing.T) {
+	testCases := []testutils.TestCase{
+		{
+			Name: "Should return iam and acm certificates",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockCertificate := NewMockECSCertificateManager(ctrl)
+
+				serverCertificate := iam.NewServerCertificateMetadata("iam-cert", "arn:aws:iam:::server-certificate/iam-cert")
+				serverCertificate.ServerCertificateId = aws.String("ASCAID")
+				serverCertificate.Expiration = aws.Time(time.Unix(1000, 0))
+
+				mockCertificate.IAM.EXPECT().
+					ListCertificates().
+					Return([]*iam.ServerCertificateMetadata{serverCertificate}, nil)
+
+				summary := acm.NewCertificateSummary("arn:aws:acm:::certificate/uuid", "example.com")
+				summary.Status = aws.String("ISSUED")
+				summary.NotAfter = aws.Time(time.Unix(2000, 0))
+
+				mockCertificate.ACM.EXPECT().
+					ListCertificates().
+					Return([]*acm.CertificateSummary{summary}, nil)
+
+				return mockCertificate.Certificate()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSCertificateManager)
+
+				certificates, err := manager.ListCertificates()
+				if err != nil {
+					reporter.Fatal(err)
+				}
+
+				expected := []*models.Certificate{
+					{
+						CertificateARN:  "arn:aws:iam:::server-certificate/iam-cert",
+						CertificateID:   "ASCAID",
+						CertificateName: "iam-cert",
+						Expiration:      time.Unix(1000, 0),
+						Source:          "iam",
+					},
+					{
+						CertificateARN: "arn:aws:acm:::certificate/uuid",
+						CertificateID:  "uuid",
+						DomainName:     "example.com",
+						Expiration:     time.Unix(2000, 0),
+						Source:         "acm",
+						Status:         "ISSUED",
+					},
+				}
+
+				reporter.AssertEqual(certificates, expected)
+			},
+		},
+	}
+
+	testutils.RunTests(t, testCases)
+}
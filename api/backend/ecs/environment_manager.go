@@ -9,7 +9,6 @@ import (
 	"time"
 
 	log "github.com/Sirupsen/logrus"
-	awsec2 "github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/quintilesims/layer0/api/backend"
 	"github.com/quintilesims/layer0/api/backend/ecs/id"
 	"github.com/quintilesims/layer0/common/aws/autoscaling"
@@ -58,6 +57,26 @@ func (e *ECSEnvironmentManager) ListEnvironments() ([]id.ECSEnvironmentID, error
 	return ecsEnvironmentIDs, nil
 }
 
+// ListEnvironmentsPage reads at most limit environments from ecs, starting at the position encoded in nextToken
+func (e *ECSEnvironmentManager) ListEnvironmentsPage(limit int, nextToken string) ([]id.ECSEnvironmentID, string, error) {
+	fn := func(_, _ string, maxResults int64, nextToken *string) ([]string, *string, error) {
+		return e.ECS.ListClusterNamesPage(id.PREFIX, maxResults, nextToken)
+	}
+
+	// clusters are listed from a single source, so there is only one 'cluster' to read from
+	clusterNames, nextToken, err := listClustersPage([]string{""}, []string{""}, limit, nextToken, fn)
+	if err != nil {
+		return nil, "", err
+	}
+
+	ecsEnvironmentIDs := make([]id.ECSEnvironmentID, len(clusterNames))
+	for i, clusterName := range clusterNames {
+		ecsEnvironmentIDs[i] = id.ECSEnvironmentID(clusterName)
+	}
+
+	return ecsEnvironmentIDs, nextToken, nil
+}
+
 func (e *ECSEnvironmentManager) GetEnvironment(environmentID string) (*models.Environment, error) {
 	ecsEnvironmentID := id.L0EnvironmentID(environmentID).ECSEnvironmentID()
 	cluster, err := e.ECS.DescribeCluster(ecsEnvironmentID.String())
@@ -79,6 +98,8 @@ func (e *ECSEnvironmentManager) populateModel(cluster *ecs.Cluster) (*models.Env
 	var clusterCount int
 	var instanceSize string
 	var amiID string
+	var spotPrice string
+	var mixedInstancesPolicy *models.MixedInstancesPolicy
 
 	asg, err := e.describeAutoscalingGroup(ecsEnvironmentID)
 	if err != nil {
@@ -105,6 +126,36 @@ func (e *ECSEnvironmentManager) populateModel(cluster *ecs.Cluster) (*models.Env
 			if launchConfig != nil {
 				instanceSize = *launchConfig.InstanceType
 				amiID = *launchConfig.ImageId
+				spotPrice = pstring(launchConfig.SpotPrice)
+			}
+		}
+
+		if policy := asg.MixedInstancesPolicy; policy != nil {
+			mixedInstancesPolicy = &models.MixedInstancesPolicy{}
+			if distribution := policy.InstancesDistribution; distribution != nil {
+				mixedInstancesPolicy.OnDemandBaseCapacity = int(pint64(distribution.OnDemandBaseCapacity))
+				mixedInstancesPolicy.OnDemandPercentageAboveBase = int(pint64(distribution.OnDemandPercentageAboveBaseCapacity))
+				spotPrice = pstring(distribution.SpotMaxPrice)
+			}
+
+			if policy.LaunchTemplate != nil {
+				for _, override := range policy.LaunchTemplate.Overrides {
+					mixedInstancesPolicy.InstanceTypes = append(mixedInstancesPolicy.InstanceTypes, pstring(override.InstanceType))
+				}
+			}
+
+			launchTemplate, err := e.EC2.DescribeLaunchTemplateData(ecsEnvironmentID.LaunchTemplateName())
+			if err != nil {
+				if ContainsErrMsg(err, "not found") || ContainsErrCode(err, "InvalidLaunchTemplateName.NotFoundException") {
+					log.Errorf("Launch Template for environment '%s' not found", ecsEnvironmentID)
+				} else {
+					return nil, err
+				}
+			}
+
+			if launchTemplate != nil {
+				instanceSize = pstring(launchTemplate.InstanceType)
+				amiID = pstring(launchTemplate.ImageId)
 			}
 		}
 	}
@@ -120,11 +171,13 @@ func (e *ECSEnvironmentManager) populateModel(cluster *ecs.Cluster) (*models.Env
 	}
 
 	model := &models.Environment{
-		EnvironmentID:   ecsEnvironmentID.L0EnvironmentID(),
-		ClusterCount:    clusterCount,
-		InstanceSize:    instanceSize,
-		SecurityGroupID: securityGroupID,
-		AMIID:           amiID,
+		EnvironmentID:        ecsEnvironmentID.L0EnvironmentID(),
+		ClusterCount:         clusterCount,
+		InstanceSize:         instanceSize,
+		SecurityGroupID:      securityGroupID,
+		AMIID:                amiID,
+		SpotPrice:            spotPrice,
+		MixedInstancesPolicy: mixedInstancesPolicy,
 	}
 
 	return model, nil
@@ -147,6 +200,8 @@ func (e *ECSEnvironmentManager) CreateEnvironment(
 	amiID string,
 	minClusterCount int,
 	userDataTemplate []byte,
+	spotPrice string,
+	mixedInstancesPolicy *models.MixedInstancesPolicy,
 ) (*models.Environment, error) {
 
 	var defaultUserDataTemplate []byte
@@ -200,7 +255,6 @@ func (e *ECSEnvironmentManager) CreateEnvironment(
 	securityGroups := []*string{groupID}
 	ecsRole := config.AWSECSInstanceProfile()
 	keyPair := config.AWSKeyPair()
-	launchConfigurationName := ecsEnvironmentID.LaunchConfigurationName()
 	volSizes := map[string]int{}
 	if operatingSystem == "linux" {
 		volSizes["/dev/xvda"] = 8
@@ -208,6 +262,46 @@ func (e *ECSEnvironmentManager) CreateEnvironment(
 		volSizes["/dev/sda1"] = 200
 	}
 
+	maxClusterCount := 0
+	if minClusterCount > 0 {
+		maxClusterCount = minClusterCount
+	}
+
+	// mixed instances policies can only be used with launch templates,
+	// otherwise we use a launch configuration, optionally with a spot price
+	if mixedInstancesPolicy != nil {
+		launchTemplateName := ecsEnvironmentID.LaunchTemplateName()
+		if err := e.EC2.CreateLaunchTemplate(
+			launchTemplateName,
+			serviceAMI,
+			ecsRole,
+			instanceSize,
+			keyPair,
+			userData,
+			securityGroups,
+			volSizes,
+		); err != nil {
+			return nil, err
+		}
+
+		if err := e.AutoScaling.CreateMixedInstancesAutoScalingGroup(
+			ecsEnvironmentID.AutoScalingGroupName(),
+			launchTemplateName,
+			config.AWSPrivateSubnets(),
+			minClusterCount,
+			maxClusterCount,
+			mixedInstancesPolicy.InstanceTypes,
+			mixedInstancesPolicy.OnDemandBaseCapacity,
+			mixedInstancesPolicy.OnDemandPercentageAboveBase,
+			spotPrice,
+		); err != nil {
+			return nil, err
+		}
+
+		return e.populateModel(cluster)
+	}
+
+	launchConfigurationName := ecsEnvironmentID.LaunchConfigurationName()
 	if err := e.AutoScaling.CreateLaunchConfiguration(
 		&launchConfigurationName,
 		&serviceAMI,
@@ -215,17 +309,13 @@ func (e *ECSEnvironmentManager) CreateEnvironment(
 		&instanceSize,
 		&keyPair,
 		&userData,
+		&spotPrice,
 		securityGroups,
 		volSizes,
 	); err != nil {
 		return nil, err
 	}
 
-	maxClusterCount := 0
-	if minClusterCount > 0 {
-		maxClusterCount = minClusterCount
-	}
-
 	if err := e.AutoScaling.CreateAutoScalingGroup(
 		ecsEnvironmentID.AutoScalingGroupName(),
 		launchConfigurationName,
@@ -307,6 +397,13 @@ func (e *ECSEnvironmentManager) DeleteEnvironment(environmentID string) error {
 		return err
 	}
 
+	// environments with a mixed instances policy use a launch template instead of a launch configuration
+	if err := e.EC2.DeleteLaunchTemplate(ecsEnvironmentID.LaunchTemplateName()); err != nil {
+		if !ContainsErrCode(err, "InvalidLaunchTemplateName.NotFoundException") {
+			return err
+		}
+	}
+
 	securityGroup, err := e.EC2.DescribeSecurityGroup(ecsEnvironmentID.SecurityGroupName())
 	if err != nil {
 		return err
@@ -327,9 +424,9 @@ func (e *ECSEnvironmentManager) DeleteEnvironment(environmentID string) error {
 	return nil
 }
 
-func (e *ECSEnvironmentManager) CreateEnvironmentLink(sourceEnvironmentID, destEnvironmentID string) error {
-	sourceECSID := id.L0EnvironmentID(sourceEnvironmentID).ECSEnvironmentID()
-	destECSID := id.L0EnvironmentID(destEnvironmentID).ECSEnvironmentID()
+func (e *ECSEnvironmentManager) CreateEnvironmentLink(link models.EnvironmentLink) error {
+	sourceECSID := id.L0EnvironmentID(link.SourceEnvironmentID).ECSEnvironmentID()
+	destECSID := id.L0EnvironmentID(link.DestEnvironmentID).ECSEnvironmentID()
 
 	sourceGroup, err := e.getEnvironmentSecurityGroup(sourceECSID)
 	if err != nil {
@@ -341,24 +438,20 @@ func (e *ECSEnvironmentManager) CreateEnvironmentLink(sourceEnvironmentID, destE
 		return err
 	}
 
-	if err := e.EC2.AuthorizeSecurityGroupIngressFromGroup(*sourceGroup.GroupId, *destGroup.GroupId); err != nil {
-		if !ContainsErrCode(err, "InvalidPermission.Duplicate") {
-			return err
-		}
-	}
-
-	if err := e.EC2.AuthorizeSecurityGroupIngressFromGroup(*destGroup.GroupId, *sourceGroup.GroupId); err != nil {
-		if !ContainsErrCode(err, "InvalidPermission.Duplicate") {
-			return err
+	for _, ingress := range environmentLinkIngresses(link, *sourceGroup.GroupId, *destGroup.GroupId) {
+		if err := e.EC2.AuthorizeSecurityGroupIngress([]*ec2.SecurityGroupIngress{ingress}); err != nil {
+			if !ContainsErrCode(err, "InvalidPermission.Duplicate") {
+				return err
+			}
 		}
 	}
 
 	return nil
 }
 
-func (e *ECSEnvironmentManager) DeleteEnvironmentLink(sourceEnvironmentID, destEnvironmentID string) error {
-	sourceECSID := id.L0EnvironmentID(sourceEnvironmentID).ECSEnvironmentID()
-	destECSID := id.L0EnvironmentID(destEnvironmentID).ECSEnvironmentID()
+func (e *ECSEnvironmentManager) DeleteEnvironmentLink(link models.EnvironmentLink) error {
+	sourceECSID := id.L0EnvironmentID(link.SourceEnvironmentID).ECSEnvironmentID()
+	destECSID := id.L0EnvironmentID(link.DestEnvironmentID).ECSEnvironmentID()
 
 	sourceGroup, err := e.EC2.DescribeSecurityGroup(sourceECSID.SecurityGroupName())
 	if err != nil {
@@ -380,38 +473,82 @@ func (e *ECSEnvironmentManager) DeleteEnvironmentLink(sourceEnvironmentID, destE
 		return nil
 	}
 
-	removeIngressRule := func(group *ec2.SecurityGroup, groupIDToRemove string) error {
-		for _, permission := range group.IpPermissions {
-			for _, pair := range permission.UserIdGroupPairs {
-				if *pair.GroupId == groupIDToRemove {
-					groupPermission := ec2.IpPermission{
-						&awsec2.IpPermission{
-							IpProtocol:       permission.IpProtocol,
-							UserIdGroupPairs: []*awsec2.UserIdGroupPair{pair},
-						},
-					}
-
-					if err := e.EC2.RevokeSecurityGroupIngressHelper(*group.GroupId, groupPermission); err != nil {
-						return err
-					}
-				}
+	for _, ingress := range environmentLinkIngresses(link, *sourceGroup.GroupId, *destGroup.GroupId) {
+		if err := e.EC2.RevokeSecurityGroupIngress([]*ec2.SecurityGroupIngress{ingress}); err != nil {
+			if !ContainsErrCode(err, "InvalidPermission.NotFound") {
+				return err
 			}
 		}
+	}
 
-		return nil
+	return nil
+}
+
+// environmentLinkIngresses returns the security group rules that make up the link:
+// each port is opened on the destination group to the source group, and,
+// unless the link is one-way, on the source group to the destination group
+func environmentLinkIngresses(link models.EnvironmentLink, sourceGroupID, destGroupID string) []*ec2.SecurityGroupIngress {
+	ports := link.Ports
+	if len(ports) == 0 {
+		ports = []models.EnvironmentLinkPort{{Protocol: ec2.ALL_PROTOCOLS}}
+	}
+
+	ingresses := []*ec2.SecurityGroupIngress{}
+	for _, port := range ports {
+		ingress := ec2.NewSecurityGroupIngressFromGroup(destGroupID, sourceGroupID, port.Protocol, port.FromPort, port.ToPort)
+		ingresses = append(ingresses, ingress)
+
+		if !link.OneWay {
+			ingress := ec2.NewSecurityGroupIngressFromGroup(sourceGroupID, destGroupID, port.Protocol, port.FromPort, port.ToPort)
+			ingresses = append(ingresses, ingress)
+		}
 	}
 
-	if err := removeIngressRule(sourceGroup, *destGroup.GroupId); err != nil {
+	return ingresses
+}
+
+func (e *ECSEnvironmentManager) AuthorizeEnvironmentIngress(environmentID string, rule models.EnvironmentIngressRule) error {
+	ecsEnvironmentID := id.L0EnvironmentID(environmentID).ECSEnvironmentID()
+	group, err := e.getEnvironmentSecurityGroup(ecsEnvironmentID)
+	if err != nil {
 		return err
 	}
 
-	if err := removeIngressRule(destGroup, *sourceGroup.GroupId); err != nil {
+	ingress := environmentIngress(rule, *group.GroupId)
+	if err := e.EC2.AuthorizeSecurityGroupIngress([]*ec2.SecurityGroupIngress{ingress}); err != nil {
+		if !ContainsErrCode(err, "InvalidPermission.Duplicate") {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *ECSEnvironmentManager) RevokeEnvironmentIngress(environmentID string, rule models.EnvironmentIngressRule) error {
+	ecsEnvironmentID := id.L0EnvironmentID(environmentID).ECSEnvironmentID()
+	group, err := e.getEnvironmentSecurityGroup(ecsEnvironmentID)
+	if err != nil {
 		return err
 	}
 
+	ingress := environmentIngress(rule, *group.GroupId)
+	if err := e.EC2.RevokeSecurityGroupIngress([]*ec2.SecurityGroupIngress{ingress}); err != nil {
+		if !ContainsErrCode(err, "InvalidPermission.NotFound") {
+			return err
+		}
+	}
+
 	return nil
 }
 
+func environmentIngress(rule models.EnvironmentIngressRule, groupID string) *ec2.SecurityGroupIngress {
+	if rule.SecurityGroupID != "" {
+		return ec2.NewSecurityGroupIngressFromGroup(groupID, rule.SecurityGroupID, rule.Protocol, rule.FromPort, rule.ToPort)
+	}
+
+	return ec2.NewSecurityGroupIngress(groupID, rule.CIDR, rule.Protocol, rule.FromPort, rule.ToPort)
+}
+
 func (e *ECSEnvironmentManager) getEnvironmentSecurityGroup(environmentID id.ECSEnvironmentID) (*ec2.SecurityGroup, error) {
 	group, err := e.EC2.DescribeSecurityGroup(environmentID.SecurityGroupName())
 	if err != nil {
@@ -7,7 +7,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
-	awsec2 "github.com/aws/aws-sdk-go/service/ec2"
+	aws_autoscaling "github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/golang/mock/gomock"
 	"github.com/quintilesims/layer0/api/backend/ecs/id"
 	"github.com/quintilesims/layer0/api/backend/mock_backend"
@@ -174,6 +174,40 @@ func TestListEnvironments(t *testing.T) {
 	assert.Equal(t, result, expected)
 }
 
+func TestListEnvironmentsPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEnvironment := NewMockECSEnvironmentManager(ctrl)
+
+	gomock.InOrder(
+		mockEnvironment.ECS.EXPECT().
+			ListClusterNamesPage(id.PREFIX, int64(2), nil).
+			Return([]string{"env_id1"}, aws.String("token_1"), nil),
+		mockEnvironment.ECS.EXPECT().
+			ListClusterNamesPage(id.PREFIX, int64(1), aws.String("token_1")).
+			Return([]string{"env_id2"}, aws.String("token_2"), nil),
+		mockEnvironment.ECS.EXPECT().
+			ListClusterNamesPage(id.PREFIX, int64(2), aws.String("token_2")).
+			Return([]string{"env_id3"}, nil, nil),
+	)
+
+	result, nextToken, err := mockEnvironment.Environment().ListEnvironmentsPage(2, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []id.ECSEnvironmentID{"env_id1", "env_id2"}, result)
+
+	result, nextToken, err = mockEnvironment.Environment().ListEnvironmentsPage(2, nextToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []id.ECSEnvironmentID{"env_id3"}, result)
+	assert.Equal(t, "", nextToken)
+}
+
 func TestDeleteEnvironment(t *testing.T) {
 	testCases := []testutils.TestCase{
 		{
@@ -209,6 +243,10 @@ func TestDeleteEnvironment(t *testing.T) {
 					DescribeAutoScalingGroup(autoScalingGroupName).
 					Return(nil, awserr.New("GroupNotFoundException", "group not found", nil))
 
+				mockEnvironment.EC2.EXPECT().
+					DeleteLaunchTemplate(ecsEnvironmentID.LaunchTemplateName()).
+					Return(nil)
+
 				mockEnvironment.EC2.EXPECT().
 					DescribeSecurityGroup(securityGroupName).
 					Return(securityGroup, nil)
@@ -253,6 +291,10 @@ func TestDeleteEnvironment(t *testing.T) {
 					DescribeAutoScalingGroup(gomock.Any()).
 					Return(nil, awserr.New("GroupNotFoundException", "group not found", nil))
 
+				mockEnvironment.EC2.EXPECT().
+					DeleteLaunchTemplate(gomock.Any()).
+					Return(awserr.New("InvalidLaunchTemplateName.NotFoundException", "not found", nil))
+
 				mockEnvironment.EC2.EXPECT().
 					DescribeSecurityGroup(gomock.Any()).
 					Return(nil, nil)
@@ -305,6 +347,11 @@ func TestDeleteEnvironment(t *testing.T) {
 						Return(autoScalingGroup, g.Error()).
 						AnyTimes()
 
+					mockEnvironment.EC2.EXPECT().
+						DeleteLaunchTemplate(gomock.Any()).
+						Return(g.Error()).
+						AnyTimes()
+
 					mockEnvironment.EC2.EXPECT().
 						DescribeSecurityGroup(gomock.Any()).
 						Return(securityGroup, g.Error()).
@@ -326,7 +373,7 @@ func TestDeleteEnvironment(t *testing.T) {
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				setup := target.(func(testutils.ErrorGenerator) *ECSEnvironmentManager)
 
-				for i := 0; i < 8; i++ {
+				for i := 0; i < 9; i++ {
 					var g testutils.ErrorGenerator
 					g.Set(i+1, fmt.Errorf("some eror"))
 
@@ -374,12 +421,13 @@ func TestCreateEnvironment(t *testing.T) {
 					AuthorizeSecurityGroupIngressFromGroup(securityGroupID, securityGroupID).
 					Return(nil)
 
-				var checkLaunchConfig = func(name, amiID, iamInstanceProfile, instanceType, keyName, userData *string, securityGroups []*string, volSizes map[string]int) error {
+				var checkLaunchConfig = func(name, amiID, iamInstanceProfile, instanceType, keyName, userData, spotPrice *string, securityGroups []*string, volSizes map[string]int) error {
 					reporter.AssertEqualf(launchConfigurationName, *name, "LaunchConfigurationName")
 					reporter.AssertEqualf("amiid", *amiID, "AMI ID")
 					reporter.AssertEqualf(config.TEST_AWS_ECS_INSTANCE_PROFILE, *iamInstanceProfile, "InstanceProfile")
 					reporter.AssertEqualf("m3.medium", *instanceType, "Instance Type")
 					reporter.AssertEqualf(config.TEST_AWS_KEY_PAIR, *keyName, "KeyPair")
+					reporter.AssertEqualf("", *spotPrice, "Spot Price")
 					reporter.AssertEqualf(securityGroupID, *securityGroups[0], "SecurityGroupID 0")
 					reporter.AssertEqualf(volSizes, map[string]int{"/dev/xvda": 8}, "Volume Sizes")
 
@@ -387,7 +435,7 @@ func TestCreateEnvironment(t *testing.T) {
 				}
 
 				mockEnvironment.AutoScaling.EXPECT().
-					CreateLaunchConfiguration(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					CreateLaunchConfiguration(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Do(checkLaunchConfig)
 
 				minCount := 2
@@ -417,7 +465,7 @@ func TestCreateEnvironment(t *testing.T) {
 			},
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				manager := target.(*ECSEnvironmentManager)
-				manager.CreateEnvironment("env_name", "m3.medium", "linux", "amiid", 2, nil)
+				manager.CreateEnvironment("env_name", "m3.medium", "linux", "amiid", 2, nil, "", nil)
 			},
 		},
 		{
@@ -447,7 +495,7 @@ func TestCreateEnvironment(t *testing.T) {
 
 				userData := base64.StdEncoding.EncodeToString([]byte("user data"))
 				mockEnvironment.AutoScaling.EXPECT().
-					CreateLaunchConfiguration(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), &userData, gomock.Any(), gomock.Any()).
+					CreateLaunchConfiguration(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), &userData, gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil)
 
 				mockEnvironment.AutoScaling.EXPECT().
@@ -470,7 +518,7 @@ func TestCreateEnvironment(t *testing.T) {
 			},
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				manager := target.(*ECSEnvironmentManager)
-				manager.CreateEnvironment("env_name", "m3.medium", "linux", "amiid", 0, []byte("user data"))
+				manager.CreateEnvironment("env_name", "m3.medium", "linux", "amiid", 0, []byte("user data"), "", nil)
 			},
 		},
 		{
@@ -499,7 +547,7 @@ func TestCreateEnvironment(t *testing.T) {
 					Return(nil)
 
 				mockEnvironment.AutoScaling.EXPECT().
-					CreateLaunchConfiguration(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					CreateLaunchConfiguration(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil)
 
 				mockEnvironment.AutoScaling.EXPECT().
@@ -523,7 +571,7 @@ func TestCreateEnvironment(t *testing.T) {
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				manager := target.(*ECSEnvironmentManager)
 
-				environment, err := manager.CreateEnvironment("env_name", "m3.medium", "linux", "amiid", 0, nil)
+				environment, err := manager.CreateEnvironment("env_name", "m3.medium", "linux", "amiid", 0, nil, "", nil)
 				if err != nil {
 					reporter.Fatal(err)
 				}
@@ -556,7 +604,7 @@ func TestCreateEnvironment(t *testing.T) {
 						AnyTimes()
 
 					mockEnvironment.AutoScaling.EXPECT().
-						CreateLaunchConfiguration(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+						CreateLaunchConfiguration(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 						Return(g.Error()).
 						AnyTimes()
 
@@ -587,7 +635,7 @@ func TestCreateEnvironment(t *testing.T) {
 					g.Set(i+1, fmt.Errorf("some error"))
 
 					manager := setup(g).(*ECSEnvironmentManager)
-					if _, err := manager.CreateEnvironment("some_name", "m3.medium", "linux", "amiid", 0, nil); err == nil {
+					if _, err := manager.CreateEnvironment("some_name", "m3.medium", "linux", "amiid", 0, nil, "", nil); err == nil {
 						reporter.Errorf("Error on variation %d, Error was nil!", i)
 					}
 				}
@@ -598,6 +646,153 @@ func TestCreateEnvironment(t *testing.T) {
 	testutils.RunTests(t, testCases)
 }
 
+func TestCreateEnvironment_mixedInstancesPolicy(t *testing.T) {
+	defer id.StubIDGeneration("envid")()
+
+	testCases := []testutils.TestCase{
+		{
+			Name: "Should create a launch template and an autoscaling group with a mixed instances policy",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockEnvironment := NewMockECSEnvironmentManager(ctrl)
+
+				ecsEnvironmentID := id.L0EnvironmentID("envid").ECSEnvironmentID()
+				autoScalingGroupName := ecsEnvironmentID.AutoScalingGroupName()
+				launchTemplateName := ecsEnvironmentID.LaunchTemplateName()
+				securityGroupID := "some_sg_id"
+				clusterName := ecsEnvironmentID.String()
+
+				mockEnvironment.ECS.EXPECT().
+					CreateCluster(clusterName).
+					Return(ecs.NewCluster(clusterName), nil)
+
+				mockEnvironment.EC2.EXPECT().
+					CreateSecurityGroup(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(&securityGroupID, nil)
+
+				mockEnvironment.EC2.EXPECT().
+					AuthorizeSecurityGroupIngressFromGroup(securityGroupID, securityGroupID).
+					Return(nil)
+
+				mockEnvironment.EC2.EXPECT().
+					CreateLaunchTemplate(
+						launchTemplateName,
+						"amiid",
+						config.TEST_AWS_ECS_INSTANCE_PROFILE,
+						"m5.large",
+						config.TEST_AWS_KEY_PAIR,
+						gomock.Any(),
+						[]*string{&securityGroupID},
+						map[string]int{"/dev/xvda": 8}).
+					Return(nil)
+
+				mockEnvironment.AutoScaling.EXPECT().
+					CreateMixedInstancesAutoScalingGroup(
+						autoScalingGroupName,
+						launchTemplateName,
+						config.TEST_AWS_PRIVATE_SUBNETS,
+						1,
+						1,
+						[]string{"m5.large", "m4.large"},
+						1,
+						25,
+						"0.05").
+					Return(nil)
+
+				asg := autoscaling.NewGroup()
+				mockEnvironment.AutoScaling.EXPECT().
+					DescribeAutoScalingGroup(autoScalingGroupName).
+					Return(asg, nil)
+
+				mockEnvironment.EC2.EXPECT().
+					DescribeSecurityGroup(gomock.Any()).
+					Return(ec2.NewSecurityGroup(securityGroupID), nil)
+
+				return mockEnvironment.Environment()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSEnvironmentManager)
+
+				policy := &models.MixedInstancesPolicy{
+					InstanceTypes:               []string{"m5.large", "m4.large"},
+					OnDemandBaseCapacity:        1,
+					OnDemandPercentageAboveBase: 25,
+				}
+
+				if _, err := manager.CreateEnvironment("env_name", "m5.large", "linux", "amiid", 1, nil, "0.05", policy); err != nil {
+					reporter.Fatal(err)
+				}
+			},
+		},
+	}
+
+	testutils.RunTests(t, testCases)
+}
+
+func TestGetEnvironment_mixedInstancesPolicy(t *testing.T) {
+	testCases := []testutils.TestCase{
+		{
+			Name: "Should populate the mixed instances policy and spot price",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockEnvironment := NewMockECSEnvironmentManager(ctrl)
+				ecsEnvironmentID := id.L0EnvironmentID("envid").ECSEnvironmentID()
+				clusterName := ecsEnvironmentID.String()
+
+				mockEnvironment.ECS.EXPECT().
+					DescribeCluster(clusterName).
+					Return(ecs.NewCluster(clusterName), nil)
+
+				asg := autoscaling.NewGroup()
+				asg.MixedInstancesPolicy = &aws_autoscaling.MixedInstancesPolicy{
+					InstancesDistribution: &aws_autoscaling.InstancesDistribution{
+						OnDemandBaseCapacity:                int64p(1),
+						OnDemandPercentageAboveBaseCapacity: int64p(25),
+						SpotMaxPrice:                        stringp("0.05"),
+					},
+					LaunchTemplate: &aws_autoscaling.LaunchTemplate{
+						Overrides: []*aws_autoscaling.LaunchTemplateOverrides{
+							{InstanceType: stringp("m5.large")},
+							{InstanceType: stringp("m4.large")},
+						},
+					},
+				}
+
+				mockEnvironment.AutoScaling.EXPECT().
+					DescribeAutoScalingGroup(ecsEnvironmentID.AutoScalingGroupName()).
+					Return(asg, nil)
+
+				mockEnvironment.EC2.EXPECT().
+					DescribeLaunchTemplateData(ecsEnvironmentID.LaunchTemplateName()).
+					Return(ec2.NewLaunchTemplateData("m5.large", "amiid"), nil)
+
+				mockEnvironment.EC2.EXPECT().
+					DescribeSecurityGroup(gomock.Any()).
+					Return(ec2.NewSecurityGroup("some_sg_id"), nil)
+
+				return mockEnvironment.Environment()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSEnvironmentManager)
+
+				environment, err := manager.GetEnvironment("envid")
+				if err != nil {
+					reporter.Fatal(err)
+				}
+
+				reporter.AssertEqual(environment.InstanceSize, "m5.large")
+				reporter.AssertEqual(environment.AMIID, "amiid")
+				reporter.AssertEqual(environment.SpotPrice, "0.05")
+				reporter.AssertEqual(environment.MixedInstancesPolicy, &models.MixedInstancesPolicy{
+					InstanceTypes:               []string{"m5.large", "m4.large"},
+					OnDemandBaseCapacity:        1,
+					OnDemandPercentageAboveBase: 25,
+				})
+			},
+		},
+	}
+
+	testutils.RunTests(t, testCases)
+}
+
 func TestUpdateEnvironmentMinCount(t *testing.T) {
 	testModel := &models.Environment{
 		EnvironmentID: "some_id",
@@ -716,18 +911,70 @@ func TestCreateEnvironmentLink(t *testing.T) {
 					Return(destSG, nil)
 
 				mockEnvironment.EC2.EXPECT().
-					AuthorizeSecurityGroupIngressFromGroup("eid1_sg", "eid2_sg").
+					AuthorizeSecurityGroupIngress([]*ec2.SecurityGroupIngress{
+						ec2.NewSecurityGroupIngressFromGroup("eid2_sg", "eid1_sg", "-1", 0, 0),
+					}).
+					Return(nil)
+
+				mockEnvironment.EC2.EXPECT().
+					AuthorizeSecurityGroupIngress([]*ec2.SecurityGroupIngress{
+						ec2.NewSecurityGroupIngressFromGroup("eid1_sg", "eid2_sg", "-1", 0, 0),
+					}).
 					Return(nil)
 
+				return mockEnvironment.Environment()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSEnvironmentManager)
+				manager.CreateEnvironmentLink(models.EnvironmentLink{SourceEnvironmentID: "eid1", DestEnvironmentID: "eid2"})
+			},
+		},
+		{
+			Name: "Should only open the link's ports from source to dest for one-way links",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockEnvironment := NewMockECSEnvironmentManager(ctrl)
+
+				sourceEnvironmentID := id.L0EnvironmentID("eid1").ECSEnvironmentID()
+				destEnvironmentID := id.L0EnvironmentID("eid2").ECSEnvironmentID()
+
 				mockEnvironment.EC2.EXPECT().
-					AuthorizeSecurityGroupIngressFromGroup("eid2_sg", "eid1_sg").
+					DescribeSecurityGroup(sourceEnvironmentID.SecurityGroupName()).
+					Return(ec2.NewSecurityGroup("eid1_sg"), nil)
+
+				mockEnvironment.EC2.EXPECT().
+					DescribeSecurityGroup(destEnvironmentID.SecurityGroupName()).
+					Return(ec2.NewSecurityGroup("eid2_sg"), nil)
+
+				mockEnvironment.EC2.EXPECT().
+					AuthorizeSecurityGroupIngress([]*ec2.SecurityGroupIngress{
+						ec2.NewSecurityGroupIngressFromGroup("eid2_sg", "eid1_sg", "tcp", 5432, 5432),
+					}).
+					Return(nil)
+
+				mockEnvironment.EC2.EXPECT().
+					AuthorizeSecurityGroupIngress([]*ec2.SecurityGroupIngress{
+						ec2.NewSecurityGroupIngressFromGroup("eid2_sg", "eid1_sg", "udp", 8000, 8100),
+					}).
 					Return(nil)
 
 				return mockEnvironment.Environment()
 			},
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				manager := target.(*ECSEnvironmentManager)
-				manager.CreateEnvironmentLink("eid1", "eid2")
+
+				link := models.EnvironmentLink{
+					SourceEnvironmentID: "eid1",
+					DestEnvironmentID:   "eid2",
+					Ports: []models.EnvironmentLinkPort{
+						{Protocol: "tcp", FromPort: 5432, ToPort: 5432},
+						{Protocol: "udp", FromPort: 8000, ToPort: 8100},
+					},
+					OneWay: true,
+				}
+
+				if err := manager.CreateEnvironmentLink(link); err != nil {
+					reporter.Fatal(err)
+				}
 			},
 		},
 		{
@@ -741,7 +988,7 @@ func TestCreateEnvironmentLink(t *testing.T) {
 					AnyTimes()
 
 				mockEnvironment.EC2.EXPECT().
-					AuthorizeSecurityGroupIngressFromGroup(gomock.Any(), gomock.Any()).
+					AuthorizeSecurityGroupIngress(gomock.Any()).
 					Return(awserr.New("InvalidPermission.Duplicate", "", nil)).
 					AnyTimes()
 
@@ -749,7 +996,7 @@ func TestCreateEnvironmentLink(t *testing.T) {
 			},
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				manager := target.(*ECSEnvironmentManager)
-				if err := manager.CreateEnvironmentLink("eid1", "eid2"); err != nil {
+				if err := manager.CreateEnvironmentLink(models.EnvironmentLink{SourceEnvironmentID: "eid1", DestEnvironmentID: "eid2"}); err != nil {
 					reporter.Fatal(err)
 				}
 			},
@@ -779,41 +1026,56 @@ func TestDeleteEnvironmentLink(t *testing.T) {
 					DescribeSecurityGroup(destEnvironmentID.SecurityGroupName()).
 					Return(destSG, nil)
 
-				sourceSG.IpPermissions = []*awsec2.IpPermission{
-					{
-						IpProtocol: aws.String("-1"),
-						UserIdGroupPairs: []*awsec2.UserIdGroupPair{
-							{
-								GroupId: aws.String("eid2_sg"),
-							},
-						},
-					},
-				}
+				mockEnvironment.EC2.EXPECT().
+					RevokeSecurityGroupIngress([]*ec2.SecurityGroupIngress{
+						ec2.NewSecurityGroupIngressFromGroup("eid2_sg", "eid1_sg", "tcp", 5432, 5432),
+					}).
+					Return(nil)
 
 				mockEnvironment.EC2.EXPECT().
-					RevokeSecurityGroupIngressHelper("eid1_sg", gomock.Any()).
+					RevokeSecurityGroupIngress([]*ec2.SecurityGroupIngress{
+						ec2.NewSecurityGroupIngressFromGroup("eid1_sg", "eid2_sg", "tcp", 5432, 5432),
+					}).
 					Return(nil)
 
-				destSG.IpPermissions = []*awsec2.IpPermission{
-					{
-						IpProtocol: aws.String("-1"),
-						UserIdGroupPairs: []*awsec2.UserIdGroupPair{
-							{
-								GroupId: aws.String("eid1_sg"),
-							},
-						},
-					},
+				return mockEnvironment.Environment()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSEnvironmentManager)
+
+				link := models.EnvironmentLink{
+					SourceEnvironmentID: "eid1",
+					DestEnvironmentID:   "eid2",
+					Ports:               []models.EnvironmentLinkPort{{Protocol: "tcp", FromPort: 5432, ToPort: 5432}},
 				}
 
+				if err := manager.DeleteEnvironmentLink(link); err != nil {
+					reporter.Fatal(err)
+				}
+			},
+		},
+		{
+			Name: "Should ignore rules that were already revoked",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockEnvironment := NewMockECSEnvironmentManager(ctrl)
+
 				mockEnvironment.EC2.EXPECT().
-					RevokeSecurityGroupIngressHelper("eid2_sg", gomock.Any()).
-					Return(nil)
+					DescribeSecurityGroup(gomock.Any()).
+					Return(ec2.NewSecurityGroup(""), nil).
+					AnyTimes()
+
+				mockEnvironment.EC2.EXPECT().
+					RevokeSecurityGroupIngress(gomock.Any()).
+					Return(awserr.New("InvalidPermission.NotFound", "", nil)).
+					Times(2)
 
 				return mockEnvironment.Environment()
 			},
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				manager := target.(*ECSEnvironmentManager)
-				manager.DeleteEnvironmentLink("eid1", "eid2")
+				if err := manager.DeleteEnvironmentLink(models.EnvironmentLink{SourceEnvironmentID: "eid1", DestEnvironmentID: "eid2"}); err != nil {
+					reporter.Fatal(err)
+				}
 			},
 		},
 		{
@@ -831,7 +1093,7 @@ func TestDeleteEnvironmentLink(t *testing.T) {
 			},
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				manager := target.(*ECSEnvironmentManager)
-				manager.DeleteEnvironmentLink("eid1", "eid2")
+				manager.DeleteEnvironmentLink(models.EnvironmentLink{SourceEnvironmentID: "eid1", DestEnvironmentID: "eid2"})
 			},
 		},
 		{
@@ -855,7 +1117,100 @@ func TestDeleteEnvironmentLink(t *testing.T) {
 			},
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				manager := target.(*ECSEnvironmentManager)
-				manager.DeleteEnvironmentLink("eid1", "eid2")
+				manager.DeleteEnvironmentLink(models.EnvironmentLink{SourceEnvironmentID: "eid1", DestEnvironmentID: "eid2"})
+			},
+		},
+	}
+
+	testutils.RunTests(t, testCases)
+}
+
+func TestAuthorizeEnvironmentIngress(t *testing.T) {
+	testCases := []testutils.TestCase{
+		{
+			Name: "Should authorize cidr ingress on the environment security group",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockEnvironment := NewMockECSEnvironmentManager(ctrl)
+
+				environmentID := id.L0EnvironmentID("eid1").ECSEnvironmentID()
+				mockEnvironment.EC2.EXPECT().
+					DescribeSecurityGroup(environmentID.SecurityGroupName()).
+					Return(ec2.NewSecurityGroup("eid1_sg"), nil)
+
+				mockEnvironment.EC2.EXPECT().
+					AuthorizeSecurityGroupIngress([]*ec2.SecurityGroupIngress{
+						ec2.NewSecurityGroupIngress("eid1_sg", "10.1.0.0/16", "tcp", 22, 22),
+					}).
+					Return(nil)
+
+				return mockEnvironment.Environment()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSEnvironmentManager)
+
+				rule := models.EnvironmentIngressRule{CIDR: "10.1.0.0/16", Protocol: "tcp", FromPort: 22, ToPort: 22}
+				if err := manager.AuthorizeEnvironmentIngress("eid1", rule); err != nil {
+					reporter.Fatal(err)
+				}
+			},
+		},
+		{
+			Name: "Should authorize security group ingress and ignore duplicates",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockEnvironment := NewMockECSEnvironmentManager(ctrl)
+
+				mockEnvironment.EC2.EXPECT().
+					DescribeSecurityGroup(gomock.Any()).
+					Return(ec2.NewSecurityGroup("eid1_sg"), nil)
+
+				mockEnvironment.EC2.EXPECT().
+					AuthorizeSecurityGroupIngress([]*ec2.SecurityGroupIngress{
+						ec2.NewSecurityGroupIngressFromGroup("eid1_sg", "sg-123", "udp", 8000, 8100),
+					}).
+					Return(awserr.New("InvalidPermission.Duplicate", "", nil))
+
+				return mockEnvironment.Environment()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSEnvironmentManager)
+
+				rule := models.EnvironmentIngressRule{SecurityGroupID: "sg-123", Protocol: "udp", FromPort: 8000, ToPort: 8100}
+				if err := manager.AuthorizeEnvironmentIngress("eid1", rule); err != nil {
+					reporter.Fatal(err)
+				}
+			},
+		},
+	}
+
+	testutils.RunTests(t, testCases)
+}
+
+func TestRevokeEnvironmentIngress(t *testing.T) {
+	testCases := []testutils.TestCase{
+		{
+			Name: "Should revoke ingress and ignore rules that do not exist",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockEnvironment := NewMockECSEnvironmentManager(ctrl)
+
+				mockEnvironment.EC2.EXPECT().
+					DescribeSecurityGroup(gomock.Any()).
+					Return(ec2.NewSecurityGroup("eid1_sg"), nil)
+
+				mockEnvironment.EC2.EXPECT().
+					RevokeSecurityGroupIngress([]*ec2.SecurityGroupIngress{
+						ec2.NewSecurityGroupIngress("eid1_sg", "10.1.0.0/16", "tcp", 22, 22),
+					}).
+					Return(awserr.New("InvalidPermission.NotFound", "", nil))
+
+				return mockEnvironment.Environment()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSEnvironmentManager)
+
+				rule := models.EnvironmentIngressRule{CIDR: "10.1.0.0/16", Protocol: "tcp", FromPort: 22, ToPort: 22}
+				if err := manager.RevokeEnvironmentIngress("eid1", rule); err != nil {
+					reporter.Fatal(err)
+				}
 			},
 		},
 	}
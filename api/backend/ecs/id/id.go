@@ -142,6 +142,10 @@ func (id ECSEnvironmentID) LaunchConfigurationName() string {
 	return id.String()
 }
 
+func (id ECSEnvironmentID) LaunchTemplateName() string {
+	return id.String()
+}
+
 func (id ECSEnvironmentID) AutoScalingGroupName() string {
 	return id.String()
 }
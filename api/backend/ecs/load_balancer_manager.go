@@ -3,38 +3,63 @@ package ecsbackend
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/aws/aws-sdk-go/aws"
+	awscloudwatch "github.com/aws/aws-sdk-go/service/cloudwatch"
 	awselb "github.com/aws/aws-sdk-go/service/elb"
 	"github.com/quintilesims/layer0/api/backend"
 	"github.com/quintilesims/layer0/api/backend/ecs/id"
+	"github.com/quintilesims/layer0/common/aws/acm"
+	"github.com/quintilesims/layer0/common/aws/cloudwatch"
 	"github.com/quintilesims/layer0/common/aws/ec2"
 	"github.com/quintilesims/layer0/common/aws/elb"
 	"github.com/quintilesims/layer0/common/aws/iam"
+	"github.com/quintilesims/layer0/common/aws/route53"
 	"github.com/quintilesims/layer0/common/config"
 	"github.com/quintilesims/layer0/common/errors"
 	"github.com/quintilesims/layer0/common/models"
 	"github.com/quintilesims/layer0/common/waitutils"
 )
 
+const (
+	ELB_METRICS_NAMESPACE = "AWS/ELB"
+	DEFAULT_INGRESS_CIDR  = "0.0.0.0/0"
+	ACM_STATUS_ISSUED     = "ISSUED"
+)
+
 type ECSLoadBalancerManager struct {
-	EC2     ec2.Provider
-	ELB     elb.Provider
-	IAM     iam.Provider
-	Backend backend.Backend
-	Clock   waitutils.Clock
+	EC2        ec2.Provider
+	ELB        elb.Provider
+	IAM        iam.Provider
+	ACM        acm.Provider
+	CloudWatch cloudwatch.Provider
+	Route53    route53.Provider
+	Backend    backend.Backend
+	Clock      waitutils.Clock
 }
 
-func NewECSLoadBalancerManager(ec2 ec2.Provider, elb elb.Provider, iam iam.Provider, backend backend.Backend) *ECSLoadBalancerManager {
+func NewECSLoadBalancerManager(
+	ec2 ec2.Provider,
+	elb elb.Provider,
+	iam iam.Provider,
+	acm acm.Provider,
+	cloudWatch cloudwatch.Provider,
+	route53 route53.Provider,
+	backend backend.Backend,
+) *ECSLoadBalancerManager {
 	return &ECSLoadBalancerManager{
-		EC2:     ec2,
-		ELB:     elb,
-		IAM:     iam,
-		Backend: backend,
-		Clock:   waitutils.RealClock{},
+		EC2:        ec2,
+		ELB:        elb,
+		IAM:        iam,
+		ACM:        acm,
+		CloudWatch: cloudWatch,
+		Route53:    route53,
+		Backend:    backend,
+		Clock:      waitutils.RealClock{},
 	}
 }
 
@@ -80,7 +105,16 @@ func (e *ECSLoadBalancerManager) GetLoadBalancer(loadBalancerID string) (*models
 		}
 	}
 
-	return e.populateModel(loadBalancer, lbAttributes), nil
+	model := e.populateModel(loadBalancer, lbAttributes)
+
+	// only public load balancers have an additional security group
+	if model.IsPublic {
+		if err := e.populatePortSources(ecsLoadBalancerID, model.Ports); err != nil {
+			return nil, err
+		}
+	}
+
+	return model, nil
 }
 
 func (e *ECSLoadBalancerManager) DeleteLoadBalancer(loadBalancerID string) error {
@@ -219,9 +253,53 @@ func (e *ECSLoadBalancerManager) populateModel(description *elb.LoadBalancerDesc
 		CrossZone:      bool(aws.BoolValue(lbAttributes.CrossZoneLoadBalancing.Enabled)),
 	}
 
+	if accessLog := lbAttributes.AccessLog; accessLog != nil {
+		model.AccessLog = models.AccessLog{
+			Enabled:      aws.BoolValue(accessLog.Enabled),
+			BucketName:   aws.StringValue(accessLog.S3BucketName),
+			BucketPrefix: aws.StringValue(accessLog.S3BucketPrefix),
+			EmitInterval: int(aws.Int64Value(accessLog.EmitInterval)),
+		}
+	}
+
 	return model
 }
 
+func (e *ECSLoadBalancerManager) populatePortSources(ecsLoadBalancerID id.ECSLoadBalancerID, ports []models.Port) error {
+	securityGroup, err := e.EC2.DescribeSecurityGroup(ecsLoadBalancerID.SecurityGroupName())
+	if err != nil {
+		return err
+	}
+
+	if securityGroup == nil {
+		return nil
+	}
+
+	for i := range ports {
+		port := &ports[i]
+		for _, permission := range securityGroup.IpPermissions {
+			if aws.Int64Value(permission.FromPort) != port.HostPort {
+				continue
+			}
+
+			for _, ipRange := range permission.IpRanges {
+				port.SourceCIDRs = append(port.SourceCIDRs, aws.StringValue(ipRange.CidrIp))
+			}
+
+			for _, pair := range permission.UserIdGroupPairs {
+				port.SourceSecurityGroups = append(port.SourceSecurityGroups, aws.StringValue(pair.GroupId))
+			}
+		}
+
+		// a port open to the world is the default, so we don't list it as a source
+		if len(port.SourceCIDRs) == 1 && port.SourceCIDRs[0] == DEFAULT_INGRESS_CIDR && len(port.SourceSecurityGroups) == 0 {
+			port.SourceCIDRs = nil
+		}
+	}
+
+	return nil
+}
+
 func (e *ECSLoadBalancerManager) listenerToPort(listener *awselb.Listener) models.Port {
 	port := models.Port{
 		ContainerPort: *listener.InstancePort,
@@ -304,6 +382,10 @@ func (e *ECSLoadBalancerManager) createLoadBalancer(
 	isPublic bool,
 	ports []models.Port,
 ) error {
+	if err := validatePortSources(isPublic, ports); err != nil {
+		return err
+	}
+
 	listeners := []*elb.Listener{}
 	for _, port := range ports {
 		listener, err := e.portToListener(port)
@@ -392,6 +474,175 @@ func (e *ECSLoadBalancerManager) UpdateLoadBalancerCrossZone(loadBalancerID stri
 	return e.GetLoadBalancer(loadBalancerID)
 }
 
+func (e *ECSLoadBalancerManager) UpdateLoadBalancerAccessLog(loadBalancerID string, accessLog models.AccessLog) (*models.LoadBalancer, error) {
+	ecsLoadBalancerID := id.L0LoadBalancerID(loadBalancerID).ECSLoadBalancerID()
+	if err := e.setAccessLog(ecsLoadBalancerID, accessLog); err != nil {
+		return nil, err
+	}
+
+	return e.GetLoadBalancer(loadBalancerID)
+}
+
+func (e *ECSLoadBalancerManager) GetLoadBalancerMetrics(loadBalancerID string, startTime, endTime time.Time, period int) (*models.LoadBalancerMetrics, error) {
+	ecsLoadBalancerID := id.L0LoadBalancerID(loadBalancerID).ECSLoadBalancerID()
+
+	dimensions := []*awscloudwatch.Dimension{
+		{
+			Name:  aws.String("LoadBalancerName"),
+			Value: aws.String(ecsLoadBalancerID.String()),
+		},
+	}
+
+	datapoints := map[time.Time]*models.LoadBalancerMetricsDatapoint{}
+	getDatapoint := func(timestamp time.Time) *models.LoadBalancerMetricsDatapoint {
+		if _, ok := datapoints[timestamp]; !ok {
+			datapoints[timestamp] = &models.LoadBalancerMetricsDatapoint{Timestamp: timestamp}
+		}
+
+		return datapoints[timestamp]
+	}
+
+	// each metric is fetched with the single statistic that makes sense for it
+	// e.g. 'Sum' for counts and 'Average' for latency
+	metrics := []struct {
+		Name      string
+		Statistic string
+		Apply     func(*models.LoadBalancerMetricsDatapoint, float64)
+	}{
+		{
+			Name:      "RequestCount",
+			Statistic: "Sum",
+			Apply:     func(d *models.LoadBalancerMetricsDatapoint, v float64) { d.RequestCount = int64(v) },
+		},
+		{
+			Name:      "Latency",
+			Statistic: "Average",
+			Apply:     func(d *models.LoadBalancerMetricsDatapoint, v float64) { d.Latency = v },
+		},
+		{
+			Name:      "HTTPCode_ELB_5XX",
+			Statistic: "Sum",
+			Apply:     func(d *models.LoadBalancerMetricsDatapoint, v float64) { d.HTTPCodeELB5XX = int64(v) },
+		},
+		{
+			Name:      "HTTPCode_Backend_5XX",
+			Statistic: "Sum",
+			Apply:     func(d *models.LoadBalancerMetricsDatapoint, v float64) { d.HTTPCodeBackend5XX = int64(v) },
+		},
+	}
+
+	for _, metric := range metrics {
+		results, err := e.CloudWatch.GetMetricStatistics(
+			ELB_METRICS_NAMESPACE,
+			metric.Name,
+			int64(period),
+			[]string{metric.Statistic},
+			dimensions,
+			startTime,
+			endTime)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, result := range results {
+			if result.Timestamp == nil {
+				continue
+			}
+
+			var value float64
+			switch metric.Statistic {
+			case "Sum":
+				value = aws.Float64Value(result.Sum)
+			case "Average":
+				value = aws.Float64Value(result.Average)
+			}
+
+			metric.Apply(getDatapoint(*result.Timestamp), value)
+		}
+	}
+
+	model := &models.LoadBalancerMetrics{
+		LoadBalancerID: loadBalancerID,
+		StartTime:      startTime,
+		EndTime:        endTime,
+		Period:         period,
+		Datapoints:     []models.LoadBalancerMetricsDatapoint{},
+	}
+
+	// the overall latency is the average of each period's latency, weighted by request count
+	var weightedLatency float64
+	for _, datapoint := range datapoints {
+		model.RequestCount += datapoint.RequestCount
+		model.HTTPCodeELB5XX += datapoint.HTTPCodeELB5XX
+		model.HTTPCodeBackend5XX += datapoint.HTTPCodeBackend5XX
+		weightedLatency += datapoint.Latency * float64(datapoint.RequestCount)
+
+		model.Datapoints = append(model.Datapoints, *datapoint)
+	}
+
+	if model.RequestCount > 0 {
+		model.Latency = weightedLatency / float64(model.RequestCount)
+	}
+
+	sort.Slice(model.Datapoints, func(i, j int) bool {
+		return model.Datapoints[i].Timestamp.Before(model.Datapoints[j].Timestamp)
+	})
+
+	return model, nil
+}
+
+func (e *ECSLoadBalancerManager) CreateLoadBalancerDNSRecord(loadBalancerID, dnsName string) error {
+	hostedZoneID := config.AWSRoute53HostedZoneID()
+	if hostedZoneID == "" {
+		err := fmt.Errorf("Cannot create DNS record '%s': no Route53 hosted zone has been configured", dnsName)
+		return errors.New(errors.InvalidRequest, err)
+	}
+
+	ecsLoadBalancerID := id.L0LoadBalancerID(loadBalancerID).ECSLoadBalancerID()
+	loadBalancer, err := e.ELB.DescribeLoadBalancer(ecsLoadBalancerID.String())
+	if err != nil {
+		return err
+	}
+
+	if err := e.Route53.CreateAliasRecord(
+		hostedZoneID,
+		dnsName,
+		aws.StringValue(loadBalancer.DNSName),
+		aws.StringValue(loadBalancer.CanonicalHostedZoneNameID)); err != nil {
+		// route53 rejects creating a record that already exists with an InvalidChangeBatch error
+		if ContainsErrMsg(err, "already exists") {
+			err := fmt.Errorf("Cannot create DNS record '%s': a record with that name already exists", dnsName)
+			return errors.New(errors.InvalidRequest, err)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (e *ECSLoadBalancerManager) DeleteLoadBalancerDNSRecord(loadBalancerID, dnsName string) error {
+	hostedZoneID := config.AWSRoute53HostedZoneID()
+	if hostedZoneID == "" {
+		log.Warningf("Skipping delete of DNS record '%s': no Route53 hosted zone has been configured", dnsName)
+		return nil
+	}
+
+	ecsLoadBalancerID := id.L0LoadBalancerID(loadBalancerID).ECSLoadBalancerID()
+	loadBalancer, err := e.ELB.DescribeLoadBalancer(ecsLoadBalancerID.String())
+	if err != nil {
+		if ContainsErrCode(err, "LoadBalancerNotFound") {
+			log.Warningf("Skipping delete of DNS record '%s': load balancer '%s' does not exist", dnsName, loadBalancerID)
+			return nil
+		}
+
+		return err
+	}
+
+	// only delete the record if it still points at this load balancer
+	return e.Route53.DeleteAliasRecord(hostedZoneID, dnsName, aws.StringValue(loadBalancer.DNSName))
+}
+
 func (e *ECSLoadBalancerManager) updateHealthCheck(ecsLoadBalancerID id.ECSLoadBalancerID, healthCheck models.HealthCheck) error {
 	elbHealthCheck := elb.NewHealthCheck(
 		healthCheck.Target,
@@ -412,6 +663,15 @@ func (e *ECSLoadBalancerManager) setCrossZone(ecsLoadBalancerID id.ECSLoadBalanc
 	return e.ELB.SetCrossZone(ecsLoadBalancerID.String(), crossZone)
 }
 
+func (e *ECSLoadBalancerManager) setAccessLog(ecsLoadBalancerID id.ECSLoadBalancerID, accessLog models.AccessLog) error {
+	return e.ELB.SetAccessLog(
+		ecsLoadBalancerID.String(),
+		accessLog.Enabled,
+		accessLog.BucketName,
+		accessLog.BucketPrefix,
+		accessLog.EmitInterval)
+}
+
 func (e *ECSLoadBalancerManager) UpdateLoadBalancerPorts(loadBalancerID string, ports []models.Port) (*models.LoadBalancer, error) {
 	model, err := e.GetLoadBalancer(loadBalancerID)
 	if err != nil {
@@ -433,6 +693,24 @@ func (e *ECSLoadBalancerManager) updatePorts(ecsLoadBalancerID id.ECSLoadBalance
 		return currentPorts, nil
 	}
 
+	if err := validatePortSources(isPublic, requestedPorts); err != nil {
+		return nil, err
+	}
+
+	// resolve certificates first so requested ports compare equal to existing listeners
+	resolvedPorts := make([]models.Port, len(requestedPorts))
+	for i, port := range requestedPorts {
+		certificateARN, err := e.resolveCertificateARN(port)
+		if err != nil {
+			return nil, err
+		}
+
+		port.CertificateARN = certificateARN
+		resolvedPorts[i] = port
+	}
+
+	requestedPorts = resolvedPorts
+
 	// remove first so we don't duplicate host ports
 	listenersToRemove := []*elb.Listener{}
 	for _, port := range portDifference(currentPorts, requestedPorts) {
@@ -491,22 +769,31 @@ func (e *ECSLoadBalancerManager) portToListener(port models.Port) (*elb.Listener
 		containerProtocol = "TCP"
 	}
 
-	// use cert arn if specified by the user
-	// otherwise, if name is specified, convert it to an arn
-	certificateARN := port.CertificateARN
-	if certificateARN == "" && port.CertificateName != "" {
-		arn, err := e.getCertificateARN(port.CertificateName)
-		if err != nil {
-			return nil, err
-		}
-
-		certificateARN = arn
+	certificateARN, err := e.resolveCertificateARN(port)
+	if err != nil {
+		return nil, err
 	}
 
 	listener := elb.NewListener(port.ContainerPort, containerProtocol, port.HostPort, hostProtocol, certificateARN)
 	return listener, nil
 }
 
+// use cert arn if specified by the user
+// otherwise, if name is specified, convert it to the arn of an iam certificate
+// or, if domain is specified, convert it to the arn of an acm certificate
+func (e *ECSLoadBalancerManager) resolveCertificateARN(port models.Port) (string, error) {
+	switch {
+	case port.CertificateARN != "":
+		return port.CertificateARN, nil
+	case port.CertificateName != "":
+		return e.getCertificateARN(port.CertificateName)
+	case port.CertificateDomain != "":
+		return e.getACMCertificateARN(port.CertificateDomain)
+	default:
+		return "", nil
+	}
+}
+
 func (e *ECSLoadBalancerManager) getCertificateARN(name string) (string, error) {
 	certificates, err := e.IAM.ListCertificates()
 	if err != nil {
@@ -522,6 +809,38 @@ func (e *ECSLoadBalancerManager) getCertificateARN(name string) (string, error)
 	return "", fmt.Errorf("Certificate with name '%s' does not exist. ", name)
 }
 
+// getACMCertificateARN returns the arn of the issued acm certificate for the specified domain.
+// If more than one certificate matches, the one that expires last is used.
+func (e *ECSLoadBalancerManager) getACMCertificateARN(domain string) (string, error) {
+	summaries, err := e.ACM.ListCertificates()
+	if err != nil {
+		return "", err
+	}
+
+	var certificateARN string
+	var expiration time.Time
+	for _, summary := range summaries {
+		if !strings.EqualFold(aws.StringValue(summary.DomainName), domain) {
+			continue
+		}
+
+		if aws.StringValue(summary.Status) != ACM_STATUS_ISSUED {
+			continue
+		}
+
+		if notAfter := aws.TimeValue(summary.NotAfter); certificateARN == "" || notAfter.After(expiration) {
+			certificateARN = aws.StringValue(summary.CertificateArn)
+			expiration = notAfter
+		}
+	}
+
+	if certificateARN == "" {
+		return "", fmt.Errorf("Issued ACM certificate for domain '%s' does not exist. ", domain)
+	}
+
+	return certificateARN, nil
+}
+
 func (e *ECSLoadBalancerManager) upsertSecurityGroup(ecsLoadBalancerID id.ECSLoadBalancerID, ports []models.Port) (*ec2.SecurityGroup, error) {
 	securityGroupName := ecsLoadBalancerID.SecurityGroupName()
 
@@ -560,21 +879,26 @@ func (e *ECSLoadBalancerManager) upsertSecurityGroup(ecsLoadBalancerID id.ECSLoa
 		}
 	}
 
-	currentIngressPorts := []int64{}
-	requestedIngressPorts := []int64{}
-
+	currentRules := []ingressRule{}
 	for _, permission := range securityGroup.IpPermissions {
-		currentIngressPorts = append(currentIngressPorts, *permission.FromPort)
+		port := aws.Int64Value(permission.FromPort)
+		for _, ipRange := range permission.IpRanges {
+			currentRules = append(currentRules, ingressRule{Port: port, CIDR: aws.StringValue(ipRange.CidrIp)})
+		}
+
+		for _, pair := range permission.UserIdGroupPairs {
+			currentRules = append(currentRules, ingressRule{Port: port, SourceSecurityGroupID: aws.StringValue(pair.GroupId)})
+		}
 	}
 
+	requestedRules := []ingressRule{}
 	for _, port := range ports {
-		requestedIngressPorts = append(requestedIngressPorts, int64(port.HostPort))
+		requestedRules = append(requestedRules, portToIngressRules(port)...)
 	}
 
 	ingressesToRemove := []*ec2.SecurityGroupIngress{}
-	for _, ingressPort := range ingressPortDifference(currentIngressPorts, requestedIngressPorts) {
-		ingress := ec2.NewSecurityGroupIngress(*securityGroup.GroupId, "0.0.0.0/0", "TCP", int(ingressPort), int(ingressPort))
-		ingressesToRemove = append(ingressesToRemove, ingress)
+	for _, rule := range ingressRuleDifference(currentRules, requestedRules) {
+		ingressesToRemove = append(ingressesToRemove, rule.ToIngress(*securityGroup.GroupId))
 	}
 
 	if len(ingressesToRemove) > 0 {
@@ -585,9 +909,8 @@ func (e *ECSLoadBalancerManager) upsertSecurityGroup(ecsLoadBalancerID id.ECSLoa
 	}
 
 	ingressesToAdd := []*ec2.SecurityGroupIngress{}
-	for _, ingressPort := range ingressPortDifference(requestedIngressPorts, currentIngressPorts) {
-		ingress := ec2.NewSecurityGroupIngress(*securityGroup.GroupId, "0.0.0.0/0", "TCP", int(ingressPort), int(ingressPort))
-		ingressesToAdd = append(ingressesToAdd, ingress)
+	for _, rule := range ingressRuleDifference(requestedRules, currentRules) {
+		ingressesToAdd = append(ingressesToAdd, rule.ToIngress(*securityGroup.GroupId))
 	}
 
 	if len(ingressesToAdd) > 0 {
@@ -600,13 +923,30 @@ func (e *ECSLoadBalancerManager) upsertSecurityGroup(ecsLoadBalancerID id.ECSLoa
 	return securityGroup, nil
 }
 
+// private load balancers don't have their own security group, so sources can't be enforced
+func validatePortSources(isPublic bool, ports []models.Port) error {
+	if isPublic {
+		return nil
+	}
+
+	for _, port := range ports {
+		if len(port.SourceCIDRs) > 0 || len(port.SourceSecurityGroups) > 0 {
+			err := fmt.Errorf("Port %d: source cidrs and security groups are only supported on public load balancers", port.HostPort)
+			return errors.New(errors.InvalidRequest, err)
+		}
+	}
+
+	return nil
+}
+
 // returns ports in "requested" that aren't in "current"
+// source cidrs and security groups are ignored since they don't affect the elb listeners
 func portDifference(requested, current []models.Port) []models.Port {
 	difference := []models.Port{}
 	for _, r := range requested {
 		var exists bool
 		for _, c := range current {
-			if reflect.DeepEqual(r, c) {
+			if reflect.DeepEqual(listenerFields(r), listenerFields(c)) {
 				exists = true
 				break
 			}
@@ -620,9 +960,56 @@ func portDifference(requested, current []models.Port) []models.Port {
 	return difference
 }
 
-// returns ports in "requested" that aren't in "current"
-func ingressPortDifference(requested, current []int64) []int64 {
-	difference := []int64{}
+func listenerFields(port models.Port) models.Port {
+	port.SourceCIDRs = nil
+	port.SourceSecurityGroups = nil
+
+	// a listener only knows its certificate by arn
+	if port.CertificateARN != "" {
+		port.CertificateName = ""
+		port.CertificateDomain = ""
+	}
+
+	return port
+}
+
+// ingressRule is a single entry in a load balancer's security group.
+// Exactly one of CIDR or SourceSecurityGroupID is set.
+type ingressRule struct {
+	Port                  int64
+	CIDR                  string
+	SourceSecurityGroupID string
+}
+
+func (r ingressRule) ToIngress(groupID string) *ec2.SecurityGroupIngress {
+	if r.SourceSecurityGroupID != "" {
+		return ec2.NewSecurityGroupIngressFromGroup(groupID, r.SourceSecurityGroupID, "TCP", int(r.Port), int(r.Port))
+	}
+
+	return ec2.NewSecurityGroupIngress(groupID, r.CIDR, "TCP", int(r.Port), int(r.Port))
+}
+
+// ports without any sources are open to the world
+func portToIngressRules(port models.Port) []ingressRule {
+	if len(port.SourceCIDRs) == 0 && len(port.SourceSecurityGroups) == 0 {
+		return []ingressRule{{Port: port.HostPort, CIDR: DEFAULT_INGRESS_CIDR}}
+	}
+
+	rules := []ingressRule{}
+	for _, cidr := range port.SourceCIDRs {
+		rules = append(rules, ingressRule{Port: port.HostPort, CIDR: cidr})
+	}
+
+	for _, groupID := range port.SourceSecurityGroups {
+		rules = append(rules, ingressRule{Port: port.HostPort, SourceSecurityGroupID: groupID})
+	}
+
+	return rules
+}
+
+// returns rules in "requested" that aren't in "current"
+func ingressRuleDifference(requested, current []ingressRule) []ingressRule {
+	difference := []ingressRule{}
 	for _, r := range requested {
 		var exists bool
 		for _, c := range current {
@@ -2,40 +2,56 @@ package ecsbackend
 
 import (
 	"fmt"
+	"os"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	aws_cloudwatch "github.com/aws/aws-sdk-go/service/cloudwatch"
 	aws_ec2 "github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/golang/mock/gomock"
 	"github.com/quintilesims/layer0/api/backend/ecs/id"
 	"github.com/quintilesims/layer0/api/backend/mock_backend"
+	"github.com/quintilesims/layer0/common/aws/acm"
+	"github.com/quintilesims/layer0/common/aws/acm/mock_acm"
+	"github.com/quintilesims/layer0/common/aws/cloudwatch/mock_cloudwatch"
 	"github.com/quintilesims/layer0/common/aws/ec2"
 	"github.com/quintilesims/layer0/common/aws/ec2/mock_ec2"
 	"github.com/quintilesims/layer0/common/aws/elb"
 	"github.com/quintilesims/layer0/common/aws/elb/mock_elb"
 	"github.com/quintilesims/layer0/common/aws/iam/mock_iam"
+	"github.com/quintilesims/layer0/common/aws/route53/mock_route53"
+	"github.com/quintilesims/layer0/common/config"
+	"github.com/quintilesims/layer0/common/errors"
 	"github.com/quintilesims/layer0/common/models"
 	"github.com/quintilesims/layer0/common/testutils"
 )
 
 type MockECSLoadBalancerManager struct {
-	EC2     *mock_ec2.MockProvider
-	ELB     *mock_elb.MockProvider
-	IAM     *mock_iam.MockProvider
-	Backend *mock_backend.MockBackend
+	EC2        *mock_ec2.MockProvider
+	ELB        *mock_elb.MockProvider
+	IAM        *mock_iam.MockProvider
+	ACM        *mock_acm.MockProvider
+	CloudWatch *mock_cloudwatch.MockProvider
+	Route53    *mock_route53.MockProvider
+	Backend    *mock_backend.MockBackend
 }
 
 func NewMockECSLoadBalancerManager(ctrl *gomock.Controller) *MockECSLoadBalancerManager {
 	return &MockECSLoadBalancerManager{
-		EC2:     mock_ec2.NewMockProvider(ctrl),
-		ELB:     mock_elb.NewMockProvider(ctrl),
-		IAM:     mock_iam.NewMockProvider(ctrl),
-		Backend: mock_backend.NewMockBackend(ctrl),
+		EC2:        mock_ec2.NewMockProvider(ctrl),
+		ELB:        mock_elb.NewMockProvider(ctrl),
+		IAM:        mock_iam.NewMockProvider(ctrl),
+		ACM:        mock_acm.NewMockProvider(ctrl),
+		CloudWatch: mock_cloudwatch.NewMockProvider(ctrl),
+		Route53:    mock_route53.NewMockProvider(ctrl),
+		Backend:    mock_backend.NewMockBackend(ctrl),
 	}
 }
 
 func (this *MockECSLoadBalancerManager) LoadBalancer() *ECSLoadBalancerManager {
-	return NewECSLoadBalancerManager(this.EC2, this.ELB, this.IAM, this.Backend)
+	return NewECSLoadBalancerManager(this.EC2, this.ELB, this.IAM, this.ACM, this.CloudWatch, this.Route53, this.Backend)
 }
 
 func makeSubnet(az string) *ec2.Subnet {
@@ -819,4 +835,467 @@ func TestUpdateLoadBalancerIdleTimeout(t *testing.T) {
 	testutils.RunTests(t, testCases)
 }
 
+func TestUpdateLoadBalancerAccessLog(t *testing.T) {
+	testCases := []testutils.TestCase{
+		{
+			Name: "Should pass proper params to ELB.SetAccessLog.",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockLB := NewMockECSLoadBalancerManager(ctrl)
+
+				loadBalancerID := id.L0LoadBalancerID("lbid").ECSLoadBalancerID()
+				loadBalancer := elb.NewLoadBalancerDescription(loadBalancerID.String(), "", nil)
+
+				mockLB.ELB.EXPECT().
+					SetAccessLog(loadBalancerID.String(), true, "bucket", "prefix", 5).
+					Return(nil)
+
+				mockLB.ELB.EXPECT().
+					DescribeLoadBalancer(loadBalancerID.String()).
+					Return(loadBalancer, nil)
+
+				loadBalancerAttributes := elb.NewLoadBalancerAttributes()
+				loadBalancerAttributes.AccessLog.Enabled = aws.Bool(true)
+				loadBalancerAttributes.AccessLog.S3BucketName = aws.String("bucket")
+				loadBalancerAttributes.AccessLog.S3BucketPrefix = aws.String("prefix")
+				loadBalancerAttributes.AccessLog.EmitInterval = aws.Int64(5)
+
+				mockLB.ELB.EXPECT().
+					DescribeLoadBalancerAttributes(gomock.Any()).
+					Return(loadBalancerAttributes, nil)
+
+				return mockLB.LoadBalancer()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSLoadBalancerManager)
+
+				accessLog := models.AccessLog{
+					Enabled:      true,
+					BucketName:   "bucket",
+					BucketPrefix: "prefix",
+					EmitInterval: 5,
+				}
+
+				model, err := manager.UpdateLoadBalancerAccessLog("lbid", accessLog)
+				if err != nil {
+					reporter.Fatal(err)
+				}
+
+				reporter.AssertEqual(accessLog, model.AccessLog)
+			},
+		},
+	}
+
+	testutils.RunTests(t, testCases)
+}
+
+func TestGetLoadBalancerMetrics(t *testing.T) {
+	startTime := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(time.Hour)
+
+	testCases := []testutils.TestCase{
+		{
+			Name: "Should aggregate cloudwatch datapoints",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockLB := NewMockECSLoadBalancerManager(ctrl)
+
+				t0 := startTime
+				t1 := startTime.Add(time.Minute)
+
+				datapoints := map[string][]aws_cloudwatch.Datapoint{
+					"RequestCount": {
+						{Timestamp: aws.Time(t1), Sum: aws.Float64(30)},
+						{Timestamp: aws.Time(t0), Sum: aws.Float64(10)},
+					},
+					"Latency": {
+						{Timestamp: aws.Time(t0), Average: aws.Float64(0.5)},
+						{Timestamp: aws.Time(t1), Average: aws.Float64(0.25)},
+					},
+					"HTTPCode_ELB_5XX": {
+						{Timestamp: aws.Time(t0), Sum: aws.Float64(1)},
+					},
+					"HTTPCode_Backend_5XX": {
+						{Timestamp: aws.Time(t1), Sum: aws.Float64(2)},
+					},
+				}
+
+				for metricName, results := range datapoints {
+					mockLB.CloudWatch.EXPECT().
+						GetMetricStatistics(ELB_METRICS_NAMESPACE, metricName, int64(60), gomock.Any(), gomock.Any(), startTime, endTime).
+						Return(results, nil)
+				}
+
+				return mockLB.LoadBalancer()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSLoadBalancerManager)
+
+				metrics, err := manager.GetLoadBalancerMetrics("lbid", startTime, endTime, 60)
+				if err != nil {
+					reporter.Fatal(err)
+				}
+
+				reporter.AssertEqual(metrics.LoadBalancerID, "lbid")
+				reporter.AssertEqual(metrics.RequestCount, int64(40))
+				reporter.AssertEqual(metrics.HTTPCodeELB5XX, int64(1))
+				reporter.AssertEqual(metrics.HTTPCodeBackend5XX, int64(2))
+				reporter.AssertEqual(metrics.Latency, 0.3125)
+
+				reporter.AssertEqual(len(metrics.Datapoints), 2)
+				reporter.AssertEqual(metrics.Datapoints[0].Timestamp, startTime)
+				reporter.AssertEqual(metrics.Datapoints[0].RequestCount, int64(10))
+				reporter.AssertEqual(metrics.Datapoints[1].RequestCount, int64(30))
+			},
+		},
+		{
+			Name: "Should propagate cloudwatch.GetMetricStatistics error",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockLB := NewMockECSLoadBalancerManager(ctrl)
+
+				mockLB.CloudWatch.EXPECT().
+					GetMetricStatistics(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("some error"))
+
+				return mockLB.LoadBalancer()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSLoadBalancerManager)
+
+				if _, err := manager.GetLoadBalancerMetrics("lbid", startTime, endTime, 60); err == nil {
+					reporter.Fatalf("Error was nil!")
+				}
+			},
+		},
+	}
+
+	testutils.RunTests(t, testCases)
+}
+
+func TestCreateLoadBalancerDNSRecord(t *testing.T) {
+	defer os.Unsetenv(config.AWS_ROUTE53_HOSTED_ZONE)
+
+	testCases := []testutils.TestCase{
+		{
+			Name: "Should create alias record to the load balancer",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				os.Setenv(config.AWS_ROUTE53_HOSTED_ZONE, "ZONEID")
+				mockLB := NewMockECSLoadBalancerManager(ctrl)
+
+				loadBalancerID := id.L0LoadBalancerID("lbid").ECSLoadBalancerID()
+				loadBalancer := elb.NewLoadBalancerDescription(loadBalancerID.String(), "", nil)
+				loadBalancer.DNSName = aws.String("elb.amazonaws.com")
+				loadBalancer.CanonicalHostedZoneNameID = aws.String("ELBZONEID")
+
+				mockLB.ELB.EXPECT().
+					DescribeLoadBalancer(loadBalancerID.String()).
+					Return(loadBalancer, nil)
+
+				mockLB.Route53.EXPECT().
+					CreateAliasRecord("ZONEID", "api.example.com", "elb.amazonaws.com", "ELBZONEID").
+					Return(nil)
+
+				return mockLB.LoadBalancer()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSLoadBalancerManager)
+
+				if err := manager.CreateLoadBalancerDNSRecord("lbid", "api.example.com"); err != nil {
+					reporter.Fatal(err)
+				}
+			},
+		},
+		{
+			Name: "Should error if no hosted zone is configured",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				os.Unsetenv(config.AWS_ROUTE53_HOSTED_ZONE)
+				mockLB := NewMockECSLoadBalancerManager(ctrl)
+
+				return mockLB.LoadBalancer()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSLoadBalancerManager)
+
+				if err := manager.CreateLoadBalancerDNSRecord("lbid", "api.example.com"); err == nil {
+					reporter.Fatalf("Error was nil!")
+				}
+			},
+		},
+		{
+			Name: "Should return invalid request if the record already exists",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				os.Setenv(config.AWS_ROUTE53_HOSTED_ZONE, "ZONEID")
+				mockLB := NewMockECSLoadBalancerManager(ctrl)
+
+				loadBalancerID := id.L0LoadBalancerID("lbid").ECSLoadBalancerID()
+				loadBalancer := elb.NewLoadBalancerDescription(loadBalancerID.String(), "", nil)
+				loadBalancer.DNSName = aws.String("elb.amazonaws.com")
+				loadBalancer.CanonicalHostedZoneNameID = aws.String("ELBZONEID")
+
+				mockLB.ELB.EXPECT().
+					DescribeLoadBalancer(loadBalancerID.String()).
+					Return(loadBalancer, nil)
+
+				mockLB.Route53.EXPECT().
+					CreateAliasRecord("ZONEID", "api.example.com", "elb.amazonaws.com", "ELBZONEID").
+					Return(awserr.New("InvalidChangeBatch", "Tried to create resource record set but it already exists", nil))
+
+				return mockLB.LoadBalancer()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSLoadBalancerManager)
+
+				err := manager.CreateLoadBalancerDNSRecord("lbid", "api.example.com")
+				if err == nil {
+					reporter.Fatalf("Error was nil!")
+				}
+
+				serverError, ok := err.(*errors.ServerError)
+				if !ok {
+					reporter.Fatalf("Error was not a ServerError: %v", err)
+				}
+
+				reporter.AssertEqual(serverError.Code, errors.InvalidRequest)
+			},
+		},
+	}
+
+	testutils.RunTests(t, testCases)
+}
+
+func TestDeleteLoadBalancerDNSRecord(t *testing.T) {
+	defer os.Unsetenv(config.AWS_ROUTE53_HOSTED_ZONE)
+
+	testCases := []testutils.TestCase{
+		{
+			Name: "Should delete the alias record",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				os.Setenv(config.AWS_ROUTE53_HOSTED_ZONE, "ZONEID")
+				mockLB := NewMockECSLoadBalancerManager(ctrl)
+
+				loadBalancerID := id.L0LoadBalancerID("lbid").ECSLoadBalancerID()
+				loadBalancer := elb.NewLoadBalancerDescription(loadBalancerID.String(), "", nil)
+				loadBalancer.DNSName = aws.String("elb.amazonaws.com")
+
+				mockLB.ELB.EXPECT().
+					DescribeLoadBalancer(loadBalancerID.String()).
+					Return(loadBalancer, nil)
+
+				mockLB.Route53.EXPECT().
+					DeleteAliasRecord("ZONEID", "api.example.com", "elb.amazonaws.com").
+					Return(nil)
+
+				return mockLB.LoadBalancer()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSLoadBalancerManager)
+
+				if err := manager.DeleteLoadBalancerDNSRecord("lbid", "api.example.com"); err != nil {
+					reporter.Fatal(err)
+				}
+			},
+		},
+		{
+			Name: "Should skip the delete if the load balancer does not exist",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				os.Setenv(config.AWS_ROUTE53_HOSTED_ZONE, "ZONEID")
+				mockLB := NewMockECSLoadBalancerManager(ctrl)
+
+				loadBalancerID := id.L0LoadBalancerID("lbid").ECSLoadBalancerID()
+				mockLB.ELB.EXPECT().
+					DescribeLoadBalancer(loadBalancerID.String()).
+					Return(nil, awserr.New("LoadBalancerNotFound", "some message", nil))
+
+				return mockLB.LoadBalancer()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSLoadBalancerManager)
+
+				if err := manager.DeleteLoadBalancerDNSRecord("lbid", "api.example.com"); err != nil {
+					reporter.Fatal(err)
+				}
+			},
+		},
+	}
+
+	testutils.RunTests(t, testCases)
+}
+
+func TestUpdateLoadBalancerPorts_sources(t *testing.T) {
+	testCases := []testutils.TestCase{
+		{
+			Name: "Should only update security group rules when sources change",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockLB := NewMockECSLoadBalancerManager(ctrl)
+
+				loadBalancerID := id.L0LoadBalancerID("lbid").ECSLoadBalancerID()
+				listener := elb.NewListener(80, "HTTP", 80, "HTTP", "")
+				loadBalancer := elb.NewLoadBalancerDescription(loadBalancerID.String(), "internet-facing", []*elb.Listener{listener})
+
+				mockLB.ELB.EXPECT().
+					DescribeLoadBalancer(loadBalancerID.String()).
+					Return(loadBalancer, nil)
+
+				mockLB.ELB.EXPECT().
+					DescribeLoadBalancerAttributes(gomock.Any()).
+					Return(elb.NewLoadBalancerAttributes(), nil)
+
+				securityGroup := ec2.NewSecurityGroup("lb_sg")
+				securityGroup.IpPermissions = []*aws_ec2.IpPermission{
+					{
+						FromPort: aws.Int64(80),
+						ToPort:   aws.Int64(80),
+						IpRanges: []*aws_ec2.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
+					},
+				}
+
+				mockLB.EC2.EXPECT().
+					DescribeSecurityGroup(loadBalancerID.SecurityGroupName()).
+					Return(securityGroup, nil).
+					Times(2)
+
+				mockLB.EC2.EXPECT().
+					RevokeSecurityGroupIngress([]*ec2.SecurityGroupIngress{
+						ec2.NewSecurityGroupIngress("lb_sg", "0.0.0.0/0", "TCP", 80, 80),
+					}).
+					Return(nil)
+
+				mockLB.EC2.EXPECT().
+					AuthorizeSecurityGroupIngress([]*ec2.SecurityGroupIngress{
+						ec2.NewSecurityGroupIngress("lb_sg", "10.0.0.0/8", "TCP", 80, 80),
+						ec2.NewSecurityGroupIngressFromGroup("lb_sg", "sg-123", "TCP", 80, 80),
+					}).
+					Return(nil)
+
+				return mockLB.LoadBalancer()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSLoadBalancerManager)
+
+				ports := []models.Port{
+					{
+						HostPort:             80,
+						ContainerPort:        80,
+						Protocol:             "HTTP",
+						SourceCIDRs:          []string{"10.0.0.0/8"},
+						SourceSecurityGroups: []string{"sg-123"},
+					},
+				}
+
+				model, err := manager.UpdateLoadBalancerPorts("lbid", ports)
+				if err != nil {
+					reporter.Fatal(err)
+				}
+
+				reporter.AssertEqual(model.Ports, ports)
+			},
+		},
+		{
+			Name: "Should error when sources are set on a private load balancer",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockLB := NewMockECSLoadBalancerManager(ctrl)
+
+				loadBalancerID := id.L0LoadBalancerID("lbid").ECSLoadBalancerID()
+				loadBalancer := elb.NewLoadBalancerDescription(loadBalancerID.String(), "internal", nil)
+
+				mockLB.ELB.EXPECT().
+					DescribeLoadBalancer(loadBalancerID.String()).
+					Return(loadBalancer, nil)
+
+				mockLB.ELB.EXPECT().
+					DescribeLoadBalancerAttributes(gomock.Any()).
+					Return(elb.NewLoadBalancerAttributes(), nil)
+
+				return mockLB.LoadBalancer()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSLoadBalancerManager)
+
+				ports := []models.Port{
+					{
+						HostPort:      80,
+						ContainerPort: 80,
+						Protocol:      "HTTP",
+						SourceCIDRs:   []string{"10.0.0.0/8"},
+					},
+				}
+
+				if _, err := manager.UpdateLoadBalancerPorts("lbid", ports); err == nil {
+					reporter.Fatalf("Error was nil!")
+				}
+			},
+		},
+	}
+
+	testutils.RunTests(t, testCases)
+}
+
+func TestPortDifference_ignoresSources(t *testing.T) {
+	current := []models.Port{{HostPort: 80, ContainerPort: 80, Protocol: "HTTP"}}
+	requested := []models.Port{{HostPort: 80, ContainerPort: 80, Protocol: "HTTP", SourceCIDRs: []string{"10.0.0.0/8"}}}
+
+	testutils.AssertEqual(t, len(portDifference(requested, current)), 0)
+	testutils.AssertEqual(t, len(portDifference(current, requested)), 0)
+}
+
 // todo: UpdateLoadBalancerPorts
+
+func TestResolveCertificateARN_acmDomain(t *testing.T) {
+	testCases := []testutils.TestCase{
+		{
+			Name: "Should use the issued acm certificate that expires last",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockLB := NewMockECSLoadBalancerManager(ctrl)
+
+				summary := func(arn, domainName, status string, notAfter time.Time) *acm.CertificateSummary {
+					summary := acm.NewCertificateSummary(arn, domainName)
+					summary.Status = aws.String(status)
+					summary.NotAfter = aws.Time(notAfter)
+					return summary
+				}
+
+				summaries := []*acm.CertificateSummary{
+					summary("arn:aws:acm:::certificate/old", "example.com", "ISSUED", time.Unix(1000, 0)),
+					summary("arn:aws:acm:::certificate/new", "EXAMPLE.com", "ISSUED", time.Unix(2000, 0)),
+					summary("arn:aws:acm:::certificate/pending", "example.com", "PENDING_VALIDATION", time.Unix(3000, 0)),
+					summary("arn:aws:acm:::certificate/other", "other.com", "ISSUED", time.Unix(4000, 0)),
+				}
+
+				mockLB.ACM.EXPECT().
+					ListCertificates().
+					Return(summaries, nil)
+
+				return mockLB.LoadBalancer()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSLoadBalancerManager)
+
+				arn, err := manager.resolveCertificateARN(models.Port{CertificateDomain: "example.com"})
+				if err != nil {
+					reporter.Fatal(err)
+				}
+
+				reporter.AssertEqual(arn, "arn:aws:acm:::certificate/new")
+			},
+		},
+		{
+			Name: "Should error if no issued acm certificate matches the domain",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockLB := NewMockECSLoadBalancerManager(ctrl)
+
+				mockLB.ACM.EXPECT().
+					ListCertificates().
+					Return([]*acm.CertificateSummary{}, nil)
+
+				return mockLB.LoadBalancer()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				manager := target.(*ECSLoadBalancerManager)
+
+				if _, err := manager.resolveCertificateARN(models.Port{CertificateDomain: "example.com"}); err == nil {
+					reporter.Fatalf("Error was nil!")
+				}
+			},
+		},
+	}
+
+	testutils.RunTests(t, testCases)
+}
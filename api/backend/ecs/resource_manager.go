@@ -69,12 +69,16 @@ func (r *ECSResourceManager) getResourceProvider(ecsEnvironmentID id.ECSEnvironm
 	// instance.ReminaingResources, not instance.RegisteredResources
 	var usedPorts []int
 	var availableMemory bytesize.Bytesize
+	var availableCPU int
 	for _, resource := range instance.RemainingResources {
 		switch pstring(resource.Name) {
 		case "MEMORY":
 			v := pint64(resource.IntegerValue)
 			availableMemory = bytesize.MiB * bytesize.Bytesize(v)
 
+		case "CPU":
+			availableCPU = int(pint64(resource.IntegerValue))
+
 		case "PORTS":
 			for _, p := range resource.StringSetValue {
 				port, err := strconv.Atoi(pstring(p))
@@ -88,8 +92,20 @@ func (r *ECSResourceManager) getResourceProvider(ecsEnvironmentID id.ECSEnvironm
 		}
 	}
 
+	var totalMemory bytesize.Bytesize
+	var totalCPU int
+	for _, resource := range instance.RegisteredResources {
+		switch pstring(resource.Name) {
+		case "MEMORY":
+			totalMemory = bytesize.MiB * bytesize.Bytesize(pint64(resource.IntegerValue))
+		case "CPU":
+			totalCPU = int(pint64(resource.IntegerValue))
+		}
+	}
+
 	inUse := pint64(instance.PendingTasksCount)+pint64(instance.RunningTasksCount) > 0
 	provider := resource.NewResourceProvider(instanceID, inUse, availableMemory, usedPorts)
+	provider.SetCapacity(totalMemory, totalCPU, availableCPU)
 
 	r.logger.Debugf("Environment '%s' generated provider: %#v\n", ecsEnvironmentID, provider)
 	return provider, true
@@ -102,16 +118,11 @@ func (r *ECSResourceManager) CalculateNewProvider(environmentID string) (*resour
 		return nil, err
 	}
 
-	config, err := r.Autoscaling.DescribeLaunchConfiguration(pstring(group.LaunchConfigurationName))
+	memory, err := r.calculateNewProviderMemory(environmentID, group)
 	if err != nil {
 		return nil, err
 	}
 
-	memory, ok := ec2.InstanceSizes[pstring(config.InstanceType)]
-	if !ok {
-		return nil, fmt.Errorf("Environment %s is using unknown instance type '%s'", environmentID, pstring(config.InstanceType))
-	}
-
 	// these ports are automatically used by the ecs agent
 	defaultPorts := []int{
 		22,
@@ -124,6 +135,39 @@ func (r *ECSResourceManager) CalculateNewProvider(environmentID string) (*resour
 	return resource.NewResourceProvider("<new instance>", false, memory, defaultPorts), nil
 }
 
+// calculateNewProviderMemory returns the memory a new instance in the group will have.
+// Groups with a mixed instances policy may launch any of their instance types,
+// so the smallest of them is used.
+func (r *ECSResourceManager) calculateNewProviderMemory(environmentID string, group *autoscaling.Group) (bytesize.Bytesize, error) {
+	if policy := group.MixedInstancesPolicy; policy != nil && policy.LaunchTemplate != nil {
+		var memory bytesize.Bytesize
+		for i, override := range policy.LaunchTemplate.Overrides {
+			size, ok := ec2.InstanceSizes[pstring(override.InstanceType)]
+			if !ok {
+				return 0, fmt.Errorf("Environment %s is using unknown instance type '%s'", environmentID, pstring(override.InstanceType))
+			}
+
+			if i == 0 || size < memory {
+				memory = size
+			}
+		}
+
+		return memory, nil
+	}
+
+	config, err := r.Autoscaling.DescribeLaunchConfiguration(pstring(group.LaunchConfigurationName))
+	if err != nil {
+		return 0, err
+	}
+
+	memory, ok := ec2.InstanceSizes[pstring(config.InstanceType)]
+	if !ok {
+		return 0, fmt.Errorf("Environment %s is using unknown instance type '%s'", environmentID, pstring(config.InstanceType))
+	}
+
+	return memory, nil
+}
+
 func (r *ECSResourceManager) ScaleTo(environmentID string, scale int, unusedProviders []*resource.ResourceProvider) (int, error) {
 	ecsEnvironmentID := id.L0EnvironmentID(environmentID).ECSEnvironmentID()
 	asg, err := r.Autoscaling.DescribeAutoScalingGroup(ecsEnvironmentID.String())
@@ -53,11 +53,25 @@ func TestResourceManager_GetProviders(t *testing.T) {
 				AgentConnected:    boolp(true),
 				RunningTasksCount: int64p(1),
 				PendingTasksCount: int64p(1),
+				RegisteredResources: []*awsecs.Resource{
+					{
+						Name:         stringp("MEMORY"),
+						IntegerValue: int64p(1000),
+					},
+					{
+						Name:         stringp("CPU"),
+						IntegerValue: int64p(1024),
+					},
+				},
 				RemainingResources: []*awsecs.Resource{
 					{
 						Name:         stringp("MEMORY"),
 						IntegerValue: int64p(500),
 					},
+					{
+						Name:         stringp("CPU"),
+						IntegerValue: int64p(256),
+					},
 					{
 						Name: stringp("PORTS"),
 						StringSetValue: []*string{
@@ -116,9 +130,44 @@ func TestResourceManager_GetProviders(t *testing.T) {
 		resource.NewResourceProvider("", false, bytesize.MiB*1000, []int{80}),
 	}
 
+	expected[0].SetCapacity(bytesize.MiB*1000, 1024, 256)
+
 	testutils.AssertEqual(t, expected, providers)
 }
 
+func TestResourceManager_CalculateNewProvider_mixedInstancesPolicy(t *testing.T) {
+	rm, ctrl := newMockResourceManager(t)
+	defer ctrl.Finish()
+
+	environmentID := id.L0EnvironmentID("eid")
+	asg := &autoscaling.Group{
+		&awsasg.Group{
+			AutoScalingGroupName: stringp("asg_name"),
+			MixedInstancesPolicy: &awsasg.MixedInstancesPolicy{
+				LaunchTemplate: &awsasg.LaunchTemplate{
+					Overrides: []*awsasg.LaunchTemplateOverrides{
+						{InstanceType: stringp("m5.large")},
+						{InstanceType: stringp("t2.small")},
+						{InstanceType: stringp("m4.large")},
+					},
+				},
+			},
+		},
+	}
+
+	rm.Autoscaling.EXPECT().
+		DescribeAutoScalingGroup(environmentID.ECSEnvironmentID().String()).
+		Return(asg, nil)
+
+	provider, err := rm.ResourceManager().CalculateNewProvider(environmentID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := resource.NewResourceProvider("<new instance>", false, bytesize.GiB*2, []int{22, 2376, 2375, 51678, 51679})
+	testutils.AssertEqual(t, provider, expected)
+}
+
 func TestResourceManager_scaleUp(t *testing.T) {
 	rm, ctrl := newMockResourceManager(t)
 	defer ctrl.Finish()
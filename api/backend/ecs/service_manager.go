@@ -62,6 +62,31 @@ func (this *ECSServiceManager) ListServices() ([]id.ECSServiceID, error) {
 	return serviceIDs, nil
 }
 
+// ListServicesPage reads at most limit services from the cluster of each environment in turn,
+// starting at the position encoded in nextToken
+func (this *ECSServiceManager) ListServicesPage(limit int, nextToken string) ([]id.ECSServiceID, string, error) {
+	clusterNames, err := listClusterNames(this.Backend)
+	if err != nil {
+		return nil, "", err
+	}
+
+	fn := func(clusterName, _ string, maxResults int64, nextToken *string) ([]string, *string, error) {
+		return this.ECS.ListClusterServiceNamesPage(clusterName, id.PREFIX, maxResults, nextToken)
+	}
+
+	serviceNames, nextToken, err := listClustersPage(clusterNames, []string{""}, limit, nextToken, fn)
+	if err != nil {
+		return nil, "", err
+	}
+
+	serviceIDs := make([]id.ECSServiceID, len(serviceNames))
+	for i, serviceName := range serviceNames {
+		serviceIDs[i] = id.ECSServiceID(serviceName)
+	}
+
+	return serviceIDs, nextToken, nil
+}
+
 func (this *ECSServiceManager) GetService(environmentID, serviceID string) (*models.Service, error) {
 	ecsEnvironmentID := id.L0EnvironmentID(environmentID).ECSEnvironmentID()
 	ecsServiceID := id.L0ServiceID(serviceID).ECSServiceID()
@@ -299,7 +324,24 @@ func (this *ECSServiceManager) ScaleService(environmentID string, serviceID stri
 	return this.GetService(environmentID, serviceID)
 }
 
-func (this *ECSServiceManager) GetServiceLogs(environmentID, serviceID, start, end string, tail int) ([]*models.LogFile, error) {
+func (this *ECSServiceManager) GetServiceLogs(environmentID, serviceID, start, end, filter, container string, tail int) ([]*models.LogFile, error) {
+	taskARNs, err := this.getServiceTaskARNs(environmentID, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetLogs(this.CloudWatchLogs, taskARNs, start, end, filter, container, tail)
+}
+
+func (this *ECSServiceManager) FollowServiceLogs(environmentID, serviceID, start, container string, cursor *backend.LogCursor) ([]*models.LogEvent, error) {
+	getTaskARNs := func() ([]*string, error) {
+		return this.getServiceTaskARNs(environmentID, serviceID)
+	}
+
+	return FollowLogs(this.CloudWatchLogs, getTaskARNs, start, container, cursor)
+}
+
+func (this *ECSServiceManager) getServiceTaskARNs(environmentID, serviceID string) ([]*string, error) {
 	ecsEnvironmentID := id.L0EnvironmentID(environmentID).ECSEnvironmentID()
 
 	service, err := this.GetService(environmentID, serviceID)
@@ -317,7 +359,7 @@ func (this *ECSServiceManager) GetServiceLogs(environmentID, serviceID, start, e
 		taskARNs = append(taskARNs, arns...)
 	}
 
-	return GetLogs(this.CloudWatchLogs, taskARNs, start, end, tail)
+	return taskARNs, nil
 }
 
 func (this *ECSServiceManager) populateModel(service *ecs.Service) *models.Service {
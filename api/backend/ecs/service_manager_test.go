@@ -481,11 +481,13 @@ func TestGetServiceLogs(t *testing.T) {
 				recorder := testutils.NewRecorder(ctrl)
 				recorder.EXPECT().Call("")
 
-				GetLogs = func(cloudWatchLogs cloudwatchlogs.Provider, taskARNs []*string, start, end string, tail int) ([]*models.LogFile, error) {
+				GetLogs = func(cloudWatchLogs cloudwatchlogs.Provider, taskARNs []*string, start, end, filter, container string, tail int) ([]*models.LogFile, error) {
 					recorder.Call("")
 					reporter.AssertEqual(tail, 100)
 					reporter.AssertEqual(start, "start")
 					reporter.AssertEqual(end, "end")
+					reporter.AssertEqual(filter, "filter")
+					reporter.AssertEqual(container, "container")
 					return nil, nil
 				}
 
@@ -493,7 +495,7 @@ func TestGetServiceLogs(t *testing.T) {
 			},
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				manager := target.(*ECSServiceManager)
-				manager.GetServiceLogs("envid", "svcid", "start", "end", 100)
+				manager.GetServiceLogs("envid", "svcid", "start", "end", "filter", "container", 100)
 			},
 		},
 		{
@@ -513,7 +515,7 @@ func TestGetServiceLogs(t *testing.T) {
 				recorder := testutils.NewRecorder(ctrl)
 				recorder.EXPECT().Call("")
 
-				GetLogs = func(cloudWatchLogs cloudwatchlogs.Provider, taskARNs []*string, start, end string, tail int) ([]*models.LogFile, error) {
+				GetLogs = func(cloudWatchLogs cloudwatchlogs.Provider, taskARNs []*string, start, end, filter, container string, tail int) ([]*models.LogFile, error) {
 					recorder.Call("")
 					return nil, fmt.Errorf("some error")
 				}
@@ -523,7 +525,7 @@ func TestGetServiceLogs(t *testing.T) {
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				manager := target.(*ECSServiceManager)
 
-				if _, err := manager.GetServiceLogs("envid", "svcid", "start", "end", 100); err == nil {
+				if _, err := manager.GetServiceLogs("envid", "svcid", "start", "end", "filter", "container", 100); err == nil {
 					reporter.Fatalf("Error was nil!")
 				}
 			},
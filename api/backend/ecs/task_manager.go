@@ -2,6 +2,7 @@ package ecsbackend
 
 import (
 	"github.com/aws/aws-sdk-go/aws"
+	awsecs "github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/quintilesims/layer0/api/backend"
 	"github.com/quintilesims/layer0/api/backend/ecs/id"
 	"github.com/quintilesims/layer0/common/aws/cloudwatchlogs"
@@ -52,6 +53,22 @@ func (this *ECSTaskManager) ListTasks() ([]string, error) {
 	return taskARNs, nil
 }
 
+// ListTasksPage reads at most limit running and then stopped tasks from the cluster of each
+// environment in turn, starting at the position encoded in nextToken
+func (this *ECSTaskManager) ListTasksPage(limit int, nextToken string) ([]string, string, error) {
+	clusterNames, err := listClusterNames(this.Backend)
+	if err != nil {
+		return nil, "", err
+	}
+
+	fn := func(clusterName, desiredStatus string, maxResults int64, nextToken *string) ([]string, *string, error) {
+		return this.ECS.ListClusterTaskARNsPage(clusterName, id.PREFIX, desiredStatus, maxResults, nextToken)
+	}
+
+	desiredStatuses := []string{awsecs.DesiredStatusRunning, awsecs.DesiredStatusStopped}
+	return listClustersPage(clusterNames, desiredStatuses, limit, nextToken, fn)
+}
+
 func (this *ECSTaskManager) GetTask(environmentID, taskARN string) (*models.Task, error) {
 	clusterName := id.L0EnvironmentID(environmentID).ECSEnvironmentID()
 	task, err := this.ECS.DescribeTask(clusterName.String(), taskARN)
@@ -110,8 +127,16 @@ func (this *ECSTaskManager) CreateTask(
 	return aws.StringValue(task.TaskArn), nil
 }
 
-func (this *ECSTaskManager) GetTaskLogs(environmentID, taskARN, start, end string, tail int) ([]*models.LogFile, error) {
-	return GetLogs(this.CloudWatchLogs, []*string{stringp(taskARN)}, start, end, tail)
+func (this *ECSTaskManager) GetTaskLogs(environmentID, taskARN, start, end, filter, container string, tail int) ([]*models.LogFile, error) {
+	return GetLogs(this.CloudWatchLogs, []*string{stringp(taskARN)}, start, end, filter, container, tail)
+}
+
+func (this *ECSTaskManager) FollowTaskLogs(environmentID, taskARN, start, container string, cursor *backend.LogCursor) ([]*models.LogEvent, error) {
+	getTaskARNs := func() ([]*string, error) {
+		return []*string{stringp(taskARN)}, nil
+	}
+
+	return FollowLogs(this.CloudWatchLogs, getTaskARNs, start, container, cursor)
 }
 
 // Assumes the tasks are all of the same type
@@ -98,6 +98,71 @@ func TestListTasks(t *testing.T) {
 	assert.Equal(t, expected, result)
 }
 
+func TestListTasksPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTask := NewMockECSTaskManager(ctrl)
+	mockTask.Backend.EXPECT().
+		ListEnvironments().
+		Return([]id.ECSEnvironmentID{"env_id2", "env_id1"}, nil).
+		Times(2)
+
+	running := aws_ecs.DesiredStatusRunning
+	stopped := aws_ecs.DesiredStatusStopped
+	gomock.InOrder(
+		mockTask.ECS.EXPECT().
+			ListClusterTaskARNsPage("env_id1", id.PREFIX, running, int64(3), nil).
+			Return([]string{"arn_1"}, aws.String("token_1"), nil),
+		mockTask.ECS.EXPECT().
+			ListClusterTaskARNsPage("env_id1", id.PREFIX, running, int64(2), aws.String("token_1")).
+			Return([]string{"arn_2"}, nil, nil),
+		mockTask.ECS.EXPECT().
+			ListClusterTaskARNsPage("env_id1", id.PREFIX, stopped, int64(1), nil).
+			Return([]string{"arn_3"}, aws.String("token_2"), nil),
+
+		mockTask.ECS.EXPECT().
+			ListClusterTaskARNsPage("env_id1", id.PREFIX, stopped, int64(3), aws.String("token_2")).
+			Return([]string{"arn_4"}, nil, nil),
+		mockTask.ECS.EXPECT().
+			ListClusterTaskARNsPage("env_id2", id.PREFIX, running, int64(2), nil).
+			Return([]string{}, nil, nil),
+		mockTask.ECS.EXPECT().
+			ListClusterTaskARNsPage("env_id2", id.PREFIX, stopped, int64(2), nil).
+			Return([]string{"arn_5"}, nil, nil),
+	)
+
+	result, nextToken, err := mockTask.Task().ListTasksPage(3, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"arn_1", "arn_2", "arn_3"}, result)
+	assert.NotEqual(t, "", nextToken)
+
+	result, nextToken, err = mockTask.Task().ListTasksPage(3, nextToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"arn_4", "arn_5"}, result)
+	assert.Equal(t, "", nextToken)
+}
+
+func TestListTasksPage_invalidToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTask := NewMockECSTaskManager(ctrl)
+	mockTask.Backend.EXPECT().
+		ListEnvironments().
+		Return([]id.ECSEnvironmentID{"env_id1"}, nil)
+
+	if _, _, err := mockTask.Task().ListTasksPage(3, "!"); err == nil {
+		t.Fatal("Error was nil!")
+	}
+}
+
 func TestDeleteTask(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -159,11 +224,13 @@ func TestGetTaskLogs(t *testing.T) {
 				recorder := testutils.NewRecorder(ctrl)
 				recorder.EXPECT().Call("")
 
-				GetLogs = func(cloudWatchLogs cloudwatchlogs.Provider, taskARNs []*string, start, end string, tail int) ([]*models.LogFile, error) {
+				GetLogs = func(cloudWatchLogs cloudwatchlogs.Provider, taskARNs []*string, start, end, filter, container string, tail int) ([]*models.LogFile, error) {
 					recorder.Call("")
 					reporter.AssertEqual(tail, 100)
 					reporter.AssertEqual(start, "start")
 					reporter.AssertEqual(end, "end")
+					reporter.AssertEqual(filter, "filter")
+					reporter.AssertEqual(container, "container")
 					return nil, nil
 				}
 
@@ -171,7 +238,7 @@ func TestGetTaskLogs(t *testing.T) {
 			},
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				manager := target.(*ECSTaskManager)
-				manager.GetTaskLogs("envid", "tskid", "start", "end", 100)
+				manager.GetTaskLogs("envid", "tskid", "start", "end", "filter", "container", 100)
 			},
 		},
 		{
@@ -188,7 +255,7 @@ func TestGetTaskLogs(t *testing.T) {
 				recorder := testutils.NewRecorder(ctrl)
 				recorder.EXPECT().Call("")
 
-				GetLogs = func(cloudWatchLogs cloudwatchlogs.Provider, taskARNs []*string, start, end string, tail int) ([]*models.LogFile, error) {
+				GetLogs = func(cloudWatchLogs cloudwatchlogs.Provider, taskARNs []*string, start, end, filter, container string, tail int) ([]*models.LogFile, error) {
 					recorder.Call("")
 					return nil, fmt.Errorf("some error")
 				}
@@ -198,7 +265,7 @@ func TestGetTaskLogs(t *testing.T) {
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				manager := target.(*ECSTaskManager)
 
-				if _, err := manager.GetTaskLogs("envid", "tskid", "start", "end", 100); err == nil {
+				if _, err := manager.GetTaskLogs("envid", "tskid", "start", "end", "filter", "container", 100); err == nil {
 					reporter.Fatalf("Error was nil!")
 				}
 			},
@@ -1,19 +1,35 @@
 package ecsbackend
 
 import (
+	"encoding/json"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	awsecs "github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/quintilesims/layer0/api/backend"
 	"github.com/quintilesims/layer0/api/backend/ecs/id"
 	"github.com/quintilesims/layer0/common/aws/cloudwatchlogs"
 	"github.com/quintilesims/layer0/common/aws/ecs"
 	"github.com/quintilesims/layer0/common/config"
+	"github.com/quintilesims/layer0/common/errors"
 	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/paging"
 )
 
 const MAX_TASK_IDS = 100
 
+// tail searches start with the newest FILTER_TAIL_WINDOW of logs and widen from there
+const FILTER_TAIL_WINDOW = time.Hour
+
+// how often the log streams of followed tasks are looked up again
+const LOG_STREAM_REFRESH_INTERVAL = time.Minute
+
+// the largest page of clusters, services, or tasks ecs returns
+const MAX_LIST_RESULTS = 100
+
 func boolp(b bool) *bool {
 	return &b
 }
@@ -101,29 +117,6 @@ func ContainsErrMsg(err error, msg string) bool {
 		strings.ToLower(msg))
 }
 
-// IteratePages performs a do-while loop on a paginatedf
-// until nextToken is nil or an error is returned
-type paginatedf func(*string) (*string, error)
-
-func IteratePages(fn paginatedf) error {
-	var err error
-	var nextToken *string
-
-	nextToken, err = fn(nextToken)
-	if err != nil {
-		return err
-	}
-
-	for nextToken != nil {
-		nextToken, err = fn(nextToken)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 var CreateRenderedDockerrun = func(body []byte) (*models.Dockerrun, error) {
 	dockerrun, err := MarshalDockerrun(body)
 	if err != nil {
@@ -166,7 +159,43 @@ var getTaskARNs = func(ecs ecs.Provider, ecsEnvironmentID id.ECSEnvironmentID, s
 	return tasks, nil
 }
 
-var GetLogs = func(cloudWatchLogs cloudwatchlogs.Provider, taskARNs []*string, start, end string, tail int) ([]*models.LogFile, error) {
+type taskLogStream struct {
+	Name          string
+	ContainerName string
+	TaskID        string
+	// FirstEventTime is when the stream's first event was written, if known
+	FirstEventTime time.Time
+}
+
+func (s taskLogStream) newLogEvent(message *string, timestamp *int64) *models.LogEvent {
+	return &models.LogEvent{
+		ContainerName: s.ContainerName,
+		Message:       aws.StringValue(message),
+		TaskID:        s.TaskID,
+		Timestamp:     millisecondsToTime(aws.Int64Value(timestamp)),
+	}
+}
+
+// parseTaskLogStream parses the name of a task's log stream, which has the
+// format <prefix>/<container name>/<task id>
+func parseTaskLogStream(name string) (taskLogStream, bool) {
+	split := strings.Split(name, "/")
+	if len(split) != 3 {
+		return taskLogStream{}, false
+	}
+
+	stream := taskLogStream{
+		Name:          name,
+		ContainerName: split[1],
+		TaskID:        split[2],
+	}
+
+	return stream, true
+}
+
+// getTaskLogStreams returns the log streams that belong to the tasks.
+// If container is not empty, only that container's streams are returned.
+func getTaskLogStreams(cloudWatchLogs cloudwatchlogs.Provider, taskARNs []*string, container string) ([]taskLogStream, error) {
 	taskIDCatalog := generateTaskIDCatalog(taskARNs)
 
 	orderBy := "LastEventTime"
@@ -175,28 +204,64 @@ var GetLogs = func(cloudWatchLogs cloudwatchlogs.Provider, taskARNs []*string, s
 		return nil, err
 	}
 
-	logFiles := []*models.LogFile{}
+	streams := []taskLogStream{}
 	for _, logStream := range logStreams {
-		// filter by streams that have <prefix>/<container name>/<stream task id>
-		streamNameSplit := strings.Split(*logStream.LogStreamName, "/")
-		if len(streamNameSplit) != 3 {
+		stream, ok := parseTaskLogStream(aws.StringValue(logStream.LogStreamName))
+		if !ok {
 			continue
 		}
 
-		streamTaskID := streamNameSplit[2]
-		if _, ok := taskIDCatalog[streamTaskID]; !ok {
+		if _, ok := taskIDCatalog[stream.TaskID]; !ok {
 			continue
 		}
 
+		if container != "" && stream.ContainerName != container {
+			continue
+		}
+
+		if logStream.FirstEventTimestamp != nil {
+			stream.FirstEventTime = millisecondsToTime(*logStream.FirstEventTimestamp)
+		} else if logStream.CreationTime != nil {
+			stream.FirstEventTime = millisecondsToTime(*logStream.CreationTime)
+		}
+
+		streams = append(streams, stream)
+	}
+
+	return streams, nil
+}
+
+func addLogEvent(logFile *models.LogFile, event *models.LogEvent) {
+	logFile.Events = append(logFile.Events, event)
+	logFile.Lines = append(logFile.Lines, event.Message)
+}
+
+// GetLogs returns the logs of each of the tasks' streams.
+// If filter is not empty, only events matching the CloudWatch filter pattern are
+// returned and streams without any matching events are omitted.
+var GetLogs = func(cloudWatchLogs cloudwatchlogs.Provider, taskARNs []*string, start, end, filter, container string, tail int) ([]*models.LogFile, error) {
+	logStreams, err := getTaskLogStreams(cloudWatchLogs, taskARNs, container)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter != "" {
+		return filterLogs(cloudWatchLogs, logStreams, start, end, filter, tail)
+	}
+
+	logFiles := []*models.LogFile{}
+	for _, logStream := range logStreams {
 		logFile := &models.LogFile{
-			Name:  streamNameSplit[1],
-			Lines: []string{},
+			Name:   logStream.ContainerName,
+			TaskID: logStream.TaskID,
+			Events: []*models.LogEvent{},
+			Lines:  []string{},
 		}
 
 		// since the time range is exclusive, expand the range to get first/last events
 		logEvents, err := cloudWatchLogs.GetLogEvents(
 			config.AWSLogGroupID(),
-			*logStream.LogStreamName,
+			logStream.Name,
 			start,
 			end,
 			int64(tail))
@@ -205,7 +270,7 @@ var GetLogs = func(cloudWatchLogs cloudwatchlogs.Provider, taskARNs []*string, s
 		}
 
 		for _, logEvent := range logEvents {
-			logFile.Lines = append(logFile.Lines, *logEvent.Message)
+			addLogEvent(logFile, logStream.newLogEvent(logEvent.Message, logEvent.Timestamp))
 		}
 
 		logFiles = append(logFiles, logFile)
@@ -214,6 +279,272 @@ var GetLogs = func(cloudWatchLogs cloudwatchlogs.Provider, taskARNs []*string, s
 	return logFiles, nil
 }
 
+func filterLogs(cloudWatchLogs cloudwatchlogs.Provider, logStreams []taskLogStream, start, end, filter string, tail int) ([]*models.LogFile, error) {
+	logStreamsByName := map[string]taskLogStream{}
+	logFilesByStream := map[string]*models.LogFile{}
+	for _, logStream := range logStreams {
+		logStreamsByName[logStream.Name] = logStream
+		logFilesByStream[logStream.Name] = &models.LogFile{
+			Name:   logStream.ContainerName,
+			TaskID: logStream.TaskID,
+			Events: []*models.LogEvent{},
+			Lines:  []string{},
+		}
+	}
+
+	// an empty list of stream names would search the entire log group, so streams are only
+	// searched when there are some, in batches as large as FilterLogEvents allows
+	for i := 0; i < len(logStreams); i += cloudwatchlogs.MAX_FILTER_STREAM_NAMES {
+		batch := logStreams[i:]
+		if len(batch) > cloudwatchlogs.MAX_FILTER_STREAM_NAMES {
+			batch = batch[:cloudwatchlogs.MAX_FILTER_STREAM_NAMES]
+		}
+
+		var logEvents []*cloudwatchlogs.FilteredLogEvent
+		var err error
+		if tail > 0 {
+			logEvents, err = filterNewestLogEvents(cloudWatchLogs, batch, start, end, filter, tail)
+		} else {
+			logEvents, err = cloudWatchLogs.FilterLogEvents(config.AWSLogGroupID(), filter, start, end, taskLogStreamNames(batch))
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, logEvent := range logEvents {
+			streamName := aws.StringValue(logEvent.LogStreamName)
+			if logFile, ok := logFilesByStream[streamName]; ok {
+				addLogEvent(logFile, logStreamsByName[streamName].newLogEvent(logEvent.Message, logEvent.Timestamp))
+			}
+		}
+	}
+
+	logFiles := []*models.LogFile{}
+	for _, logStream := range logStreams {
+		logFile := logFilesByStream[logStream.Name]
+		if len(logFile.Events) == 0 {
+			continue
+		}
+
+		if tail > 0 && len(logFile.Events) > tail {
+			logFile.Events = logFile.Events[len(logFile.Events)-tail:]
+			logFile.Lines = logFile.Lines[len(logFile.Lines)-tail:]
+		}
+
+		logFiles = append(logFiles, logFile)
+	}
+
+	return logFiles, nil
+}
+
+// filterNewestLogEvents returns at least the newest tail events of each stream that match filter.
+// FilterLogEvents returns events oldest first and stops after MAX_FILTER_EVENTS_COUNT, so searching
+// the whole range could return events from its middle. Instead, the search starts with the newest
+// FILTER_TAIL_WINDOW of the range and doubles it until each stream has tail matches or the window
+// covers the range. If a window has more matches than a single search returns, it is paged through.
+func filterNewestLogEvents(cloudWatchLogs cloudwatchlogs.Provider, logStreams []taskLogStream, start, end, filter string, tail int) ([]*cloudwatchlogs.FilteredLogEvent, error) {
+	endTime := time.Now().UTC()
+	if end != "" {
+		t, err := cloudwatchlogs.ParseTime(end)
+		if err != nil {
+			return nil, err
+		}
+
+		endTime = t
+	}
+
+	// without a start, the range begins with the oldest stream
+	var startTime time.Time
+	if start != "" {
+		t, err := cloudwatchlogs.ParseTime(start)
+		if err != nil {
+			return nil, err
+		}
+
+		startTime = t
+	} else {
+		startTime = endTime
+		for _, logStream := range logStreams {
+			if logStream.FirstEventTime.IsZero() {
+				startTime = time.Unix(0, 0).UTC()
+				break
+			}
+
+			if logStream.FirstEventTime.Before(startTime) {
+				startTime = logStream.FirstEventTime
+			}
+		}
+	}
+
+	streamNames := taskLogStreamNames(logStreams)
+	for window := FILTER_TAIL_WINDOW; ; window *= 2 {
+		windowStart := endTime.Add(-window)
+		coversRange := !windowStart.After(startTime)
+		if coversRange {
+			windowStart = startTime
+		}
+
+		logEvents, err := cloudWatchLogs.FilterLogEvents(config.AWSLogGroupID(), filter, windowStart.Format(time.RFC3339), end, streamNames)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(logEvents) >= cloudwatchlogs.MAX_FILTER_EVENTS_COUNT {
+			return pageFilterLogEvents(cloudWatchLogs, streamNames, logEvents, end, filter, tail)
+		}
+
+		if coversRange || hasTailPerStream(logEvents, streamNames, tail) {
+			return logEvents, nil
+		}
+	}
+}
+
+// pageFilterLogEvents continues a search that returned MAX_FILTER_EVENTS_COUNT events from
+// the time of its last event, keeping only the newest tail events of each stream
+func pageFilterLogEvents(cloudWatchLogs cloudwatchlogs.Provider, streamNames []string, logEvents []*cloudwatchlogs.FilteredLogEvent, end, filter string, tail int) ([]*cloudwatchlogs.FilteredLogEvent, error) {
+	seen := map[string]bool{}
+	for _, logEvent := range logEvents {
+		seen[aws.StringValue(logEvent.EventId)] = true
+	}
+
+	result := tailPerStream(logEvents, tail)
+	for len(logEvents) >= cloudwatchlogs.MAX_FILTER_EVENTS_COUNT {
+		last := millisecondsToTime(aws.Int64Value(logEvents[len(logEvents)-1].Timestamp))
+		first := millisecondsToTime(aws.Int64Value(logEvents[0].Timestamp))
+
+		// searches start on a whole second; stop if a single second has more matches than a search returns
+		if last.Truncate(time.Second).Equal(first.Truncate(time.Second)) {
+			break
+		}
+
+		next, err := cloudWatchLogs.FilterLogEvents(config.AWSLogGroupID(), filter, last.Format(time.RFC3339), end, streamNames)
+		if err != nil {
+			return nil, err
+		}
+
+		logEvents = next
+		for _, logEvent := range next {
+			if id := aws.StringValue(logEvent.EventId); !seen[id] {
+				seen[id] = true
+				result = append(result, logEvent)
+			}
+		}
+
+		result = tailPerStream(result, tail)
+	}
+
+	return result, nil
+}
+
+func hasTailPerStream(logEvents []*cloudwatchlogs.FilteredLogEvent, streamNames []string, tail int) bool {
+	counts := map[string]int{}
+	for _, logEvent := range logEvents {
+		counts[aws.StringValue(logEvent.LogStreamName)]++
+	}
+
+	for _, streamName := range streamNames {
+		if counts[streamName] < tail {
+			return false
+		}
+	}
+
+	return true
+}
+
+// tailPerStream returns the last tail events of each stream, in their original order
+func tailPerStream(logEvents []*cloudwatchlogs.FilteredLogEvent, tail int) []*cloudwatchlogs.FilteredLogEvent {
+	remaining := map[string]int{}
+	for _, logEvent := range logEvents {
+		remaining[aws.StringValue(logEvent.LogStreamName)]++
+	}
+
+	result := []*cloudwatchlogs.FilteredLogEvent{}
+	for _, logEvent := range logEvents {
+		streamName := aws.StringValue(logEvent.LogStreamName)
+		if remaining[streamName] <= tail {
+			result = append(result, logEvent)
+		}
+
+		remaining[streamName]--
+	}
+
+	return result
+}
+
+func taskLogStreamNames(logStreams []taskLogStream) []string {
+	names := make([]string, len(logStreams))
+	for i, logStream := range logStreams {
+		names[i] = logStream.Name
+	}
+
+	return names
+}
+
+// FollowLogs returns the events written to the tasks' log streams since the previous call.
+// The cursor holds the position of each stream and is updated in place; streams
+// without a token are read from start, or from the current time if start is empty.
+// Describing the streams of a log group is heavily rate limited, so the tasks' streams are
+// looked up on the first call and then only every LOG_STREAM_REFRESH_INTERVAL to find new ones.
+// If container is not empty, only that container's streams are followed.
+// Events from all streams are interleaved by timestamp.
+var FollowLogs = func(cloudWatchLogs cloudwatchlogs.Provider, getTaskARNs func() ([]*string, error), start, container string, cursor *backend.LogCursor) ([]*models.LogEvent, error) {
+	if start == "" {
+		start = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	if cursor.StreamsUpdated.IsZero() || time.Since(cursor.StreamsUpdated) > LOG_STREAM_REFRESH_INTERVAL {
+		taskARNs, err := getTaskARNs()
+		if err != nil {
+			return nil, err
+		}
+
+		logStreams, err := getTaskLogStreams(cloudWatchLogs, taskARNs, container)
+		if err != nil {
+			return nil, err
+		}
+
+		cursor.Streams = taskLogStreamNames(logStreams)
+		cursor.StreamsUpdated = time.Now()
+	}
+
+	events := []*models.LogEvent{}
+	for _, streamName := range cursor.Streams {
+		logStream, ok := parseTaskLogStream(streamName)
+		if !ok {
+			continue
+		}
+
+		var nextToken *string
+		if token, ok := cursor.Tokens[logStream.Name]; ok {
+			nextToken = stringp(token)
+		}
+
+		logEvents, token, err := cloudWatchLogs.GetNextLogEvents(config.AWSLogGroupID(), logStream.Name, start, nextToken)
+		if err != nil {
+			return nil, err
+		}
+
+		if token != nil {
+			cursor.Tokens[logStream.Name] = *token
+		}
+
+		for _, logEvent := range logEvents {
+			events = append(events, logStream.newLogEvent(logEvent.Message, logEvent.Timestamp))
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+
+	return events, nil
+}
+
+func millisecondsToTime(ms int64) time.Time {
+	return time.Unix(0, ms*int64(time.Millisecond)).UTC()
+}
+
 func generateTaskIDCatalog(taskARNs []*string) map[string]bool {
 	catalog := map[string]bool{}
 	for _, taskARN := range taskARNs {
@@ -223,3 +554,130 @@ func generateTaskIDCatalog(taskARNs []*string) map[string]bool {
 
 	return catalog
 }
+
+// listCursor is the position of a page in a list that is read from each cluster in turn.
+// Stage is used when each cluster is listed more than once, e.g. once per task status.
+type listCursor struct {
+	ClusterName string `json:"cluster,omitempty"`
+	Stage       string `json:"stage,omitempty"`
+	NextToken   string `json:"token,omitempty"`
+}
+
+func encodeListCursor(cursor listCursor) string {
+	data, _ := json.Marshal(cursor)
+	return paging.EncodeToken(string(data))
+}
+
+func decodeListCursor(nextToken string) (listCursor, error) {
+	var cursor listCursor
+	if nextToken == "" {
+		return cursor, nil
+	}
+
+	value, err := paging.DecodeToken(nextToken)
+	if err != nil {
+		return cursor, errors.New(errors.InvalidRequest, err)
+	}
+
+	if err := json.Unmarshal([]byte(value), &cursor); err != nil {
+		return cursor, errors.Newf(errors.InvalidRequest, "Invalid next token '%s'", nextToken)
+	}
+
+	return cursor, nil
+}
+
+// listMaxResults returns how many results to request from aws when count of limit results
+// have been read so far; a limit of 0 means no limit
+func listMaxResults(limit, count int) int64 {
+	if limit == 0 || limit-count > MAX_LIST_RESULTS {
+		return MAX_LIST_RESULTS
+	}
+
+	return int64(limit - count)
+}
+
+// listClusterNames returns the names of the clusters of every environment.
+// There are few enough environments to list them all when paging through their services or tasks.
+func listClusterNames(b backend.Backend) ([]string, error) {
+	ecsEnvironmentIDs, err := b.ListEnvironments()
+	if err != nil {
+		return nil, err
+	}
+
+	clusterNames := make([]string, len(ecsEnvironmentIDs))
+	for i, ecsEnvironmentID := range ecsEnvironmentIDs {
+		clusterNames[i] = ecsEnvironmentID.String()
+	}
+
+	return clusterNames, nil
+}
+
+// listClusterPagef reads a single page of results from a cluster
+type listClusterPagef func(clusterName, stage string, maxResults int64, nextToken *string) ([]string, *string, error)
+
+// listClustersPage reads at most limit results from each stage of each cluster in turn, starting at the
+// position encoded in nextToken, and returns the token of the following page.
+// Only the pages that are returned are read from aws, so the cost of a request doesn't grow with the
+// total number of results.
+func listClustersPage(clusterNames, stages []string, limit int, nextToken string, fn listClusterPagef) ([]string, string, error) {
+	cursor, err := decodeListCursor(nextToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	cursorStage := 0
+	for i, stage := range stages {
+		if stage == cursor.Stage {
+			cursorStage = i
+		}
+	}
+
+	sorted := make([]string, len(clusterNames))
+	copy(sorted, clusterNames)
+	sort.Strings(sorted)
+
+	results := []string{}
+	for _, clusterName := range sorted {
+		for i, stage := range stages {
+			position := listCursor{ClusterName: clusterName, Stage: stage}
+			if clusterName < cursor.ClusterName {
+				continue
+			}
+
+			if clusterName == cursor.ClusterName {
+				if i < cursorStage {
+					continue
+				}
+
+				if i == cursorStage {
+					position.NextToken = cursor.NextToken
+				}
+			}
+
+			for {
+				if limit > 0 && len(results) >= limit {
+					return results, encodeListCursor(position), nil
+				}
+
+				var token *string
+				if position.NextToken != "" {
+					token = aws.String(position.NextToken)
+				}
+
+				page, next, err := fn(clusterName, stage, listMaxResults(limit, len(results)), token)
+				if err != nil {
+					return nil, "", err
+				}
+
+				results = append(results, page...)
+				if next == nil {
+					break
+				}
+
+				position.NextToken = aws.StringValue(next)
+			}
+		}
+	}
+
+	return results, "", nil
+}
@@ -1,9 +1,12 @@
 package ecsbackend
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/quintilesims/layer0/api/backend"
 	"github.com/quintilesims/layer0/common/aws/cloudwatchlogs"
 	"github.com/quintilesims/layer0/common/aws/cloudwatchlogs/mock_cloudwatchlogs"
 	"github.com/quintilesims/layer0/common/config"
@@ -36,6 +39,7 @@ func TestGetLogs(t *testing.T) {
 					Return([]*cloudwatchlogs.LogStream{stream}, nil)
 
 				event := cloudwatchlogs.NewOutputLogEvent("some_message")
+				event.Timestamp = int64p(1000)
 
 				mockCW.EXPECT().
 					GetLogEvents(
@@ -51,13 +55,282 @@ func TestGetLogs(t *testing.T) {
 			Run: func(reporter *testutils.Reporter, target interface{}) {
 				provider := target.(cloudwatchlogs.Provider)
 
-				logs, err := GetLogs(provider, []*string{stringp(taskARN)}, "start", "end", 30)
+				logs, err := GetLogs(provider, []*string{stringp(taskARN)}, "start", "end", "", "", 30)
 				if err != nil {
 					reporter.Fatal(err)
 				}
 
-				testutils.AssertEqual(t, len(logs), 1)
-				testutils.AssertInSlice(t, "some_message", logs[0].Lines)
+				expected := []*models.LogFile{
+					{
+						Name:   "container_name",
+						TaskID: "taskARN",
+						Events: []*models.LogEvent{
+							{
+								ContainerName: "container_name",
+								Message:       "some_message",
+								TaskID:        "taskARN",
+								Timestamp:     millisecondsToTime(1000),
+							},
+						},
+						Lines: []string{"some_message"},
+					},
+				}
+
+				reporter.AssertEqual(logs, expected)
+			},
+		},
+	}
+
+	testutils.RunTests(t, testCases)
+}
+
+func TestGetLogs_filter(t *testing.T) {
+	taskARN := "arn:aws:ecs:region:aws_account_id:task/taskARN"
+
+	testCases := []testutils.TestCase{
+		{
+			Name: "Should search the container's streams and tail the matches",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockCW := mock_cloudwatchlogs.NewMockProvider(ctrl)
+
+				streams := []*cloudwatchlogs.LogStream{
+					cloudwatchlogs.NewLogStream("prefix/api/taskARN"),
+					cloudwatchlogs.NewLogStream("prefix/worker/taskARN"),
+					cloudwatchlogs.NewLogStream("prefix/api/otherTaskARN"),
+				}
+
+				mockCW.EXPECT().
+					DescribeLogStreams(config.AWSLogGroupID(), "LastEventTime").
+					Return(streams, nil)
+
+				events := []*cloudwatchlogs.FilteredLogEvent{
+					cloudwatchlogs.NewFilteredLogEvent("prefix/api/taskARN", "ERROR 1"),
+					cloudwatchlogs.NewFilteredLogEvent("prefix/api/taskARN", "ERROR 2"),
+					cloudwatchlogs.NewFilteredLogEvent("prefix/api/taskARN", "ERROR 3"),
+				}
+
+				// the newest hour of the range already has enough matches
+				mockCW.EXPECT().
+					FilterLogEvents(config.AWSLogGroupID(), "ERROR", "2001-01-01T09:00:00Z", "2001-01-01 10:00", []string{"prefix/api/taskARN"}).
+					Return(events, nil)
+
+				return mockCW
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				provider := target.(cloudwatchlogs.Provider)
+
+				logs, err := GetLogs(provider, []*string{stringp(taskARN)}, "2001-01-01 00:00", "2001-01-01 10:00", "ERROR", "api", 2)
+				if err != nil {
+					reporter.Fatal(err)
+				}
+
+				expected := []*models.LogFile{
+					{
+						Name:   "api",
+						TaskID: "taskARN",
+						Events: []*models.LogEvent{
+							{ContainerName: "api", Message: "ERROR 2", TaskID: "taskARN", Timestamp: millisecondsToTime(0)},
+							{ContainerName: "api", Message: "ERROR 3", TaskID: "taskARN", Timestamp: millisecondsToTime(0)},
+						},
+						Lines: []string{"ERROR 2", "ERROR 3"},
+					},
+				}
+
+				reporter.AssertEqual(logs, expected)
+			},
+		},
+		{
+			Name: "Should not search the log group when there are no matching streams",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockCW := mock_cloudwatchlogs.NewMockProvider(ctrl)
+
+				mockCW.EXPECT().
+					DescribeLogStreams(config.AWSLogGroupID(), "LastEventTime").
+					Return([]*cloudwatchlogs.LogStream{}, nil)
+
+				return mockCW
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				provider := target.(cloudwatchlogs.Provider)
+
+				logs, err := GetLogs(provider, []*string{stringp(taskARN)}, "", "", "ERROR", "", 0)
+				if err != nil {
+					reporter.Fatal(err)
+				}
+
+				reporter.AssertEqual(logs, []*models.LogFile{})
+			},
+		},
+	}
+
+	testutils.RunTests(t, testCases)
+}
+
+func newTestFilteredLogEvent(streamName, eventID string, timestamp time.Time) *cloudwatchlogs.FilteredLogEvent {
+	event := cloudwatchlogs.NewFilteredLogEvent(streamName, eventID)
+	event.EventId = stringp(eventID)
+	event.Timestamp = int64p(timestamp.UnixNano() / int64(time.Millisecond))
+	return event
+}
+
+func TestFilterNewestLogEvents_widensWindow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCW := mock_cloudwatchlogs.NewMockProvider(ctrl)
+	streams := []taskLogStream{{Name: "prefix/api/taskARN"}}
+	streamNames := []string{"prefix/api/taskARN"}
+	end := time.Date(2001, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	gomock.InOrder(
+		mockCW.EXPECT().
+			FilterLogEvents(config.AWSLogGroupID(), "ERROR", "2001-01-01T09:00:00Z", "2001-01-01 10:00", streamNames).
+			Return([]*cloudwatchlogs.FilteredLogEvent{newTestFilteredLogEvent(streamNames[0], "3", end)}, nil),
+		// the doubled window reaches past start, so it is clamped to start
+		mockCW.EXPECT().
+			FilterLogEvents(config.AWSLogGroupID(), "ERROR", "2001-01-01T08:30:00Z", "2001-01-01 10:00", streamNames).
+			Return([]*cloudwatchlogs.FilteredLogEvent{
+				newTestFilteredLogEvent(streamNames[0], "2", end.Add(-time.Hour*1)),
+				newTestFilteredLogEvent(streamNames[0], "3", end),
+			}, nil),
+	)
+
+	events, err := filterNewestLogEvents(mockCW, streams, "2001-01-01 08:30", "2001-01-01 10:00", "ERROR", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, len(events), 2)
+}
+
+func TestFilterNewestLogEvents_pagesFullWindow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCW := mock_cloudwatchlogs.NewMockProvider(ctrl)
+	streams := []taskLogStream{{Name: "prefix/api/taskARN"}}
+	streamNames := []string{"prefix/api/taskARN"}
+	end := time.Date(2001, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	// the newest hour has more matches than a single search returns
+	first := []*cloudwatchlogs.FilteredLogEvent{}
+	for i := 0; i < cloudwatchlogs.MAX_FILTER_EVENTS_COUNT; i++ {
+		timestamp := end.Add(-time.Hour).Add(time.Duration(i) * time.Millisecond * 100)
+		first = append(first, newTestFilteredLogEvent(streamNames[0], fmt.Sprintf("%d", i), timestamp))
+	}
+
+	last := first[len(first)-1]
+	lastTime := millisecondsToTime(*last.Timestamp)
+	newest := newTestFilteredLogEvent(streamNames[0], "newest", end)
+
+	gomock.InOrder(
+		mockCW.EXPECT().
+			FilterLogEvents(config.AWSLogGroupID(), "ERROR", "2001-01-01T09:00:00Z", "2001-01-01 10:00", streamNames).
+			Return(first, nil),
+		mockCW.EXPECT().
+			FilterLogEvents(config.AWSLogGroupID(), "ERROR", lastTime.Format(time.RFC3339), "2001-01-01 10:00", streamNames).
+			Return([]*cloudwatchlogs.FilteredLogEvent{last, newest}, nil),
+	)
+
+	events, err := filterNewestLogEvents(mockCW, streams, "", "2001-01-01 10:00", "ERROR", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, len(events), 2)
+	testutils.AssertEqual(t, *events[0].EventId, *last.EventId)
+	testutils.AssertEqual(t, *events[1].EventId, "newest")
+}
+
+func TestFollowLogs(t *testing.T) {
+	taskARN := "arn:aws:ecs:region:aws_account_id:task/taskARN"
+
+	testCases := []testutils.TestCase{
+		{
+			Name: "Should interleave events from all streams and update tokens",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockCW := mock_cloudwatchlogs.NewMockProvider(ctrl)
+
+				streams := []*cloudwatchlogs.LogStream{
+					cloudwatchlogs.NewLogStream("prefix/api/taskARN"),
+					cloudwatchlogs.NewLogStream("prefix/worker/taskARN"),
+					cloudwatchlogs.NewLogStream("prefix/other/otherTaskARN"),
+				}
+
+				mockCW.EXPECT().
+					DescribeLogStreams(config.AWSLogGroupID(), "LastEventTime").
+					Return(streams, nil)
+
+				apiEvent := cloudwatchlogs.NewOutputLogEvent("api_message")
+				apiEvent.Timestamp = int64p(2000)
+
+				mockCW.EXPECT().
+					GetNextLogEvents(config.AWSLogGroupID(), "prefix/api/taskARN", "start", stringp("api_token")).
+					Return([]*cloudwatchlogs.OutputLogEvent{apiEvent}, stringp("api_token2"), nil)
+
+				workerEvent := cloudwatchlogs.NewOutputLogEvent("worker_message")
+				workerEvent.Timestamp = int64p(1000)
+
+				mockCW.EXPECT().
+					GetNextLogEvents(config.AWSLogGroupID(), "prefix/worker/taskARN", "start", nil).
+					Return([]*cloudwatchlogs.OutputLogEvent{workerEvent}, stringp("worker_token"), nil)
+
+				return mockCW
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				provider := target.(cloudwatchlogs.Provider)
+
+				getTaskARNs := func() ([]*string, error) {
+					return []*string{stringp(taskARN)}, nil
+				}
+
+				cursor := backend.NewLogCursor()
+				cursor.Tokens["prefix/api/taskARN"] = "api_token"
+				events, err := FollowLogs(provider, getTaskARNs, "start", "", cursor)
+				if err != nil {
+					reporter.Fatal(err)
+				}
+
+				expected := []*models.LogEvent{
+					{ContainerName: "worker", Message: "worker_message", TaskID: "taskARN", Timestamp: millisecondsToTime(1000)},
+					{ContainerName: "api", Message: "api_message", TaskID: "taskARN", Timestamp: millisecondsToTime(2000)},
+				}
+
+				reporter.AssertEqual(events, expected)
+				reporter.AssertEqual(cursor.Tokens, map[string]string{
+					"prefix/api/taskARN":    "api_token2",
+					"prefix/worker/taskARN": "worker_token",
+				})
+			},
+		},
+		{
+			Name: "Should not look up the streams again before they are due to be refreshed",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockCW := mock_cloudwatchlogs.NewMockProvider(ctrl)
+
+				mockCW.EXPECT().
+					GetNextLogEvents(config.AWSLogGroupID(), "prefix/api/taskARN", "start", stringp("api_token")).
+					Return([]*cloudwatchlogs.OutputLogEvent{}, stringp("api_token"), nil)
+
+				return mockCW
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				provider := target.(cloudwatchlogs.Provider)
+
+				getTaskARNs := func() ([]*string, error) {
+					reporter.Errorf("Task ARNs were looked up")
+					return nil, nil
+				}
+
+				cursor := &backend.LogCursor{
+					Tokens:         map[string]string{"prefix/api/taskARN": "api_token"},
+					Streams:        []string{"prefix/api/taskARN"},
+					StreamsUpdated: time.Now(),
+				}
+
+				if _, err := FollowLogs(provider, getTaskARNs, "start", "", cursor); err != nil {
+					reporter.Fatal(err)
+				}
 			},
 		},
 	}
@@ -1,18 +1,23 @@
 package backend
 
 import (
+	"time"
+
 	"github.com/quintilesims/layer0/api/backend/ecs/id"
 	"github.com/quintilesims/layer0/common/models"
 )
 
 type Backend interface {
-	CreateEnvironment(environmentName, instanceSize, operatingSystem, amiID string, minClusterCount int, userData []byte) (*models.Environment, error)
+	CreateEnvironment(environmentName, instanceSize, operatingSystem, amiID string, minClusterCount int, userData []byte, spotPrice string, mixedInstancesPolicy *models.MixedInstancesPolicy) (*models.Environment, error)
 	UpdateEnvironment(environmentID string, minClusterCount int) (*models.Environment, error)
 	DeleteEnvironment(environmentID string) error
 	GetEnvironment(environmentID string) (*models.Environment, error)
 	ListEnvironments() ([]id.ECSEnvironmentID, error)
-	CreateEnvironmentLink(sourceEnvironmentID, destEnvironmentID string) error
-	DeleteEnvironmentLink(sourceEnvironmentID, destEnvironmentID string) error
+	ListEnvironmentsPage(limit int, nextToken string) ([]id.ECSEnvironmentID, string, error)
+	CreateEnvironmentLink(link models.EnvironmentLink) error
+	DeleteEnvironmentLink(link models.EnvironmentLink) error
+	AuthorizeEnvironmentIngress(environmentID string, rule models.EnvironmentIngressRule) error
+	RevokeEnvironmentIngress(environmentID string, rule models.EnvironmentIngressRule) error
 
 	ListDeploys() ([]*models.Deploy, error)
 	GetDeploy(deployID string) (*models.Deploy, error)
@@ -20,20 +25,24 @@ type Backend interface {
 	DeleteDeploy(deployID string) error
 
 	ListServices() ([]id.ECSServiceID, error)
+	ListServicesPage(limit int, nextToken string) ([]id.ECSServiceID, string, error)
 	GetService(environmentID, serviceID string) (*models.Service, error)
 	GetEnvironmentServices(environmentID string) ([]*models.Service, error)
 	CreateService(serviceName, environmentID, deployID, loadBalancerID string) (*models.Service, error)
 	DeleteService(environmentID, serviceID string) error
 	ScaleService(environmentID, serviceID string, count int) (*models.Service, error)
 	UpdateService(environmentID, serviceID, deployID string) (*models.Service, error)
-	GetServiceLogs(environmentID, serviceID, start, end string, tail int) ([]*models.LogFile, error)
+	GetServiceLogs(environmentID, serviceID, start, end, filter, container string, tail int) ([]*models.LogFile, error)
+	FollowServiceLogs(environmentID, serviceID, start, container string, cursor *LogCursor) ([]*models.LogEvent, error)
 
 	CreateTask(environmentID, deployID string, overrides []models.ContainerOverride) (string, error)
 	ListTasks() ([]string, error)
+	ListTasksPage(limit int, nextToken string) ([]string, string, error)
 	GetTask(environmentID, taskARN string) (*models.Task, error)
 	GetEnvironmentTasks(environmentID string) (map[string]*models.Task, error)
 	DeleteTask(environmentID, taskARN string) error
-	GetTaskLogs(environmentID, taskARN, start, end string, tail int) ([]*models.LogFile, error)
+	GetTaskLogs(environmentID, taskARN, start, end, filter, container string, tail int) ([]*models.LogFile, error)
+	FollowTaskLogs(environmentID, taskARN, start, container string, cursor *LogCursor) ([]*models.LogEvent, error)
 
 	ListLoadBalancers() ([]*models.LoadBalancer, error)
 	GetLoadBalancer(id string) (*models.LoadBalancer, error)
@@ -43,4 +52,10 @@ type Backend interface {
 	UpdateLoadBalancerHealthCheck(loadBalancerID string, healthCheck models.HealthCheck) (*models.LoadBalancer, error)
 	UpdateLoadBalancerIdleTimeout(loadBalancerID string, idleTimeout int) (*models.LoadBalancer, error)
 	UpdateLoadBalancerCrossZone(loadBalancerID string, crossZone bool) (*models.LoadBalancer, error)
+	UpdateLoadBalancerAccessLog(loadBalancerID string, accessLog models.AccessLog) (*models.LoadBalancer, error)
+	GetLoadBalancerMetrics(loadBalancerID string, startTime, endTime time.Time, period int) (*models.LoadBalancerMetrics, error)
+	CreateLoadBalancerDNSRecord(loadBalancerID, dnsName string) error
+	DeleteLoadBalancerDNSRecord(loadBalancerID, dnsName string) error
+
+	ListCertificates() ([]*models.Certificate, error)
 }
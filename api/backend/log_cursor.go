@@ -0,0 +1,22 @@
+package backend
+
+import (
+	"time"
+)
+
+// LogCursor is the position of a client that is following logs. It is kept between polls
+// so the log streams being followed are only looked up once in a while instead of on every poll.
+type LogCursor struct {
+	// Tokens holds the position in each log stream
+	Tokens map[string]string
+	// Streams are the names of the log streams being followed
+	Streams []string
+	// StreamsUpdated is when Streams were last looked up; they have not been if it is zero
+	StreamsUpdated time.Time
+}
+
+func NewLogCursor() *LogCursor {
+	return &LogCursor{
+		Tokens: map[string]string{},
+	}
+}
@@ -6,9 +6,11 @@ package mock_backend
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	backend "github.com/quintilesims/layer0/api/backend"
 	id "github.com/quintilesims/layer0/api/backend/ecs/id"
 	models "github.com/quintilesims/layer0/common/models"
 	reflect "reflect"
+	time "time"
 )
 
 // MockBackend is a mock of Backend interface
@@ -34,6 +36,18 @@ func (m *MockBackend) EXPECT() *MockBackendMockRecorder {
 	return m.recorder
 }
 
+// AuthorizeEnvironmentIngress mocks base method
+func (m *MockBackend) AuthorizeEnvironmentIngress(arg0 string, arg1 models.EnvironmentIngressRule) error {
+	ret := m.ctrl.Call(m, "AuthorizeEnvironmentIngress", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthorizeEnvironmentIngress indicates an expected call of AuthorizeEnvironmentIngress
+func (mr *MockBackendMockRecorder) AuthorizeEnvironmentIngress(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeEnvironmentIngress", reflect.TypeOf((*MockBackend)(nil).AuthorizeEnvironmentIngress), arg0, arg1)
+}
+
 // CreateDeploy mocks base method
 func (m *MockBackend) CreateDeploy(arg0 string, arg1 []byte) (*models.Deploy, error) {
 	ret := m.ctrl.Call(m, "CreateDeploy", arg0, arg1)
@@ -48,28 +62,28 @@ func (mr *MockBackendMockRecorder) CreateDeploy(arg0, arg1 interface{}) *gomock.
 }
 
 // CreateEnvironment mocks base method
-func (m *MockBackend) CreateEnvironment(arg0, arg1, arg2, arg3 string, arg4 int, arg5 []byte) (*models.Environment, error) {
-	ret := m.ctrl.Call(m, "CreateEnvironment", arg0, arg1, arg2, arg3, arg4, arg5)
+func (m *MockBackend) CreateEnvironment(arg0, arg1, arg2, arg3 string, arg4 int, arg5 []byte, arg6 string, arg7 *models.MixedInstancesPolicy) (*models.Environment, error) {
+	ret := m.ctrl.Call(m, "CreateEnvironment", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
 	ret0, _ := ret[0].(*models.Environment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateEnvironment indicates an expected call of CreateEnvironment
-func (mr *MockBackendMockRecorder) CreateEnvironment(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEnvironment", reflect.TypeOf((*MockBackend)(nil).CreateEnvironment), arg0, arg1, arg2, arg3, arg4, arg5)
+func (mr *MockBackendMockRecorder) CreateEnvironment(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEnvironment", reflect.TypeOf((*MockBackend)(nil).CreateEnvironment), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
 }
 
 // CreateEnvironmentLink mocks base method
-func (m *MockBackend) CreateEnvironmentLink(arg0, arg1 string) error {
-	ret := m.ctrl.Call(m, "CreateEnvironmentLink", arg0, arg1)
+func (m *MockBackend) CreateEnvironmentLink(arg0 models.EnvironmentLink) error {
+	ret := m.ctrl.Call(m, "CreateEnvironmentLink", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateEnvironmentLink indicates an expected call of CreateEnvironmentLink
-func (mr *MockBackendMockRecorder) CreateEnvironmentLink(arg0, arg1 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEnvironmentLink", reflect.TypeOf((*MockBackend)(nil).CreateEnvironmentLink), arg0, arg1)
+func (mr *MockBackendMockRecorder) CreateEnvironmentLink(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEnvironmentLink", reflect.TypeOf((*MockBackend)(nil).CreateEnvironmentLink), arg0)
 }
 
 // CreateLoadBalancer mocks base method
@@ -85,6 +99,18 @@ func (mr *MockBackendMockRecorder) CreateLoadBalancer(arg0, arg1, arg2, arg3, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoadBalancer", reflect.TypeOf((*MockBackend)(nil).CreateLoadBalancer), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
+// CreateLoadBalancerDNSRecord mocks base method
+func (m *MockBackend) CreateLoadBalancerDNSRecord(arg0, arg1 string) error {
+	ret := m.ctrl.Call(m, "CreateLoadBalancerDNSRecord", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateLoadBalancerDNSRecord indicates an expected call of CreateLoadBalancerDNSRecord
+func (mr *MockBackendMockRecorder) CreateLoadBalancerDNSRecord(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoadBalancerDNSRecord", reflect.TypeOf((*MockBackend)(nil).CreateLoadBalancerDNSRecord), arg0, arg1)
+}
+
 // CreateService mocks base method
 func (m *MockBackend) CreateService(arg0, arg1, arg2, arg3 string) (*models.Service, error) {
 	ret := m.ctrl.Call(m, "CreateService", arg0, arg1, arg2, arg3)
@@ -136,15 +162,15 @@ func (mr *MockBackendMockRecorder) DeleteEnvironment(arg0 interface{}) *gomock.C
 }
 
 // DeleteEnvironmentLink mocks base method
-func (m *MockBackend) DeleteEnvironmentLink(arg0, arg1 string) error {
-	ret := m.ctrl.Call(m, "DeleteEnvironmentLink", arg0, arg1)
+func (m *MockBackend) DeleteEnvironmentLink(arg0 models.EnvironmentLink) error {
+	ret := m.ctrl.Call(m, "DeleteEnvironmentLink", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteEnvironmentLink indicates an expected call of DeleteEnvironmentLink
-func (mr *MockBackendMockRecorder) DeleteEnvironmentLink(arg0, arg1 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEnvironmentLink", reflect.TypeOf((*MockBackend)(nil).DeleteEnvironmentLink), arg0, arg1)
+func (mr *MockBackendMockRecorder) DeleteEnvironmentLink(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEnvironmentLink", reflect.TypeOf((*MockBackend)(nil).DeleteEnvironmentLink), arg0)
 }
 
 // DeleteLoadBalancer mocks base method
@@ -159,6 +185,18 @@ func (mr *MockBackendMockRecorder) DeleteLoadBalancer(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoadBalancer", reflect.TypeOf((*MockBackend)(nil).DeleteLoadBalancer), arg0)
 }
 
+// DeleteLoadBalancerDNSRecord mocks base method
+func (m *MockBackend) DeleteLoadBalancerDNSRecord(arg0, arg1 string) error {
+	ret := m.ctrl.Call(m, "DeleteLoadBalancerDNSRecord", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLoadBalancerDNSRecord indicates an expected call of DeleteLoadBalancerDNSRecord
+func (mr *MockBackendMockRecorder) DeleteLoadBalancerDNSRecord(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoadBalancerDNSRecord", reflect.TypeOf((*MockBackend)(nil).DeleteLoadBalancerDNSRecord), arg0, arg1)
+}
+
 // DeleteService mocks base method
 func (m *MockBackend) DeleteService(arg0, arg1 string) error {
 	ret := m.ctrl.Call(m, "DeleteService", arg0, arg1)
@@ -183,6 +221,32 @@ func (mr *MockBackendMockRecorder) DeleteTask(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockBackend)(nil).DeleteTask), arg0, arg1)
 }
 
+// FollowServiceLogs mocks base method
+func (m *MockBackend) FollowServiceLogs(arg0, arg1, arg2, arg3 string, arg4 *backend.LogCursor) ([]*models.LogEvent, error) {
+	ret := m.ctrl.Call(m, "FollowServiceLogs", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*models.LogEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FollowServiceLogs indicates an expected call of FollowServiceLogs
+func (mr *MockBackendMockRecorder) FollowServiceLogs(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowServiceLogs", reflect.TypeOf((*MockBackend)(nil).FollowServiceLogs), arg0, arg1, arg2, arg3, arg4)
+}
+
+// FollowTaskLogs mocks base method
+func (m *MockBackend) FollowTaskLogs(arg0, arg1, arg2, arg3 string, arg4 *backend.LogCursor) ([]*models.LogEvent, error) {
+	ret := m.ctrl.Call(m, "FollowTaskLogs", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*models.LogEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FollowTaskLogs indicates an expected call of FollowTaskLogs
+func (mr *MockBackendMockRecorder) FollowTaskLogs(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowTaskLogs", reflect.TypeOf((*MockBackend)(nil).FollowTaskLogs), arg0, arg1, arg2, arg3, arg4)
+}
+
 // GetDeploy mocks base method
 func (m *MockBackend) GetDeploy(arg0 string) (*models.Deploy, error) {
 	ret := m.ctrl.Call(m, "GetDeploy", arg0)
@@ -248,6 +312,19 @@ func (mr *MockBackendMockRecorder) GetLoadBalancer(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancer", reflect.TypeOf((*MockBackend)(nil).GetLoadBalancer), arg0)
 }
 
+// GetLoadBalancerMetrics mocks base method
+func (m *MockBackend) GetLoadBalancerMetrics(arg0 string, arg1, arg2 time.Time, arg3 int) (*models.LoadBalancerMetrics, error) {
+	ret := m.ctrl.Call(m, "GetLoadBalancerMetrics", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*models.LoadBalancerMetrics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoadBalancerMetrics indicates an expected call of GetLoadBalancerMetrics
+func (mr *MockBackendMockRecorder) GetLoadBalancerMetrics(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerMetrics", reflect.TypeOf((*MockBackend)(nil).GetLoadBalancerMetrics), arg0, arg1, arg2, arg3)
+}
+
 // GetService mocks base method
 func (m *MockBackend) GetService(arg0, arg1 string) (*models.Service, error) {
 	ret := m.ctrl.Call(m, "GetService", arg0, arg1)
@@ -262,16 +339,16 @@ func (mr *MockBackendMockRecorder) GetService(arg0, arg1 interface{}) *gomock.Ca
 }
 
 // GetServiceLogs mocks base method
-func (m *MockBackend) GetServiceLogs(arg0, arg1, arg2, arg3 string, arg4 int) ([]*models.LogFile, error) {
-	ret := m.ctrl.Call(m, "GetServiceLogs", arg0, arg1, arg2, arg3, arg4)
+func (m *MockBackend) GetServiceLogs(arg0, arg1, arg2, arg3, arg4, arg5 string, arg6 int) ([]*models.LogFile, error) {
+	ret := m.ctrl.Call(m, "GetServiceLogs", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 	ret0, _ := ret[0].([]*models.LogFile)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetServiceLogs indicates an expected call of GetServiceLogs
-func (mr *MockBackendMockRecorder) GetServiceLogs(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceLogs", reflect.TypeOf((*MockBackend)(nil).GetServiceLogs), arg0, arg1, arg2, arg3, arg4)
+func (mr *MockBackendMockRecorder) GetServiceLogs(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceLogs", reflect.TypeOf((*MockBackend)(nil).GetServiceLogs), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
 // GetTask mocks base method
@@ -288,16 +365,29 @@ func (mr *MockBackendMockRecorder) GetTask(arg0, arg1 interface{}) *gomock.Call
 }
 
 // GetTaskLogs mocks base method
-func (m *MockBackend) GetTaskLogs(arg0, arg1, arg2, arg3 string, arg4 int) ([]*models.LogFile, error) {
-	ret := m.ctrl.Call(m, "GetTaskLogs", arg0, arg1, arg2, arg3, arg4)
+func (m *MockBackend) GetTaskLogs(arg0, arg1, arg2, arg3, arg4, arg5 string, arg6 int) ([]*models.LogFile, error) {
+	ret := m.ctrl.Call(m, "GetTaskLogs", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 	ret0, _ := ret[0].([]*models.LogFile)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTaskLogs indicates an expected call of GetTaskLogs
-func (mr *MockBackendMockRecorder) GetTaskLogs(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskLogs", reflect.TypeOf((*MockBackend)(nil).GetTaskLogs), arg0, arg1, arg2, arg3, arg4)
+func (mr *MockBackendMockRecorder) GetTaskLogs(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskLogs", reflect.TypeOf((*MockBackend)(nil).GetTaskLogs), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
+// ListCertificates mocks base method
+func (m *MockBackend) ListCertificates() ([]*models.Certificate, error) {
+	ret := m.ctrl.Call(m, "ListCertificates")
+	ret0, _ := ret[0].([]*models.Certificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCertificates indicates an expected call of ListCertificates
+func (mr *MockBackendMockRecorder) ListCertificates() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCertificates", reflect.TypeOf((*MockBackend)(nil).ListCertificates))
 }
 
 // ListDeploys mocks base method
@@ -326,6 +416,20 @@ func (mr *MockBackendMockRecorder) ListEnvironments() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnvironments", reflect.TypeOf((*MockBackend)(nil).ListEnvironments))
 }
 
+// ListEnvironmentsPage mocks base method
+func (m *MockBackend) ListEnvironmentsPage(arg0 int, arg1 string) ([]id.ECSEnvironmentID, string, error) {
+	ret := m.ctrl.Call(m, "ListEnvironmentsPage", arg0, arg1)
+	ret0, _ := ret[0].([]id.ECSEnvironmentID)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListEnvironmentsPage indicates an expected call of ListEnvironmentsPage
+func (mr *MockBackendMockRecorder) ListEnvironmentsPage(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnvironmentsPage", reflect.TypeOf((*MockBackend)(nil).ListEnvironmentsPage), arg0, arg1)
+}
+
 // ListLoadBalancers mocks base method
 func (m *MockBackend) ListLoadBalancers() ([]*models.LoadBalancer, error) {
 	ret := m.ctrl.Call(m, "ListLoadBalancers")
@@ -352,6 +456,20 @@ func (mr *MockBackendMockRecorder) ListServices() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServices", reflect.TypeOf((*MockBackend)(nil).ListServices))
 }
 
+// ListServicesPage mocks base method
+func (m *MockBackend) ListServicesPage(arg0 int, arg1 string) ([]id.ECSServiceID, string, error) {
+	ret := m.ctrl.Call(m, "ListServicesPage", arg0, arg1)
+	ret0, _ := ret[0].([]id.ECSServiceID)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListServicesPage indicates an expected call of ListServicesPage
+func (mr *MockBackendMockRecorder) ListServicesPage(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServicesPage", reflect.TypeOf((*MockBackend)(nil).ListServicesPage), arg0, arg1)
+}
+
 // ListTasks mocks base method
 func (m *MockBackend) ListTasks() ([]string, error) {
 	ret := m.ctrl.Call(m, "ListTasks")
@@ -365,6 +483,32 @@ func (mr *MockBackendMockRecorder) ListTasks() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasks", reflect.TypeOf((*MockBackend)(nil).ListTasks))
 }
 
+// ListTasksPage mocks base method
+func (m *MockBackend) ListTasksPage(arg0 int, arg1 string) ([]string, string, error) {
+	ret := m.ctrl.Call(m, "ListTasksPage", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListTasksPage indicates an expected call of ListTasksPage
+func (mr *MockBackendMockRecorder) ListTasksPage(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasksPage", reflect.TypeOf((*MockBackend)(nil).ListTasksPage), arg0, arg1)
+}
+
+// RevokeEnvironmentIngress mocks base method
+func (m *MockBackend) RevokeEnvironmentIngress(arg0 string, arg1 models.EnvironmentIngressRule) error {
+	ret := m.ctrl.Call(m, "RevokeEnvironmentIngress", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeEnvironmentIngress indicates an expected call of RevokeEnvironmentIngress
+func (mr *MockBackendMockRecorder) RevokeEnvironmentIngress(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeEnvironmentIngress", reflect.TypeOf((*MockBackend)(nil).RevokeEnvironmentIngress), arg0, arg1)
+}
+
 // ScaleService mocks base method
 func (m *MockBackend) ScaleService(arg0, arg1 string, arg2 int) (*models.Service, error) {
 	ret := m.ctrl.Call(m, "ScaleService", arg0, arg1, arg2)
@@ -391,6 +535,19 @@ func (mr *MockBackendMockRecorder) UpdateEnvironment(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEnvironment", reflect.TypeOf((*MockBackend)(nil).UpdateEnvironment), arg0, arg1)
 }
 
+// UpdateLoadBalancerAccessLog mocks base method
+func (m *MockBackend) UpdateLoadBalancerAccessLog(arg0 string, arg1 models.AccessLog) (*models.LoadBalancer, error) {
+	ret := m.ctrl.Call(m, "UpdateLoadBalancerAccessLog", arg0, arg1)
+	ret0, _ := ret[0].(*models.LoadBalancer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLoadBalancerAccessLog indicates an expected call of UpdateLoadBalancerAccessLog
+func (mr *MockBackendMockRecorder) UpdateLoadBalancerAccessLog(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLoadBalancerAccessLog", reflect.TypeOf((*MockBackend)(nil).UpdateLoadBalancerAccessLog), arg0, arg1)
+}
+
 // UpdateLoadBalancerCrossZone mocks base method
 func (m *MockBackend) UpdateLoadBalancerCrossZone(arg0 string, arg1 bool) (*models.LoadBalancer, error) {
 	ret := m.ctrl.Call(m, "UpdateLoadBalancerCrossZone", arg0, arg1)
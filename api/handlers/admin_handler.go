@@ -48,6 +48,18 @@ func (this AdminHandler) Routes() *restful.WebService {
 		Doc("Returns Configuration of the API Server").
 		Writes(models.APIConfig{}))
 
+	service.Route(service.GET("/cache").
+		Filter(basicAuthenticate).
+		To(this.GetBackendCacheStats).
+		Doc("Returns statistics of the backend cache").
+		Writes(models.BackendCacheStats{}))
+
+	service.Route(service.DELETE("/cache").
+		Filter(basicAuthenticate).
+		To(this.FlushBackendCache).
+		Doc("Removes every result from the backend cache").
+		Returns(http.StatusNoContent, "Flushed", nil))
+
 	service.Route(service.POST("/sql").
 		Filter(basicAuthenticate).
 		To(this.UpdateSQL).
@@ -100,6 +112,16 @@ func (this *AdminHandler) RunEnvironmentScaler(request *restful.Request, respons
 	response.WriteAsJson(info)
 }
 
+func (this *AdminHandler) GetBackendCacheStats(request *restful.Request, response *restful.Response) {
+	stats := this.AdminLogic.GetBackendCacheStats()
+	response.WriteAsJson(stats)
+}
+
+func (this *AdminHandler) FlushBackendCache(request *restful.Request, response *restful.Response) {
+	this.AdminLogic.FlushBackendCache()
+	response.WriteHeader(http.StatusNoContent)
+}
+
 func (this *AdminHandler) UpdateSQL(request *restful.Request, response *restful.Response) {
 	if err := this.AdminLogic.UpdateSQL(); err != nil {
 		ReturnError(response, err)
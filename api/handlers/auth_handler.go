@@ -8,11 +8,7 @@ import (
 )
 
 func basicAuthenticate(req *restful.Request, resp *restful.Response, chain *restful.FilterChain) {
-	encoded := req.Request.Header.Get("Authorization")
-	expected := "Basic " + config.AuthToken()
-
-	// a better implementation would connect to an external service
-	if len(encoded) == 0 || expected != encoded {
+	if !isAuthenticated(req) {
 		resp.AddHeader("WWW-Authenticate", "Basic realm=Protected Area")
 		resp.WriteErrorString(401, "401: Not Authorized")
 		return
@@ -21,6 +17,14 @@ func basicAuthenticate(req *restful.Request, resp *restful.Response, chain *rest
 	chain.ProcessFilter(req, resp)
 }
 
+func isAuthenticated(req *restful.Request) bool {
+	encoded := req.Request.Header.Get("Authorization")
+	expected := "Basic " + config.AuthToken()
+
+	// a better implementation would connect to an external service
+	return len(encoded) != 0 && expected == encoded
+}
+
 func HttpsRedirect(req *restful.Request, resp *restful.Response, chain *restful.FilterChain) {
 	proto := req.Request.Header.Get("X-Forwarded-Proto")
 	if proto == "http" {
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"github.com/emicklei/go-restful"
+	"github.com/quintilesims/layer0/api/logic"
+	"github.com/quintilesims/layer0/common/errors"
+	"github.com/quintilesims/layer0/common/models"
+)
+
+type CertificateHandler struct {
+	CertificateLogic logic.CertificateLogic
+}
+
+func NewCertificateHandler(certificateLogic logic.CertificateLogic) *CertificateHandler {
+	return &CertificateHandler{
+		CertificateLogic: certificateLogic,
+	}
+}
+
+func (this *CertificateHandler) Routes() *restful.WebService {
+	service := new(restful.WebService)
+	service.Path("/certificate").
+		Consumes(restful.MIME_JSON).
+		Produces(restful.MIME_JSON)
+
+	service.Route(service.GET("/").
+		Filter(basicAuthenticate).
+		To(this.ListCertificates).
+		Doc("List all IAM and ACM Certificates").
+		Param(service.QueryParameter("limit", "Return at most the specified number of results").DataType("integer")).
+		Param(service.QueryParameter("next_token", "Return the page of results after the page that returned this token").DataType("string")).
+		Returns(200, "OK", []models.Certificate{}))
+
+	return service
+}
+
+func (this *CertificateHandler) ListCertificates(request *restful.Request, response *restful.Response) {
+	limit, nextToken, err := parsePageParams(request)
+	if err != nil {
+		BadRequest(response, errors.InvalidRequest, err)
+		return
+	}
+
+	certificates, nextToken, err := this.CertificateLogic.ListCertificatesPage(limit, nextToken)
+	if err != nil {
+		ReturnError(response, err)
+		return
+	}
+
+	WritePage(response, certificates, nextToken)
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/emicklei/go-restful"
+	"github.com/golang/mock/gomock"
+	"github.com/quintilesims/layer0/api/logic/mock_logic"
+	"github.com/quintilesims/layer0/common/errors"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func TestListCertificates(t *testing.T) {
+	certificates := []*models.Certificate{
+		{CertificateID: "c1", CertificateName: "cert", Source: "iam"},
+		{CertificateID: "c2", DomainName: "example.com", Source: "acm", Status: "ISSUED"},
+	}
+
+	testCases := []HandlerTestCase{
+		{
+			Name:    "Should return certificates from logic layer",
+			Request: &TestRequest{},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				logicMock := mock_logic.NewMockCertificateLogic(ctrl)
+				logicMock.EXPECT().
+					ListCertificatesPage(0, "").
+					Return(certificates, "", nil)
+
+				return NewCertificateHandler(logicMock)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*CertificateHandler)
+				handler.ListCertificates(req, resp)
+
+				var response []*models.Certificate
+				read(&response)
+
+				reporter.AssertEqual(response, certificates)
+			},
+		},
+		{
+			Name:    "Should propagate ListCertificates error",
+			Request: &TestRequest{},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				logicMock := mock_logic.NewMockCertificateLogic(ctrl)
+				logicMock.EXPECT().
+					ListCertificatesPage(0, "").
+					Return(nil, "", errors.Newf(errors.UnexpectedError, "some error"))
+
+				return NewCertificateHandler(logicMock)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*CertificateHandler)
+				handler.ListCertificates(req, resp)
+
+				var response *models.ServerError
+				read(&response)
+
+				reporter.AssertEqual(response.ErrorCode, int64(errors.UnexpectedError))
+			},
+		},
+	}
+
+	RunHandlerTestCases(t, testCases)
+}
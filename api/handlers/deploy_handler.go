@@ -33,6 +33,8 @@ func (this *DeployHandler) Routes() *restful.WebService {
 		Filter(basicAuthenticate).
 		To(this.ListDeploys).
 		Doc("List all Deploys").
+		Param(service.QueryParameter("limit", "Return at most the specified number of results").DataType("integer")).
+		Param(service.QueryParameter("next_token", "Return the page of results after the page that returned this token").DataType("string")).
 		Returns(200, "OK", []models.DeploySummary{}))
 
 	service.Route(service.GET("{id}").
@@ -60,13 +62,19 @@ func (this *DeployHandler) Routes() *restful.WebService {
 }
 
 func (this *DeployHandler) ListDeploys(request *restful.Request, response *restful.Response) {
-	deploys, err := this.DeployLogic.ListDeploys()
+	limit, nextToken, err := parsePageParams(request)
+	if err != nil {
+		BadRequest(response, errors.InvalidRequest, err)
+		return
+	}
+
+	deploys, nextToken, err := this.DeployLogic.ListDeploysPage(limit, nextToken)
 	if err != nil {
 		ReturnError(response, err)
 		return
 	}
 
-	response.WriteAsJson(deploys)
+	WritePage(response, deploys, nextToken)
 }
 
 func (this *DeployHandler) GetDeploy(request *restful.Request, response *restful.Response) {
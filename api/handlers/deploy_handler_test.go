@@ -24,8 +24,8 @@ func TestListDeploys(t *testing.T) {
 			Setup: func(ctrl *gomock.Controller) interface{} {
 				logicMock := mock_logic.NewMockDeployLogic(ctrl)
 				logicMock.EXPECT().
-					ListDeploys().
-					Return(deploys, nil)
+					ListDeploysPage(0, "").
+					Return(deploys, "", nil)
 
 				return NewDeployHandler(logicMock)
 			},
@@ -45,8 +45,8 @@ func TestListDeploys(t *testing.T) {
 			Setup: func(ctrl *gomock.Controller) interface{} {
 				logicMock := mock_logic.NewMockDeployLogic(ctrl)
 				logicMock.EXPECT().
-					ListDeploys().
-					Return(nil, errors.Newf(errors.UnexpectedError, "some error"))
+					ListDeploysPage(0, "").
+					Return(nil, "", errors.Newf(errors.UnexpectedError, "some error"))
 
 				return NewDeployHandler(logicMock)
 			},
@@ -3,6 +3,7 @@ package handlers
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/emicklei/go-restful"
 	"github.com/quintilesims/layer0/api/logic"
@@ -36,6 +37,8 @@ func (e *EnvironmentHandler) Routes() *restful.WebService {
 		Filter(basicAuthenticate).
 		To(e.ListEnvironments).
 		Doc("List all Environments").
+		Param(service.QueryParameter("limit", "Return at most the specified number of results").DataType("integer")).
+		Param(service.QueryParameter("next_token", "Return the page of results after the page that returned this token").DataType("string")).
 		Returns(200, "OK", []models.Environment{}))
 
 	service.Route(service.GET("{id}").
@@ -45,12 +48,29 @@ func (e *EnvironmentHandler) Routes() *restful.WebService {
 		Param(id).
 		Writes(models.Environment{}))
 
+	service.Route(service.GET("{id}/capacity").
+		Filter(basicAuthenticate).
+		To(e.GetEnvironmentCapacity).
+		Doc("Return the resource utilization of an Environment's instances").
+		Param(id).
+		Writes(models.EnvironmentCapacity{}))
+
+	service.Route(service.GET("{id}/cost").
+		Filter(basicAuthenticate).
+		To(e.GetEnvironmentCost).
+		Doc("Return the estimated monthly cost of an Environment, broken down by service").
+		Param(id).
+		Param(service.QueryParameter("data_transfer_gb", "estimated GB of data transferred out each month").DataType("string")).
+		Writes(models.EnvironmentCost{}))
+
 	service.Route(service.POST("/").
 		Filter(basicAuthenticate).
 		To(e.CreateEnvironment).
 		Doc("Create a new Environment").
 		Reads(models.CreateEnvironmentRequest{}).
+		Param(service.QueryParameter("async", "If true, run the request as a job and return its id instead of waiting for it to complete").DataType("bool")).
 		Returns(http.StatusCreated, "Created", models.Environment{}).
+		Returns(http.StatusAccepted, "Accepted", nil).
 		Writes(models.Environment{}))
 
 	service.Route(service.PUT("{id}").
@@ -76,6 +96,22 @@ func (e *EnvironmentHandler) Routes() *restful.WebService {
 		Param(id).
 		Returns(http.StatusNoContent, "Created", nil))
 
+	service.Route(service.POST("{id}/ingress").
+		Filter(basicAuthenticate).
+		To(e.AuthorizeEnvironmentIngress).
+		Doc("Allow traffic to an Environment from CIDR blocks or security groups").
+		Reads(models.UpdateEnvironmentIngressRequest{}).
+		Param(id).
+		Writes(models.Environment{}))
+
+	service.Route(service.DELETE("{id}/ingress").
+		Filter(basicAuthenticate).
+		To(e.RevokeEnvironmentIngress).
+		Doc("Revoke traffic to an Environment from CIDR blocks or security groups").
+		Reads(models.UpdateEnvironmentIngressRequest{}).
+		Param(id).
+		Writes(models.Environment{}))
+
 	sourceID := service.PathParameter("source_id", "identifier of the source environment").
 		DataType("string")
 
@@ -94,13 +130,19 @@ func (e *EnvironmentHandler) Routes() *restful.WebService {
 }
 
 func (e *EnvironmentHandler) ListEnvironments(request *restful.Request, response *restful.Response) {
-	environments, err := e.EnvironmentLogic.ListEnvironments()
+	limit, nextToken, err := parsePageParams(request)
+	if err != nil {
+		BadRequest(response, errors.InvalidRequest, err)
+		return
+	}
+
+	environments, nextToken, err := e.EnvironmentLogic.ListEnvironmentsPage(limit, nextToken)
 	if err != nil {
 		ReturnError(response, err)
 		return
 	}
 
-	response.WriteAsJson(environments)
+	WritePage(response, environments, nextToken)
 }
 
 func (e *EnvironmentHandler) GetEnvironment(request *restful.Request, response *restful.Response) {
@@ -120,6 +162,52 @@ func (e *EnvironmentHandler) GetEnvironment(request *restful.Request, response *
 	response.WriteAsJson(environment)
 }
 
+func (e *EnvironmentHandler) GetEnvironmentCapacity(request *restful.Request, response *restful.Response) {
+	id := request.PathParameter("id")
+	if id == "" {
+		err := fmt.Errorf("Parameter 'id' is required")
+		BadRequest(response, errors.MissingParameter, err)
+		return
+	}
+
+	capacity, err := e.EnvironmentLogic.GetEnvironmentCapacity(id)
+	if err != nil {
+		ReturnError(response, err)
+		return
+	}
+
+	response.WriteAsJson(capacity)
+}
+
+func (e *EnvironmentHandler) GetEnvironmentCost(request *restful.Request, response *restful.Response) {
+	id := request.PathParameter("id")
+	if id == "" {
+		err := fmt.Errorf("Parameter 'id' is required")
+		BadRequest(response, errors.MissingParameter, err)
+		return
+	}
+
+	var dataTransferGB float64
+	if param := request.QueryParameter("data_transfer_gb"); param != "" {
+		v, err := strconv.ParseFloat(param, 64)
+		if err != nil || v < 0 {
+			err := fmt.Errorf("Parameter 'data_transfer_gb' must be a non-negative number")
+			BadRequest(response, errors.InvalidRequest, err)
+			return
+		}
+
+		dataTransferGB = v
+	}
+
+	cost, err := e.EnvironmentLogic.GetEnvironmentCost(id, dataTransferGB)
+	if err != nil {
+		ReturnError(response, err)
+		return
+	}
+
+	response.WriteAsJson(cost)
+}
+
 func (e *EnvironmentHandler) DeleteEnvironment(request *restful.Request, response *restful.Response) {
 	id := request.PathParameter("id")
 	if id == "" {
@@ -156,6 +244,17 @@ func (e *EnvironmentHandler) CreateEnvironment(request *restful.Request, respons
 		return
 	}
 
+	if request.QueryParameter("async") == "true" {
+		job, err := e.JobLogic.CreateJob(types.CreateEnvironmentJob, req)
+		if err != nil {
+			ReturnError(response, err)
+			return
+		}
+
+		WriteJobResponse(response, job.JobID)
+		return
+	}
+
 	environment, err := e.EnvironmentLogic.CreateEnvironment(req)
 	if err != nil {
 		ReturnError(response, err)
@@ -202,7 +301,7 @@ func (e *EnvironmentHandler) CreateEnvironmentLink(request *restful.Request, res
 		return
 	}
 
-	if err := e.EnvironmentLogic.CreateEnvironmentLink(id, req.EnvironmentID); err != nil {
+	if err := e.EnvironmentLogic.CreateEnvironmentLink(id, req); err != nil {
 		ReturnError(response, err)
 		return
 	}
@@ -232,3 +331,49 @@ func (e *EnvironmentHandler) DeleteEnvironmentLink(request *restful.Request, res
 
 	response.WriteAsJson("")
 }
+
+func (e *EnvironmentHandler) AuthorizeEnvironmentIngress(request *restful.Request, response *restful.Response) {
+	id := request.PathParameter("id")
+	if id == "" {
+		err := fmt.Errorf("Parameter 'id' is required")
+		BadRequest(response, errors.MissingParameter, err)
+		return
+	}
+
+	var req models.UpdateEnvironmentIngressRequest
+	if err := request.ReadEntity(&req); err != nil {
+		BadRequest(response, errors.InvalidJSON, err)
+		return
+	}
+
+	environment, err := e.EnvironmentLogic.AuthorizeEnvironmentIngress(id, req)
+	if err != nil {
+		ReturnError(response, err)
+		return
+	}
+
+	response.WriteAsJson(environment)
+}
+
+func (e *EnvironmentHandler) RevokeEnvironmentIngress(request *restful.Request, response *restful.Response) {
+	id := request.PathParameter("id")
+	if id == "" {
+		err := fmt.Errorf("Parameter 'id' is required")
+		BadRequest(response, errors.MissingParameter, err)
+		return
+	}
+
+	var req models.UpdateEnvironmentIngressRequest
+	if err := request.ReadEntity(&req); err != nil {
+		BadRequest(response, errors.InvalidJSON, err)
+		return
+	}
+
+	environment, err := e.EnvironmentLogic.RevokeEnvironmentIngress(id, req)
+	if err != nil {
+		ReturnError(response, err)
+		return
+	}
+
+	response.WriteAsJson(environment)
+}
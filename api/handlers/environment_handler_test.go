@@ -30,8 +30,8 @@ func TestListEnvironments(t *testing.T) {
 			Setup: func(ctrl *gomock.Controller) interface{} {
 				envLogicMock := mock_logic.NewMockEnvironmentLogic(ctrl)
 				envLogicMock.EXPECT().
-					ListEnvironments().
-					Return(environments, nil)
+					ListEnvironmentsPage(0, "").
+					Return(environments, "", nil)
 
 				jobLogicMock := mock_logic.NewMockJobLogic(ctrl)
 
@@ -53,8 +53,8 @@ func TestListEnvironments(t *testing.T) {
 			Setup: func(ctrl *gomock.Controller) interface{} {
 				envLogicMock := mock_logic.NewMockEnvironmentLogic(ctrl)
 				envLogicMock.EXPECT().
-					ListEnvironments().
-					Return(nil, errors.Newf(errors.UnexpectedError, "some error"))
+					ListEnvironmentsPage(0, "").
+					Return(nil, "", errors.Newf(errors.UnexpectedError, "some error"))
 
 				jobLogicMock := mock_logic.NewMockJobLogic(ctrl)
 
@@ -174,6 +174,120 @@ func TestGetEnvironment(t *testing.T) {
 	RunHandlerTestCases(t, testCases)
 }
 
+func TestGetEnvironmentCapacity(t *testing.T) {
+	capacity := &models.EnvironmentCapacity{
+		EnvironmentID:        "some_id",
+		ReclaimableInstances: 1,
+	}
+
+	testCases := []HandlerTestCase{
+		{
+			Name: "Should return capacity from logic layer",
+			Request: &TestRequest{
+				Parameters: map[string]string{"id": "some_id"},
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				envLogicMock := mock_logic.NewMockEnvironmentLogic(ctrl)
+				envLogicMock.EXPECT().
+					GetEnvironmentCapacity("some_id").
+					Return(capacity, nil)
+
+				jobLogicMock := mock_logic.NewMockJobLogic(ctrl)
+
+				return NewEnvironmentHandler(envLogicMock, jobLogicMock)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*EnvironmentHandler)
+				handler.GetEnvironmentCapacity(req, resp)
+
+				var response *models.EnvironmentCapacity
+				read(&response)
+
+				reporter.AssertEqual(response, capacity)
+			},
+		},
+		{
+			Name:    "Should return MissingParameter error with no id",
+			Request: &TestRequest{},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				envLogicMock := mock_logic.NewMockEnvironmentLogic(ctrl)
+				jobLogicMock := mock_logic.NewMockJobLogic(ctrl)
+				return NewEnvironmentHandler(envLogicMock, jobLogicMock)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*EnvironmentHandler)
+				handler.GetEnvironmentCapacity(req, resp)
+
+				var response *models.ServerError
+				read(&response)
+
+				reporter.AssertEqual(response.ErrorCode, int64(errors.MissingParameter))
+			},
+		},
+	}
+
+	RunHandlerTestCases(t, testCases)
+}
+
+func TestGetEnvironmentCost(t *testing.T) {
+	cost := &models.EnvironmentCost{
+		EnvironmentID:    "some_id",
+		TotalMonthlyCost: 100,
+	}
+
+	testCases := []HandlerTestCase{
+		{
+			Name: "Should return cost from logic layer",
+			Request: &TestRequest{
+				Parameters: map[string]string{"id": "some_id"},
+				Query:      "data_transfer_gb=50",
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				envLogicMock := mock_logic.NewMockEnvironmentLogic(ctrl)
+				envLogicMock.EXPECT().
+					GetEnvironmentCost("some_id", 50.0).
+					Return(cost, nil)
+
+				jobLogicMock := mock_logic.NewMockJobLogic(ctrl)
+
+				return NewEnvironmentHandler(envLogicMock, jobLogicMock)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*EnvironmentHandler)
+				handler.GetEnvironmentCost(req, resp)
+
+				var response *models.EnvironmentCost
+				read(&response)
+
+				reporter.AssertEqual(response, cost)
+			},
+		},
+		{
+			Name: "Should return InvalidRequest error with bad data_transfer_gb",
+			Request: &TestRequest{
+				Parameters: map[string]string{"id": "some_id"},
+				Query:      "data_transfer_gb=lots",
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				envLogicMock := mock_logic.NewMockEnvironmentLogic(ctrl)
+				jobLogicMock := mock_logic.NewMockJobLogic(ctrl)
+				return NewEnvironmentHandler(envLogicMock, jobLogicMock)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*EnvironmentHandler)
+				handler.GetEnvironmentCost(req, resp)
+
+				var response *models.ServerError
+				read(&response)
+
+				reporter.AssertEqual(response.ErrorCode, int64(errors.InvalidRequest))
+			},
+		},
+	}
+
+	RunHandlerTestCases(t, testCases)
+}
+
 func TestDeleteEnvironment(t *testing.T) {
 	testCases := []HandlerTestCase{
 		{
@@ -298,6 +412,32 @@ func TestCreateEnvironment(t *testing.T) {
 				handler.CreateEnvironment(req, resp)
 			},
 		},
+		{
+			Name: "Should create a create environment job when async is true",
+			Request: &TestRequest{
+				Body:  request,
+				Query: "async=true",
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				mockEnvironment := mock_logic.NewMockEnvironmentLogic(ctrl)
+				mockEnvironment.EXPECT().
+					CanCreateEnvironment(request).
+					Return(true, nil)
+
+				mockJob := mock_logic.NewMockJobLogic(ctrl)
+				mockJob.EXPECT().
+					CreateJob(types.CreateEnvironmentJob, request).
+					Return(&models.Job{JobID: "job_id"}, nil)
+
+				return NewEnvironmentHandler(mockEnvironment, mockJob)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*EnvironmentHandler)
+				handler.CreateEnvironment(req, resp)
+
+				reporter.AssertInSlice("job_id", resp.Header()["X-Jobid"])
+			},
+		},
 		{
 			Name: "Should return error if CanCreateEnvironment returns false",
 			Request: &TestRequest{
@@ -440,6 +580,8 @@ func TestUpdateEnvironment(t *testing.T) {
 func TestCreateEnvironmentLink(t *testing.T) {
 	request := models.CreateEnvironmentLinkRequest{
 		EnvironmentID: "eid2",
+		Ports:         []models.EnvironmentLinkPort{{Protocol: "tcp", FromPort: 5432, ToPort: 5432}},
+		OneWay:        true,
 	}
 
 	testCases := []HandlerTestCase{
@@ -453,7 +595,7 @@ func TestCreateEnvironmentLink(t *testing.T) {
 				mockEnvironment := mock_logic.NewMockEnvironmentLogic(ctrl)
 
 				mockEnvironment.EXPECT().
-					CreateEnvironmentLink("eid1", "eid2").
+					CreateEnvironmentLink("eid1", request).
 					Return(nil)
 
 				mockJob := mock_logic.NewMockJobLogic(ctrl)
@@ -546,3 +688,102 @@ func TestDeleteEnvironmentLink(t *testing.T) {
 
 	RunHandlerTestCases(t, testCases)
 }
+
+func TestAuthorizeEnvironmentIngress(t *testing.T) {
+	request := models.UpdateEnvironmentIngressRequest{
+		Rules: []models.EnvironmentIngressRule{
+			{CIDR: "10.1.0.0/16", Protocol: "tcp", FromPort: 22, ToPort: 22},
+		},
+	}
+
+	testCases := []HandlerTestCase{
+		{
+			Name: "Should call AuthorizeEnvironmentIngress with correct params",
+			Request: &TestRequest{
+				Body:       request,
+				Parameters: map[string]string{"id": "eid1"},
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				mockEnvironment := mock_logic.NewMockEnvironmentLogic(ctrl)
+
+				mockEnvironment.EXPECT().
+					AuthorizeEnvironmentIngress("eid1", request).
+					Return(&models.Environment{EnvironmentID: "eid1"}, nil)
+
+				mockJob := mock_logic.NewMockJobLogic(ctrl)
+				return NewEnvironmentHandler(mockEnvironment, mockJob)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*EnvironmentHandler)
+				handler.AuthorizeEnvironmentIngress(req, resp)
+
+				var response models.Environment
+				read(&response)
+
+				reporter.AssertEqual(response.EnvironmentID, "eid1")
+			},
+		},
+		{
+			Name: "Should propagate AuthorizeEnvironmentIngress error",
+			Request: &TestRequest{
+				Body:       request,
+				Parameters: map[string]string{"id": "eid1"},
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				mockEnvironment := mock_logic.NewMockEnvironmentLogic(ctrl)
+
+				mockEnvironment.EXPECT().
+					AuthorizeEnvironmentIngress(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("some error"))
+
+				mockJob := mock_logic.NewMockJobLogic(ctrl)
+				return NewEnvironmentHandler(mockEnvironment, mockJob)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*EnvironmentHandler)
+				handler.AuthorizeEnvironmentIngress(req, resp)
+
+				var response *models.ServerError
+				read(&response)
+
+				reporter.AssertEqual(int64(errors.UnexpectedError), response.ErrorCode)
+			},
+		},
+	}
+
+	RunHandlerTestCases(t, testCases)
+}
+
+func TestRevokeEnvironmentIngress(t *testing.T) {
+	request := models.UpdateEnvironmentIngressRequest{
+		Rules: []models.EnvironmentIngressRule{
+			{SecurityGroupID: "sg-123", Protocol: "tcp", FromPort: 443, ToPort: 443},
+		},
+	}
+
+	testCases := []HandlerTestCase{
+		{
+			Name: "Should call RevokeEnvironmentIngress with correct params",
+			Request: &TestRequest{
+				Body:       request,
+				Parameters: map[string]string{"id": "eid1"},
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				mockEnvironment := mock_logic.NewMockEnvironmentLogic(ctrl)
+
+				mockEnvironment.EXPECT().
+					RevokeEnvironmentIngress("eid1", request).
+					Return(&models.Environment{EnvironmentID: "eid1"}, nil)
+
+				mockJob := mock_logic.NewMockJobLogic(ctrl)
+				return NewEnvironmentHandler(mockEnvironment, mockJob)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*EnvironmentHandler)
+				handler.RevokeEnvironmentIngress(req, resp)
+			},
+		},
+	}
+
+	RunHandlerTestCases(t, testCases)
+}
@@ -17,7 +17,7 @@ func ToHttpError(code errors.ErrorCode) int {
 	switch code {
 	case errors.InvalidJSON, errors.MissingParameter, errors.InvalidEntityType,
 		errors.InvalidEnvironmentID, errors.InvalidServiceID, errors.InvalidDeployID,
-		errors.InvalidTagKey, errors.InvalidTagValue, errors.InvalidCertificateID:
+		errors.InvalidTagKey, errors.InvalidTagValue, errors.InvalidCertificateID, errors.InvalidRequest:
 		ret = http.StatusBadRequest
 	case errors.Throttled:
 		ret = http.StatusServiceUnavailable
@@ -1,19 +1,50 @@
 package handlers
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/emicklei/go-restful"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/quintilesims/layer0/common/config"
 )
 
+var (
+	requestCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "layer0_api_requests_total",
+			Help: "Total number of API requests by method, route, and status code",
+		},
+		[]string{"method", "route", "code"})
+
+	requestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "layer0_api_request_duration_seconds",
+			Help: "Latency of API requests by method and route",
+		},
+		[]string{"method", "route"})
+)
+
+func init() {
+	prometheus.MustRegister(requestCount, requestDuration)
+}
+
 func LogRequest(req *restful.Request, resp *restful.Response, chain *restful.FilterChain) {
 	start := time.Now()
 	chain.ProcessFilter(req, resp)
 	duration := time.Since(start)
 
-	if req.Request.URL.String() != "/health" {
+	// use the route template so ids don't create a new series for each entity
+	route := req.SelectedRoutePath()
+	if route == "" {
+		route = "unmatched"
+	}
+
+	requestCount.WithLabelValues(req.Request.Method, route, strconv.Itoa(resp.StatusCode())).Inc()
+	requestDuration.WithLabelValues(req.Request.Method, route).Observe(duration.Seconds())
+
+	if path := req.Request.URL.String(); path != "/health" && path != "/health/ping" && path != "/metrics" {
 		logrus.Infof("request %s %s (%v) %v", req.Request.Method, req.Request.URL, resp.StatusCode(), duration)
 	}
 }
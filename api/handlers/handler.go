@@ -1,15 +1,113 @@
 package handlers
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/Sirupsen/logrus"
 	"github.com/emicklei/go-restful"
+	"github.com/quintilesims/layer0/api/backend"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/paging"
 )
 
+// how long to wait between polls for new log events when following logs
+var logFollowInterval = time.Second * 5
+
+// how long a followed log stream can go without writing anything; this is
+// well below the 60 second default idle timeout of the api's load balancer
+var logKeepaliveInterval = time.Second * 30
+
 func WriteJobResponse(response *restful.Response, jobID string) {
 	response.AddHeader("Location", fmt.Sprintf("/job/%s", jobID))
 	response.AddHeader("X-JobID", jobID)
 	response.WriteHeader(http.StatusAccepted)
 	response.WriteAsJson(``)
 }
+
+// parsePageParams returns the 'limit' and 'next_token' query parameters of a list request.
+// A limit of 0 means every result after the token is returned.
+func parsePageParams(request *restful.Request) (int, string, error) {
+	var limit int
+	if val := request.QueryParameter("limit"); val != "" {
+		i, err := strconv.Atoi(val)
+		if err != nil || i < 1 || i > paging.MaxLimit {
+			return 0, "", fmt.Errorf("Parameter 'limit' must be an integer between 1 and %d", paging.MaxLimit)
+		}
+
+		limit = i
+	}
+
+	return limit, request.QueryParameter("next_token"), nil
+}
+
+// WritePage writes a page of results to the response; the token of the next page
+// is returned in the X-Next-Token header if there are more results
+func WritePage(response *restful.Response, page interface{}, nextToken string) {
+	if nextToken != "" {
+		response.AddHeader(paging.NextTokenHeader, nextToken)
+	}
+
+	response.WriteAsJson(page)
+}
+
+type followLogsf func(cursor *backend.LogCursor) ([]*models.LogEvent, error)
+
+// WriteLogStream polls fn for new log events and writes each one to the response
+// as a line of json until the client disconnects or fn returns an error.
+// A newline is written when no events have been written for logKeepaliveInterval
+// so load balancers do not close quiet streams as idle.
+func WriteLogStream(request *restful.Request, response *restful.Response, fn followLogsf) {
+	cursor := backend.NewLogCursor()
+
+	// the first poll happens before the status is written so errors can still be returned normally
+	events, err := fn(cursor)
+	if err != nil {
+		ReturnError(response, err)
+		return
+	}
+
+	response.AddHeader("Content-Type", "application/x-ndjson")
+	response.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(response)
+	flusher, _ := response.ResponseWriter.(http.Flusher)
+
+	lastWrite := time.Now()
+	for {
+		for _, event := range events {
+			if err := encoder.Encode(event); err != nil {
+				return
+			}
+
+			lastWrite = time.Now()
+		}
+
+		if time.Since(lastWrite) >= logKeepaliveInterval {
+			if _, err := response.Write([]byte("\n")); err != nil {
+				return
+			}
+
+			lastWrite = time.Now()
+		}
+
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		select {
+		case <-request.Request.Context().Done():
+			return
+		case <-time.After(logFollowInterval):
+		}
+
+		events, err = fn(cursor)
+		if err != nil {
+			logrus.Errorf("Failed to follow logs: %v", err)
+			return
+		}
+	}
+}
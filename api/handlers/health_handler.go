@@ -1,8 +1,11 @@
 package handlers
 
 import (
+	"net/http"
+
 	"github.com/emicklei/go-restful"
 	"github.com/quintilesims/layer0/api/logic"
+	"github.com/quintilesims/layer0/common/models"
 )
 
 type HealthHandler struct {
@@ -23,11 +26,34 @@ func (this HealthHandler) Routes() *restful.WebService {
 
 	service.Route(service.GET("/").
 		To(this.GetHealth).
-		Doc("Returns Health of API Server"))
+		Doc("Returns the health of the API Server and its dependencies. Returns 503 if a critical dependency is unhealthy. " +
+			"Component details are only included for authenticated requests").
+		Param(service.HeaderParameter("Authorization", "Basic realm authentication token")).
+		Writes(models.APIHealth{}))
+
+	service.Route(service.GET("/ping").
+		To(this.Ping).
+		Doc("Returns 200 if the API Server is running, without checking its dependencies"))
 
 	return service
 }
 
 func (this *HealthHandler) GetHealth(request *restful.Request, response *restful.Response) {
+	health := this.HealthLogic.GetHealth()
+
+	// component messages can contain aws errors, so anonymous callers only see the overall status
+	if !isAuthenticated(request) {
+		health = &models.APIHealth{Status: health.Status}
+	}
+
+	if health.Status == logic.HEALTH_UNHEALTHY {
+		response.WriteHeaderAndJson(http.StatusServiceUnavailable, health, restful.MIME_JSON)
+		return
+	}
+
+	response.WriteAsJson(health)
+}
+
+func (this *HealthHandler) Ping(request *restful.Request, response *restful.Response) {
 	response.WriteAsJson("")
 }
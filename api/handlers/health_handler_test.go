@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/emicklei/go-restful"
+	"github.com/golang/mock/gomock"
+	"github.com/quintilesims/layer0/api/logic"
+	"github.com/quintilesims/layer0/api/logic/mock_logic"
+	"github.com/quintilesims/layer0/common/config"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func TestGetHealth(t *testing.T) {
+	components := []models.ComponentHealth{
+		{Name: "aws_credentials", Status: logic.HEALTH_ERROR, Critical: true, Message: "expired token"},
+	}
+
+	testCases := []HandlerTestCase{
+		{
+			Name:    "Should return health from logic layer",
+			Request: &TestRequest{},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				logicMock := mock_logic.NewMockHealthLogic(ctrl)
+				logicMock.EXPECT().
+					GetHealth().
+					Return(&models.APIHealth{Status: logic.HEALTH_DEGRADED})
+
+				return NewHealthHandler(logicMock)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*HealthHandler)
+				handler.GetHealth(req, resp)
+
+				var response models.APIHealth
+				read(&response)
+
+				reporter.AssertEqual(resp.StatusCode(), http.StatusOK)
+				reporter.AssertEqual(response.Status, logic.HEALTH_DEGRADED)
+			},
+		},
+		{
+			Name:    "Should return 503 when unhealthy",
+			Request: &TestRequest{},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				logicMock := mock_logic.NewMockHealthLogic(ctrl)
+				logicMock.EXPECT().
+					GetHealth().
+					Return(&models.APIHealth{Status: logic.HEALTH_UNHEALTHY})
+
+				return NewHealthHandler(logicMock)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*HealthHandler)
+				handler.GetHealth(req, resp)
+
+				var response models.APIHealth
+				read(&response)
+
+				reporter.AssertEqual(resp.StatusCode(), http.StatusServiceUnavailable)
+				reporter.AssertEqual(response.Status, logic.HEALTH_UNHEALTHY)
+			},
+		},
+		{
+			Name:    "Should only return the overall status when unauthenticated",
+			Request: &TestRequest{},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				logicMock := mock_logic.NewMockHealthLogic(ctrl)
+				logicMock.EXPECT().
+					GetHealth().
+					Return(&models.APIHealth{Status: logic.HEALTH_UNHEALTHY, Components: components})
+
+				return NewHealthHandler(logicMock)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*HealthHandler)
+				handler.GetHealth(req, resp)
+
+				var response models.APIHealth
+				read(&response)
+
+				reporter.AssertEqual(response.Status, logic.HEALTH_UNHEALTHY)
+				reporter.AssertEqual(len(response.Components), 0)
+			},
+		},
+		{
+			Name:    "Should return components when authenticated",
+			Request: &TestRequest{},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				logicMock := mock_logic.NewMockHealthLogic(ctrl)
+				logicMock.EXPECT().
+					GetHealth().
+					Return(&models.APIHealth{Status: logic.HEALTH_UNHEALTHY, Components: components})
+
+				return NewHealthHandler(logicMock)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				req.Request.Header.Set("Authorization", "Basic "+config.AuthToken())
+
+				handler := target.(*HealthHandler)
+				handler.GetHealth(req, resp)
+
+				var response models.APIHealth
+				read(&response)
+
+				reporter.AssertEqual(response.Status, logic.HEALTH_UNHEALTHY)
+				reporter.AssertEqual(response.Components, components)
+			},
+		},
+	}
+
+	RunHandlerTestCases(t, testCases)
+}
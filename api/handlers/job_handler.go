@@ -33,6 +33,8 @@ func (j *JobHandler) Routes() *restful.WebService {
 		Filter(basicAuthenticate).
 		To(j.ListJobs).
 		Doc("List all Jobs").
+		Param(service.QueryParameter("limit", "Return at most the specified number of results").DataType("integer")).
+		Param(service.QueryParameter("next_token", "Return the page of results after the page that returned this token").DataType("string")).
 		Returns(200, "OK", []models.Job{}))
 
 	service.Route(service.GET("{id}").
@@ -42,6 +44,13 @@ func (j *JobHandler) Routes() *restful.WebService {
 		Param(id).
 		Writes(models.Job{}))
 
+	service.Route(service.POST("/{id}/cancel").
+		Filter(basicAuthenticate).
+		To(j.CancelJob).
+		Doc("Cancel a pending or running job").
+		Param(id).
+		Returns(http.StatusNoContent, "Cancelled", nil))
+
 	service.Route(service.DELETE("/{id}").
 		Filter(basicAuthenticate).
 		To(j.Delete).
@@ -53,13 +62,19 @@ func (j *JobHandler) Routes() *restful.WebService {
 }
 
 func (j *JobHandler) ListJobs(request *restful.Request, response *restful.Response) {
-	jobs, err := j.JobLogic.ListJobs()
+	limit, nextToken, err := parsePageParams(request)
+	if err != nil {
+		BadRequest(response, errors.InvalidRequest, err)
+		return
+	}
+
+	jobs, nextToken, err := j.JobLogic.ListJobsPage(limit, nextToken)
 	if err != nil {
 		ReturnError(response, err)
 		return
 	}
 
-	response.WriteAsJson(jobs)
+	WritePage(response, jobs, nextToken)
 }
 
 func (j *JobHandler) GetJob(request *restful.Request, response *restful.Response) {
@@ -79,6 +94,22 @@ func (j *JobHandler) GetJob(request *restful.Request, response *restful.Response
 	response.WriteAsJson(job)
 }
 
+func (j *JobHandler) CancelJob(request *restful.Request, response *restful.Response) {
+	id := request.PathParameter("id")
+	if id == "" {
+		err := fmt.Errorf("Parameter 'id' is required")
+		BadRequest(response, errors.MissingParameter, err)
+		return
+	}
+
+	if err := j.JobLogic.CancelJob(id); err != nil {
+		ReturnError(response, err)
+		return
+	}
+
+	response.WriteAsJson(``)
+}
+
 func (j *JobHandler) Delete(request *restful.Request, response *restful.Response) {
 	id := request.PathParameter("id")
 	if id == "" {
@@ -33,8 +33,8 @@ func TestListJobs(t *testing.T) {
 			Setup: func(ctrl *gomock.Controller) interface{} {
 				logicMock := mock_logic.NewMockJobLogic(ctrl)
 				logicMock.EXPECT().
-					ListJobs().
-					Return(jobs, nil)
+					ListJobsPage(0, "").
+					Return(jobs, "", nil)
 
 				return NewJobHandler(logicMock)
 			},
@@ -57,8 +57,8 @@ func TestListJobs(t *testing.T) {
 			Setup: func(ctrl *gomock.Controller) interface{} {
 				logicMock := mock_logic.NewMockJobLogic(ctrl)
 				logicMock.EXPECT().
-					ListJobs().
-					Return(nil, errors.Newf(errors.UnexpectedError, "some error"))
+					ListJobsPage(0, "").
+					Return(nil, "", errors.Newf(errors.UnexpectedError, "some error"))
 
 				return NewJobHandler(logicMock)
 			},
@@ -170,6 +170,54 @@ func TestGetJob(t *testing.T) {
 	RunHandlerTestCases(t, testCases)
 }
 
+func TestCancelJob(t *testing.T) {
+	testCases := []HandlerTestCase{
+		{
+			Name: "Should call CancelJob with proper params",
+			Request: &TestRequest{
+				Parameters: map[string]string{"id": "some_id"},
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				logicMock := mock_logic.NewMockJobLogic(ctrl)
+				logicMock.EXPECT().
+					CancelJob("some_id").
+					Return(nil)
+
+				return NewJobHandler(logicMock)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*JobHandler)
+				handler.CancelJob(req, resp)
+			},
+		},
+		{
+			Name: "Should propagate CancelJob error",
+			Request: &TestRequest{
+				Parameters: map[string]string{"id": "some_id"},
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				logicMock := mock_logic.NewMockJobLogic(ctrl)
+				logicMock.EXPECT().
+					CancelJob(gomock.Any()).
+					Return(errors.Newf(errors.InvalidRequest, "some error"))
+
+				return NewJobHandler(logicMock)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*JobHandler)
+				handler.CancelJob(req, resp)
+
+				var response *models.ServerError
+				read(&response)
+
+				reporter.AssertEqual(response.ErrorCode, int64(errors.InvalidRequest))
+			},
+		},
+	}
+
+	RunHandlerTestCases(t, testCases)
+}
+
 func TestDelete(t *testing.T) {
 	testCases := []HandlerTestCase{
 		{
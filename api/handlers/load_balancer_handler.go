@@ -3,9 +3,12 @@ package handlers
 import (
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/emicklei/go-restful"
 	"github.com/quintilesims/layer0/api/logic"
+	"github.com/quintilesims/layer0/common/aws/cloudwatchlogs"
 	"github.com/quintilesims/layer0/common/errors"
 	"github.com/quintilesims/layer0/common/models"
 	"github.com/quintilesims/layer0/common/types"
@@ -36,6 +39,8 @@ func (l *LoadBalancerHandler) Routes() *restful.WebService {
 		Filter(basicAuthenticate).
 		To(l.ListLoadBalancers).
 		Doc("List all LoadBalancers").
+		Param(service.QueryParameter("limit", "Return at most the specified number of results").DataType("integer")).
+		Param(service.QueryParameter("next_token", "Return the page of results after the page that returned this token").DataType("string")).
 		Returns(200, "OK", []models.LoadBalancer{}))
 
 	service.Route(service.GET("{id}").
@@ -50,7 +55,9 @@ func (l *LoadBalancerHandler) Routes() *restful.WebService {
 		To(l.CreateLoadBalancer).
 		Doc("Create a new LoadBalancer").
 		Reads(models.CreateLoadBalancerRequest{}).
+		Param(service.QueryParameter("async", "If true, run the request as a job and return its id instead of waiting for it to complete").DataType("bool")).
 		Returns(http.StatusCreated, "Created", models.LoadBalancer{}).
+		Returns(http.StatusAccepted, "Accepted", nil).
 		Writes(models.LoadBalancer{}))
 
 	service.Route(service.DELETE("{id}").
@@ -92,17 +99,41 @@ func (l *LoadBalancerHandler) Routes() *restful.WebService {
 		Doc("Update load balancer cross-zone load balancing").
 		Writes(models.LoadBalancer{}))
 
+	service.Route(service.PUT("{id}/accesslog").
+		Filter(basicAuthenticate).
+		To(l.UpdateLoadBalancerAccessLog).
+		Reads(models.UpdateLoadBalancerAccessLogRequest{}).
+		Param(id).
+		Doc("Update load balancer access logs").
+		Writes(models.LoadBalancer{}))
+
+	service.Route(service.GET("{id}/metrics").
+		Filter(basicAuthenticate).
+		To(l.GetLoadBalancerMetrics).
+		Doc("Return request count, latency and 5xx metrics for a load balancer").
+		Param(id).
+		Param(service.QueryParameter("start", "The start of the time range to fetch metrics (format YYYY-MM-DD HH:MM, default 1 hour before end)").DataType("string")).
+		Param(service.QueryParameter("end", "The end of the time range to fetch metrics (format YYYY-MM-DD HH:MM, default now)").DataType("string")).
+		Param(service.QueryParameter("period", "The granularity of the returned datapoints in seconds (default 60)").DataType("string")).
+		Writes(models.LoadBalancerMetrics{}))
+
 	return service
 }
 
 func (l *LoadBalancerHandler) ListLoadBalancers(request *restful.Request, response *restful.Response) {
-	loadbalancers, err := l.LoadBalancerLogic.ListLoadBalancers()
+	limit, nextToken, err := parsePageParams(request)
+	if err != nil {
+		BadRequest(response, errors.InvalidRequest, err)
+		return
+	}
+
+	loadbalancers, nextToken, err := l.LoadBalancerLogic.ListLoadBalancersPage(limit, nextToken)
 	if err != nil {
 		ReturnError(response, err)
 		return
 	}
 
-	response.WriteAsJson(loadbalancers)
+	WritePage(response, loadbalancers, nextToken)
 }
 
 func (l *LoadBalancerHandler) GetLoadBalancer(request *restful.Request, response *restful.Response) {
@@ -146,6 +177,17 @@ func (l *LoadBalancerHandler) CreateLoadBalancer(request *restful.Request, respo
 		return
 	}
 
+	if request.QueryParameter("async") == "true" {
+		job, err := l.JobLogic.CreateJob(types.CreateLoadBalancerJob, req)
+		if err != nil {
+			ReturnError(response, err)
+			return
+		}
+
+		WriteJobResponse(response, job.JobID)
+		return
+	}
+
 	loadBalancer, err := l.LoadBalancerLogic.CreateLoadBalancer(req)
 	if err != nil {
 		ReturnError(response, err)
@@ -246,3 +288,79 @@ func (l *LoadBalancerHandler) UpdateLoadBalancerCrossZone(request *restful.Reque
 
 	response.WriteAsJson(loadBalancer)
 }
+
+func (l *LoadBalancerHandler) UpdateLoadBalancerAccessLog(request *restful.Request, response *restful.Response) {
+	id := request.PathParameter("id")
+	if id == "" {
+		err := fmt.Errorf("Parameter 'id' is required")
+		BadRequest(response, errors.MissingParameter, err)
+		return
+	}
+
+	var req models.UpdateLoadBalancerAccessLogRequest
+	if err := request.ReadEntity(&req); err != nil {
+		BadRequest(response, errors.InvalidJSON, err)
+		return
+	}
+
+	loadBalancer, err := l.LoadBalancerLogic.UpdateLoadBalancerAccessLog(id, req.AccessLog)
+	if err != nil {
+		ReturnError(response, err)
+		return
+	}
+
+	response.WriteAsJson(loadBalancer)
+}
+
+func (l *LoadBalancerHandler) GetLoadBalancerMetrics(request *restful.Request, response *restful.Response) {
+	id := request.PathParameter("id")
+	if id == "" {
+		err := fmt.Errorf("Parameter 'id' is required")
+		BadRequest(response, errors.MissingParameter, err)
+		return
+	}
+
+	parseTime := func(param string) (time.Time, error) {
+		if param == "" {
+			return time.Time{}, nil
+		}
+
+		t, err := time.Parse(cloudwatchlogs.TIME_LAYOUT, param)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("Invalid time '%s': must be in format YYYY-MM-DD HH:MM", param)
+		}
+
+		return t, nil
+	}
+
+	startTime, err := parseTime(request.QueryParameter("start"))
+	if err != nil {
+		BadRequest(response, errors.InvalidRequest, err)
+		return
+	}
+
+	endTime, err := parseTime(request.QueryParameter("end"))
+	if err != nil {
+		BadRequest(response, errors.InvalidRequest, err)
+		return
+	}
+
+	var period int
+	if param := request.QueryParameter("period"); param != "" {
+		p, err := strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			BadRequest(response, errors.InvalidRequest, err)
+			return
+		}
+
+		period = int(p)
+	}
+
+	metrics, err := l.LoadBalancerLogic.GetLoadBalancerMetrics(id, startTime, endTime, period)
+	if err != nil {
+		ReturnError(response, err)
+		return
+	}
+
+	response.WriteAsJson(metrics)
+}
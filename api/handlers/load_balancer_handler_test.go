@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"testing"
+	"time"
 
 	"github.com/emicklei/go-restful"
 	"github.com/golang/mock/gomock"
@@ -29,8 +30,8 @@ func TestListLoadBalancers(t *testing.T) {
 			Setup: func(ctrl *gomock.Controller) interface{} {
 				logicMock := mock_logic.NewMockLoadBalancerLogic(ctrl)
 				logicMock.EXPECT().
-					ListLoadBalancers().
-					Return(loadBalancers, nil)
+					ListLoadBalancersPage(0, "").
+					Return(loadBalancers, "", nil)
 
 				mockJob := mock_logic.NewMockJobLogic(ctrl)
 				return NewLoadBalancerHandler(logicMock, mockJob)
@@ -51,8 +52,8 @@ func TestListLoadBalancers(t *testing.T) {
 			Setup: func(ctrl *gomock.Controller) interface{} {
 				logicMock := mock_logic.NewMockLoadBalancerLogic(ctrl)
 				logicMock.EXPECT().
-					ListLoadBalancers().
-					Return(nil, errors.Newf(errors.UnexpectedError, "some error"))
+					ListLoadBalancersPage(0, "").
+					Return(nil, "", errors.Newf(errors.UnexpectedError, "some error"))
 
 				mockJob := mock_logic.NewMockJobLogic(ctrl)
 				return NewLoadBalancerHandler(logicMock, mockJob)
@@ -196,6 +197,29 @@ func TestCreateLoadBalancer(t *testing.T) {
 				handler.CreateLoadBalancer(req, resp)
 			},
 		},
+		{
+			Name: "Should create a create load balancer job when async is true",
+			Request: &TestRequest{
+				Body:  request,
+				Query: "async=true",
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				mockLB := mock_logic.NewMockLoadBalancerLogic(ctrl)
+
+				mockJob := mock_logic.NewMockJobLogic(ctrl)
+				mockJob.EXPECT().
+					CreateJob(types.CreateLoadBalancerJob, request).
+					Return(&models.Job{JobID: "job_id"}, nil)
+
+				return NewLoadBalancerHandler(mockLB, mockJob)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*LoadBalancerHandler)
+				handler.CreateLoadBalancer(req, resp)
+
+				reporter.AssertInSlice("job_id", resp.Header()["X-Jobid"])
+			},
+		},
 		{
 			Name: "Should propagate CreateLoadBalancer error",
 			Request: &TestRequest{
@@ -414,3 +438,90 @@ func TestUpdateLoadBalancerCrossZone(t *testing.T) {
 
 	RunHandlerTestCases(t, testCases)
 }
+
+func TestUpdateLoadBalancerAccessLog(t *testing.T) {
+	request := models.UpdateLoadBalancerAccessLogRequest{
+		AccessLog: models.AccessLog{
+			Enabled:      true,
+			BucketName:   "bucket",
+			EmitInterval: 5,
+		},
+	}
+
+	testCases := []HandlerTestCase{
+		{
+			Name: "Should call UpdateLoadBalancerAccessLog with correct params",
+			Request: &TestRequest{
+				Parameters: map[string]string{"id": "some_id"},
+				Body:       request,
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				mockLogic := mock_logic.NewMockLoadBalancerLogic(ctrl)
+				mockJob := mock_logic.NewMockJobLogic(ctrl)
+
+				mockLogic.EXPECT().
+					UpdateLoadBalancerAccessLog("some_id", request.AccessLog)
+
+				return NewLoadBalancerHandler(mockLogic, mockJob)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*LoadBalancerHandler)
+				handler.UpdateLoadBalancerAccessLog(req, resp)
+			},
+		},
+	}
+
+	RunHandlerTestCases(t, testCases)
+}
+
+func TestGetLoadBalancerMetrics(t *testing.T) {
+	testCases := []HandlerTestCase{
+		{
+			Name: "Should call GetLoadBalancerMetrics with correct params",
+			Request: &TestRequest{
+				Parameters: map[string]string{"id": "some_id"},
+				Query:      "start=2017-01-01+00:00&end=2017-01-01+01:00&period=300",
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				mockLogic := mock_logic.NewMockLoadBalancerLogic(ctrl)
+				mockJob := mock_logic.NewMockJobLogic(ctrl)
+
+				startTime := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+				endTime := time.Date(2017, 1, 1, 1, 0, 0, 0, time.UTC)
+
+				mockLogic.EXPECT().
+					GetLoadBalancerMetrics("some_id", startTime, endTime, 300)
+
+				return NewLoadBalancerHandler(mockLogic, mockJob)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*LoadBalancerHandler)
+				handler.GetLoadBalancerMetrics(req, resp)
+			},
+		},
+		{
+			Name: "Should return bad request with invalid start time",
+			Request: &TestRequest{
+				Parameters: map[string]string{"id": "some_id"},
+				Query:      "start=yesterday",
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				mockLogic := mock_logic.NewMockLoadBalancerLogic(ctrl)
+				mockJob := mock_logic.NewMockJobLogic(ctrl)
+
+				return NewLoadBalancerHandler(mockLogic, mockJob)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*LoadBalancerHandler)
+				handler.GetLoadBalancerMetrics(req, resp)
+
+				var response *models.ServerError
+				read(&response)
+
+				reporter.AssertEqual(response.ErrorCode, int64(errors.InvalidRequest))
+			},
+		},
+	}
+
+	RunHandlerTestCases(t, testCases)
+}
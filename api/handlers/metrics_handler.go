@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"github.com/emicklei/go-restful"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type MetricsHandler struct{}
+
+func NewMetricsHandler() *MetricsHandler {
+	return &MetricsHandler{}
+}
+
+func (this MetricsHandler) Routes() *restful.WebService {
+	service := new(restful.WebService)
+	service.Path("/metrics").
+		Produces(restful.MIME_JSON, "text/plain")
+
+	service.Route(service.GET("/").
+		Filter(basicAuthenticate).
+		To(this.GetMetrics).
+		Doc("Returns API metrics in the Prometheus text exposition format"))
+
+	return service
+}
+
+func (this *MetricsHandler) GetMetrics(request *restful.Request, response *restful.Response) {
+	promhttp.Handler().ServeHTTP(response.ResponseWriter, request.Request)
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/emicklei/go-restful"
+	"github.com/golang/mock/gomock"
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func TestGetMetrics(t *testing.T) {
+	testCases := []HandlerTestCase{
+		{
+			Name:    "Should return metrics in the prometheus text format",
+			Request: &TestRequest{},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				// record a request so the request counter has a series to export
+				requestCount.WithLabelValues("GET", "/metrics", "200").Inc()
+
+				return NewMetricsHandler()
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*MetricsHandler)
+				handler.GetMetrics(req, resp)
+
+				recorder := resp.ResponseWriter.(*httptest.ResponseRecorder)
+				body := recorder.Body.String()
+
+				expected := []string{
+					"# TYPE layer0_api_requests_total counter",
+					`layer0_api_requests_total{code="200",method="GET",route="/metrics"} 1`,
+				}
+
+				for _, line := range expected {
+					if !strings.Contains(body, line) {
+						reporter.Errorf("Metrics output does not contain '%s'", line)
+					}
+				}
+
+				reporter.AssertEqual(recorder.Header().Get("Content-Type"), "text/plain; version=0.0.4; charset=utf-8")
+			},
+		},
+	}
+
+	RunHandlerTestCases(t, testCases)
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/emicklei/go-restful"
+	"github.com/quintilesims/layer0/api/backend"
 	"github.com/quintilesims/layer0/api/logic"
 	"github.com/quintilesims/layer0/common/errors"
 	"github.com/quintilesims/layer0/common/models"
@@ -37,6 +38,8 @@ func (this *ServiceHandler) Routes() *restful.WebService {
 		Filter(basicAuthenticate).
 		To(this.ListServices).
 		Doc("List all services").
+		Param(service.QueryParameter("limit", "Return at most the specified number of results").DataType("integer")).
+		Param(service.QueryParameter("next_token", "Return the page of results after the page that returned this token").DataType("string")).
 		Returns(200, "OK", []models.Service{}))
 
 	service.Route(service.GET("/{id}").
@@ -58,7 +61,9 @@ func (this *ServiceHandler) Routes() *restful.WebService {
 		To(this.CreateService).
 		Doc("Create a service").
 		Reads(models.CreateServiceRequest{}).
+		Param(service.QueryParameter("async", "If true, run the request as a job and return its id instead of waiting for it to complete").DataType("bool")).
 		Returns(http.StatusCreated, "Created", models.Service{}).
+		Returns(http.StatusAccepted, "Accepted", nil).
 		Returns(400, "Invalid request", models.ServerError{}).
 		Writes(models.Service{}))
 
@@ -78,6 +83,7 @@ func (this *ServiceHandler) Routes() *restful.WebService {
 		Doc("Run a new deploy on a service").
 		Reads(models.UpdateServiceRequest{}).
 		Param(id).
+		Param(service.QueryParameter("async", "If true, run the request as a job and return its id instead of waiting for it to complete").DataType("bool")).
 		Returns(http.StatusAccepted, "Scaling", models.Service{}).
 		Returns(400, "Invalid request", models.ServerError{}).
 		Writes(models.Service{}))
@@ -85,24 +91,33 @@ func (this *ServiceHandler) Routes() *restful.WebService {
 	service.Route(service.GET("/{id}/logs").
 		Filter(basicAuthenticate).
 		To(this.GetServiceLogs).
-		Doc("Return recent service logs").
+		Doc("Return recent service logs, or stream new ones if follow is true").
 		Param(service.PathParameter("id", "identifier of the service").DataType("string")).
 		Param(service.QueryParameter("tail", "number of lines from the end to return").DataType("string")).
-		Param(service.QueryParameter("start", "The start of the time range to fetch logs (format YYYY-MM-DD HH:MM)").DataType("string")).
-		Param(service.QueryParameter("end", "The end of the time range to fetch logs (format YYYY-MM-DD HH:MM)").DataType("string")).
+		Param(service.QueryParameter("start", "The start of the time range to fetch logs (format YYYY-MM-DD HH:MM or RFC3339)").DataType("string")).
+		Param(service.QueryParameter("end", "The end of the time range to fetch logs (format YYYY-MM-DD HH:MM or RFC3339)").DataType("string")).
+		Param(service.QueryParameter("filter", "CloudWatch Logs filter pattern that returned log lines must match").DataType("string")).
+		Param(service.QueryParameter("container", "only return logs from the container with this name").DataType("string")).
+		Param(service.QueryParameter("follow", "If true, stream new log events as lines of json until the connection is closed").DataType("bool")).
 		Writes([]models.LogFile{}))
 
 	return service
 }
 
 func (this *ServiceHandler) ListServices(request *restful.Request, response *restful.Response) {
-	services, err := this.ServiceLogic.ListServices()
+	limit, nextToken, err := parsePageParams(request)
+	if err != nil {
+		BadRequest(response, errors.InvalidRequest, err)
+		return
+	}
+
+	services, nextToken, err := this.ServiceLogic.ListServicesPage(limit, nextToken)
 	if err != nil {
 		ReturnError(response, err)
 		return
 	}
 
-	response.WriteAsJson(services)
+	WritePage(response, services, nextToken)
 }
 
 func (this *ServiceHandler) DeleteService(request *restful.Request, response *restful.Response) {
@@ -146,6 +161,17 @@ func (this *ServiceHandler) CreateService(request *restful.Request, response *re
 		return
 	}
 
+	if request.QueryParameter("async") == "true" {
+		job, err := this.JobLogic.CreateJob(types.CreateServiceJob, req)
+		if err != nil {
+			ReturnError(response, err)
+			return
+		}
+
+		WriteJobResponse(response, job.JobID)
+		return
+	}
+
 	service, err := this.ServiceLogic.CreateService(req)
 	if err != nil {
 		ReturnError(response, err)
@@ -192,6 +218,22 @@ func (this *ServiceHandler) UpdateService(request *restful.Request, response *re
 		return
 	}
 
+	if request.QueryParameter("async") == "true" {
+		jobRequest := models.UpdateServiceJobRequest{
+			ServiceID: serviceID,
+			DeployID:  req.DeployID,
+		}
+
+		job, err := this.JobLogic.CreateJob(types.UpdateServiceJob, jobRequest)
+		if err != nil {
+			ReturnError(response, err)
+			return
+		}
+
+		WriteJobResponse(response, job.JobID)
+		return
+	}
+
 	service, err := this.ServiceLogic.UpdateService(serviceID, req)
 	if err != nil {
 		ReturnError(response, err)
@@ -209,6 +251,24 @@ func (this *ServiceHandler) GetServiceLogs(request *restful.Request, response *r
 		return
 	}
 
+	start := request.QueryParameter("start")
+	filter := request.QueryParameter("filter")
+	container := request.QueryParameter("container")
+
+	if request.QueryParameter("follow") == "true" {
+		if filter != "" {
+			err := fmt.Errorf("Parameter 'filter' cannot be used with 'follow'")
+			BadRequest(response, errors.InvalidRequest, err)
+			return
+		}
+
+		WriteLogStream(request, response, func(cursor *backend.LogCursor) ([]*models.LogEvent, error) {
+			return this.ServiceLogic.FollowServiceLogs(serviceID, start, container, cursor)
+		})
+
+		return
+	}
+
 	var tail int
 	if param := request.QueryParameter("tail"); param != "" {
 		t, err := strconv.ParseInt(param, 10, 64)
@@ -220,7 +280,7 @@ func (this *ServiceHandler) GetServiceLogs(request *restful.Request, response *r
 		tail = int(t)
 	}
 
-	logs, err := this.ServiceLogic.GetServiceLogs(serviceID, request.QueryParameter("start"), request.QueryParameter("end"), tail)
+	logs, err := this.ServiceLogic.GetServiceLogs(serviceID, start, request.QueryParameter("end"), filter, container, tail)
 	if err != nil {
 		ReturnError(response, err)
 		return
@@ -1,10 +1,14 @@
 package handlers
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/emicklei/go-restful"
 	"github.com/golang/mock/gomock"
+	"github.com/quintilesims/layer0/api/backend"
 	"github.com/quintilesims/layer0/api/logic/mock_logic"
 	"github.com/quintilesims/layer0/common/errors"
 	"github.com/quintilesims/layer0/common/models"
@@ -29,8 +33,8 @@ func TestListServices(t *testing.T) {
 			Setup: func(ctrl *gomock.Controller) interface{} {
 				svcLogicMock := mock_logic.NewMockServiceLogic(ctrl)
 				svcLogicMock.EXPECT().
-					ListServices().
-					Return(services, nil)
+					ListServicesPage(0, "").
+					Return(services, "", nil)
 
 				jobLogicMock := mock_logic.NewMockJobLogic(ctrl)
 
@@ -46,14 +50,57 @@ func TestListServices(t *testing.T) {
 				reporter.AssertEqual(response, services)
 			},
 		},
+		{
+			Name:    "Should pass page params to logic layer and return next token",
+			Request: &TestRequest{Query: "limit=2&next_token=token1"},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				svcLogicMock := mock_logic.NewMockServiceLogic(ctrl)
+				svcLogicMock.EXPECT().
+					ListServicesPage(2, "token1").
+					Return(services, "token2", nil)
+
+				jobLogicMock := mock_logic.NewMockJobLogic(ctrl)
+
+				return NewServiceHandler(svcLogicMock, jobLogicMock)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*ServiceHandler)
+				handler.ListServices(req, resp)
+
+				var response []models.ServiceSummary
+				read(&response)
+
+				reporter.AssertEqual(response, services)
+				reporter.AssertEqual(resp.Header().Get("X-Next-Token"), "token2")
+			},
+		},
+		{
+			Name:    "Should return InvalidRequest on bad limit",
+			Request: &TestRequest{Query: "limit=0"},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				svcLogicMock := mock_logic.NewMockServiceLogic(ctrl)
+				jobLogicMock := mock_logic.NewMockJobLogic(ctrl)
+
+				return NewServiceHandler(svcLogicMock, jobLogicMock)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*ServiceHandler)
+				handler.ListServices(req, resp)
+
+				var response *models.ServerError
+				read(&response)
+
+				reporter.AssertEqual(response.ErrorCode, int64(errors.InvalidRequest))
+			},
+		},
 		{
 			Name:    "Should propagate ListServices error",
 			Request: &TestRequest{},
 			Setup: func(ctrl *gomock.Controller) interface{} {
 				svcLogicMock := mock_logic.NewMockServiceLogic(ctrl)
 				svcLogicMock.EXPECT().
-					ListServices().
-					Return(nil, errors.Newf(errors.UnexpectedError, "some error"))
+					ListServicesPage(0, "").
+					Return(nil, "", errors.Newf(errors.UnexpectedError, "some error"))
 
 				jobLogicMock := mock_logic.NewMockJobLogic(ctrl)
 
@@ -297,6 +344,29 @@ func TestCreateService(t *testing.T) {
 				handler.CreateService(req, resp)
 			},
 		},
+		{
+			Name: "Should create a create service job when async is true",
+			Request: &TestRequest{
+				Body:  request,
+				Query: "async=true",
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				mockService := mock_logic.NewMockServiceLogic(ctrl)
+
+				mockJob := mock_logic.NewMockJobLogic(ctrl)
+				mockJob.EXPECT().
+					CreateJob(types.CreateServiceJob, request).
+					Return(&models.Job{JobID: "job_id"}, nil)
+
+				return NewServiceHandler(mockService, mockJob)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*ServiceHandler)
+				handler.CreateService(req, resp)
+
+				reporter.AssertInSlice("job_id", resp.Header()["X-Jobid"])
+			},
+		},
 		{
 			Name: "Should propagate CreateService error",
 			Request: &TestRequest{
@@ -327,6 +397,46 @@ func TestCreateService(t *testing.T) {
 	RunHandlerTestCases(t, testCases)
 }
 
+func TestUpdateService_async(t *testing.T) {
+	request := models.UpdateServiceRequest{
+		DeployID: "dply_id",
+	}
+
+	testCases := []HandlerTestCase{
+		{
+			Name: "Should create an update service job when async is true",
+			Request: &TestRequest{
+				Body:       request,
+				Parameters: map[string]string{"id": "svc_id"},
+				Query:      "async=true",
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				mockService := mock_logic.NewMockServiceLogic(ctrl)
+
+				jobRequest := models.UpdateServiceJobRequest{
+					ServiceID: "svc_id",
+					DeployID:  "dply_id",
+				}
+
+				mockJob := mock_logic.NewMockJobLogic(ctrl)
+				mockJob.EXPECT().
+					CreateJob(types.UpdateServiceJob, jobRequest).
+					Return(&models.Job{JobID: "job_id"}, nil)
+
+				return NewServiceHandler(mockService, mockJob)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*ServiceHandler)
+				handler.UpdateService(req, resp)
+
+				reporter.AssertInSlice("job_id", resp.Header()["X-Jobid"])
+			},
+		},
+	}
+
+	RunHandlerTestCases(t, testCases)
+}
+
 func TestScaleService(t *testing.T) {
 	request := models.ScaleServiceRequest{
 		DesiredCount: int64(2),
@@ -384,3 +494,178 @@ func TestScaleService(t *testing.T) {
 
 	RunHandlerTestCases(t, testCases)
 }
+
+func TestGetServiceLogs_follow(t *testing.T) {
+	tmp := logFollowInterval
+	logFollowInterval = 0
+	defer func() { logFollowInterval = tmp }()
+
+	testCases := []HandlerTestCase{
+		{
+			Name: "Should stream events until FollowServiceLogs errors",
+			Request: &TestRequest{
+				Parameters: map[string]string{"id": "some_id"},
+				Query:      "follow=true&start=start",
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				mockService := mock_logic.NewMockServiceLogic(ctrl)
+
+				gomock.InOrder(
+					mockService.EXPECT().
+						FollowServiceLogs("some_id", "start", "", backend.NewLogCursor()).
+						Return([]*models.LogEvent{{ContainerName: "alpha", Message: "first"}}, nil),
+					mockService.EXPECT().
+						FollowServiceLogs("some_id", "start", "", gomock.Any()).
+						Return([]*models.LogEvent{{ContainerName: "beta", Message: "second"}}, nil),
+					mockService.EXPECT().
+						FollowServiceLogs("some_id", "start", "", gomock.Any()).
+						Return(nil, errors.Newf(errors.UnexpectedError, "some error")),
+				)
+
+				jobLogicMock := mock_logic.NewMockJobLogic(ctrl)
+				return NewServiceHandler(mockService, jobLogicMock)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*ServiceHandler)
+				handler.GetServiceLogs(req, resp)
+
+				recorder := resp.ResponseWriter.(*httptest.ResponseRecorder)
+				reporter.AssertEqual(recorder.Code, http.StatusOK)
+
+				decoder := json.NewDecoder(recorder.Body)
+				events := []*models.LogEvent{}
+				for decoder.More() {
+					var event *models.LogEvent
+					if err := decoder.Decode(&event); err != nil {
+						reporter.Fatal(err)
+					}
+
+					events = append(events, event)
+				}
+
+				expected := []*models.LogEvent{
+					{ContainerName: "alpha", Message: "first"},
+					{ContainerName: "beta", Message: "second"},
+				}
+
+				reporter.AssertEqual(events, expected)
+			},
+		},
+		{
+			Name: "Should return error from first FollowServiceLogs call",
+			Request: &TestRequest{
+				Parameters: map[string]string{"id": "some_id"},
+				Query:      "follow=true",
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				mockService := mock_logic.NewMockServiceLogic(ctrl)
+
+				mockService.EXPECT().
+					FollowServiceLogs("some_id", "", "", gomock.Any()).
+					Return(nil, errors.Newf(errors.ServiceDoesNotExist, "some error"))
+
+				jobLogicMock := mock_logic.NewMockJobLogic(ctrl)
+				return NewServiceHandler(mockService, jobLogicMock)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*ServiceHandler)
+				handler.GetServiceLogs(req, resp)
+
+				var response *models.ServerError
+				read(&response)
+
+				reporter.AssertEqual(int64(errors.ServiceDoesNotExist), response.ErrorCode)
+			},
+		},
+	}
+
+	RunHandlerTestCases(t, testCases)
+}
+
+func TestGetServiceLogs_followKeepalive(t *testing.T) {
+	tmpFollow, tmpKeepalive := logFollowInterval, logKeepaliveInterval
+	logFollowInterval, logKeepaliveInterval = 0, 0
+	defer func() { logFollowInterval, logKeepaliveInterval = tmpFollow, tmpKeepalive }()
+
+	testCase := HandlerTestCase{
+		Name: "Should write a newline when no events are written",
+		Request: &TestRequest{
+			Parameters: map[string]string{"id": "some_id"},
+			Query:      "follow=true",
+		},
+		Setup: func(ctrl *gomock.Controller) interface{} {
+			mockService := mock_logic.NewMockServiceLogic(ctrl)
+
+			gomock.InOrder(
+				mockService.EXPECT().
+					FollowServiceLogs("some_id", "", "", gomock.Any()).
+					Return([]*models.LogEvent{}, nil),
+				mockService.EXPECT().
+					FollowServiceLogs("some_id", "", "", gomock.Any()).
+					Return(nil, errors.Newf(errors.UnexpectedError, "some error")),
+			)
+
+			jobLogicMock := mock_logic.NewMockJobLogic(ctrl)
+			return NewServiceHandler(mockService, jobLogicMock)
+		},
+		Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+			handler := target.(*ServiceHandler)
+			handler.GetServiceLogs(req, resp)
+
+			recorder := resp.ResponseWriter.(*httptest.ResponseRecorder)
+			reporter.AssertEqual(recorder.Code, http.StatusOK)
+			reporter.AssertEqual(recorder.Body.String(), "\n")
+		},
+	}
+
+	RunHandlerTestCase(t, testCase)
+}
+
+func TestGetServiceLogs(t *testing.T) {
+	testCases := []HandlerTestCase{
+		{
+			Name: "Should call GetServiceLogs with correct params",
+			Request: &TestRequest{
+				Parameters: map[string]string{"id": "some_id"},
+				Query:      "start=start&end=end&filter=ERROR&container=api&tail=10",
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				mockService := mock_logic.NewMockServiceLogic(ctrl)
+
+				mockService.EXPECT().
+					GetServiceLogs("some_id", "start", "end", "ERROR", "api", 10).
+					Return([]*models.LogFile{}, nil)
+
+				jobLogicMock := mock_logic.NewMockJobLogic(ctrl)
+				return NewServiceHandler(mockService, jobLogicMock)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*ServiceHandler)
+				handler.GetServiceLogs(req, resp)
+			},
+		},
+		{
+			Name: "Should return BadRequest when filter is used with follow",
+			Request: &TestRequest{
+				Parameters: map[string]string{"id": "some_id"},
+				Query:      "follow=true&filter=ERROR",
+			},
+			Setup: func(ctrl *gomock.Controller) interface{} {
+				mockService := mock_logic.NewMockServiceLogic(ctrl)
+				jobLogicMock := mock_logic.NewMockJobLogic(ctrl)
+				return NewServiceHandler(mockService, jobLogicMock)
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler := target.(*ServiceHandler)
+				handler.GetServiceLogs(req, resp)
+
+				var response *models.ServerError
+				read(&response)
+
+				reporter.AssertEqual(int64(errors.InvalidRequest), response.ErrorCode)
+			},
+		},
+	}
+
+	RunHandlerTestCases(t, testCases)
+}
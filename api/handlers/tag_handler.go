@@ -10,6 +10,8 @@ import (
 	"github.com/quintilesims/layer0/common/db/tag_store"
 	"github.com/quintilesims/layer0/common/errors"
 	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/paging"
+	"github.com/quintilesims/layer0/common/tagquery"
 )
 
 type TagHandler struct {
@@ -38,12 +40,16 @@ func (t *TagHandler) Routes() *restful.WebService {
 		Param(service.QueryParameter("fuzz", "Require the prefix of the EntityID field or 'name' tag match the specified parameter").DataType("string")).
 		Param(service.QueryParameter("version", "Require the 'version' tag match the specified parameter. If 'latest' is used, only the latest will be returned").DataType("string")).
 		Param(service.QueryParameter("environment_id", "Require the 'environment_id' tag match the specified parameter").DataType("string")).
+		Param(service.QueryParameter("filter", "Require the tags match the filter expression, e.g. 'team=payments,env!=prod;owner in (a,b)'").DataType("string")).
+		Param(service.QueryParameter("sort", "Comma-separated tag keys to sort by; prefix a key with '-' to sort in descending order").DataType("string")).
+		Param(service.QueryParameter("limit", "Return at most the specified number of results").DataType("integer")).
+		Param(service.QueryParameter("next_token", "Return the page of results after the page that returned this token").DataType("string")).
 		Returns(200, "OK", []models.EntityWithTags{}))
 
 	service.Route(service.POST("/").
 		Filter(basicAuthenticate).
 		To(t.CreateTag).
-		Doc("Create a tag for a service, deploy, or environment").
+		Doc("Create a user-defined tag for an entity; reserved keys such as 'name' and 'environment_id' cannot be used").
 		Reads(models.Tag{}).
 		Returns(http.StatusCreated, "Created", models.Tag{}).
 		Returns(400, "Invalid request", models.ServerError{}).
@@ -55,7 +61,7 @@ func (t *TagHandler) Routes() *restful.WebService {
 	service.Route(service.DELETE("/").
 		Filter(basicAuthenticate).
 		To(t.DeleteTag).
-		Doc("Delete a tag").
+		Doc("Delete a user-defined tag").
 		Reads(models.Tag{}).
 		Param(id).
 		Returns(http.StatusNoContent, "Deleted", nil))
@@ -73,6 +79,9 @@ func (t *TagHandler) FindTags(request *restful.Request, response *restful.Respon
 	var entityID string
 	var fuzz string
 	var latestVersion bool
+	var filter string
+	var sortKeys string
+	var offset int
 
 	// break out special filter params so we don't filter
 	// them by tag.Key and tag.Value
@@ -96,12 +105,55 @@ func (t *TagHandler) FindTags(request *restful.Request, response *restful.Respon
 		delete(params, "version")
 	}
 
+	if val, ok := params["filter"]; ok {
+		filter = val
+		delete(params, "filter")
+	}
+
+	if val, ok := params["sort"]; ok {
+		sortKeys = val
+		delete(params, "sort")
+	}
+
+	limit, pageToken, err := parsePageParams(request)
+	if err != nil {
+		BadRequest(response, errors.InvalidRequest, err)
+		return
+	}
+
+	delete(params, "limit")
+	delete(params, "next_token")
+
+	// the token of a tag query holds the offset of the next page, since results may be sorted by any tag
+	if pageToken != "" {
+		token, err := paging.DecodeToken(pageToken)
+		if err != nil {
+			BadRequest(response, errors.InvalidRequest, err)
+			return
+		}
+
+		i, err := strconv.Atoi(token)
+		if err != nil || i < 0 {
+			err := fmt.Errorf("Invalid next token '%s'", pageToken)
+			BadRequest(response, errors.InvalidRequest, err)
+			return
+		}
+
+		offset = i
+	}
+
 	if entityType == "" {
 		err := fmt.Errorf("Parameter 'type' is required")
 		BadRequest(response, errors.MissingParameter, err)
 		return
 	}
 
+	tagQuery, err := tagquery.Parse(filter)
+	if err != nil {
+		BadRequest(response, errors.InvalidRequest, err)
+		return
+	}
+
 	var query func() (models.Tags, error)
 	if entityID == "" {
 		query = func() (models.Tags, error) { return t.TagStore.SelectByType(entityType) }
@@ -137,6 +189,8 @@ func (t *TagHandler) FindTags(request *restful.Request, response *restful.Respon
 		})
 	}
 
+	ewts = tagQuery.Filter(ewts)
+
 	if latestVersion {
 		indexOfLatestVersion := -1
 		latestVersion := -1
@@ -163,7 +217,20 @@ func (t *TagHandler) FindTags(request *restful.Request, response *restful.Respon
 		}
 	}
 
-	response.WriteAsJson(ewts)
+	// results are always sorted when paginating so pages are consistent across requests
+	if sortKeys != "" || offset > 0 || limit > 0 {
+		if err := tagquery.Sort(ewts, sortKeys); err != nil {
+			BadRequest(response, errors.InvalidRequest, err)
+			return
+		}
+	}
+
+	var nextToken string
+	if limit > 0 && offset+limit < len(ewts) {
+		nextToken = paging.EncodeToken(strconv.Itoa(offset + limit))
+	}
+
+	WritePage(response, tagquery.Page(ewts, offset, limit), nextToken)
 }
 
 func (t *TagHandler) DeleteTag(request *restful.Request, response *restful.Response) {
@@ -173,6 +240,11 @@ func (t *TagHandler) DeleteTag(request *restful.Request, response *restful.Respo
 		return
 	}
 
+	if err := validateUserTag(tag); err != nil {
+		ReturnError(response, err)
+		return
+	}
+
 	if err := t.TagStore.Delete(tag.EntityType, tag.EntityID, tag.Key); err != nil {
 		ReturnError(response, err)
 		return
@@ -188,6 +260,11 @@ func (t *TagHandler) CreateTag(request *restful.Request, response *restful.Respo
 		return
 	}
 
+	if err := validateUserTag(tag); err != nil {
+		ReturnError(response, err)
+		return
+	}
+
 	if err := t.TagStore.Insert(tag); err != nil {
 		ReturnError(response, err)
 		return
@@ -195,3 +272,16 @@ func (t *TagHandler) CreateTag(request *restful.Request, response *restful.Respo
 
 	response.WriteHeader(http.StatusCreated)
 }
+
+// validateUserTag makes sure users cannot overwrite or delete the tags layer0 uses to track its entities
+func validateUserTag(tag models.Tag) error {
+	if tag.EntityType == "" || tag.EntityID == "" || tag.Key == "" {
+		return errors.Newf(errors.MissingParameter, "EntityType, EntityID, and Key are required")
+	}
+
+	if models.IsReservedTagKey(tag.Key) {
+		return errors.Newf(errors.InvalidRequest, "Tag key '%s' is reserved by Layer0", tag.Key)
+	}
+
+	return nil
+}
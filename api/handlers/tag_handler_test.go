@@ -5,7 +5,9 @@ import (
 
 	"github.com/emicklei/go-restful"
 	"github.com/quintilesims/layer0/common/db/tag_store"
+	"github.com/quintilesims/layer0/common/errors"
 	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/paging"
 	"github.com/quintilesims/layer0/common/testutils"
 )
 
@@ -151,7 +153,182 @@ func TestFindTags(t *testing.T) {
 				r.AssertEqual(tags[0].EntityID, "d2")
 			},
 		},
+		{
+			Name: "type=service&filter=name=svc*,environment_id!=e1",
+			Request: &TestRequest{
+				Query: "type=service&filter=name%3Dsvc*%2Cenvironment_id!%3De1",
+			},
+			Run: func(r *testutils.Reporter, _ interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler.FindTags(req, resp)
+
+				var tags []models.EntityWithTags
+				read(&tags)
+
+				r.AssertEqual(len(tags), 1)
+				r.AssertEqual(tags[0].EntityID, "s2")
+			},
+		},
+		{
+			Name: "type=load_balancer&filter=name=lb1;environment_id in (e2)&sort=-name",
+			Request: &TestRequest{
+				Query: "type=load_balancer&filter=name%3Dlb1%3Benvironment_id+in+(e2)&sort=-name",
+			},
+			Run: func(r *testutils.Reporter, _ interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler.FindTags(req, resp)
+
+				var tags []models.EntityWithTags
+				read(&tags)
+
+				r.AssertEqual(len(tags), 2)
+				r.AssertEqual(tags[0].EntityID, "l2")
+				r.AssertEqual(tags[1].EntityID, "l1")
+			},
+		},
+		{
+			Name: "type=task&limit=1",
+			Request: &TestRequest{
+				Query: "type=task&limit=1",
+			},
+			Run: func(r *testutils.Reporter, _ interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler.FindTags(req, resp)
+
+				var tags []models.EntityWithTags
+				read(&tags)
+
+				r.AssertEqual(len(tags), 1)
+				r.AssertEqual(tags[0].EntityID, "t1")
+				r.AssertEqual(resp.Header().Get("X-Next-Token"), paging.EncodeToken("1"))
+			},
+		},
+		{
+			Name: "type=task&limit=1&next_token=...",
+			Request: &TestRequest{
+				Query: "type=task&limit=1&next_token=" + paging.EncodeToken("1"),
+			},
+			Run: func(r *testutils.Reporter, _ interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler.FindTags(req, resp)
+
+				var tags []models.EntityWithTags
+				read(&tags)
+
+				r.AssertEqual(len(tags), 1)
+				r.AssertEqual(tags[0].EntityID, "t2")
+				r.AssertEqual(resp.Header().Get("X-Next-Token"), "")
+			},
+		},
 	}
 
 	RunHandlerTestCases(t, cases)
 }
+
+func TestFindTags_invalidQuery(t *testing.T) {
+	store := getTestTagStore(t, TestTags)
+	handler := NewTagHandler(store)
+
+	queries := []string{
+		"type=service&filter=name~(",
+		"type=service&sort=-",
+		"type=service&limit=-1",
+		"type=service&limit=abc",
+		"type=service&limit=1001",
+		"type=service&next_token=!!!",
+	}
+
+	cases := []HandlerTestCase{}
+	for _, query := range queries {
+		cases = append(cases, HandlerTestCase{
+			Name: query,
+			Request: &TestRequest{
+				Query: query,
+			},
+			Run: func(r *testutils.Reporter, _ interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler.FindTags(req, resp)
+
+				var response models.ServerError
+				read(&response)
+
+				r.AssertEqual(response.ErrorCode, int64(errors.InvalidRequest))
+			},
+		})
+	}
+
+	RunHandlerTestCases(t, cases)
+}
+
+func TestCreateTag(t *testing.T) {
+	store := getTestTagStore(t, TestTags)
+	handler := NewTagHandler(store)
+
+	testCase := HandlerTestCase{
+		Name: "Should insert user-defined tags",
+		Request: &TestRequest{
+			Body: models.Tag{EntityID: "s1", EntityType: "service", Key: "team", Value: "payments"},
+		},
+		Run: func(r *testutils.Reporter, _ interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+			handler.CreateTag(req, resp)
+
+			tags, err := store.SelectByTypeAndID("service", "s1")
+			if err != nil {
+				r.Fatal(err)
+			}
+
+			tag, ok := tags.WithKey("team").First()
+			r.AssertEqual(ok, true)
+			r.AssertEqual(tag.Value, "payments")
+		},
+	}
+
+	RunHandlerTestCase(t, testCase)
+}
+
+func TestCreateAndDeleteTag_reservedKeys(t *testing.T) {
+	store := getTestTagStore(t, TestTags)
+	handler := NewTagHandler(store)
+
+	tags := []models.Tag{
+		{EntityID: "s1", EntityType: "service", Key: "name", Value: "svc"},
+		{EntityID: "s1", EntityType: "service", Key: "environment_id", Value: "e2"},
+		{EntityID: "d1", EntityType: "deploy", Key: "version", Value: "3"},
+		{EntityID: "e1", EntityType: "environment", Key: "link", Value: "e2"},
+		{EntityID: "e1", EntityType: "environment", Key: "ingress:tcp:22-22:10.0.0.0/8", Value: "{}"},
+	}
+
+	cases := []HandlerTestCase{}
+	for _, tag := range tags {
+		cases = append(cases, HandlerTestCase{
+			Name:    "Create " + tag.Key,
+			Request: &TestRequest{Body: tag},
+			Run: func(r *testutils.Reporter, _ interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler.CreateTag(req, resp)
+
+				var response models.ServerError
+				read(&response)
+
+				r.AssertEqual(response.ErrorCode, int64(errors.InvalidRequest))
+			},
+		})
+
+		cases = append(cases, HandlerTestCase{
+			Name:    "Delete " + tag.Key,
+			Request: &TestRequest{Body: tag},
+			Run: func(r *testutils.Reporter, _ interface{}, req *restful.Request, resp *restful.Response, read Readf) {
+				handler.DeleteTag(req, resp)
+
+				var response models.ServerError
+				read(&response)
+
+				r.AssertEqual(response.ErrorCode, int64(errors.InvalidRequest))
+			},
+		})
+	}
+
+	RunHandlerTestCases(t, cases)
+
+	result, err := store.SelectByType("service")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tag, _ := result.WithID("s1").WithKey("name").First()
+	testutils.AssertEqual(t, tag.Value, "svc1")
+}
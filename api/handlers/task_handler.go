@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/emicklei/go-restful"
+	"github.com/quintilesims/layer0/api/backend"
 	"github.com/quintilesims/layer0/api/logic"
 	"github.com/quintilesims/layer0/common/errors"
 	"github.com/quintilesims/layer0/common/models"
@@ -37,6 +38,8 @@ func (this *TaskHandler) Routes() *restful.WebService {
 		Filter(basicAuthenticate).
 		To(this.ListTasks).
 		Doc("List all tasks").
+		Param(service.QueryParameter("limit", "Return at most the specified number of results").DataType("integer")).
+		Param(service.QueryParameter("next_token", "Return the page of results after the page that returned this token").DataType("string")).
 		Returns(200, "OK", []models.Task{}))
 
 	service.Route(service.GET("/{id}").
@@ -65,24 +68,33 @@ func (this *TaskHandler) Routes() *restful.WebService {
 	service.Route(service.GET("/{id}/logs").
 		Filter(basicAuthenticate).
 		To(this.GetTaskLogs).
-		Doc("Return recent task logs").
+		Doc("Return recent task logs, or stream new ones if follow is true").
 		Param(service.PathParameter("id", "identifier of the task").DataType("string")).
 		Param(service.QueryParameter("tail", "number of lines from the end to return").DataType("string")).
-		Param(service.QueryParameter("start", "The start of the time range to fetch logs (format YYYY-MM-DD HH:MM)").DataType("string")).
-		Param(service.QueryParameter("end", "The end of the time range to fetch logs (format YYYY-MM-DD HH:MM)").DataType("string")).
+		Param(service.QueryParameter("start", "The start of the time range to fetch logs (format YYYY-MM-DD HH:MM or RFC3339)").DataType("string")).
+		Param(service.QueryParameter("end", "The end of the time range to fetch logs (format YYYY-MM-DD HH:MM or RFC3339)").DataType("string")).
+		Param(service.QueryParameter("filter", "CloudWatch Logs filter pattern that returned log lines must match").DataType("string")).
+		Param(service.QueryParameter("container", "only return logs from the container with this name").DataType("string")).
+		Param(service.QueryParameter("follow", "If true, stream new log events as lines of json until the connection is closed").DataType("bool")).
 		Writes([]models.LogFile{}))
 
 	return service
 }
 
 func (this *TaskHandler) ListTasks(request *restful.Request, response *restful.Response) {
-	tasks, err := this.TaskLogic.ListTasks()
+	limit, nextToken, err := parsePageParams(request)
+	if err != nil {
+		BadRequest(response, errors.InvalidRequest, err)
+		return
+	}
+
+	tasks, nextToken, err := this.TaskLogic.ListTasksPage(limit, nextToken)
 	if err != nil {
 		ReturnError(response, err)
 		return
 	}
 
-	response.WriteAsJson(tasks)
+	WritePage(response, tasks, nextToken)
 }
 
 func (this *TaskHandler) DeleteTask(request *restful.Request, response *restful.Response) {
@@ -142,6 +154,24 @@ func (this *TaskHandler) GetTaskLogs(request *restful.Request, response *restful
 		return
 	}
 
+	start := request.QueryParameter("start")
+	filter := request.QueryParameter("filter")
+	container := request.QueryParameter("container")
+
+	if request.QueryParameter("follow") == "true" {
+		if filter != "" {
+			err := fmt.Errorf("Parameter 'filter' cannot be used with 'follow'")
+			BadRequest(response, errors.InvalidRequest, err)
+			return
+		}
+
+		WriteLogStream(request, response, func(cursor *backend.LogCursor) ([]*models.LogEvent, error) {
+			return this.TaskLogic.FollowTaskLogs(taskID, start, container, cursor)
+		})
+
+		return
+	}
+
 	var tail int
 	if param := request.QueryParameter("tail"); param != "" {
 		t, err := strconv.ParseInt(param, 10, 64)
@@ -153,7 +183,7 @@ func (this *TaskHandler) GetTaskLogs(request *restful.Request, response *restful
 		tail = int(t)
 	}
 
-	logs, err := this.TaskLogic.GetTaskLogs(taskID, request.QueryParameter("start"), request.QueryParameter("end"), tail)
+	logs, err := this.TaskLogic.GetTaskLogs(taskID, start, request.QueryParameter("end"), filter, container, tail)
 	if err != nil {
 		ReturnError(response, err)
 		return
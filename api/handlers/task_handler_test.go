@@ -29,8 +29,8 @@ func TestListTasks(t *testing.T) {
 			Setup: func(ctrl *gomock.Controller) interface{} {
 				logicMock := mock_logic.NewMockTaskLogic(ctrl)
 				logicMock.EXPECT().
-					ListTasks().
-					Return(tasks, nil)
+					ListTasksPage(0, "").
+					Return(tasks, "", nil)
 
 				return NewTaskHandler(logicMock, nil)
 			},
@@ -50,8 +50,8 @@ func TestListTasks(t *testing.T) {
 			Setup: func(ctrl *gomock.Controller) interface{} {
 				logicMock := mock_logic.NewMockTaskLogic(ctrl)
 				logicMock.EXPECT().
-					ListTasks().
-					Return(nil, errors.Newf(errors.UnexpectedError, "some error"))
+					ListTasksPage(0, "").
+					Return(nil, "", errors.Newf(errors.UnexpectedError, "some error"))
 
 				return NewTaskHandler(logicMock, nil)
 			},
@@ -8,6 +8,14 @@ import (
 type AdminLogic interface {
 	RunEnvironmentScaler(string) (*models.ScalerRunInfo, error)
 	UpdateSQL() error
+	GetBackendCacheStats() models.BackendCacheStats
+	FlushBackendCache()
+}
+
+// backendCache is implemented by backends that cache the results of read calls
+type backendCache interface {
+	Stats() models.BackendCacheStats
+	Flush()
 }
 
 type L0AdminLogic struct {
@@ -24,6 +32,20 @@ func (a *L0AdminLogic) RunEnvironmentScaler(environmentID string) (*models.Scale
 	return a.Logic.Scaler.Scale(environmentID)
 }
 
+func (a *L0AdminLogic) GetBackendCacheStats() models.BackendCacheStats {
+	if cache, ok := a.Backend.(backendCache); ok {
+		return cache.Stats()
+	}
+
+	return models.BackendCacheStats{Enabled: false}
+}
+
+func (a *L0AdminLogic) FlushBackendCache() {
+	if cache, ok := a.Backend.(backendCache); ok {
+		cache.Flush()
+	}
+}
+
 func (a *L0AdminLogic) UpdateSQL() error {
 	if err := a.TagStore.Init(); err != nil {
 		return err
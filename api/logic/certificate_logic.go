@@ -0,0 +1,51 @@
+package logic
+
+import (
+	"github.com/quintilesims/layer0/common/models"
+)
+
+type CertificateLogic interface {
+	ListCertificates() ([]*models.Certificate, error)
+	ListCertificatesPage(limit int, nextToken string) ([]*models.Certificate, string, error)
+}
+
+type L0CertificateLogic struct {
+	Logic
+}
+
+func NewL0CertificateLogic(logic Logic) *L0CertificateLogic {
+	return &L0CertificateLogic{
+		Logic: logic,
+	}
+}
+
+func (c *L0CertificateLogic) ListCertificates() ([]*models.Certificate, error) {
+	certificates, _, err := c.ListCertificatesPage(0, "")
+	return certificates, err
+}
+
+func (c *L0CertificateLogic) ListCertificatesPage(limit int, nextToken string) ([]*models.Certificate, string, error) {
+	certificates, err := c.Backend.ListCertificates()
+	if err != nil {
+		return nil, "", err
+	}
+
+	certificatesByID := map[string]*models.Certificate{}
+	certificateIDs := make([]string, len(certificates))
+	for i, certificate := range certificates {
+		certificatesByID[certificate.CertificateID] = certificate
+		certificateIDs[i] = certificate.CertificateID
+	}
+
+	page, nextToken, err := pageIDs(certificateIDs, limit, nextToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	results := make([]*models.Certificate, len(page))
+	for i, certificateID := range page {
+		results[i] = certificatesByID[certificateID]
+	}
+
+	return results, nextToken, nil
+}
@@ -0,0 +1,30 @@
+package logic
+
+import (
+	"testing"
+
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func TestListCertificates(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	retCertificates := []*models.Certificate{
+		{CertificateID: "c1", CertificateName: "cert", Source: "iam"},
+		{CertificateID: "c2", DomainName: "example.com", Source: "acm"},
+	}
+
+	testLogic.Backend.EXPECT().
+		ListCertificates().
+		Return(retCertificates, nil)
+
+	certificateLogic := NewL0CertificateLogic(testLogic.Logic())
+	received, err := certificateLogic.ListCertificates()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, received, retCertificates)
+}
@@ -7,6 +7,7 @@ import (
 
 type DeployLogic interface {
 	ListDeploys() ([]*models.DeploySummary, error)
+	ListDeploysPage(limit int, nextToken string) ([]*models.DeploySummary, string, error)
 	GetDeploy(deployID string) (*models.Deploy, error)
 	DeleteDeploy(deployID string) error
 	CreateDeploy(model models.CreateDeployRequest) (*models.Deploy, error)
@@ -21,25 +22,52 @@ func NewL0DeployLogic(lgc Logic) *L0DeployLogic {
 }
 
 func (d *L0DeployLogic) ListDeploys() ([]*models.DeploySummary, error) {
+	summaries, _, err := d.ListDeploysPage(0, "")
+	return summaries, err
+}
+
+func (d *L0DeployLogic) ListDeploysPage(limit int, nextToken string) ([]*models.DeploySummary, string, error) {
 	deploys, err := d.Backend.ListDeploys()
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	summaries := make([]*models.DeploySummary, len(deploys))
+	deployIDs := make([]string, len(deploys))
 	for i, deploy := range deploys {
-		if err := d.populateModel(deploy); err != nil {
-			return nil, err
+		deployIDs[i] = deploy.DeployID
+	}
+
+	// only the deploys in the page are populated from the tag store
+	page, nextToken, err := pageIDs(deployIDs, limit, nextToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	tags, err := d.TagStore.SelectByTypeAndIDs("deploy", page)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// revisions rendered with an environment's log sinks are internal to layer0,
+	// so a page may hold fewer deploys than the limit
+	summaries := []*models.DeploySummary{}
+	for _, deployID := range page {
+		deployTags := tags.WithID(deployID)
+		if _, ok := deployTags.WithKey(logSinkSourceTagKey).First(); ok {
+			continue
 		}
 
-		summaries[i] = &models.DeploySummary{
+		deploy := &models.Deploy{DeployID: deployID}
+		populateDeployTags(deploy, deployTags)
+
+		summaries = append(summaries, &models.DeploySummary{
 			DeployID:   deploy.DeployID,
 			DeployName: deploy.DeployName,
 			Version:    deploy.Version,
-		}
+		})
 	}
 
-	return summaries, nil
+	return summaries, nextToken, nil
 }
 
 func (d *L0DeployLogic) GetDeploy(deployID string) (*models.Deploy, error) {
@@ -98,6 +126,11 @@ func (d *L0DeployLogic) populateModel(model *models.Deploy) error {
 		return err
 	}
 
+	populateDeployTags(model, tags)
+	return nil
+}
+
+func populateDeployTags(model *models.Deploy, tags models.Tags) {
 	if tag, ok := tags.WithKey("name").First(); ok {
 		model.DeployName = tag.Value
 	}
@@ -106,5 +139,5 @@ func (d *L0DeployLogic) populateModel(model *models.Deploy) error {
 		model.Version = tag.Value
 	}
 
-	return nil
+	model.Tags = tags.UserDefined()
 }
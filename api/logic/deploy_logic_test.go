@@ -40,6 +40,7 @@ func TestListDeploys(t *testing.T) {
 	retDeploys := []*models.Deploy{
 		{DeployID: "d1"},
 		{DeployID: "d2"},
+		{DeployID: "d3"},
 	}
 
 	testLogic.Backend.EXPECT().
@@ -51,6 +52,7 @@ func TestListDeploys(t *testing.T) {
 		{EntityID: "d1", EntityType: "deploy", Key: "version", Value: "2"},
 		{EntityID: "d2", EntityType: "deploy", Key: "name", Value: "dpl_2"},
 		{EntityID: "d2", EntityType: "deploy", Key: "version", Value: "3"},
+		{EntityID: "d3", EntityType: "deploy", Key: "log_sink_source", Value: "d2"},
 		{EntityID: "extra", EntityType: "deploy", Key: "name", Value: "extra"},
 	})
 
@@ -68,6 +70,20 @@ func TestListDeploys(t *testing.T) {
 	testutils.AssertEqual(t, received, expected)
 }
 
+func TestListDeploysPage_invalidToken(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	testLogic.Backend.EXPECT().
+		ListDeploys().
+		Return([]*models.Deploy{{DeployID: "d1"}}, nil)
+
+	deployLogic := NewL0DeployLogic(testLogic.Logic())
+	if _, _, err := deployLogic.ListDeploysPage(1, "!!!"); err == nil {
+		t.Fatal("error was unexpectedly nil")
+	}
+}
+
 func TestDeleteDeploy(t *testing.T) {
 	testLogic, ctrl := NewTestLogic(t)
 	defer ctrl.Finish()
@@ -0,0 +1,178 @@
+package logic
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/quintilesims/layer0/api/backend/ecs"
+	"github.com/quintilesims/layer0/common/aws/ec2"
+	"github.com/quintilesims/layer0/common/config"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/pricing"
+	"github.com/zpatrick/go-bytesize"
+)
+
+// GetEnvironmentCost estimates the monthly cost of an environment's instances, load balancers,
+// and (optionally) data transfer. The instance cost is broken down by service according to the
+// share of the cluster's memory reserved by each service; the remainder is reported as unallocated.
+func (e *L0EnvironmentLogic) GetEnvironmentCost(environmentID string, dataTransferGB float64) (*models.EnvironmentCost, error) {
+	environment, err := e.GetEnvironment(environmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	cost := &models.EnvironmentCost{
+		EnvironmentID:   environment.EnvironmentID,
+		EnvironmentName: environment.EnvironmentName,
+		Currency:        "USD",
+		InstanceSize:    environment.InstanceSize,
+		InstanceCount:   environment.ClusterCount,
+		DataTransferGB:  dataTransferGB,
+		Services:        []models.ServiceCost{},
+		Warnings:        []string{},
+	}
+
+	// prices differ between regions, so a table for another region only gives a rough estimate
+	if region, priceRegion := config.AWSRegion(), e.PriceTable.PriceRegion(); region != "" && region != priceRegion {
+		warning := fmt.Sprintf("Prices are for region '%s' but Layer0 runs in '%s'; set %s to a price table for '%s' for accurate estimates", priceRegion, region, config.PRICE_TABLE, region)
+		cost.Warnings = append(cost.Warnings, warning)
+	}
+
+	if environment.ClusterCount > 0 {
+		hourly, ok := e.PriceTable.InstanceHourly(environment.InstanceSize)
+		if !ok {
+			warning := fmt.Sprintf("No price found for instance type '%s'; instance costs are not included", environment.InstanceSize)
+			cost.Warnings = append(cost.Warnings, warning)
+		}
+
+		cost.InstanceMonthlyCost = hourly * pricing.HoursPerMonth * float64(environment.ClusterCount)
+	}
+
+	if environment.SpotPrice != "" {
+		cost.Warnings = append(cost.Warnings, "Environment uses spot instances; instance costs are estimated using on-demand prices")
+	}
+
+	loadBalancerTags, err := e.TagStore.SelectByType("load_balancer")
+	if err != nil {
+		return nil, err
+	}
+
+	loadBalancerMonthlyCost := e.PriceTable.LoadBalancerHourly() * pricing.HoursPerMonth
+	cost.LoadBalancerCount = len(loadBalancerTags.WithKey("environment_id").WithValue(environmentID))
+	cost.LoadBalancerMonthlyCost = loadBalancerMonthlyCost * float64(cost.LoadBalancerCount)
+	cost.DataTransferMonthlyCost = e.PriceTable.DataTransferPerGB() * dataTransferGB
+
+	services, err := e.getServiceCosts(environmentID, loadBalancerMonthlyCost)
+	if err != nil {
+		return nil, err
+	}
+
+	// services are charged for their share of the cluster's memory; if the instance size
+	// is unknown, fall back to the share of the memory reserved by all services
+	clusterMemory := int64(ec2.InstanceSizes[environment.InstanceSize]/bytesize.MiB) * int64(environment.ClusterCount)
+	var reservedMemory int64
+	for _, service := range services {
+		reservedMemory += service.MemoryMiB
+	}
+
+	totalMemory := clusterMemory
+	if totalMemory < reservedMemory {
+		totalMemory = reservedMemory
+	}
+
+	allocatedCost := 0.0
+	for i := range services {
+		if totalMemory > 0 {
+			services[i].MemoryShare = float64(services[i].MemoryMiB) / float64(totalMemory)
+		}
+
+		services[i].InstanceMonthlyCost = cost.InstanceMonthlyCost * services[i].MemoryShare
+		services[i].TotalMonthlyCost = services[i].InstanceMonthlyCost + services[i].LoadBalancerMonthlyCost
+		allocatedCost += services[i].InstanceMonthlyCost
+
+		services[i].InstanceMonthlyCost = roundCents(services[i].InstanceMonthlyCost)
+		services[i].LoadBalancerMonthlyCost = roundCents(services[i].LoadBalancerMonthlyCost)
+		services[i].TotalMonthlyCost = roundCents(services[i].TotalMonthlyCost)
+		cost.Services = append(cost.Services, services[i])
+	}
+
+	cost.UnallocatedMonthlyCost = roundCents(cost.InstanceMonthlyCost - allocatedCost)
+	cost.TotalMonthlyCost = roundCents(cost.InstanceMonthlyCost + cost.LoadBalancerMonthlyCost + cost.DataTransferMonthlyCost)
+	cost.InstanceMonthlyCost = roundCents(cost.InstanceMonthlyCost)
+	cost.LoadBalancerMonthlyCost = roundCents(cost.LoadBalancerMonthlyCost)
+	cost.DataTransferMonthlyCost = roundCents(cost.DataTransferMonthlyCost)
+
+	return cost, nil
+}
+
+func (e *L0EnvironmentLogic) getServiceCosts(environmentID string, loadBalancerMonthlyCost float64) ([]models.ServiceCost, error) {
+	services, err := e.Backend.GetEnvironmentServices(environmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceTags, err := e.TagStore.SelectByType("service")
+	if err != nil {
+		return nil, err
+	}
+
+	deployMemory := map[string]int64{}
+	serviceCosts := make([]models.ServiceCost, len(services))
+	for i, service := range services {
+		serviceCosts[i].ServiceID = service.ServiceID
+
+		tags := serviceTags.WithID(service.ServiceID)
+		if tag, ok := tags.WithKey("name").First(); ok {
+			serviceCosts[i].ServiceName = tag.Value
+		}
+
+		if tag, ok := tags.WithKey("load_balancer_id").First(); ok && tag.Value != "" {
+			serviceCosts[i].LoadBalancerMonthlyCost = loadBalancerMonthlyCost
+		}
+
+		for _, deployment := range service.Deployments {
+			memory, ok := deployMemory[deployment.DeployID]
+			if !ok {
+				memory, err = e.getDeployMemory(deployment.DeployID)
+				if err != nil {
+					return nil, err
+				}
+
+				deployMemory[deployment.DeployID] = memory
+			}
+
+			serviceCosts[i].MemoryMiB += memory * deployment.DesiredCount
+		}
+	}
+
+	return serviceCosts, nil
+}
+
+// getDeployMemory returns the memory, in MiB, reserved by a single copy of the deploy
+func (e *L0EnvironmentLogic) getDeployMemory(deployID string) (int64, error) {
+	deploy, err := e.Backend.GetDeploy(deployID)
+	if err != nil {
+		return 0, err
+	}
+
+	dockerrun, err := ecsbackend.MarshalDockerrun(deploy.Dockerrun)
+	if err != nil {
+		return 0, err
+	}
+
+	var memory int64
+	for _, container := range dockerrun.ContainerDefinitions {
+		switch {
+		case container.Memory != nil && *container.Memory != 0:
+			memory += *container.Memory
+		case container.MemoryReservation != nil:
+			memory += *container.MemoryReservation
+		}
+	}
+
+	return memory, nil
+}
+
+func roundCents(value float64) float64 {
+	return math.Round(value*100) / 100
+}
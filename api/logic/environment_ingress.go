@@ -0,0 +1,134 @@
+package logic
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/quintilesims/layer0/common/errors"
+	"github.com/quintilesims/layer0/common/models"
+)
+
+// each ingress rule is stored on its environment under 'ingress:<rule>'
+const ingressTagPrefix = "ingress:"
+
+func (e *L0EnvironmentLogic) AuthorizeEnvironmentIngress(environmentID string, req models.UpdateEnvironmentIngressRequest) (*models.Environment, error) {
+	rules, err := validateIngressRules(req.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	// make sure the environment exists before changing its security group
+	if _, err := e.Backend.GetEnvironment(environmentID); err != nil {
+		return nil, err
+	}
+
+	for _, rule := range rules {
+		if err := e.Backend.AuthorizeEnvironmentIngress(environmentID, rule); err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(rule)
+		if err != nil {
+			return nil, err
+		}
+
+		tag := models.Tag{
+			EntityID:   environmentID,
+			EntityType: "environment",
+			Key:        ingressTagKey(rule),
+			Value:      string(value),
+		}
+
+		if err := e.TagStore.Insert(tag); err != nil {
+			return nil, err
+		}
+	}
+
+	return e.GetEnvironment(environmentID)
+}
+
+func (e *L0EnvironmentLogic) RevokeEnvironmentIngress(environmentID string, req models.UpdateEnvironmentIngressRequest) (*models.Environment, error) {
+	rules, err := validateIngressRules(req.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := e.Backend.GetEnvironment(environmentID); err != nil {
+		return nil, err
+	}
+
+	for _, rule := range rules {
+		if err := e.Backend.RevokeEnvironmentIngress(environmentID, rule); err != nil {
+			return nil, err
+		}
+
+		if err := e.TagStore.Delete("environment", environmentID, ingressTagKey(rule)); err != nil {
+			return nil, err
+		}
+	}
+
+	return e.GetEnvironment(environmentID)
+}
+
+func ingressTagKey(rule models.EnvironmentIngressRule) string {
+	source := rule.CIDR
+	if rule.SecurityGroupID != "" {
+		source = rule.SecurityGroupID
+	}
+
+	return fmt.Sprintf("%s%s:%d-%d:%s", ingressTagPrefix, rule.Protocol, rule.FromPort, rule.ToPort, source)
+}
+
+func getIngressRules(tags models.Tags) []models.EnvironmentIngressRule {
+	var rules []models.EnvironmentIngressRule
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag.Key, ingressTagPrefix) {
+			continue
+		}
+
+		var rule models.EnvironmentIngressRule
+		if err := json.Unmarshal([]byte(tag.Value), &rule); err == nil {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules
+}
+
+// validateIngressRules returns a normalized copy of the rules
+func validateIngressRules(rules []models.EnvironmentIngressRule) ([]models.EnvironmentIngressRule, error) {
+	if len(rules) == 0 {
+		return nil, errors.Newf(errors.MissingParameter, "At least one ingress rule is required")
+	}
+
+	normalized := make([]models.EnvironmentIngressRule, len(rules))
+	for i, rule := range rules {
+		switch {
+		case rule.CIDR != "" && rule.SecurityGroupID != "":
+			return nil, errors.Newf(errors.InvalidRequest, "Ingress rules must specify either a CIDR or a security group, not both")
+		case rule.CIDR != "":
+			if _, _, err := net.ParseCIDR(rule.CIDR); err != nil {
+				return nil, errors.Newf(errors.InvalidRequest, "'%s' is not a valid CIDR block", rule.CIDR)
+			}
+		case rule.SecurityGroupID != "":
+			if !strings.HasPrefix(rule.SecurityGroupID, "sg-") {
+				return nil, errors.Newf(errors.InvalidRequest, "'%s' is not a valid security group id", rule.SecurityGroupID)
+			}
+		default:
+			return nil, errors.Newf(errors.InvalidRequest, "Ingress rules must specify a CIDR or a security group")
+		}
+
+		protocol, toPort, err := validatePortRange(rule.Protocol, rule.FromPort, rule.ToPort)
+		if err != nil {
+			return nil, err
+		}
+
+		rule.Protocol = protocol
+		rule.ToPort = toPort
+		normalized[i] = rule
+	}
+
+	return normalized, nil
+}
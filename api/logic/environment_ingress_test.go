@@ -0,0 +1,112 @@
+package logic
+
+import (
+	"testing"
+
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func TestAuthorizeEnvironmentIngress(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	testLogic.Backend.EXPECT().
+		GetEnvironment("e1").
+		Return(&models.Environment{EnvironmentID: "e1"}, nil).
+		Times(2)
+
+	cidrRule := models.EnvironmentIngressRule{CIDR: "10.1.0.0/16", Protocol: "tcp", FromPort: 22, ToPort: 22}
+	groupRule := models.EnvironmentIngressRule{SecurityGroupID: "sg-123", Protocol: "udp", FromPort: 8000, ToPort: 8100}
+
+	testLogic.Backend.EXPECT().
+		AuthorizeEnvironmentIngress("e1", cidrRule).
+		Return(nil)
+
+	testLogic.Backend.EXPECT().
+		AuthorizeEnvironmentIngress("e1", groupRule).
+		Return(nil)
+
+	req := models.UpdateEnvironmentIngressRequest{
+		Rules: []models.EnvironmentIngressRule{
+			{CIDR: "10.1.0.0/16", FromPort: 22},
+			{SecurityGroupID: "sg-123", Protocol: "UDP", FromPort: 8000, ToPort: 8100},
+		},
+	}
+
+	environmentLogic := NewL0EnvironmentLogic(testLogic.Logic())
+	environment, err := environmentLogic.AuthorizeEnvironmentIngress("e1", req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, environment.IngressRules, []models.EnvironmentIngressRule{cidrRule, groupRule})
+	testLogic.AssertTagExists(t, models.Tag{
+		EntityID:   "e1",
+		EntityType: "environment",
+		Key:        "ingress:tcp:22-22:10.1.0.0/16",
+		Value:      `{"cidr":"10.1.0.0/16","security_group_id":"","protocol":"tcp","from_port":22,"to_port":22}`,
+	})
+}
+
+func TestAuthorizeEnvironmentIngress_invalidRules(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	environmentLogic := NewL0EnvironmentLogic(testLogic.Logic())
+
+	cases := map[string][]models.EnvironmentIngressRule{
+		"no rules":       nil,
+		"no source":      {{FromPort: 22}},
+		"both sources":   {{CIDR: "10.0.0.0/8", SecurityGroupID: "sg-123", FromPort: 22}},
+		"invalid cidr":   {{CIDR: "10.0.0.0", FromPort: 22}},
+		"invalid group":  {{SecurityGroupID: "l0-env", FromPort: 22}},
+		"invalid port":   {{CIDR: "10.0.0.0/8"}},
+		"invalid proto":  {{CIDR: "10.0.0.0/8", Protocol: "icmp", FromPort: 22}},
+		"invalid ranges": {{CIDR: "10.0.0.0/8", FromPort: 90, ToPort: 80}},
+	}
+
+	for name, rules := range cases {
+		req := models.UpdateEnvironmentIngressRequest{Rules: rules}
+		if _, err := environmentLogic.AuthorizeEnvironmentIngress("e1", req); err == nil {
+			t.Errorf("%s: error was unexpectedly nil", name)
+		}
+	}
+}
+
+func TestRevokeEnvironmentIngress(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	testLogic.Backend.EXPECT().
+		GetEnvironment("e1").
+		Return(&models.Environment{EnvironmentID: "e1"}, nil).
+		Times(2)
+
+	rule := models.EnvironmentIngressRule{CIDR: "10.1.0.0/16", Protocol: "tcp", FromPort: 22, ToPort: 22}
+	testLogic.Backend.EXPECT().
+		RevokeEnvironmentIngress("e1", rule).
+		Return(nil)
+
+	testLogic.AddTags(t, []*models.Tag{
+		{EntityID: "e1", EntityType: "environment", Key: "name", Value: "env"},
+		{EntityID: "e1", EntityType: "environment", Key: "ingress:tcp:22-22:10.1.0.0/16", Value: `{"cidr":"10.1.0.0/16","protocol":"tcp","from_port":22,"to_port":22}`},
+		{EntityID: "e1", EntityType: "environment", Key: "ingress:tcp:443-443:sg-123", Value: `{"security_group_id":"sg-123","protocol":"tcp","from_port":443,"to_port":443}`},
+	})
+
+	req := models.UpdateEnvironmentIngressRequest{
+		Rules: []models.EnvironmentIngressRule{{CIDR: "10.1.0.0/16", FromPort: 22}},
+	}
+
+	environmentLogic := NewL0EnvironmentLogic(testLogic.Logic())
+	environment, err := environmentLogic.RevokeEnvironmentIngress("e1", req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []models.EnvironmentIngressRule{
+		{SecurityGroupID: "sg-123", Protocol: "tcp", FromPort: 443, ToPort: 443},
+	}
+
+	testutils.AssertEqual(t, environment.IngressRules, expected)
+}
@@ -0,0 +1,105 @@
+package logic
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/quintilesims/layer0/common/errors"
+	"github.com/quintilesims/layer0/common/models"
+)
+
+// the details of a link are stored on both environments under 'link:<other environment id>';
+// links created before ports and directions were supported do not have this tag
+func environmentLinkTagKey(environmentID string) string {
+	return "link:" + environmentID
+}
+
+func environmentLinkTagValue(link models.EnvironmentLink) (string, error) {
+	bytes, err := json.Marshal(link)
+	if err != nil {
+		return "", err
+	}
+
+	return string(bytes), nil
+}
+
+// getEnvironmentLink returns the link between the environment and its peer. If the link's
+// details were never stored (or cannot be read), it is a bidirectional link that allows all traffic.
+func getEnvironmentLink(tags models.Tags, environmentID, peerEnvironmentID string) models.EnvironmentLink {
+	if tag, ok := tags.WithID(environmentID).WithKey(environmentLinkTagKey(peerEnvironmentID)).First(); ok {
+		var link models.EnvironmentLink
+		if err := json.Unmarshal([]byte(tag.Value), &link); err == nil {
+			return link
+		}
+	}
+
+	return models.EnvironmentLink{
+		SourceEnvironmentID: environmentID,
+		DestEnvironmentID:   peerEnvironmentID,
+	}
+}
+
+// sameEnvironmentLink returns true if both links allow the same traffic in the same directions
+func sameEnvironmentLink(a, b models.EnvironmentLink) bool {
+	if a.SourceEnvironmentID != b.SourceEnvironmentID || a.DestEnvironmentID != b.DestEnvironmentID {
+		return false
+	}
+
+	if a.OneWay != b.OneWay || len(a.Ports) != len(b.Ports) {
+		return false
+	}
+
+	for i := range a.Ports {
+		if a.Ports[i] != b.Ports[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validateEnvironmentLink checks the link's environments and normalizes its ports
+func validateEnvironmentLink(link *models.EnvironmentLink) error {
+	if link.DestEnvironmentID == "" {
+		return errors.Newf(errors.MissingParameter, "EnvironmentID is required")
+	}
+
+	if link.SourceEnvironmentID == link.DestEnvironmentID {
+		return errors.Newf(errors.InvalidRequest, "Cannot link an environment to itself")
+	}
+
+	for i, port := range link.Ports {
+		protocol, toPort, err := validatePortRange(port.Protocol, port.FromPort, port.ToPort)
+		if err != nil {
+			return err
+		}
+
+		link.Ports[i].Protocol = protocol
+		link.Ports[i].ToPort = toPort
+	}
+
+	return nil
+}
+
+// validatePortRange returns the normalized protocol, which defaults to tcp,
+// and upper bound of the range, which defaults to the lower bound
+func validatePortRange(protocol string, fromPort, toPort int) (string, int, error) {
+	protocol = strings.ToLower(protocol)
+	if protocol == "" {
+		protocol = "tcp"
+	}
+
+	if protocol != "tcp" && protocol != "udp" {
+		return "", 0, errors.Newf(errors.InvalidRequest, "Protocol '%s' is not supported; must be tcp or udp", protocol)
+	}
+
+	if toPort == 0 {
+		toPort = fromPort
+	}
+
+	if fromPort < 1 || toPort > 65535 || fromPort > toPort {
+		return "", 0, errors.Newf(errors.InvalidRequest, "Port range %d-%d is invalid; ports must be between 1 and 65535", fromPort, toPort)
+	}
+
+	return protocol, toPort, nil
+}
@@ -1,39 +1,67 @@
 package logic
 
 import (
-	"github.com/quintilesims/layer0/api/backend/ecs/id"
+	"strconv"
+
 	"github.com/quintilesims/layer0/common/errors"
 	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/pricing"
 )
 
 type EnvironmentLogic interface {
 	ListEnvironments() ([]models.EnvironmentSummary, error)
+	ListEnvironmentsPage(limit int, nextToken string) ([]models.EnvironmentSummary, string, error)
 	GetEnvironment(id string) (*models.Environment, error)
+	GetEnvironmentCapacity(id string) (*models.EnvironmentCapacity, error)
+	GetEnvironmentCost(id string, dataTransferGB float64) (*models.EnvironmentCost, error)
 	DeleteEnvironment(id string) error
 	CanCreateEnvironment(req models.CreateEnvironmentRequest) (bool, error)
 	CreateEnvironment(req models.CreateEnvironmentRequest) (*models.Environment, error)
 	UpdateEnvironment(id string, minClusterCount int) (*models.Environment, error)
-	CreateEnvironmentLink(sourceEnvironmentID, destEnvironmentID string) error
+	CreateEnvironmentLink(sourceEnvironmentID string, req models.CreateEnvironmentLinkRequest) error
 	DeleteEnvironmentLink(sourceEnvironmentID, destEnvironmentID string) error
+	AuthorizeEnvironmentIngress(id string, req models.UpdateEnvironmentIngressRequest) (*models.Environment, error)
+	RevokeEnvironmentIngress(id string, req models.UpdateEnvironmentIngressRequest) (*models.Environment, error)
 }
 
 type L0EnvironmentLogic struct {
 	Logic
+	PriceTable pricing.PriceTable
 }
 
 func NewL0EnvironmentLogic(logic Logic) *L0EnvironmentLogic {
 	return &L0EnvironmentLogic{
-		Logic: logic,
+		Logic:      logic,
+		PriceTable: pricing.DefaultPriceTable,
 	}
 }
 
 func (e *L0EnvironmentLogic) ListEnvironments() ([]models.EnvironmentSummary, error) {
-	environmentIDs, err := e.Backend.ListEnvironments()
+	summaries, _, err := e.ListEnvironmentsPage(0, "")
+	return summaries, err
+}
+
+func (e *L0EnvironmentLogic) ListEnvironmentsPage(limit int, nextToken string) ([]models.EnvironmentSummary, string, error) {
+	if err := validateLimit(limit); err != nil {
+		return nil, "", err
+	}
+
+	ecsEnvironmentIDs, nextToken, err := e.Backend.ListEnvironmentsPage(limit, nextToken)
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+
+	environmentIDs := make([]string, len(ecsEnvironmentIDs))
+	for i, ecsEnvironmentID := range ecsEnvironmentIDs {
+		environmentIDs[i] = ecsEnvironmentID.L0EnvironmentID()
 	}
 
-	return e.makeEnvironmentSummaryModels(environmentIDs)
+	summaries, err := e.makeEnvironmentSummaryModels(environmentIDs)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return summaries, nextToken, nil
 }
 
 func (e *L0EnvironmentLogic) GetEnvironment(environmentID string) (*models.Environment, error) {
@@ -49,6 +77,15 @@ func (e *L0EnvironmentLogic) GetEnvironment(environmentID string) (*models.Envir
 	return environment, nil
 }
 
+func (e *L0EnvironmentLogic) GetEnvironmentCapacity(environmentID string) (*models.EnvironmentCapacity, error) {
+	// make sure the environment exists before querying its instances
+	if _, err := e.Backend.GetEnvironment(environmentID); err != nil {
+		return nil, err
+	}
+
+	return e.Scaler.GetCapacity(environmentID)
+}
+
 func (e *L0EnvironmentLogic) DeleteEnvironment(environmentID string) error {
 	tags, err := e.TagStore.SelectByTypeAndID("environment", environmentID)
 	if err != nil {
@@ -91,13 +128,45 @@ func (e *L0EnvironmentLogic) CreateEnvironment(req models.CreateEnvironmentReque
 		return nil, errors.Newf(errors.MissingParameter, "OperatingSystem is required")
 	}
 
+	if req.SpotPrice != "" {
+		if price, err := strconv.ParseFloat(req.SpotPrice, 64); err != nil || price <= 0 {
+			return nil, errors.Newf(errors.InvalidRequest, "SpotPrice must be a positive number")
+		}
+	}
+
+	if policy := req.MixedInstancesPolicy; policy != nil {
+		if len(policy.InstanceTypes) == 0 {
+			return nil, errors.Newf(errors.InvalidRequest, "MixedInstancesPolicy requires at least one instance type")
+		}
+
+		if policy.OnDemandBaseCapacity < 0 {
+			return nil, errors.Newf(errors.InvalidRequest, "OnDemandBaseCapacity must not be negative")
+		}
+
+		if policy.OnDemandPercentageAboveBase < 0 || policy.OnDemandPercentageAboveBase > 100 {
+			return nil, errors.Newf(errors.InvalidRequest, "OnDemandPercentageAboveBase must be between 0 and 100")
+		}
+	}
+
+	if len(req.LogSinks) > 0 && req.OperatingSystem != "linux" {
+		return nil, errors.Newf(errors.InvalidRequest, "Log sinks are only supported in linux environments")
+	}
+
+	for _, sink := range req.LogSinks {
+		if err := validateLogSink(sink); err != nil {
+			return nil, err
+		}
+	}
+
 	environment, err := e.Backend.CreateEnvironment(
 		req.EnvironmentName,
 		req.InstanceSize,
 		req.OperatingSystem,
 		req.AMIID,
 		req.MinClusterCount,
-		req.UserDataTemplate)
+		req.UserDataTemplate,
+		req.SpotPrice,
+		req.MixedInstancesPolicy)
 	if err != nil {
 		return nil, err
 	}
@@ -110,6 +179,12 @@ func (e *L0EnvironmentLogic) CreateEnvironment(req models.CreateEnvironmentReque
 		return nil, err
 	}
 
+	for _, sink := range req.LogSinks {
+		if err := e.TagStore.Insert(models.Tag{EntityID: environment.EnvironmentID, EntityType: "environment", Key: "log_sink", Value: logSinkTagValue(sink)}); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := e.populateModel(environment); err != nil {
 		return environment, err
 	}
@@ -130,29 +205,67 @@ func (e *L0EnvironmentLogic) UpdateEnvironment(environmentID string, minClusterC
 	return environment, nil
 }
 
-func (e *L0EnvironmentLogic) CreateEnvironmentLink(sourceEnvironmentID, destEnvironmentID string) error {
-	if err := e.Backend.CreateEnvironmentLink(sourceEnvironmentID, destEnvironmentID); err != nil {
+func (e *L0EnvironmentLogic) CreateEnvironmentLink(sourceEnvironmentID string, req models.CreateEnvironmentLinkRequest) error {
+	link := models.EnvironmentLink{
+		SourceEnvironmentID: sourceEnvironmentID,
+		DestEnvironmentID:   req.EnvironmentID,
+		Ports:               req.Ports,
+		OneWay:              req.OneWay,
+	}
+
+	if err := validateEnvironmentLink(&link); err != nil {
 		return err
 	}
 
-	if err := e.TagStore.Insert(models.Tag{EntityID: sourceEnvironmentID, EntityType: "environment", Key: "link", Value: destEnvironmentID}); err != nil {
+	// the rules of an existing link are only revoked by DeleteEnvironmentLink,
+	// so the environments cannot be re-linked with different ports or directions
+	sourceTags, err := e.TagStore.SelectByTypeAndID("environment", sourceEnvironmentID)
+	if err != nil {
 		return err
 	}
 
-	if err := e.TagStore.Insert(models.Tag{EntityID: destEnvironmentID, EntityType: "environment", Key: "link", Value: sourceEnvironmentID}); err != nil {
+	if _, ok := sourceTags.WithKey("link").WithValue(link.DestEnvironmentID).First(); ok {
+		existing := getEnvironmentLink(sourceTags, sourceEnvironmentID, link.DestEnvironmentID)
+		if sameEnvironmentLink(existing, link) {
+			return nil
+		}
+
+		return errors.Newf(errors.InvalidRequest, "Environments '%s' and '%s' are already linked; delete the link before linking them again", link.SourceEnvironmentID, link.DestEnvironmentID)
+	}
+
+	if err := e.Backend.CreateEnvironmentLink(link); err != nil {
 		return err
 	}
 
+	value, err := environmentLinkTagValue(link)
+	if err != nil {
+		return err
+	}
+
+	tags := []models.Tag{
+		{EntityID: link.SourceEnvironmentID, EntityType: "environment", Key: "link", Value: link.DestEnvironmentID},
+		{EntityID: link.SourceEnvironmentID, EntityType: "environment", Key: environmentLinkTagKey(link.DestEnvironmentID), Value: value},
+		{EntityID: link.DestEnvironmentID, EntityType: "environment", Key: "link", Value: link.SourceEnvironmentID},
+		{EntityID: link.DestEnvironmentID, EntityType: "environment", Key: environmentLinkTagKey(link.SourceEnvironmentID), Value: value},
+	}
+
+	for _, tag := range tags {
+		if err := e.TagStore.Insert(tag); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 func (e *L0EnvironmentLogic) DeleteEnvironmentLink(sourceEnvironmentID, destEnvironmentID string) error {
-	if err := e.Backend.DeleteEnvironmentLink(sourceEnvironmentID, destEnvironmentID); err != nil {
+	sourceTags, err := e.TagStore.SelectByTypeAndID("environment", sourceEnvironmentID)
+	if err != nil {
 		return err
 	}
 
-	sourceTags, err := e.TagStore.SelectByTypeAndID("environment", sourceEnvironmentID)
-	if err != nil {
+	link := getEnvironmentLink(sourceTags, sourceEnvironmentID, destEnvironmentID)
+	if err := e.Backend.DeleteEnvironmentLink(link); err != nil {
 		return err
 	}
 
@@ -162,6 +275,10 @@ func (e *L0EnvironmentLogic) DeleteEnvironmentLink(sourceEnvironmentID, destEnvi
 		}
 	}
 
+	if err := e.TagStore.Delete("environment", sourceEnvironmentID, environmentLinkTagKey(destEnvironmentID)); err != nil {
+		return err
+	}
+
 	destTags, err := e.TagStore.SelectByTypeAndID("environment", destEnvironmentID)
 	if err != nil {
 		return err
@@ -173,6 +290,10 @@ func (e *L0EnvironmentLogic) DeleteEnvironmentLink(sourceEnvironmentID, destEnvi
 		}
 	}
 
+	if err := e.TagStore.Delete("environment", destEnvironmentID, environmentLinkTagKey(sourceEnvironmentID)); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -191,22 +312,32 @@ func (e *L0EnvironmentLogic) populateModel(model *models.Environment) error {
 	}
 
 	model.Links = []string{}
+	model.LinkDetails = []models.EnvironmentLink{}
 	for _, tag := range tags.WithKey("link") {
 		model.Links = append(model.Links, tag.Value)
+		model.LinkDetails = append(model.LinkDetails, getEnvironmentLink(tags, model.EnvironmentID, tag.Value))
+	}
+
+	for _, tag := range tags.WithKey("log_sink") {
+		if sink, ok := parseLogSinkTagValue(tag.Value); ok {
+			model.LogSinks = append(model.LogSinks, sink)
+		}
 	}
 
+	model.IngressRules = getIngressRules(tags)
+	model.Tags = tags.UserDefined()
+
 	return nil
 }
 
-func (e *L0EnvironmentLogic) makeEnvironmentSummaryModels(environmentIDs []id.ECSEnvironmentID) ([]models.EnvironmentSummary, error) {
-	tags, err := e.TagStore.SelectByType("environment")
+func (e *L0EnvironmentLogic) makeEnvironmentSummaryModels(environmentIDs []string) ([]models.EnvironmentSummary, error) {
+	tags, err := e.TagStore.SelectByTypeAndIDs("environment", environmentIDs)
 	if err != nil {
 		return nil, err
 	}
 
 	summaries := make([]models.EnvironmentSummary, len(environmentIDs))
-	for i, ecsEnvironmentID := range environmentIDs {
-		environmentID := ecsEnvironmentID.L0EnvironmentID()
+	for i, environmentID := range environmentIDs {
 		summaries[i].EnvironmentID = environmentID
 
 		if tag, ok := tags.WithID(environmentID).WithKey("name").First(); ok {
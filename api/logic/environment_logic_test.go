@@ -1,10 +1,13 @@
 package logic
 
 import (
+	"os"
 	"testing"
 
 	"github.com/quintilesims/layer0/api/backend/ecs/id"
+	"github.com/quintilesims/layer0/common/config"
 	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/pricing"
 	"github.com/quintilesims/layer0/common/testutils"
 	"github.com/stretchr/testify/assert"
 )
@@ -25,6 +28,8 @@ func TestGetEnvironment(t *testing.T) {
 		{EntityID: "e1", EntityType: "environment", Key: "name", Value: "env"},
 		{EntityID: "e1", EntityType: "environment", Key: "os", Value: "linux"},
 		{EntityID: "e1", EntityType: "environment", Key: "link", Value: "e2"},
+		{EntityID: "e1", EntityType: "environment", Key: "link", Value: "e3"},
+		{EntityID: "e1", EntityType: "environment", Key: "link:e3", Value: `{"source_environment_id":"e3","dest_environment_id":"e1","ports":[{"protocol":"tcp","from_port":5432,"to_port":5432}],"one_way":true}`},
 		{EntityID: "extra", EntityType: "environment", Key: "name", Value: "extra"},
 	})
 
@@ -38,12 +43,43 @@ func TestGetEnvironment(t *testing.T) {
 		EnvironmentID:   "e1",
 		EnvironmentName: "env",
 		OperatingSystem: "linux",
-		Links:           []string{"e2"},
+		Links:           []string{"e2", "e3"},
+		LinkDetails: []models.EnvironmentLink{
+			{SourceEnvironmentID: "e1", DestEnvironmentID: "e2"},
+			{
+				SourceEnvironmentID: "e3",
+				DestEnvironmentID:   "e1",
+				Ports:               []models.EnvironmentLinkPort{{Protocol: "tcp", FromPort: 5432, ToPort: 5432}},
+				OneWay:              true,
+			},
+		},
 	}
 
 	testutils.AssertEqual(t, received, expected)
 }
 
+func TestGetEnvironmentCapacity(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	testLogic.Backend.EXPECT().
+		GetEnvironment("e1").
+		Return(&models.Environment{EnvironmentID: "e1"}, nil)
+
+	capacity := &models.EnvironmentCapacity{EnvironmentID: "e1"}
+	testLogic.Scaler.EXPECT().
+		GetCapacity("e1").
+		Return(capacity, nil)
+
+	environmentLogic := NewL0EnvironmentLogic(testLogic.Logic())
+	received, err := environmentLogic.GetEnvironmentCapacity("e1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, received, capacity)
+}
+
 func TestListEnvironments(t *testing.T) {
 	testLogic, ctrl := NewTestLogic(t)
 	defer ctrl.Finish()
@@ -54,8 +90,8 @@ func TestListEnvironments(t *testing.T) {
 	}
 
 	testLogic.Backend.EXPECT().
-		ListEnvironments().
-		Return(ecsEnvironmentIDs, nil)
+		ListEnvironmentsPage(0, "").
+		Return(ecsEnvironmentIDs, "", nil)
 
 	testLogic.AddTags(t, []*models.Tag{
 		{EntityID: "env_id1", EntityType: "environment", Key: "name", Value: "env_name1"},
@@ -96,7 +132,7 @@ func TestDeleteEnvironment(t *testing.T) {
 		Return(nil)
 
 	testLogic.Backend.EXPECT().
-		DeleteEnvironmentLink("eid1", "eid2").
+		DeleteEnvironmentLink(models.EnvironmentLink{SourceEnvironmentID: "eid1", DestEnvironmentID: "eid2"}).
 		Return(nil)
 
 	testLogic.AddTags(t, []*models.Tag{
@@ -164,7 +200,7 @@ func TestCreateEnvironment(t *testing.T) {
 	}
 
 	testLogic.Backend.EXPECT().
-		CreateEnvironment("name", "m3.medium", "linux", "amiid", 2, []byte("user_data")).
+		CreateEnvironment("name", "m3.medium", "linux", "amiid", 2, []byte("user_data"), "", nil).
 		Return(retEnvironment, nil)
 
 	request := models.CreateEnvironmentRequest{
@@ -187,6 +223,7 @@ func TestCreateEnvironment(t *testing.T) {
 		EnvironmentName: "name",
 		OperatingSystem: "linux",
 		Links:           []string{},
+		LinkDetails:     []models.EnvironmentLink{},
 	}
 
 	testutils.AssertEqual(t, received, expected)
@@ -194,6 +231,65 @@ func TestCreateEnvironment(t *testing.T) {
 	testLogic.AssertTagExists(t, models.Tag{EntityID: "e1", EntityType: "environment", Key: "os", Value: "linux"})
 }
 
+func TestCreateEnvironment_logSinks(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	testLogic.Backend.EXPECT().
+		CreateEnvironment("name", "", "linux", "", 0, nil, "", nil).
+		Return(&models.Environment{EnvironmentID: "e1"}, nil)
+
+	request := models.CreateEnvironmentRequest{
+		EnvironmentName: "name",
+		OperatingSystem: "linux",
+		LogSinks: []models.LogSink{
+			{Type: "syslog", Address: "tcp://logs.example.com:514"},
+			{Type: "s3", Address: "s3://bucket/archive"},
+		},
+	}
+
+	environmentLogic := NewL0EnvironmentLogic(testLogic.Logic())
+	received, err := environmentLogic.CreateEnvironment(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, received.LogSinks, request.LogSinks)
+	testLogic.AssertTagExists(t, models.Tag{EntityID: "e1", EntityType: "environment", Key: "log_sink", Value: "syslog=tcp://logs.example.com:514"})
+	testLogic.AssertTagExists(t, models.Tag{EntityID: "e1", EntityType: "environment", Key: "log_sink", Value: "s3=s3://bucket/archive"})
+}
+
+func TestCreateEnvironmentError_invalidLogSinks(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	environmentLogic := NewL0EnvironmentLogic(testLogic.Logic())
+
+	cases := map[string]models.CreateEnvironmentRequest{
+		"Bad type": {
+			EnvironmentName: "name",
+			OperatingSystem: "linux",
+			LogSinks:        []models.LogSink{{Type: "kafka", Address: "tcp://logs:9092"}},
+		},
+		"Bad address": {
+			EnvironmentName: "name",
+			OperatingSystem: "linux",
+			LogSinks:        []models.LogSink{{Type: "http", Address: "tcp://logs:80"}},
+		},
+		"Windows": {
+			EnvironmentName: "name",
+			OperatingSystem: "windows",
+			LogSinks:        []models.LogSink{{Type: "s3", Address: "s3://bucket"}},
+		},
+	}
+
+	for name, request := range cases {
+		if _, err := environmentLogic.CreateEnvironment(request); err == nil {
+			t.Errorf("Case %s: error was nil!", name)
+		}
+	}
+}
+
 func TestCreateEnvironmentError_missingRequiredParams(t *testing.T) {
 	testLogic, ctrl := NewTestLogic(t)
 	defer ctrl.Finish()
@@ -207,6 +303,24 @@ func TestCreateEnvironmentError_missingRequiredParams(t *testing.T) {
 		"Missing OperatingSystem": {
 			EnvironmentName: "name",
 		},
+		"Invalid SpotPrice": {
+			EnvironmentName: "name",
+			OperatingSystem: "linux",
+			SpotPrice:       "cheap",
+		},
+		"Empty MixedInstancesPolicy": {
+			EnvironmentName:      "name",
+			OperatingSystem:      "linux",
+			MixedInstancesPolicy: &models.MixedInstancesPolicy{},
+		},
+		"Invalid OnDemandPercentageAboveBase": {
+			EnvironmentName: "name",
+			OperatingSystem: "linux",
+			MixedInstancesPolicy: &models.MixedInstancesPolicy{
+				InstanceTypes:               []string{"m5.large"},
+				OnDemandPercentageAboveBase: 101,
+			},
+		},
 	}
 
 	for name, request := range cases {
@@ -243,6 +357,7 @@ func TestUpdateEnvironment(t *testing.T) {
 		EnvironmentID:   "e1",
 		EnvironmentName: "env",
 		Links:           []string{},
+		LinkDetails:     []models.EnvironmentLink{},
 	}
 
 	testutils.AssertEqual(t, received, expected)
@@ -253,16 +368,105 @@ func TestCreateEnvironmentLink(t *testing.T) {
 	defer ctrl.Finish()
 
 	testLogic.Backend.EXPECT().
-		CreateEnvironmentLink("eid1", "eid2").
+		CreateEnvironmentLink(models.EnvironmentLink{SourceEnvironmentID: "eid1", DestEnvironmentID: "eid2"}).
 		Return(nil)
 
 	environmentLogic := NewL0EnvironmentLogic(testLogic.Logic())
-	if err := environmentLogic.CreateEnvironmentLink("eid1", "eid2"); err != nil {
+	if err := environmentLogic.CreateEnvironmentLink("eid1", models.CreateEnvironmentLinkRequest{EnvironmentID: "eid2"}); err != nil {
 		t.Fatal(err)
 	}
 
+	value := `{"source_environment_id":"eid1","dest_environment_id":"eid2","ports":null,"one_way":false}`
 	testLogic.AssertTagExists(t, models.Tag{EntityID: "eid1", EntityType: "environment", Key: "link", Value: "eid2"})
+	testLogic.AssertTagExists(t, models.Tag{EntityID: "eid1", EntityType: "environment", Key: "link:eid2", Value: value})
 	testLogic.AssertTagExists(t, models.Tag{EntityID: "eid2", EntityType: "environment", Key: "link", Value: "eid1"})
+	testLogic.AssertTagExists(t, models.Tag{EntityID: "eid2", EntityType: "environment", Key: "link:eid1", Value: value})
+}
+
+func TestCreateEnvironmentLink_ports(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	link := models.EnvironmentLink{
+		SourceEnvironmentID: "eid1",
+		DestEnvironmentID:   "eid2",
+		Ports: []models.EnvironmentLinkPort{
+			{Protocol: "tcp", FromPort: 5432, ToPort: 5432},
+			{Protocol: "udp", FromPort: 8000, ToPort: 8100},
+		},
+		OneWay: true,
+	}
+
+	testLogic.Backend.EXPECT().
+		CreateEnvironmentLink(link).
+		Return(nil)
+
+	req := models.CreateEnvironmentLinkRequest{
+		EnvironmentID: "eid2",
+		Ports: []models.EnvironmentLinkPort{
+			{FromPort: 5432},
+			{Protocol: "UDP", FromPort: 8000, ToPort: 8100},
+		},
+		OneWay: true,
+	}
+
+	environmentLogic := NewL0EnvironmentLogic(testLogic.Logic())
+	if err := environmentLogic.CreateEnvironmentLink("eid1", req); err != nil {
+		t.Fatal(err)
+	}
+
+	value := `{"source_environment_id":"eid1","dest_environment_id":"eid2","ports":[{"protocol":"tcp","from_port":5432,"to_port":5432},{"protocol":"udp","from_port":8000,"to_port":8100}],"one_way":true}`
+	testLogic.AssertTagExists(t, models.Tag{EntityID: "eid1", EntityType: "environment", Key: "link:eid2", Value: value})
+	testLogic.AssertTagExists(t, models.Tag{EntityID: "eid2", EntityType: "environment", Key: "link:eid1", Value: value})
+}
+
+func TestCreateEnvironmentLink_alreadyLinked(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	value := `{"source_environment_id":"eid1","dest_environment_id":"eid2","ports":[{"protocol":"tcp","from_port":5432,"to_port":5432}],"one_way":false}`
+	testLogic.AddTags(t, []*models.Tag{
+		{EntityID: "eid1", EntityType: "environment", Key: "link", Value: "eid2"},
+		{EntityID: "eid1", EntityType: "environment", Key: "link:eid2", Value: value},
+		{EntityID: "eid2", EntityType: "environment", Key: "link", Value: "eid1"},
+		{EntityID: "eid2", EntityType: "environment", Key: "link:eid1", Value: value},
+	})
+
+	environmentLogic := NewL0EnvironmentLogic(testLogic.Logic())
+
+	// re-creating the same link is a no-op
+	same := models.CreateEnvironmentLinkRequest{EnvironmentID: "eid2", Ports: []models.EnvironmentLinkPort{{FromPort: 5432}}}
+	if err := environmentLogic.CreateEnvironmentLink("eid1", same); err != nil {
+		t.Fatal(err)
+	}
+
+	different := models.CreateEnvironmentLinkRequest{EnvironmentID: "eid2", Ports: []models.EnvironmentLinkPort{{FromPort: 80}}}
+	if err := environmentLogic.CreateEnvironmentLink("eid1", different); err == nil {
+		t.Fatal("Error was nil!")
+	}
+
+	testLogic.AssertTagExists(t, models.Tag{EntityID: "eid1", EntityType: "environment", Key: "link:eid2", Value: value})
+}
+
+func TestCreateEnvironmentLink_invalidPorts(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	environmentLogic := NewL0EnvironmentLogic(testLogic.Logic())
+
+	cases := map[string]models.CreateEnvironmentLinkRequest{
+		"self":            {EnvironmentID: "eid1"},
+		"protocol":        {EnvironmentID: "eid2", Ports: []models.EnvironmentLinkPort{{Protocol: "icmp", FromPort: 1}}},
+		"zero port":       {EnvironmentID: "eid2", Ports: []models.EnvironmentLinkPort{{Protocol: "tcp"}}},
+		"port too large":  {EnvironmentID: "eid2", Ports: []models.EnvironmentLinkPort{{FromPort: 80, ToPort: 70000}}},
+		"range backwards": {EnvironmentID: "eid2", Ports: []models.EnvironmentLinkPort{{FromPort: 90, ToPort: 80}}},
+	}
+
+	for name, req := range cases {
+		if err := environmentLogic.CreateEnvironmentLink("eid1", req); err == nil {
+			t.Errorf("%s: error was unexpectedly nil", name)
+		}
+	}
 }
 
 func TestDeleteEnvironmentLink(t *testing.T) {
@@ -270,7 +474,7 @@ func TestDeleteEnvironmentLink(t *testing.T) {
 	defer ctrl.Finish()
 
 	testLogic.Backend.EXPECT().
-		DeleteEnvironmentLink("eid1", "eid2").
+		DeleteEnvironmentLink(models.EnvironmentLink{SourceEnvironmentID: "eid1", DestEnvironmentID: "eid2"}).
 		Return(nil)
 
 	testLogic.AddTags(t, []*models.Tag{
@@ -291,3 +495,160 @@ func TestDeleteEnvironmentLink(t *testing.T) {
 	// make sure the 'extra' tag is the only one left
 	testutils.AssertEqual(t, len(tags), 1)
 }
+
+func TestDeleteEnvironmentLink_storedLink(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	// the link was created from eid2 to eid1, so its rules must be revoked in that direction
+	testLogic.Backend.EXPECT().
+		DeleteEnvironmentLink(models.EnvironmentLink{
+			SourceEnvironmentID: "eid2",
+			DestEnvironmentID:   "eid1",
+			Ports:               []models.EnvironmentLinkPort{{Protocol: "tcp", FromPort: 5432, ToPort: 5432}},
+			OneWay:              true,
+		}).
+		Return(nil)
+
+	value := `{"source_environment_id":"eid2","dest_environment_id":"eid1","ports":[{"protocol":"tcp","from_port":5432,"to_port":5432}],"one_way":true}`
+	testLogic.AddTags(t, []*models.Tag{
+		{EntityID: "eid1", EntityType: "environment", Key: "link", Value: "eid2"},
+		{EntityID: "eid1", EntityType: "environment", Key: "link:eid2", Value: value},
+		{EntityID: "eid2", EntityType: "environment", Key: "link", Value: "eid1"},
+		{EntityID: "eid2", EntityType: "environment", Key: "link:eid1", Value: value},
+		{EntityID: "extra", EntityType: "environment", Key: "name", Value: "extra"},
+	})
+
+	environmentLogic := NewL0EnvironmentLogic(testLogic.Logic())
+	if err := environmentLogic.DeleteEnvironmentLink("eid1", "eid2"); err != nil {
+		t.Fatal(err)
+	}
+
+	tags, err := testLogic.TagStore.SelectByType("environment")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, len(tags), 1)
+}
+
+func TestGetEnvironmentCost(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	testLogic.Backend.EXPECT().
+		GetEnvironment("e1").
+		Return(&models.Environment{EnvironmentID: "e1", InstanceSize: "m5.large", ClusterCount: 2}, nil)
+
+	testLogic.Backend.EXPECT().
+		GetEnvironmentServices("e1").
+		Return([]*models.Service{
+			{
+				ServiceID: "s1",
+				Deployments: []models.Deployment{
+					{DeployID: "d1", DesiredCount: 2},
+				},
+			},
+		}, nil)
+
+	testLogic.Backend.EXPECT().
+		GetDeploy("d1").
+		Return(&models.Deploy{Dockerrun: []byte(`{"containerDefinitions":[{"name":"c1","memory":1024}]}`)}, nil)
+
+	testLogic.AddTags(t, []*models.Tag{
+		{EntityID: "e1", EntityType: "environment", Key: "name", Value: "env"},
+		{EntityID: "l1", EntityType: "load_balancer", Key: "environment_id", Value: "e1"},
+		{EntityID: "l2", EntityType: "load_balancer", Key: "environment_id", Value: "e2"},
+		{EntityID: "s1", EntityType: "service", Key: "name", Value: "svc"},
+		{EntityID: "s1", EntityType: "service", Key: "load_balancer_id", Value: "l1"},
+	})
+
+	environmentLogic := NewL0EnvironmentLogic(testLogic.Logic())
+	environmentLogic.PriceTable = &pricing.StaticPriceTable{
+		Instances:       map[string]float64{"m5.large": 0.1},
+		LoadBalancer:    0.02,
+		DataTransferOut: 0.1,
+	}
+
+	cost, err := environmentLogic.GetEnvironmentCost("e1", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &models.EnvironmentCost{
+		EnvironmentID:           "e1",
+		EnvironmentName:         "env",
+		Currency:                "USD",
+		InstanceSize:            "m5.large",
+		InstanceCount:           2,
+		InstanceMonthlyCost:     146,
+		LoadBalancerCount:       1,
+		LoadBalancerMonthlyCost: 14.6,
+		DataTransferGB:          100,
+		DataTransferMonthlyCost: 10,
+		UnallocatedMonthlyCost:  127.75,
+		TotalMonthlyCost:        170.6,
+		Services: []models.ServiceCost{
+			{
+				ServiceID:               "s1",
+				ServiceName:             "svc",
+				MemoryMiB:               2048,
+				MemoryShare:             0.125,
+				InstanceMonthlyCost:     18.25,
+				LoadBalancerMonthlyCost: 14.6,
+				TotalMonthlyCost:        32.85,
+			},
+		},
+		Warnings: []string{},
+	}
+
+	testutils.AssertEqual(t, cost, expected)
+}
+
+func TestGetEnvironmentCostOtherRegion(t *testing.T) {
+	os.Setenv(config.AWS_REGION, "eu-west-1")
+	defer os.Unsetenv(config.AWS_REGION)
+
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	testLogic.Backend.EXPECT().
+		GetEnvironment("e1").
+		Return(&models.Environment{EnvironmentID: "e1"}, nil)
+
+	testLogic.Backend.EXPECT().
+		GetEnvironmentServices("e1").
+		Return([]*models.Service{}, nil)
+
+	environmentLogic := NewL0EnvironmentLogic(testLogic.Logic())
+	environmentLogic.PriceTable = &pricing.StaticPriceTable{Region: "us-east-1"}
+
+	cost, err := environmentLogic.GetEnvironmentCost("e1", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, len(cost.Warnings), 1)
+}
+
+func TestGetEnvironmentCostUnknownInstanceType(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	testLogic.Backend.EXPECT().
+		GetEnvironment("e1").
+		Return(&models.Environment{EnvironmentID: "e1", InstanceSize: "x9.huge", ClusterCount: 1}, nil)
+
+	testLogic.Backend.EXPECT().
+		GetEnvironmentServices("e1").
+		Return([]*models.Service{}, nil)
+
+	environmentLogic := NewL0EnvironmentLogic(testLogic.Logic())
+	cost, err := environmentLogic.GetEnvironmentCost("e1", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, cost.InstanceMonthlyCost, 0.0)
+	testutils.AssertEqual(t, len(cost.Warnings), 1)
+}
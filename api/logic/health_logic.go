@@ -1,13 +1,248 @@
 package logic
 
-type HealthLogic interface{}
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/quintilesims/layer0/common/aws/sts"
+	"github.com/quintilesims/layer0/common/errors"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/waitutils"
+)
+
+const (
+	HEALTH_OK        = "ok"
+	HEALTH_DEGRADED  = "degraded"
+	HEALTH_UNHEALTHY = "unhealthy"
+	HEALTH_ERROR     = "error"
+	HEALTH_STALE     = "stale"
+)
+
+// a background loop is considered stale if it hasn't run in this many intervals
+const staleLoopIntervals = 2
+
+const (
+	// dependency checks are cached so frequent health requests don't hit aws on every call
+	DEFAULT_HEALTH_CACHE_DURATION = time.Second * 30
+	DEFAULT_HEALTH_CHECK_TIMEOUT  = time.Second * 5
+)
+
+type HealthLogic interface {
+	GetHealth() *models.APIHealth
+}
 
 type L0HealthLogic struct {
 	Logic
+	STS           sts.Provider
+	Clock         waitutils.Clock
+	CacheDuration time.Duration
+	CheckTimeout  time.Duration
+	mutex         sync.Mutex
+	checkedAt     time.Time
+	checks        []models.ComponentHealth
 }
 
-func NewL0HealthLogic(l Logic) *L0HealthLogic {
+func NewL0HealthLogic(l Logic, stsProvider sts.Provider) *L0HealthLogic {
 	return &L0HealthLogic{
-		Logic: l,
+		Logic:         l,
+		STS:           stsProvider,
+		Clock:         waitutils.RealClock{},
+		CacheDuration: DEFAULT_HEALTH_CACHE_DURATION,
+		CheckTimeout:  DEFAULT_HEALTH_CHECK_TIMEOUT,
+	}
+}
+
+func (this *L0HealthLogic) GetHealth() *models.APIHealth {
+	health := &models.APIHealth{
+		Status:     HEALTH_OK,
+		Components: []models.ComponentHealth{},
+	}
+
+	for _, component := range this.dependencyHealth() {
+		if component.Status != HEALTH_OK {
+			health.Status = HEALTH_UNHEALTHY
+		}
+
+		health.Components = append(health.Components, component)
+	}
+
+	for _, component := range this.loopHealth() {
+		if component.Status != HEALTH_OK && health.Status == HEALTH_OK {
+			health.Status = HEALTH_DEGRADED
+		}
+
+		health.Components = append(health.Components, component)
+	}
+
+	return health
+}
+
+// dependencyHealth returns the result of the critical dependency checks,
+// running them again only once the cached result has expired
+func (this *L0HealthLogic) dependencyHealth() []models.ComponentHealth {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	if this.checks == nil || this.Clock.Since(this.checkedAt) > this.CacheDuration {
+		this.checks = this.runDependencyChecks()
+		this.checkedAt = this.Clock.Now()
+	}
+
+	components := make([]models.ComponentHealth, len(this.checks))
+	copy(components, this.checks)
+	return components
+}
+
+func (this *L0HealthLogic) runDependencyChecks() []models.ComponentHealth {
+	checks := []struct {
+		Name  string
+		Check func() error
+	}{
+		{Name: "tag_store", Check: this.checkTagStore},
+		{Name: "job_store", Check: this.checkJobStore},
+		{Name: "aws_credentials", Check: this.checkAWSCredentials},
+		{Name: "ecs", Check: this.checkECS},
+	}
+
+	// the checks run concurrently so a slow dependency only delays the response by the timeout
+	components := make([]models.ComponentHealth, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, name string, check func() error) {
+			defer wg.Done()
+
+			components[i] = models.ComponentHealth{
+				Name:     name,
+				Status:   HEALTH_OK,
+				Critical: true,
+			}
+
+			if err := this.runWithTimeout(check); err != nil {
+				components[i].Status = HEALTH_ERROR
+				components[i].Message = err.Error()
+			}
+		}(i, check.Name, check.Check)
+	}
+
+	wg.Wait()
+	return components
+}
+
+func (this *L0HealthLogic) runWithTimeout(check func() error) error {
+	// the channel is buffered so the check can finish after a timeout without blocking
+	errc := make(chan error, 1)
+	go func() {
+		errc <- check()
+	}()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-time.After(this.CheckTimeout):
+		return fmt.Errorf("Check did not complete within %v", this.CheckTimeout)
+	}
+}
+
+func (this *L0HealthLogic) checkTagStore() error {
+	_, err := this.TagStore.SelectByType("health")
+	return err
+}
+
+func (this *L0HealthLogic) checkJobStore() error {
+	if _, err := this.JobStore.SelectByID("health"); err != nil {
+		// the job doesn't exist, but we were able to reach the table
+		if err, ok := err.(*errors.ServerError); ok && err.Code == errors.JobDoesNotExist {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (this *L0HealthLogic) checkAWSCredentials() error {
+	_, err := this.STS.GetCallerIdentity()
+	return err
+}
+
+func (this *L0HealthLogic) checkECS() error {
+	_, err := this.Backend.ListEnvironments()
+	return err
+}
+
+func (this *L0HealthLogic) loopHealth() []models.ComponentHealth {
+	backgroundLoops.mutex.Lock()
+	defer backgroundLoops.mutex.Unlock()
+
+	components := []models.ComponentHealth{}
+	for name, loop := range backgroundLoops.loops {
+		component := models.ComponentHealth{
+			Name:   name,
+			Status: HEALTH_OK,
+		}
+
+		lastSeen := loop.Registered
+		if !loop.LastRun.IsZero() {
+			lastRun := loop.LastRun
+			component.LastRun = &lastRun
+			lastSeen = lastRun
+		}
+
+		switch {
+		case this.Clock.Since(lastSeen) > loop.Interval*staleLoopIntervals:
+			component.Status = HEALTH_STALE
+			component.Message = fmt.Sprintf("Loop has not run in over %v", loop.Interval*staleLoopIntervals)
+		case loop.Err != nil:
+			component.Status = HEALTH_ERROR
+			component.Message = loop.Err.Error()
+		}
+
+		components = append(components, component)
+	}
+
+	sort.Slice(components, func(i, j int) bool {
+		return components[i].Name < components[j].Name
+	})
+
+	return components
+}
+
+type backgroundLoop struct {
+	Interval   time.Duration
+	Registered time.Time
+	LastRun    time.Time
+	Err        error
+}
+
+var backgroundLoops = struct {
+	loops map[string]*backgroundLoop
+	mutex sync.Mutex
+}{
+	loops: map[string]*backgroundLoop{},
+}
+
+// RegisterBackgroundLoop adds a loop that is expected to call RecordBackgroundLoopRun every interval
+func RegisterBackgroundLoop(name string, interval time.Duration) {
+	backgroundLoops.mutex.Lock()
+	defer backgroundLoops.mutex.Unlock()
+
+	backgroundLoops.loops[name] = &backgroundLoop{
+		Interval:   interval,
+		Registered: time.Now(),
+	}
+}
+
+// RecordBackgroundLoopRun records the completion of a background loop's iteration for health checks
+func RecordBackgroundLoopRun(name string, err error) {
+	backgroundLoops.mutex.Lock()
+	defer backgroundLoops.mutex.Unlock()
+
+	if loop, ok := backgroundLoops.loops[name]; ok {
+		loop.LastRun = time.Now()
+		loop.Err = err
 	}
 }
@@ -0,0 +1,181 @@
+package logic
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/quintilesims/layer0/api/backend/ecs/id"
+	"github.com/quintilesims/layer0/common/aws/sts/mock_sts"
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func resetBackgroundLoops() {
+	backgroundLoops.mutex.Lock()
+	defer backgroundLoops.mutex.Unlock()
+
+	backgroundLoops.loops = map[string]*backgroundLoop{}
+}
+
+func TestGetHealth(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+	resetBackgroundLoops()
+
+	stsMock := mock_sts.NewMockProvider(ctrl)
+	stsMock.EXPECT().
+		GetCallerIdentity().
+		Return(nil, nil)
+
+	testLogic.Backend.EXPECT().
+		ListEnvironments().
+		Return([]id.ECSEnvironmentID{}, nil)
+
+	RegisterBackgroundLoop("scaler", time.Minute)
+	RecordBackgroundLoopRun("scaler", nil)
+
+	healthLogic := NewL0HealthLogic(testLogic.Logic(), stsMock)
+	health := healthLogic.GetHealth()
+
+	testutils.AssertEqual(t, health.Status, HEALTH_OK)
+	testutils.AssertEqual(t, len(health.Components), 5)
+
+	for _, component := range health.Components {
+		testutils.AssertEqual(t, component.Status, HEALTH_OK)
+	}
+
+	scaler := health.Components[4]
+	testutils.AssertEqual(t, scaler.Name, "scaler")
+	testutils.AssertEqual(t, scaler.Critical, false)
+	if scaler.LastRun == nil {
+		t.Fatal("LastRun was not set")
+	}
+}
+
+func TestGetHealthCriticalError(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+	resetBackgroundLoops()
+
+	stsMock := mock_sts.NewMockProvider(ctrl)
+	stsMock.EXPECT().
+		GetCallerIdentity().
+		Return(nil, fmt.Errorf("expired token"))
+
+	testLogic.Backend.EXPECT().
+		ListEnvironments().
+		Return([]id.ECSEnvironmentID{}, nil)
+
+	healthLogic := NewL0HealthLogic(testLogic.Logic(), stsMock)
+	health := healthLogic.GetHealth()
+
+	testutils.AssertEqual(t, health.Status, HEALTH_UNHEALTHY)
+
+	credentials := health.Components[2]
+	testutils.AssertEqual(t, credentials.Name, "aws_credentials")
+	testutils.AssertEqual(t, credentials.Status, HEALTH_ERROR)
+	testutils.AssertEqual(t, credentials.Critical, true)
+	testutils.AssertEqual(t, credentials.Message, "expired token")
+}
+
+func TestGetHealthDegraded(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+	resetBackgroundLoops()
+
+	stsMock := mock_sts.NewMockProvider(ctrl)
+	stsMock.EXPECT().
+		GetCallerIdentity().
+		Return(nil, nil)
+
+	testLogic.Backend.EXPECT().
+		ListEnvironments().
+		Return([]id.ECSEnvironmentID{}, nil)
+
+	RegisterBackgroundLoop("janitor", time.Minute)
+	RegisterBackgroundLoop("scaler", time.Hour)
+	RecordBackgroundLoopRun("scaler", fmt.Errorf("some error"))
+
+	healthLogic := NewL0HealthLogic(testLogic.Logic(), stsMock)
+	healthLogic.Clock = &testutils.StubClock{Time: time.Now().Add(time.Minute * 5)}
+	health := healthLogic.GetHealth()
+
+	testutils.AssertEqual(t, health.Status, HEALTH_DEGRADED)
+
+	janitor := health.Components[4]
+	testutils.AssertEqual(t, janitor.Name, "janitor")
+	testutils.AssertEqual(t, janitor.Status, HEALTH_STALE)
+
+	scaler := health.Components[5]
+	testutils.AssertEqual(t, scaler.Name, "scaler")
+	testutils.AssertEqual(t, scaler.Status, HEALTH_ERROR)
+	testutils.AssertEqual(t, scaler.Message, "some error")
+}
+
+func TestGetHealthCachesDependencyChecks(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+	resetBackgroundLoops()
+
+	// each dependency should only be checked once while the result is cached
+	stsMock := mock_sts.NewMockProvider(ctrl)
+	stsMock.EXPECT().
+		GetCallerIdentity().
+		Return(nil, nil)
+
+	testLogic.Backend.EXPECT().
+		ListEnvironments().
+		Return([]id.ECSEnvironmentID{}, nil)
+
+	healthLogic := NewL0HealthLogic(testLogic.Logic(), stsMock)
+	healthLogic.Clock = &testutils.StubClock{}
+
+	for i := 0; i < 3; i++ {
+		health := healthLogic.GetHealth()
+		testutils.AssertEqual(t, health.Status, HEALTH_OK)
+		testutils.AssertEqual(t, len(health.Components), 4)
+	}
+
+	stsMock.EXPECT().
+		GetCallerIdentity().
+		Return(nil, fmt.Errorf("expired token"))
+
+	testLogic.Backend.EXPECT().
+		ListEnvironments().
+		Return([]id.ECSEnvironmentID{}, nil)
+
+	healthLogic.Clock.Sleep(DEFAULT_HEALTH_CACHE_DURATION)
+	health := healthLogic.GetHealth()
+
+	testutils.AssertEqual(t, health.Status, HEALTH_UNHEALTHY)
+}
+
+func TestGetHealthCheckTimeout(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+	resetBackgroundLoops()
+
+	done := make(chan struct{})
+	defer close(done)
+
+	stsMock := mock_sts.NewMockProvider(ctrl)
+	stsMock.EXPECT().
+		GetCallerIdentity().
+		Do(func() { <-done }).
+		Return(nil, nil)
+
+	testLogic.Backend.EXPECT().
+		ListEnvironments().
+		Return([]id.ECSEnvironmentID{}, nil)
+
+	healthLogic := NewL0HealthLogic(testLogic.Logic(), stsMock)
+	healthLogic.CheckTimeout = time.Millisecond * 10
+	health := healthLogic.GetHealth()
+
+	testutils.AssertEqual(t, health.Status, HEALTH_UNHEALTHY)
+
+	credentials := health.Components[2]
+	testutils.AssertEqual(t, credentials.Name, "aws_credentials")
+	testutils.AssertEqual(t, credentials.Status, HEALTH_ERROR)
+	testutils.AssertEqual(t, credentials.Message, "Check did not complete within 10ms")
+}
@@ -28,10 +28,13 @@ func NewJobJanitor(jobLogic JobLogic) *JobJanitor {
 }
 
 func (this *JobJanitor) Run() {
+	RegisterBackgroundLoop("job_janitor", JANITOR_SLEEP_DURATION)
+
 	go func() {
 		for {
 			jobLogger.Info("Starting cleanup")
-			this.pulse()
+			err := this.pulse()
+			RecordBackgroundLoopRun("job_janitor", err)
 			jobLogger.Infof("Finished cleanup")
 			this.Clock.Sleep(JANITOR_SLEEP_DURATION)
 		}
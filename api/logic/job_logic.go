@@ -17,8 +17,11 @@ import (
 
 type JobLogic interface {
 	ListJobs() ([]*models.Job, error)
+	ListJobsPage(limit int, nextToken string) ([]*models.Job, string, error)
 	GetJob(string) (*models.Job, error)
 	CreateJob(types.JobType, interface{}) (*models.Job, error)
+	CancelJob(string) error
+	ResumeJob(string, string) error
 	Delete(string) error
 }
 
@@ -45,6 +48,14 @@ func (this *L0JobLogic) ListJobs() ([]*models.Job, error) {
 	return jobs, nil
 }
 
+func (this *L0JobLogic) ListJobsPage(limit int, nextToken string) ([]*models.Job, string, error) {
+	if err := validateLimit(limit); err != nil {
+		return nil, "", err
+	}
+
+	return this.JobStore.SelectPage(limit, nextToken)
+}
+
 func (this *L0JobLogic) GetJob(jobID string) (*models.Job, error) {
 	job, err := this.JobStore.SelectByID(jobID)
 	if err != nil {
@@ -54,6 +65,116 @@ func (this *L0JobLogic) GetJob(jobID string) (*models.Job, error) {
 	return job, nil
 }
 
+// CancelJob marks an unfinished job as Cancelled.
+// The job's runner stops running steps once it sees the new status.
+func (this *L0JobLogic) CancelJob(jobID string) error {
+	cancelled, err := this.JobStore.UpdateUnfinishedJobStatus(jobID, types.Cancelled)
+	if err != nil {
+		return err
+	}
+
+	if !cancelled {
+		job, err := this.GetJob(jobID)
+		if err != nil {
+			return err
+		}
+
+		status := types.JobStatus(job.JobStatus)
+		return errors.Newf(errors.InvalidRequest, "Cannot cancel job '%s' because its status is '%s'", jobID, status)
+	}
+
+	return nil
+}
+
+// ResumeJob launches a new runner for an unfinished job whose runner task, taskID, has stopped.
+// The new runner continues from the job's checkpoint. If the stopped runner was part way
+// through a step that cannot be resumed, or the runner has been launched
+// config.JobMaxAttempts() times, the job is marked as Error instead.
+// Nothing is done if another caller has already replaced the stopped runner.
+func (this *L0JobLogic) ResumeJob(jobID, taskID string) error {
+	job, err := this.GetJob(jobID)
+	if err != nil {
+		return err
+	}
+
+	if !isUnfinished(job) {
+		status := types.JobStatus(job.JobStatus)
+		return errors.Newf(errors.InvalidRequest, "Cannot resume job '%s' because its status is '%s'", jobID, status)
+	}
+
+	if job.TaskID != taskID {
+		return nil
+	}
+
+	// every api instance runs a JobResumer; only the one that claims the stopped runner
+	// decides what happens to the job, so a stale caller can't fail a job that was resumed
+	claimed, err := this.JobStore.IncrementJobAttempts(jobID, taskID)
+	if err != nil {
+		return err
+	}
+
+	if !claimed {
+		return nil
+	}
+
+	if step, ok := interruptedStep(job); ok && !step.Resumable {
+		if _, err := this.JobStore.UpdateUnfinishedJobStatus(jobID, types.Error); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("Job '%s' was marked as failed because its runner stopped during step '%s', which cannot be resumed", jobID, step.Name)
+	}
+
+	if job.Attempts >= int64(config.JobMaxAttempts()) {
+		if _, err := this.JobStore.UpdateUnfinishedJobStatus(jobID, types.Error); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("Job '%s' was marked as failed after its runner stopped %d times", jobID, job.Attempts)
+	}
+
+	newTaskID, err := this.launchJobRunner(jobID)
+	if err != nil {
+		// the job has no runner now, so it would never finish
+		if _, err := this.JobStore.UpdateUnfinishedJobStatus(jobID, types.Error); err != nil {
+			return err
+		}
+
+		return err
+	}
+
+	if err := this.JobStore.SetJobTask(jobID, newTaskID); err != nil {
+		return err
+	}
+
+	if err := this.TagStore.Delete("job", jobID, "task_id"); err != nil {
+		return err
+	}
+
+	return this.TagStore.Insert(models.Tag{EntityID: jobID, EntityType: "job", Key: "task_id", Value: newTaskID})
+}
+
+func (this *L0JobLogic) launchJobRunner(jobID string) (string, error) {
+	deploy, err := this.createJobDeploy(jobID)
+	if err != nil {
+		return "", err
+	}
+
+	return this.createJobTask(jobID, deploy.DeployID)
+}
+
+// interruptedStep returns the step a resumed runner would start from,
+// if the stopped runner had already started it.
+func interruptedStep(job *models.Job) (models.JobStep, bool) {
+	checkpoint := int(job.Checkpoint)
+	if checkpoint < 0 || checkpoint >= len(job.Steps) {
+		return models.JobStep{}, false
+	}
+
+	step := job.Steps[checkpoint]
+	return step, !step.TimeStarted.IsZero()
+}
+
 func (this *L0JobLogic) Delete(jobID string) error {
 	job, err := this.GetJob(jobID)
 	if err != nil {
@@ -122,6 +243,7 @@ func (this *L0JobLogic) CreateJob(jobType types.JobType, request interface{}) (*
 		Request:     reqStr,
 		TimeCreated: time.Now(),
 		TimeToExist: time.Now().Add(time.Hour * time.Duration(jobTTLHours)).Unix(),
+		Attempts:    1,
 	}
 
 	if err := this.JobStore.Insert(job); err != nil {
@@ -132,18 +254,59 @@ func (this *L0JobLogic) CreateJob(jobType types.JobType, request interface{}) (*
 		return nil, err
 	}
 
-	if jobType == types.CreateTaskJob {
+	switch jobType {
+	case types.CreateTaskJob:
 		req, ok := request.(models.CreateTaskRequest)
 		if !ok {
 			return nil, fmt.Errorf("Unexpected request type for 'CreateTask' job type!")
 		}
 
 		this.Logic.Scaler.ScheduleRun(req.EnvironmentID, time.Second*10)
+	case types.CreateServiceJob:
+		req, ok := request.(models.CreateServiceRequest)
+		if !ok {
+			return nil, fmt.Errorf("Unexpected request type for 'CreateService' job type!")
+		}
+
+		this.Logic.Scaler.ScheduleRun(req.EnvironmentID, serviceJobScaleDelay)
+	case types.UpdateServiceJob:
+		req, ok := request.(models.UpdateServiceJobRequest)
+		if !ok {
+			return nil, fmt.Errorf("Unexpected request type for 'UpdateService' job type!")
+		}
+
+		environmentID, err := this.getServiceEnvironmentID(req.ServiceID)
+		if err != nil {
+			return nil, err
+		}
+
+		if environmentID != "" {
+			this.Logic.Scaler.ScheduleRun(environmentID, serviceJobScaleDelay)
+		}
 	}
 
 	return job, nil
 }
 
+// the runner exits once the service has been created or updated, so the scaler
+// run that ServiceLogic schedules in-process never fires; schedule it here instead,
+// late enough for the runner task to have started and applied the change
+const serviceJobScaleDelay = time.Minute
+
+func (this *L0JobLogic) getServiceEnvironmentID(serviceID string) (string, error) {
+	tags, err := this.TagStore.SelectByTypeAndID("service", serviceID)
+	if err != nil {
+		return "", err
+	}
+
+	if tag, ok := tags.WithKey("environment_id").First(); ok {
+		return tag.Value, nil
+	}
+
+	// the periodic scaler run will pick up services without an environment tag
+	return "", nil
+}
+
 func (this *L0JobLogic) createJobTask(jobID, deployID string) (string, error) {
 	taskRequest := models.CreateTaskRequest{
 		DeployID:      deployID,
@@ -211,10 +374,79 @@ func (this *L0JobLogic) createJobDeploy(jobID string) (*models.Deploy, error) {
 				Key: config.AWS_PRIVATE_SUBNETS,
 				Val: config.AWSPrivateSubnets(),
 			},
+			{
+				Key: config.AWS_ROUTE53_HOSTED_ZONE,
+				Val: config.AWSRoute53HostedZoneID(),
+			},
 			{
 				Key: config.RUNNER_LOG_LEVEL,
 				Val: config.RunnerLogLevel(),
 			},
+			// jobs create environments, load balancers and services with the same backend as the api
+			{
+				Key: config.AWS_ACCOUNT_ID,
+				Val: config.AWSAccountID(),
+			},
+			{
+				Key: config.AWS_ECS_ROLE,
+				Val: config.AWSECSRole(),
+			},
+			{
+				Key: config.AWS_SSH_KEY_PAIR,
+				Val: config.AWSKeyPair(),
+			},
+			{
+				Key: config.AWS_S3_BUCKET,
+				Val: config.AWSS3Bucket(),
+			},
+			{
+				Key: config.AWS_ECS_INSTANCE_PROFILE,
+				Val: config.AWSECSInstanceProfile(),
+			},
+			{
+				Key: config.AWS_LINUX_SERVICE_AMI,
+				Val: config.AWSLinuxServiceAMI(),
+			},
+			{
+				Key: config.AWS_WINDOWS_SERVICE_AMI,
+				Val: config.AWSWindowsServiceAMI(),
+			},
+			{
+				Key: config.LOG_ROUTER_IMAGE,
+				Val: config.LogRouterImage(),
+			},
+			{
+				Key: config.AWS_TIME_BETWEEN_REQUESTS,
+				Val: config.AWSTimeBetweenRequests(),
+			},
+			{
+				Key: config.AWS_RATE_LIMITS,
+				Val: config.AWSRateLimits(),
+			},
+			{
+				Key: config.AWS_RETRY_MAX_ATTEMPTS,
+				Val: config.AWSRetryMaxAttempts(),
+			},
+			{
+				Key: config.AWS_RETRY_BASE_DELAY,
+				Val: config.AWSRetryBaseDelay(),
+			},
+			{
+				Key: config.AWS_RETRY_MAX_DELAY,
+				Val: config.AWSRetryMaxDelay(),
+			},
+			{
+				Key: config.AWS_RETRY_MAX_ELAPSED,
+				Val: config.AWSRetryMaxElapsedTime(),
+			},
+			{
+				Key: config.AWS_RETRY_MULTIPLIER,
+				Val: config.AWSRetryMultiplier(),
+			},
+			{
+				Key: config.AWS_RETRY_JITTER,
+				Val: config.AWSRetryJitter(),
+			},
 		},
 	}
 
@@ -1,11 +1,15 @@
 package logic
 
 import (
+	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/quintilesims/layer0/api/backend/ecs/id"
 	"github.com/quintilesims/layer0/api/logic/mock_logic"
+	"github.com/quintilesims/layer0/common/config"
 	"github.com/quintilesims/layer0/common/models"
 	"github.com/quintilesims/layer0/common/testutils"
 	"github.com/quintilesims/layer0/common/types"
@@ -48,6 +52,177 @@ func TestJobSelectAll(t *testing.T) {
 	testutils.AssertEqual(t, jobs[1].JobID, "j2")
 }
 
+func TestCancelJob(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	testLogic.AddJobs(t, []*models.Job{
+		{JobID: "j1", JobStatus: int64(types.InProgress)},
+		{JobID: "j2", JobStatus: int64(types.Completed)},
+	})
+
+	jobLogic := NewL0JobLogic(testLogic.Logic(), nil, nil)
+	if err := jobLogic.CancelJob("j1"); err != nil {
+		t.Fatal(err)
+	}
+
+	job, err := testLogic.JobStore.SelectByID("j1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, types.JobStatus(job.JobStatus), types.Cancelled)
+
+	// finished jobs cannot be cancelled
+	if err := jobLogic.CancelJob("j2"); err == nil {
+		t.Fatal("Error was nil!")
+	}
+}
+
+func TestResumeJob(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	taskLogic := mock_logic.NewMockTaskLogic(ctrl)
+	deployLogic := mock_logic.NewMockDeployLogic(ctrl)
+	defer ctrl.Finish()
+
+	testLogic.AddJobs(t, []*models.Job{
+		{
+			JobID:     "j1",
+			TaskID:    "t1",
+			JobStatus: int64(types.InProgress),
+			Attempts:  1,
+			Steps:     []models.JobStep{{Name: "Delete Environment", TimeStarted: time.Now(), Resumable: true}},
+		},
+	})
+
+	testLogic.AddTags(t, []*models.Tag{
+		{EntityID: "j1", EntityType: "job", Key: "task_id", Value: "t1"},
+	})
+
+	deployLogic.EXPECT().
+		CreateDeploy(gomock.Any()).
+		Return(&models.Deploy{DeployID: "d1"}, nil)
+
+	taskLogic.EXPECT().
+		CreateTask(gomock.Any()).
+		Return("t2", nil)
+
+	jobLogic := NewL0JobLogic(testLogic.Logic(), taskLogic, deployLogic)
+	if err := jobLogic.ResumeJob("j1", "t1"); err != nil {
+		t.Fatal(err)
+	}
+
+	job, err := testLogic.JobStore.SelectByID("j1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, job.TaskID, "t2")
+	testutils.AssertEqual(t, job.Attempts, int64(2))
+	testLogic.AssertTagExists(t, models.Tag{EntityID: "j1", EntityType: "job", Key: "task_id", Value: "t2"})
+}
+
+func TestResumeJob_maxAttempts(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	testLogic.AddJobs(t, []*models.Job{
+		{JobID: "j1", TaskID: "t1", JobStatus: int64(types.InProgress), Attempts: int64(config.JobMaxAttempts())},
+	})
+
+	jobLogic := NewL0JobLogic(testLogic.Logic(), nil, nil)
+	if err := jobLogic.ResumeJob("j1", "t1"); err == nil {
+		t.Fatal("Error was nil!")
+	}
+
+	job, err := testLogic.JobStore.SelectByID("j1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, types.JobStatus(job.JobStatus), types.Error)
+}
+
+func TestResumeJob_alreadyResumed(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	// another api instance already replaced the stopped runner t1
+	testLogic.AddJobs(t, []*models.Job{
+		{JobID: "j1", TaskID: "t2", JobStatus: int64(types.InProgress), Attempts: 2},
+	})
+
+	jobLogic := NewL0JobLogic(testLogic.Logic(), nil, nil)
+	if err := jobLogic.ResumeJob("j1", "t1"); err != nil {
+		t.Fatal(err)
+	}
+
+	job, err := testLogic.JobStore.SelectByID("j1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, job.TaskID, "t2")
+	testutils.AssertEqual(t, job.Attempts, int64(2))
+}
+
+func TestResumeJob_stepNotResumable(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	testLogic.AddJobs(t, []*models.Job{
+		{
+			JobID:     "j1",
+			TaskID:    "t1",
+			JobStatus: int64(types.InProgress),
+			Attempts:  1,
+			Steps:     []models.JobStep{{Name: "Create Environment", TimeStarted: time.Now()}},
+		},
+	})
+
+	jobLogic := NewL0JobLogic(testLogic.Logic(), nil, nil)
+	if err := jobLogic.ResumeJob("j1", "t1"); err == nil {
+		t.Fatal("Error was nil!")
+	}
+
+	job, err := testLogic.JobStore.SelectByID("j1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, types.JobStatus(job.JobStatus), types.Error)
+}
+
+func TestResumeJob_stepNotResumableAlreadyResumed(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	// another api instance already replaced the stopped runner t1 with t2
+	testLogic.AddJobs(t, []*models.Job{
+		{
+			JobID:     "j1",
+			TaskID:    "t2",
+			JobStatus: int64(types.InProgress),
+			Attempts:  2,
+			Steps:     []models.JobStep{{Name: "Create Environment", TimeStarted: time.Now()}},
+		},
+	})
+
+	jobLogic := NewL0JobLogic(testLogic.Logic(), nil, nil)
+	if err := jobLogic.ResumeJob("j1", "t1"); err != nil {
+		t.Fatal(err)
+	}
+
+	job, err := testLogic.JobStore.SelectByID("j1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, types.JobStatus(job.JobStatus), types.InProgress)
+	testutils.AssertEqual(t, job.TaskID, "t2")
+	testutils.AssertEqual(t, job.Attempts, int64(2))
+}
+
 func TestJobDelete(t *testing.T) {
 	testLogic, ctrl := NewTestLogic(t)
 	taskLogic := mock_logic.NewMockTaskLogic(ctrl)
@@ -121,3 +296,66 @@ func TestCreateJob(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestCreateJob_passesBackendConfig(t *testing.T) {
+	os.Setenv(config.AWS_LINUX_SERVICE_AMI, "ami-123")
+	defer os.Unsetenv(config.AWS_LINUX_SERVICE_AMI)
+
+	testLogic, ctrl := NewTestLogic(t)
+	taskLogic := mock_logic.NewMockTaskLogic(ctrl)
+	deployLogic := mock_logic.NewMockDeployLogic(ctrl)
+	defer ctrl.Finish()
+
+	deployLogic.EXPECT().
+		CreateDeploy(gomock.Any()).
+		Do(func(req models.CreateDeployRequest) {
+			dockerrun := string(req.Dockerrun)
+			for _, key := range []string{config.AWS_S3_BUCKET, config.AWS_ECS_INSTANCE_PROFILE, config.LOG_ROUTER_IMAGE} {
+				if !strings.Contains(dockerrun, key) {
+					t.Errorf("Dockerrun is missing variable %s", key)
+				}
+			}
+
+			if !strings.Contains(dockerrun, "ami-123") {
+				t.Errorf("Dockerrun is missing the linux service ami")
+			}
+		}).
+		Return(&models.Deploy{DeployID: "d1"}, nil)
+
+	taskLogic.EXPECT().
+		CreateTask(gomock.Any()).
+		Return("t1", nil)
+
+	jobLogic := NewL0JobLogic(testLogic.Logic(), taskLogic, deployLogic)
+	if _, err := jobLogic.CreateJob(types.CreateEnvironmentJob, models.CreateEnvironmentRequest{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCreateJob_updateServiceSchedulesScaler(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	taskLogic := mock_logic.NewMockTaskLogic(ctrl)
+	deployLogic := mock_logic.NewMockDeployLogic(ctrl)
+	defer ctrl.Finish()
+
+	testLogic.AddTags(t, []*models.Tag{
+		{EntityID: "s1", EntityType: "service", Key: "environment_id", Value: "e1"},
+	})
+
+	deployLogic.EXPECT().
+		CreateDeploy(gomock.Any()).
+		Return(&models.Deploy{DeployID: "d1"}, nil)
+
+	taskLogic.EXPECT().
+		CreateTask(gomock.Any()).
+		Return("t1", nil)
+
+	testLogic.Scaler.EXPECT().
+		ScheduleRun("e1", serviceJobScaleDelay)
+
+	jobLogic := NewL0JobLogic(testLogic.Logic(), taskLogic, deployLogic)
+	request := models.UpdateServiceJobRequest{ServiceID: "s1", DeployID: "d2"}
+	if _, err := jobLogic.CreateJob(types.UpdateServiceJob, request); err != nil {
+		t.Fatal(err)
+	}
+}
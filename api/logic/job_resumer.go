@@ -0,0 +1,148 @@
+package logic
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/quintilesims/layer0/common/errors"
+	"github.com/quintilesims/layer0/common/logutils"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/types"
+	"github.com/quintilesims/layer0/common/waitutils"
+)
+
+const (
+	RESUMER_SLEEP_DURATION = time.Minute * 5
+)
+
+var resumerLogger = logutils.NewStackTraceLogger("Job Resumer")
+
+// the resumer already lists every job, so the job gauges are set there instead of on each scrape
+var jobCount = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "layer0_jobs",
+		Help: "Number of jobs by status as of the last job resumer run",
+	},
+	[]string{"status"})
+
+func init() {
+	prometheus.MustRegister(jobCount)
+}
+
+// JobResumer relaunches the runners of unfinished jobs whose runner tasks have stopped,
+// e.g. because their instance was terminated or they ran out of memory.
+type JobResumer struct {
+	jobLogic  JobLogic
+	taskLogic TaskLogic
+	Clock     waitutils.Clock
+}
+
+func NewJobResumer(jobLogic JobLogic, taskLogic TaskLogic) *JobResumer {
+	return &JobResumer{
+		jobLogic:  jobLogic,
+		taskLogic: taskLogic,
+		Clock:     waitutils.RealClock{},
+	}
+}
+
+func (this *JobResumer) Run() {
+	RegisterBackgroundLoop("job_resumer", RESUMER_SLEEP_DURATION)
+
+	go func() {
+		for {
+			resumerLogger.Info("Checking for stopped job runners")
+			err := this.pulse()
+			RecordBackgroundLoopRun("job_resumer", err)
+			resumerLogger.Infof("Finished checking for stopped job runners")
+			this.Clock.Sleep(RESUMER_SLEEP_DURATION)
+		}
+	}()
+}
+
+func (this *JobResumer) pulse() error {
+	jobs, err := this.jobLogic.ListJobs()
+	if err != nil {
+		resumerLogger.Errorf("Failed to list jobs: %v", err)
+		return err
+	}
+
+	setJobCount(jobs)
+
+	errs := []error{}
+	for _, job := range jobs {
+		// a job without a task is having its runner launched
+		if !isUnfinished(job) || job.TaskID == "" {
+			continue
+		}
+
+		stopped, err := this.runnerStopped(job.TaskID)
+		if err != nil {
+			resumerLogger.Errorf("Failed to check the runner of job '%s': %v", job.JobID, err)
+			errs = append(errs, err)
+			continue
+		}
+
+		if !stopped {
+			continue
+		}
+
+		// the runner may have finished the job after the jobs were listed
+		stoppedTaskID := job.TaskID
+		job, err := this.jobLogic.GetJob(job.JobID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if !isUnfinished(job) {
+			continue
+		}
+
+		resumerLogger.Infof("Resuming job '%s' from step %d", job.JobID, job.Checkpoint)
+		if err := this.jobLogic.ResumeJob(job.JobID, stoppedTaskID); err != nil {
+			resumerLogger.Errorf("Failed to resume job '%s': %v", job.JobID, err)
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.MultiError(errs)
+}
+
+func (this *JobResumer) runnerStopped(taskID string) (bool, error) {
+	task, err := this.taskLogic.GetTask(taskID)
+	if err != nil {
+		if err, ok := err.(*errors.ServerError); ok {
+			if err.Code == errors.InvalidTaskID || err.Code == errors.TaskDoesNotExist {
+				return true, nil
+			}
+		}
+
+		return false, err
+	}
+
+	return task.RunningCount+task.PendingCount == 0, nil
+}
+
+func setJobCount(jobs []*models.Job) {
+	counts := map[types.JobStatus]int{
+		types.Pending:    0,
+		types.InProgress: 0,
+		types.Completed:  0,
+		types.Error:      0,
+		types.Cancelled:  0,
+	}
+
+	for _, job := range jobs {
+		counts[types.JobStatus(job.JobStatus)]++
+	}
+
+	jobCount.Reset()
+	for status, count := range counts {
+		jobCount.WithLabelValues(status.String()).Set(float64(count))
+	}
+}
+
+func isUnfinished(job *models.Job) bool {
+	status := types.JobStatus(job.JobStatus)
+	return status == types.Pending || status == types.InProgress
+}
@@ -0,0 +1,120 @@
+package logic
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/quintilesims/layer0/api/logic/mock_logic"
+	"github.com/quintilesims/layer0/common/errors"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/types"
+)
+
+func TestJobResumerPulse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jobLogicMock := mock_logic.NewMockJobLogic(ctrl)
+	taskLogicMock := mock_logic.NewMockTaskLogic(ctrl)
+
+	jobs := []*models.Job{
+		{JobID: "running_job", TaskID: "t1", JobStatus: int64(types.InProgress)},
+		{JobID: "stopped_job", TaskID: "t2", JobStatus: int64(types.InProgress)},
+		{JobID: "deleted_job", TaskID: "t3", JobStatus: int64(types.Pending)},
+		{JobID: "finished_job", TaskID: "t4", JobStatus: int64(types.InProgress)},
+		{JobID: "completed_job", TaskID: "t5", JobStatus: int64(types.Completed)},
+		{JobID: "launching_job", JobStatus: int64(types.InProgress)},
+	}
+
+	jobLogicMock.EXPECT().
+		ListJobs().
+		Return(jobs, nil)
+
+	taskLogicMock.EXPECT().
+		GetTask("t1").
+		Return(&models.Task{RunningCount: 1}, nil)
+
+	taskLogicMock.EXPECT().
+		GetTask("t2").
+		Return(&models.Task{}, nil)
+
+	taskLogicMock.EXPECT().
+		GetTask("t3").
+		Return(nil, errors.Newf(errors.TaskDoesNotExist, "some error"))
+
+	taskLogicMock.EXPECT().
+		GetTask("t4").
+		Return(&models.Task{}, nil)
+
+	jobLogicMock.EXPECT().
+		GetJob("stopped_job").
+		Return(jobs[1], nil)
+
+	jobLogicMock.EXPECT().
+		GetJob("deleted_job").
+		Return(jobs[2], nil)
+
+	// the runner marked this job as completed before it stopped
+	jobLogicMock.EXPECT().
+		GetJob("finished_job").
+		Return(&models.Job{JobID: "finished_job", JobStatus: int64(types.Completed)}, nil)
+
+	jobLogicMock.EXPECT().
+		ResumeJob("stopped_job", "t2").
+		Return(nil)
+
+	jobLogicMock.EXPECT().
+		ResumeJob("deleted_job", "t3").
+		Return(nil)
+
+	resumer := NewJobResumer(jobLogicMock, taskLogicMock)
+	if err := resumer.pulse(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestJobResumerPulse_jobMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jobLogicMock := mock_logic.NewMockJobLogic(ctrl)
+	taskLogicMock := mock_logic.NewMockTaskLogic(ctrl)
+
+	jobs := []*models.Job{
+		{JobID: "j1", JobStatus: int64(types.Pending)},
+		{JobID: "j2", JobStatus: int64(types.Completed)},
+		{JobID: "j3", JobStatus: int64(types.Completed)},
+	}
+
+	jobLogicMock.EXPECT().
+		ListJobs().
+		Return(jobs, nil)
+
+	resumer := NewJobResumer(jobLogicMock, taskLogicMock)
+	if err := resumer.pulse(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the api serves the default registry on /metrics
+	recorder := httptest.NewRecorder()
+	promhttp.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body := recorder.Body.String()
+
+	expected := []string{
+		"# TYPE layer0_jobs gauge",
+		`layer0_jobs{status="pending"} 1`,
+		`layer0_jobs{status="in progress"} 0`,
+		`layer0_jobs{status="completed"} 2`,
+		`layer0_jobs{status="error"} 0`,
+		`layer0_jobs{status="cancelled"} 0`,
+	}
+
+	for _, line := range expected {
+		if !strings.Contains(body, line) {
+			t.Errorf("Metrics output does not contain '%s'", line)
+		}
+	}
+}
@@ -2,13 +2,18 @@ package logic
 
 import (
 	"fmt"
+	"net"
+	"strings"
+	"time"
 
+	"github.com/quintilesims/layer0/common/config"
 	"github.com/quintilesims/layer0/common/errors"
 	"github.com/quintilesims/layer0/common/models"
 )
 
 type LoadBalancerLogic interface {
 	ListLoadBalancers() ([]*models.LoadBalancerSummary, error)
+	ListLoadBalancersPage(limit int, nextToken string) ([]*models.LoadBalancerSummary, string, error)
 	GetLoadBalancer(loadBalancerID string) (*models.LoadBalancer, error)
 	DeleteLoadBalancer(loadBalancerID string) error
 	CreateLoadBalancer(req models.CreateLoadBalancerRequest) (*models.LoadBalancer, error)
@@ -16,6 +21,8 @@ type LoadBalancerLogic interface {
 	UpdateLoadBalancerHealthCheck(loadBalancerID string, healthCheck models.HealthCheck) (*models.LoadBalancer, error)
 	UpdateLoadBalancerIdleTimeout(loadBalancerID string, idleTimeout int) (*models.LoadBalancer, error)
 	UpdateLoadBalancerCrossZone(loadBalancerID string, crossZone bool) (*models.LoadBalancer, error)
+	UpdateLoadBalancerAccessLog(loadBalancerID string, accessLog models.AccessLog) (*models.LoadBalancer, error)
+	GetLoadBalancerMetrics(loadBalancerID string, startTime, endTime time.Time, period int) (*models.LoadBalancerMetrics, error)
 }
 
 type L0LoadBalancerLogic struct {
@@ -29,15 +36,32 @@ func NewL0LoadBalancerLogic(logic Logic) *L0LoadBalancerLogic {
 }
 
 func (l *L0LoadBalancerLogic) ListLoadBalancers() ([]*models.LoadBalancerSummary, error) {
+	summaries, _, err := l.ListLoadBalancersPage(0, "")
+	return summaries, err
+}
+
+func (l *L0LoadBalancerLogic) ListLoadBalancersPage(limit int, nextToken string) ([]*models.LoadBalancerSummary, string, error) {
 	loadBalancers, err := l.Backend.ListLoadBalancers()
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	summaries := make([]*models.LoadBalancerSummary, len(loadBalancers))
+	loadBalancerIDs := make([]string, len(loadBalancers))
 	for i, loadBalancer := range loadBalancers {
+		loadBalancerIDs[i] = loadBalancer.LoadBalancerID
+	}
+
+	// only the load balancers in the page are populated from the tag store
+	page, nextToken, err := pageIDs(loadBalancerIDs, limit, nextToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	summaries := make([]*models.LoadBalancerSummary, len(page))
+	for i, loadBalancerID := range page {
+		loadBalancer := &models.LoadBalancer{LoadBalancerID: loadBalancerID}
 		if err := l.populateModel(loadBalancer); err != nil {
-			return nil, err
+			return nil, "", err
 		}
 
 		summaries[i] = &models.LoadBalancerSummary{
@@ -48,7 +72,7 @@ func (l *L0LoadBalancerLogic) ListLoadBalancers() ([]*models.LoadBalancerSummary
 		}
 	}
 
-	return summaries, nil
+	return summaries, nextToken, nil
 }
 
 func (l *L0LoadBalancerLogic) GetLoadBalancer(loadBalancerID string) (*models.LoadBalancer, error) {
@@ -65,6 +89,18 @@ func (l *L0LoadBalancerLogic) GetLoadBalancer(loadBalancerID string) (*models.Lo
 }
 
 func (l *L0LoadBalancerLogic) DeleteLoadBalancer(loadBalancerID string) error {
+	tags, err := l.TagStore.SelectByTypeAndID("load_balancer", loadBalancerID)
+	if err != nil {
+		return err
+	}
+
+	// the dns record is deleted first since it aliases the load balancer
+	if tag, ok := tags.WithKey("dns_name").First(); ok {
+		if err := l.Backend.DeleteLoadBalancerDNSRecord(loadBalancerID, tag.Value); err != nil {
+			return err
+		}
+	}
+
 	if err := l.Backend.DeleteLoadBalancer(loadBalancerID); err != nil {
 		return err
 	}
@@ -85,6 +121,14 @@ func (l *L0LoadBalancerLogic) CreateLoadBalancer(req models.CreateLoadBalancerRe
 		return nil, errors.Newf(errors.MissingParameter, "LoadBalancerName not specified")
 	}
 
+	if err := validatePortSources(req.Ports); err != nil {
+		return nil, err
+	}
+
+	if req.DNSName != "" && config.AWSRoute53HostedZoneID() == "" {
+		return nil, errors.Newf(errors.InvalidRequest, "Cannot use DNSName '%s': no Route53 hosted zone has been configured", req.DNSName)
+	}
+
 	exists, err := l.doesLoadBalancerTagExist(req.EnvironmentID, req.LoadBalancerName)
 	if err != nil {
 		return nil, err
@@ -95,6 +139,17 @@ func (l *L0LoadBalancerLogic) CreateLoadBalancer(req models.CreateLoadBalancerRe
 		return nil, errors.New(errors.InvalidLoadBalancerName, err)
 	}
 
+	if req.DNSName != "" {
+		loadBalancerID, err := l.getLoadBalancerIDByDNSName(req.DNSName)
+		if err != nil {
+			return nil, err
+		}
+
+		if loadBalancerID != "" {
+			return nil, errors.Newf(errors.InvalidRequest, "DNSName '%s' is already used by LoadBalancer '%s'", req.DNSName, loadBalancerID)
+		}
+	}
+
 	loadBalancer, err := l.Backend.CreateLoadBalancer(
 		req.LoadBalancerName,
 		req.EnvironmentID,
@@ -119,6 +174,16 @@ func (l *L0LoadBalancerLogic) CreateLoadBalancer(req models.CreateLoadBalancerRe
 		return loadBalancer, err
 	}
 
+	if req.DNSName != "" {
+		if err := l.Backend.CreateLoadBalancerDNSRecord(loadBalancerID, req.DNSName); err != nil {
+			return loadBalancer, err
+		}
+
+		if err := l.TagStore.Insert(models.Tag{EntityID: loadBalancerID, EntityType: "load_balancer", Key: "dns_name", Value: req.DNSName}); err != nil {
+			return loadBalancer, err
+		}
+	}
+
 	if err := l.populateModel(loadBalancer); err != nil {
 		return loadBalancer, err
 	}
@@ -127,6 +192,10 @@ func (l *L0LoadBalancerLogic) CreateLoadBalancer(req models.CreateLoadBalancerRe
 }
 
 func (l *L0LoadBalancerLogic) UpdateLoadBalancerPorts(loadBalancerID string, ports []models.Port) (*models.LoadBalancer, error) {
+	if err := validatePortSources(ports); err != nil {
+		return nil, err
+	}
+
 	loadBalancer, err := l.Backend.UpdateLoadBalancerPorts(loadBalancerID, ports)
 	if err != nil {
 		return nil, err
@@ -178,6 +247,77 @@ func (l *L0LoadBalancerLogic) UpdateLoadBalancerCrossZone(loadBalancerID string,
 	return loadBalancer, nil
 }
 
+func (l *L0LoadBalancerLogic) UpdateLoadBalancerAccessLog(loadBalancerID string, accessLog models.AccessLog) (*models.LoadBalancer, error) {
+	if accessLog.Enabled {
+		if accessLog.BucketName == "" {
+			return nil, errors.Newf(errors.MissingParameter, "BucketName not specified")
+		}
+
+		if accessLog.EmitInterval == 0 {
+			accessLog.EmitInterval = 60
+		}
+
+		// elb only supports publishing access logs every 5 or 60 minutes
+		if accessLog.EmitInterval != 5 && accessLog.EmitInterval != 60 {
+			return nil, errors.Newf(errors.InvalidRequest, "EmitInterval must be 5 or 60 minutes")
+		}
+	}
+
+	loadBalancer, err := l.Backend.UpdateLoadBalancerAccessLog(loadBalancerID, accessLog)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.populateModel(loadBalancer); err != nil {
+		return nil, err
+	}
+
+	return loadBalancer, nil
+}
+
+func (l *L0LoadBalancerLogic) GetLoadBalancerMetrics(loadBalancerID string, startTime, endTime time.Time, period int) (*models.LoadBalancerMetrics, error) {
+	if endTime.IsZero() {
+		endTime = time.Now().UTC()
+	}
+
+	if startTime.IsZero() {
+		startTime = endTime.Add(-time.Hour)
+	}
+
+	if period == 0 {
+		period = 60
+	}
+
+	if !startTime.Before(endTime) {
+		return nil, errors.Newf(errors.InvalidRequest, "Start time must be before end time")
+	}
+
+	// cloudwatch requires periods to be a multiple of 60 seconds
+	if period < 60 || period%60 != 0 {
+		return nil, errors.Newf(errors.InvalidRequest, "Period must be a multiple of 60 seconds")
+	}
+
+	return l.Backend.GetLoadBalancerMetrics(loadBalancerID, startTime, endTime, period)
+}
+
+func validatePortSources(ports []models.Port) error {
+	for _, port := range ports {
+		for _, cidr := range port.SourceCIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return errors.Newf(errors.InvalidRequest, "Port %d: '%s' is not a valid CIDR block", port.HostPort, cidr)
+			}
+		}
+
+		for _, groupID := range port.SourceSecurityGroups {
+			if !strings.HasPrefix(groupID, "sg-") {
+				return errors.Newf(errors.InvalidRequest, "Port %d: '%s' is not a valid security group id", port.HostPort, groupID)
+			}
+		}
+	}
+
+	return nil
+}
+
 func (l *L0LoadBalancerLogic) doesLoadBalancerTagExist(environmentID, name string) (bool, error) {
 	tags, err := l.TagStore.SelectByType("load_balancer")
 	if err != nil {
@@ -193,6 +333,27 @@ func (l *L0LoadBalancerLogic) doesLoadBalancerTagExist(environmentID, name strin
 	return len(ewts) > 0, nil
 }
 
+// getLoadBalancerIDByDNSName returns the id of the load balancer tagged with the specified dns name,
+// or an empty string if no load balancer uses it. DNS names are compared case-insensitively.
+func (l *L0LoadBalancerLogic) getLoadBalancerIDByDNSName(dnsName string) (string, error) {
+	tags, err := l.TagStore.SelectByType("load_balancer")
+	if err != nil {
+		return "", err
+	}
+
+	for _, tag := range tags.WithKey("dns_name") {
+		if normalizeDNSName(tag.Value) == normalizeDNSName(dnsName) {
+			return tag.EntityID, nil
+		}
+	}
+
+	return "", nil
+}
+
+func normalizeDNSName(dnsName string) string {
+	return strings.TrimSuffix(strings.ToLower(dnsName), ".")
+}
+
 func (l *L0LoadBalancerLogic) populateModel(model *models.LoadBalancer) error {
 	tags, err := l.TagStore.SelectByTypeAndID("load_balancer", model.LoadBalancerID)
 	if err != nil {
@@ -207,6 +368,12 @@ func (l *L0LoadBalancerLogic) populateModel(model *models.LoadBalancer) error {
 		model.LoadBalancerName = tag.Value
 	}
 
+	if tag, ok := tags.WithKey("dns_name").First(); ok {
+		model.DNSName = tag.Value
+	}
+
+	model.Tags = tags.UserDefined()
+
 	if model.EnvironmentID != "" {
 		tags, err := l.TagStore.SelectByTypeAndID("environment", model.EnvironmentID)
 		if err != nil {
@@ -1,7 +1,12 @@
 package logic
 
 import (
+	"os"
 	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/quintilesims/layer0/common/config"
 
 	"github.com/quintilesims/layer0/common/models"
 	"github.com/quintilesims/layer0/common/testutils"
@@ -109,6 +114,30 @@ func TestDeleteLoadBalancer(t *testing.T) {
 	testutils.AssertEqual(t, len(tags), 1)
 }
 
+func TestDeleteLoadBalancer_dnsName(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		testLogic.Backend.EXPECT().
+			DeleteLoadBalancerDNSRecord("l1", "api.example.com").
+			Return(nil),
+		testLogic.Backend.EXPECT().
+			DeleteLoadBalancer("l1").
+			Return(nil),
+	)
+
+	testLogic.AddTags(t, []*models.Tag{
+		{EntityID: "l1", EntityType: "load_balancer", Key: "name", Value: "lb"},
+		{EntityID: "l1", EntityType: "load_balancer", Key: "dns_name", Value: "api.example.com"},
+	})
+
+	loadBalancerLogic := NewL0LoadBalancerLogic(testLogic.Logic())
+	if err := loadBalancerLogic.DeleteLoadBalancer("l1"); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestCreateLoadBalancer(t *testing.T) {
 	testLogic, ctrl := NewTestLogic(t)
 	defer ctrl.Finish()
@@ -165,6 +194,78 @@ func TestCreateLoadBalancer(t *testing.T) {
 	testLogic.AssertTagExists(t, models.Tag{EntityID: "l1", EntityType: "load_balancer", Key: "environment_id", Value: "e1"})
 }
 
+func TestCreateLoadBalancer_dnsName(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	os.Setenv(config.AWS_ROUTE53_HOSTED_ZONE, "ZONEID")
+	defer os.Unsetenv(config.AWS_ROUTE53_HOSTED_ZONE)
+
+	testLogic.Backend.EXPECT().
+		CreateLoadBalancer("name", "e1", false, nil, models.HealthCheck{}, 0, false).
+		Return(&models.LoadBalancer{LoadBalancerID: "l1", EnvironmentID: "e1"}, nil)
+
+	testLogic.Backend.EXPECT().
+		CreateLoadBalancerDNSRecord("l1", "api.example.com").
+		Return(nil)
+
+	request := models.CreateLoadBalancerRequest{
+		LoadBalancerName: "name",
+		EnvironmentID:    "e1",
+		DNSName:          "api.example.com",
+	}
+
+	loadBalancerLogic := NewL0LoadBalancerLogic(testLogic.Logic())
+	received, err := loadBalancerLogic.CreateLoadBalancer(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, received.DNSName, "api.example.com")
+	testLogic.AssertTagExists(t, models.Tag{EntityID: "l1", EntityType: "load_balancer", Key: "dns_name", Value: "api.example.com"})
+}
+
+func TestCreateLoadBalancerError_missingHostedZone(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	os.Unsetenv(config.AWS_ROUTE53_HOSTED_ZONE)
+
+	request := models.CreateLoadBalancerRequest{
+		LoadBalancerName: "name",
+		EnvironmentID:    "e1",
+		DNSName:          "api.example.com",
+	}
+
+	loadBalancerLogic := NewL0LoadBalancerLogic(testLogic.Logic())
+	if _, err := loadBalancerLogic.CreateLoadBalancer(request); err == nil {
+		t.Errorf("Error was nil!")
+	}
+}
+
+func TestCreateLoadBalancerError_dnsNameInUse(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	os.Setenv(config.AWS_ROUTE53_HOSTED_ZONE, "ZONEID")
+	defer os.Unsetenv(config.AWS_ROUTE53_HOSTED_ZONE)
+
+	testLogic.AddTags(t, []*models.Tag{
+		{EntityID: "l1", EntityType: "load_balancer", Key: "dns_name", Value: "API.example.com."},
+	})
+
+	request := models.CreateLoadBalancerRequest{
+		LoadBalancerName: "name",
+		EnvironmentID:    "e1",
+		DNSName:          "api.example.com",
+	}
+
+	loadBalancerLogic := NewL0LoadBalancerLogic(testLogic.Logic())
+	if _, err := loadBalancerLogic.CreateLoadBalancer(request); err == nil {
+		t.Errorf("Error was nil!")
+	}
+}
+
 func TestCreateLoadBalancerError_missingRequiredParams(t *testing.T) {
 	testLogic, ctrl := NewTestLogic(t)
 	defer ctrl.Finish()
@@ -187,6 +288,36 @@ func TestCreateLoadBalancerError_missingRequiredParams(t *testing.T) {
 	}
 }
 
+func TestCreateLoadBalancerError_invalidPortSources(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	loadBalancerLogic := NewL0LoadBalancerLogic(testLogic.Logic())
+
+	cases := map[string]models.Port{
+		"Invalid SourceCIDR": {
+			HostPort:    80,
+			SourceCIDRs: []string{"10.0.0.0"},
+		},
+		"Invalid SourceSecurityGroup": {
+			HostPort:             80,
+			SourceSecurityGroups: []string{"my-group"},
+		},
+	}
+
+	for name, port := range cases {
+		request := models.CreateLoadBalancerRequest{
+			EnvironmentID:    "e1",
+			LoadBalancerName: "name",
+			Ports:            []models.Port{port},
+		}
+
+		if _, err := loadBalancerLogic.CreateLoadBalancer(request); err == nil {
+			t.Errorf("Case %s: error was nil!", name)
+		}
+	}
+}
+
 func TestCreateLoadBalancerError_duplicateName(t *testing.T) {
 	testLogic, ctrl := NewTestLogic(t)
 	defer ctrl.Finish()
@@ -305,3 +436,103 @@ func TestUpdateLoadBalancerCrossZone(t *testing.T) {
 
 	testutils.AssertEqual(t, received.CrossZone, crossZone)
 }
+
+func TestUpdateLoadBalancerAccessLog(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	accessLog := models.AccessLog{
+		Enabled:      true,
+		BucketName:   "bucket",
+		EmitInterval: 60,
+	}
+
+	testLogic.Backend.EXPECT().
+		UpdateLoadBalancerAccessLog("lb_id", accessLog).
+		Return(&models.LoadBalancer{AccessLog: accessLog}, nil)
+
+	loadBalancerLogic := NewL0LoadBalancerLogic(testLogic.Logic())
+	received, err := loadBalancerLogic.UpdateLoadBalancerAccessLog("lb_id", models.AccessLog{Enabled: true, BucketName: "bucket"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, received.AccessLog, accessLog)
+}
+
+func TestUpdateLoadBalancerAccessLogError_invalidParams(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	loadBalancerLogic := NewL0LoadBalancerLogic(testLogic.Logic())
+
+	cases := map[string]models.AccessLog{
+		"Missing BucketName": {
+			Enabled:      true,
+			EmitInterval: 5,
+		},
+		"Invalid EmitInterval": {
+			Enabled:      true,
+			BucketName:   "bucket",
+			EmitInterval: 10,
+		},
+	}
+
+	for name, accessLog := range cases {
+		if _, err := loadBalancerLogic.UpdateLoadBalancerAccessLog("lb_id", accessLog); err == nil {
+			t.Errorf("Case %s: error was nil!", name)
+		}
+	}
+}
+
+func TestGetLoadBalancerMetrics(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	startTime := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(time.Hour)
+
+	testLogic.Backend.EXPECT().
+		GetLoadBalancerMetrics("lb_id", startTime, endTime, 300).
+		Return(&models.LoadBalancerMetrics{LoadBalancerID: "lb_id"}, nil)
+
+	loadBalancerLogic := NewL0LoadBalancerLogic(testLogic.Logic())
+	if _, err := loadBalancerLogic.GetLoadBalancerMetrics("lb_id", startTime, endTime, 300); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetLoadBalancerMetrics_defaults(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	testLogic.Backend.EXPECT().
+		GetLoadBalancerMetrics("lb_id", gomock.Any(), gomock.Any(), 60).
+		Do(func(loadBalancerID string, startTime, endTime time.Time, period int) {
+			testutils.AssertEqual(t, endTime.Sub(startTime), time.Hour)
+		}).
+		Return(&models.LoadBalancerMetrics{LoadBalancerID: "lb_id"}, nil)
+
+	loadBalancerLogic := NewL0LoadBalancerLogic(testLogic.Logic())
+	if _, err := loadBalancerLogic.GetLoadBalancerMetrics("lb_id", time.Time{}, time.Time{}, 0); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetLoadBalancerMetricsError_invalidParams(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	startTime := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	endTime := startTime.Add(time.Hour)
+
+	loadBalancerLogic := NewL0LoadBalancerLogic(testLogic.Logic())
+
+	if _, err := loadBalancerLogic.GetLoadBalancerMetrics("lb_id", endTime, startTime, 60); err == nil {
+		t.Errorf("Error was nil for start time after end time!")
+	}
+
+	if _, err := loadBalancerLogic.GetLoadBalancerMetrics("lb_id", startTime, endTime, 90); err == nil {
+		t.Errorf("Error was nil for invalid period!")
+	}
+}
@@ -0,0 +1,387 @@
+package logic
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsecs "github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/quintilesims/layer0/api/backend/ecs/id"
+	"github.com/quintilesims/layer0/common/aws/ecs"
+	"github.com/quintilesims/layer0/common/config"
+	"github.com/quintilesims/layer0/common/errors"
+	"github.com/quintilesims/layer0/common/models"
+)
+
+const (
+	LOG_SINK_SYSLOG = "syslog"
+	LOG_SINK_HTTP   = "http"
+	LOG_SINK_S3     = "s3"
+)
+
+const (
+	logRouterContainerName = "l0-log-router"
+	logRouterConfigEnvVar  = "L0_LOG_SINKS_CONFIG"
+	logRouterConfigPath    = "/fluent-bit/etc/l0-log-sinks.conf"
+	logRouterMemory        = 50
+)
+
+// FireLens tags each record with '<container>-firelens-<task id>'
+const logSinkMatch = "*-firelens-*"
+
+// a deploy's tag with this prefix and a hash of a rendered revision holds the id of that revision
+const logSinkDeployTagPrefix = "log_sink_deploy:"
+
+// a rendered revision is tagged with the id of the deploy it was rendered from instead of a name and
+// version, so it isn't listed or resolved as the latest version of its family
+const logSinkSourceTagKey = "log_sink_source"
+
+func logSinkTagValue(sink models.LogSink) string {
+	return fmt.Sprintf("%s=%s", sink.Type, sink.Address)
+}
+
+func parseLogSinkTagValue(value string) (models.LogSink, bool) {
+	split := strings.SplitN(value, "=", 2)
+	if len(split) != 2 {
+		return models.LogSink{}, false
+	}
+
+	return models.LogSink{Type: split[0], Address: split[1]}, true
+}
+
+func validateLogSink(sink models.LogSink) error {
+	var buffer bytes.Buffer
+	return renderLogSinkOutput(&buffer, "", sink)
+}
+
+// renderLogSinkConfig returns the fluent-bit configuration the log router uses
+// to forward container logs to each of the environment's sinks
+func renderLogSinkConfig(environmentID string, sinks []models.LogSink) (string, error) {
+	var buffer bytes.Buffer
+	for _, sink := range sinks {
+		if err := renderLogSinkOutput(&buffer, environmentID, sink); err != nil {
+			return "", err
+		}
+	}
+
+	return buffer.String(), nil
+}
+
+func renderLogSinkOutput(buffer *bytes.Buffer, environmentID string, sink models.LogSink) error {
+	address, err := url.Parse(sink.Address)
+	if err != nil || address.Host == "" {
+		return errors.Newf(errors.InvalidRequest, "Log sink address '%s' is not a valid url", sink.Address)
+	}
+
+	write := func(key, value string) {
+		fmt.Fprintf(buffer, "    %-20s %s\n", key, value)
+	}
+
+	switch sink.Type {
+	case LOG_SINK_SYSLOG:
+		switch address.Scheme {
+		case "tcp", "udp", "tls":
+		default:
+			return errors.Newf(errors.InvalidRequest, "Syslog log sinks must use the tcp://, udp:// or tls:// scheme")
+		}
+
+		if address.Port() == "" {
+			return errors.Newf(errors.InvalidRequest, "Syslog log sinks must specify a port")
+		}
+
+		buffer.WriteString("[OUTPUT]\n")
+		write("Name", "syslog")
+		write("Match", logSinkMatch)
+		write("Host", address.Hostname())
+		write("Port", address.Port())
+		write("Mode", address.Scheme)
+		write("Syslog_Format", "rfc5424")
+		write("Syslog_Appname_Key", "container_name")
+		write("Syslog_Message_Key", "log")
+	case LOG_SINK_HTTP:
+		port := address.Port()
+		switch address.Scheme {
+		case "http":
+			if port == "" {
+				port = "80"
+			}
+		case "https":
+			if port == "" {
+				port = "443"
+			}
+		default:
+			return errors.Newf(errors.InvalidRequest, "HTTP log sinks must use the http:// or https:// scheme")
+		}
+
+		buffer.WriteString("[OUTPUT]\n")
+		write("Name", "http")
+		write("Match", logSinkMatch)
+		write("Host", address.Hostname())
+		write("Port", port)
+		write("URI", address.RequestURI())
+		write("Format", "json_lines")
+		if address.Scheme == "https" {
+			write("tls", "On")
+		}
+	case LOG_SINK_S3:
+		if address.Scheme != "s3" {
+			return errors.Newf(errors.InvalidRequest, "S3 log sinks must use the s3:// scheme")
+		}
+
+		prefix := strings.Trim(address.Path, "/")
+		if prefix != "" {
+			prefix = "/" + prefix
+		}
+
+		buffer.WriteString("[OUTPUT]\n")
+		write("Name", "s3")
+		write("Match", logSinkMatch)
+		write("bucket", address.Host)
+		write("region", config.AWSRegion())
+		write("s3_key_format", fmt.Sprintf("%s/%s/$TAG/%%Y/%%m/%%d/%%H-%%M-%%S", prefix, environmentID))
+	default:
+		return errors.Newf(errors.InvalidRequest, "Log sink type '%s' is not supported, must be one of: %s, %s, %s",
+			sink.Type, LOG_SINK_SYSLOG, LOG_SINK_HTTP, LOG_SINK_S3)
+	}
+
+	buffer.WriteString("\n")
+	return nil
+}
+
+func isDefaultLogConfiguration(logConfiguration *awsecs.LogConfiguration) bool {
+	if logConfiguration == nil {
+		return true
+	}
+
+	if aws.StringValue(logConfiguration.LogDriver) != awsecs.LogDriverAwslogs {
+		return false
+	}
+
+	group := aws.StringValue(logConfiguration.Options["awslogs-group"])
+	prefix := aws.StringValue(logConfiguration.Options["awslogs-stream-prefix"])
+	return group == config.AWSLogGroupID() && prefix == "l0"
+}
+
+// isLogRouterConfiguration returns true if the log configuration was set by injectLogSinks
+func isLogRouterConfiguration(logConfiguration *awsecs.LogConfiguration) bool {
+	if logConfiguration == nil || aws.StringValue(logConfiguration.LogDriver) != awsecs.LogDriverAwsfirelens {
+		return false
+	}
+
+	return aws.StringValue(logConfiguration.Options["log_group_name"]) == config.AWSLogGroupID()
+}
+
+// injectLogSinks routes each container that uses the default Layer0 log configuration
+// through a fluent-bit log router. The router continues to write to the Layer0 log group
+// using the same stream names as the awslogs driver, and additionally ships to the sinks.
+// A log router that is already in the dockerrun is replaced, since it may have been rendered
+// for another environment's sinks. Returns false if the dockerrun did not need to be changed.
+func injectLogSinks(dockerrun *models.Dockerrun, environmentID string, sinks []models.LogSink) (bool, error) {
+	containers := []*ecs.ContainerDefinition{}
+	for _, container := range dockerrun.ContainerDefinitions {
+		if aws.StringValue(container.Name) != logRouterContainerName {
+			containers = append(containers, container)
+		}
+	}
+
+	dockerrun.ContainerDefinitions = containers
+
+	sinkConfig, err := renderLogSinkConfig(environmentID, sinks)
+	if err != nil {
+		return false, err
+	}
+
+	var injected bool
+	for _, container := range dockerrun.ContainerDefinitions {
+		if !isDefaultLogConfiguration(container.LogConfiguration) && !isLogRouterConfiguration(container.LogConfiguration) {
+			continue
+		}
+
+		container.LogConfiguration = &awsecs.LogConfiguration{
+			LogDriver: aws.String(awsecs.LogDriverAwsfirelens),
+			Options: map[string]*string{
+				"Name":              aws.String("cloudwatch"),
+				"region":            aws.String(config.AWSRegion()),
+				"log_group_name":    aws.String(config.AWSLogGroupID()),
+				"log_stream_name":   aws.String(fmt.Sprintf("l0/%s/$(ecs_task_id)", aws.StringValue(container.Name))),
+				"auto_create_group": aws.String("false"),
+			},
+		}
+
+		injected = true
+	}
+
+	if !injected {
+		return false, nil
+	}
+
+	command := fmt.Sprintf("printf '%%s' \"$%s\" > %s && exec /fluent-bit/bin/fluent-bit "+
+		"-e /fluent-bit/firehose.so -e /fluent-bit/cloudwatch.so -e /fluent-bit/kinesis.so "+
+		"-c /fluent-bit/etc/fluent-bit.conf", logRouterConfigEnvVar, logRouterConfigPath)
+
+	router := &ecs.ContainerDefinition{
+		&awsecs.ContainerDefinition{
+			Name:              aws.String(logRouterContainerName),
+			Image:             aws.String(config.LogRouterImage()),
+			Essential:         aws.Bool(true),
+			MemoryReservation: aws.Int64(logRouterMemory),
+			EntryPoint:        []*string{aws.String("/bin/sh"), aws.String("-c")},
+			Command:           []*string{aws.String(command)},
+			Environment: []*awsecs.KeyValuePair{
+				{Name: aws.String(logRouterConfigEnvVar), Value: aws.String(sinkConfig)},
+			},
+			FirelensConfiguration: &awsecs.FirelensConfiguration{
+				Type: aws.String(awsecs.FirelensConfigurationTypeFluentbit),
+				Options: map[string]*string{
+					"enable-ecs-log-metadata": aws.String("true"),
+					"config-file-type":        aws.String("file"),
+					"config-file-value":       aws.String(logRouterConfigPath),
+				},
+			},
+			LogConfiguration: &awsecs.LogConfiguration{
+				LogDriver: aws.String(awsecs.LogDriverAwslogs),
+				Options: map[string]*string{
+					"awslogs-group":         aws.String(config.AWSLogGroupID()),
+					"awslogs-region":        aws.String(config.AWSRegion()),
+					"awslogs-stream-prefix": aws.String("l0"),
+				},
+			},
+		},
+	}
+
+	dockerrun.ContainerDefinitions = append(dockerrun.ContainerDefinitions, router)
+	return true, nil
+}
+
+func (this *Logic) getEnvironmentLogSinks(environmentID string) ([]models.LogSink, error) {
+	tags, err := this.TagStore.SelectByTypeAndID("environment", environmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	sinks := []models.LogSink{}
+	for _, tag := range tags.WithKey("log_sink") {
+		if sink, ok := parseLogSinkTagValue(tag.Value); ok {
+			sinks = append(sinks, sink)
+		}
+	}
+
+	return sinks, nil
+}
+
+// applyEnvironmentLogSinks returns the id of the deploy that should be used in the
+// specified environment. If the environment has log sinks, a revision of the deploy
+// with the log router injected into it is used. Revisions are keyed on the deploy and
+// a hash of what was rendered, so a new one is only created when the deploy, sinks or
+// log router change. Rendered revisions are always rendered again from their source deploy.
+func (this *Logic) applyEnvironmentLogSinks(environmentID, deployID string) (string, error) {
+	deployID, err := this.getLogSinkSourceDeployID(deployID)
+	if err != nil {
+		return "", err
+	}
+
+	sinks, err := this.getEnvironmentLogSinks(environmentID)
+	if err != nil {
+		return "", err
+	}
+
+	if len(sinks) == 0 {
+		return deployID, nil
+	}
+
+	deploy, err := this.Backend.GetDeploy(deployID)
+	if err != nil {
+		return "", err
+	}
+
+	var dockerrun models.Dockerrun
+	if err := json.Unmarshal(deploy.Dockerrun, &dockerrun); err != nil {
+		return "", fmt.Errorf("Failed to decode deploy '%s': %s", deployID, err.Error())
+	}
+
+	injected, err := injectLogSinks(&dockerrun, environmentID, sinks)
+	if err != nil {
+		return "", err
+	}
+
+	if !injected {
+		return deployID, nil
+	}
+
+	body, err := json.Marshal(dockerrun)
+	if err != nil {
+		return "", err
+	}
+
+	renderedKey := fmt.Sprintf("%s%x", logSinkDeployTagPrefix, sha256.Sum256(body))
+	renderedDeployID, ok, err := this.getRenderedDeployID(deployID, renderedKey)
+	if err != nil {
+		return "", err
+	}
+
+	if ok {
+		return renderedDeployID, nil
+	}
+
+	deployName := id.L0DeployID(deployID).ECSDeployID().FamilyName()
+	rendered, err := this.Backend.CreateDeploy(deployName, body)
+	if err != nil {
+		return "", err
+	}
+
+	tags := []models.Tag{
+		{EntityID: rendered.DeployID, EntityType: "deploy", Key: logSinkSourceTagKey, Value: deployID},
+		{EntityID: deployID, EntityType: "deploy", Key: renderedKey, Value: rendered.DeployID},
+	}
+
+	for _, tag := range tags {
+		if err := this.TagStore.Insert(tag); err != nil {
+			return "", err
+		}
+	}
+
+	return rendered.DeployID, nil
+}
+
+// getRenderedDeployID returns the id of the revision of deployID that was previously rendered
+// with the log sinks hashed in renderedKey, if that revision still exists
+func (this *Logic) getRenderedDeployID(deployID, renderedKey string) (string, bool, error) {
+	tags, err := this.TagStore.SelectByTypeAndID("deploy", deployID)
+	if err != nil {
+		return "", false, err
+	}
+
+	tag, ok := tags.WithKey(renderedKey).First()
+	if !ok {
+		return "", false, nil
+	}
+
+	if _, err := this.Backend.GetDeploy(tag.Value); err != nil {
+		if err, ok := err.(*errors.ServerError); ok && err.Code == errors.DeployDoesNotExist {
+			return "", false, this.TagStore.Delete("deploy", deployID, renderedKey)
+		}
+
+		return "", false, err
+	}
+
+	return tag.Value, true, nil
+}
+
+// getLogSinkSourceDeployID returns the id of the deploy that deployID was rendered from,
+// or deployID if it isn't a rendered revision
+func (this *Logic) getLogSinkSourceDeployID(deployID string) (string, error) {
+	tags, err := this.TagStore.SelectByTypeAndID("deploy", deployID)
+	if err != nil {
+		return "", err
+	}
+
+	if tag, ok := tags.WithKey(logSinkSourceTagKey).First(); ok {
+		return tag.Value, nil
+	}
+
+	return deployID, nil
+}
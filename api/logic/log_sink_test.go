@@ -0,0 +1,299 @@
+package logic
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsecs "github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/golang/mock/gomock"
+	"github.com/quintilesims/layer0/common/aws/ecs"
+	"github.com/quintilesims/layer0/common/config"
+	"github.com/quintilesims/layer0/common/errors"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func TestRenderLogSinkConfig(t *testing.T) {
+	sinks := []models.LogSink{
+		{Type: "syslog", Address: "udp://logs.example.com:514"},
+		{Type: "http", Address: "https://collector.example.com/ingest?source=l0"},
+		{Type: "s3", Address: "s3://bucket/archive/"},
+	}
+
+	received, err := renderLogSinkConfig("e1", sinks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"Name                 syslog",
+		"Host                 logs.example.com",
+		"Port                 514",
+		"Mode                 udp",
+		"Name                 http",
+		"Host                 collector.example.com",
+		"Port                 443",
+		"URI                  /ingest?source=l0",
+		"tls                  On",
+		"Name                 s3",
+		"bucket               bucket",
+		"s3_key_format        /archive/e1/$TAG/%Y/%m/%d/%H-%M-%S",
+	}
+
+	for _, line := range expected {
+		if !strings.Contains(received, line) {
+			t.Errorf("Config does not contain '%s':\n%s", line, received)
+		}
+	}
+
+	testutils.AssertEqual(t, strings.Count(received, "[OUTPUT]"), 3)
+}
+
+func TestValidateLogSink(t *testing.T) {
+	cases := map[models.LogSink]bool{
+		{Type: "syslog", Address: "tcp://logs:514"}:  true,
+		{Type: "syslog", Address: "tls://logs:6514"}: true,
+		{Type: "syslog", Address: "tcp://logs"}:      false,
+		{Type: "syslog", Address: "http://logs:514"}: false,
+		{Type: "http", Address: "http://logs"}:       true,
+		{Type: "http", Address: "logs:80"}:           false,
+		{Type: "s3", Address: "s3://bucket"}:         true,
+		{Type: "s3", Address: "https://bucket"}:      false,
+		{Type: "kafka", Address: "tcp://logs:9092"}:  false,
+	}
+
+	for sink, valid := range cases {
+		if err := validateLogSink(sink); (err == nil) != valid {
+			t.Errorf("Sink %#v: expected valid=%t, got error: %v", sink, valid, err)
+		}
+	}
+}
+
+func TestInjectLogSinks(t *testing.T) {
+	custom := &awsecs.LogConfiguration{
+		LogDriver: aws.String("syslog"),
+	}
+
+	dockerrun := &models.Dockerrun{
+		ContainerDefinitions: []*ecs.ContainerDefinition{
+			{&awsecs.ContainerDefinition{Name: aws.String("app")}},
+			{&awsecs.ContainerDefinition{Name: aws.String("sidecar"), LogConfiguration: custom}},
+		},
+	}
+
+	sinks := []models.LogSink{{Type: "syslog", Address: "tcp://logs:514"}}
+	injected, err := injectLogSinks(dockerrun, "e1", sinks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, injected, true)
+	testutils.AssertEqual(t, len(dockerrun.ContainerDefinitions), 3)
+
+	app := dockerrun.ContainerDefinitions[0]
+	testutils.AssertEqual(t, *app.LogConfiguration.LogDriver, "awsfirelens")
+	testutils.AssertEqual(t, *app.LogConfiguration.Options["log_group_name"], config.AWSLogGroupID())
+	testutils.AssertEqual(t, *app.LogConfiguration.Options["log_stream_name"], "l0/app/$(ecs_task_id)")
+
+	testutils.AssertEqual(t, dockerrun.ContainerDefinitions[1].LogConfiguration, custom)
+
+	router := dockerrun.ContainerDefinitions[2]
+	testutils.AssertEqual(t, *router.Name, "l0-log-router")
+	testutils.AssertEqual(t, *router.FirelensConfiguration.Type, "fluentbit")
+	testutils.AssertEqual(t, *router.LogConfiguration.LogDriver, "awslogs")
+
+	// injecting into a dockerrun that already has a log router should replace the router
+	sinks = []models.LogSink{{Type: "s3", Address: "s3://bucket"}}
+	injected, err = injectLogSinks(dockerrun, "e2", sinks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, injected, true)
+	testutils.AssertEqual(t, len(dockerrun.ContainerDefinitions), 3)
+	testutils.AssertEqual(t, *dockerrun.ContainerDefinitions[0].LogConfiguration.LogDriver, "awsfirelens")
+
+	router = dockerrun.ContainerDefinitions[2]
+	testutils.AssertEqual(t, *router.Name, "l0-log-router")
+
+	sinkConfig := aws.StringValue(router.Environment[0].Value)
+	if !strings.Contains(sinkConfig, "/e2/$TAG") || strings.Contains(sinkConfig, "logs") {
+		t.Errorf("Log router was not rendered for e2:\n%s", sinkConfig)
+	}
+}
+
+func TestApplyEnvironmentLogSinks(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	testLogic.AddTags(t, []*models.Tag{
+		{EntityID: "e1", EntityType: "environment", Key: "log_sink", Value: "http=http://collector"},
+	})
+
+	dockerrun := models.Dockerrun{
+		ContainerDefinitions: []*ecs.ContainerDefinition{
+			{&awsecs.ContainerDefinition{Name: aws.String("app")}},
+		},
+	}
+
+	body, err := json.Marshal(dockerrun)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testLogic.Backend.EXPECT().
+		GetDeploy("dpl.1").
+		Return(&models.Deploy{DeployID: "dpl.1", Dockerrun: body}, nil)
+
+	testLogic.Backend.EXPECT().
+		CreateDeploy("dpl", gomock.Any()).
+		Do(func(name string, body []byte) {
+			var rendered models.Dockerrun
+			if err := json.Unmarshal(body, &rendered); err != nil {
+				t.Fatal(err)
+			}
+
+			testutils.AssertEqual(t, len(rendered.ContainerDefinitions), 2)
+		}).
+		Return(&models.Deploy{DeployID: "dpl.2", Version: "2"}, nil)
+
+	logic := testLogic.Logic()
+	deployID, err := logic.applyEnvironmentLogSinks("e1", "dpl.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, deployID, "dpl.2")
+	testLogic.AssertTagExists(t, models.Tag{EntityID: "dpl.2", EntityType: "deploy", Key: "log_sink_source", Value: "dpl.1"})
+
+	// the rendered revision should not be resolvable by name or version
+	tags, err := testLogic.TagStore.SelectByTypeAndID("deploy", "dpl.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, len(tags.WithKey("name")), 0)
+	testutils.AssertEqual(t, len(tags.WithKey("version")), 0)
+
+	// applying the same sinks to the same deploy again should reuse the rendered revision
+	testLogic.Backend.EXPECT().
+		GetDeploy("dpl.1").
+		Return(&models.Deploy{DeployID: "dpl.1", Dockerrun: body}, nil)
+
+	testLogic.Backend.EXPECT().
+		GetDeploy("dpl.2").
+		Return(&models.Deploy{DeployID: "dpl.2"}, nil)
+
+	deployID, err = logic.applyEnvironmentLogSinks("e1", "dpl.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, deployID, "dpl.2")
+
+	// environments without log sinks should use the deploy as-is
+	deployID, err = logic.applyEnvironmentLogSinks("e2", "dpl.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, deployID, "dpl.1")
+}
+
+func TestApplyEnvironmentLogSinks_renderedDeploy(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	testLogic.AddTags(t, []*models.Tag{
+		{EntityID: "e2", EntityType: "environment", Key: "log_sink", Value: "http=http://collector"},
+		{EntityID: "dpl.2", EntityType: "deploy", Key: "log_sink_source", Value: "dpl.1"},
+	})
+
+	dockerrun := models.Dockerrun{
+		ContainerDefinitions: []*ecs.ContainerDefinition{
+			{&awsecs.ContainerDefinition{Name: aws.String("app")}},
+		},
+	}
+
+	body, err := json.Marshal(dockerrun)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// deploying a rendered revision should render its source deploy for the target environment
+	testLogic.Backend.EXPECT().
+		GetDeploy("dpl.1").
+		Return(&models.Deploy{DeployID: "dpl.1", Dockerrun: body}, nil)
+
+	testLogic.Backend.EXPECT().
+		CreateDeploy("dpl", gomock.Any()).
+		Return(&models.Deploy{DeployID: "dpl.3", Version: "3"}, nil)
+
+	logic := testLogic.Logic()
+	deployID, err := logic.applyEnvironmentLogSinks("e2", "dpl.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, deployID, "dpl.3")
+	testLogic.AssertTagExists(t, models.Tag{EntityID: "dpl.3", EntityType: "deploy", Key: "log_sink_source", Value: "dpl.1"})
+
+	// environments without log sinks should use the source deploy
+	deployID, err = logic.applyEnvironmentLogSinks("e1", "dpl.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, deployID, "dpl.1")
+}
+
+func TestApplyEnvironmentLogSinks_renderedDeployDeleted(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	dockerrun := models.Dockerrun{
+		ContainerDefinitions: []*ecs.ContainerDefinition{
+			{&awsecs.ContainerDefinition{Name: aws.String("app")}},
+		},
+	}
+
+	body, err := json.Marshal(dockerrun)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testLogic.AddTags(t, []*models.Tag{
+		{EntityID: "e1", EntityType: "environment", Key: "log_sink", Value: "http=http://collector"},
+	})
+
+	testLogic.Backend.EXPECT().
+		GetDeploy("dpl.1").
+		Return(&models.Deploy{DeployID: "dpl.1", Dockerrun: body}, nil).
+		Times(2)
+
+	gomock.InOrder(
+		testLogic.Backend.EXPECT().
+			CreateDeploy("dpl", gomock.Any()).
+			Return(&models.Deploy{DeployID: "dpl.2", Version: "2"}, nil),
+		testLogic.Backend.EXPECT().
+			GetDeploy("dpl.2").
+			Return(nil, errors.Newf(errors.DeployDoesNotExist, "some error")),
+		testLogic.Backend.EXPECT().
+			CreateDeploy("dpl", gomock.Any()).
+			Return(&models.Deploy{DeployID: "dpl.3", Version: "3"}, nil),
+	)
+
+	logic := testLogic.Logic()
+	if _, err := logic.applyEnvironmentLogSinks("e1", "dpl.1"); err != nil {
+		t.Fatal(err)
+	}
+
+	deployID, err := logic.applyEnvironmentLogSinks("e1", "dpl.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, deployID, "dpl.3")
+}
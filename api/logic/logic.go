@@ -5,6 +5,8 @@ import (
 	"github.com/quintilesims/layer0/api/scheduler"
 	"github.com/quintilesims/layer0/common/db/job_store"
 	"github.com/quintilesims/layer0/common/db/tag_store"
+	"github.com/quintilesims/layer0/common/errors"
+	"github.com/quintilesims/layer0/common/paging"
 )
 
 type Logic struct {
@@ -42,3 +44,22 @@ func (this *Logic) deleteEntityTags(entityType, entityID string) error {
 
 	return nil
 }
+
+// validateLimit returns an InvalidRequest error if limit is not a valid page size
+func validateLimit(limit int) error {
+	if err := paging.ValidateLimit(limit); err != nil {
+		return errors.New(errors.InvalidRequest, err)
+	}
+
+	return nil
+}
+
+// pageIDs returns the page of ids specified by limit and nextToken, and the token of the following page
+func pageIDs(ids []string, limit int, nextToken string) ([]string, string, error) {
+	page, nextToken, err := paging.Page(ids, limit, nextToken)
+	if err != nil {
+		return nil, "", errors.New(errors.InvalidRequest, err)
+	}
+
+	return page, nextToken, nil
+}
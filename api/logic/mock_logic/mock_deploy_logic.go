@@ -83,3 +83,17 @@ func (m *MockDeployLogic) ListDeploys() ([]*models.DeploySummary, error) {
 func (mr *MockDeployLogicMockRecorder) ListDeploys() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeploys", reflect.TypeOf((*MockDeployLogic)(nil).ListDeploys))
 }
+
+// ListDeploysPage mocks base method
+func (m *MockDeployLogic) ListDeploysPage(arg0 int, arg1 string) ([]*models.DeploySummary, string, error) {
+	ret := m.ctrl.Call(m, "ListDeploysPage", arg0, arg1)
+	ret0, _ := ret[0].([]*models.DeploySummary)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListDeploysPage indicates an expected call of ListDeploysPage
+func (mr *MockDeployLogicMockRecorder) ListDeploysPage(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeploysPage", reflect.TypeOf((*MockDeployLogic)(nil).ListDeploysPage), arg0, arg1)
+}
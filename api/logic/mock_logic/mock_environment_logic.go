@@ -33,6 +33,19 @@ func (m *MockEnvironmentLogic) EXPECT() *MockEnvironmentLogicMockRecorder {
 	return m.recorder
 }
 
+// AuthorizeEnvironmentIngress mocks base method
+func (m *MockEnvironmentLogic) AuthorizeEnvironmentIngress(arg0 string, arg1 models.UpdateEnvironmentIngressRequest) (*models.Environment, error) {
+	ret := m.ctrl.Call(m, "AuthorizeEnvironmentIngress", arg0, arg1)
+	ret0, _ := ret[0].(*models.Environment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorizeEnvironmentIngress indicates an expected call of AuthorizeEnvironmentIngress
+func (mr *MockEnvironmentLogicMockRecorder) AuthorizeEnvironmentIngress(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeEnvironmentIngress", reflect.TypeOf((*MockEnvironmentLogic)(nil).AuthorizeEnvironmentIngress), arg0, arg1)
+}
+
 // CanCreateEnvironment mocks base method
 func (m *MockEnvironmentLogic) CanCreateEnvironment(arg0 models.CreateEnvironmentRequest) (bool, error) {
 	ret := m.ctrl.Call(m, "CanCreateEnvironment", arg0)
@@ -60,7 +73,7 @@ func (mr *MockEnvironmentLogicMockRecorder) CreateEnvironment(arg0 interface{})
 }
 
 // CreateEnvironmentLink mocks base method
-func (m *MockEnvironmentLogic) CreateEnvironmentLink(arg0, arg1 string) error {
+func (m *MockEnvironmentLogic) CreateEnvironmentLink(arg0 string, arg1 models.CreateEnvironmentLinkRequest) error {
 	ret := m.ctrl.Call(m, "CreateEnvironmentLink", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
@@ -108,6 +121,32 @@ func (mr *MockEnvironmentLogicMockRecorder) GetEnvironment(arg0 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvironment", reflect.TypeOf((*MockEnvironmentLogic)(nil).GetEnvironment), arg0)
 }
 
+// GetEnvironmentCapacity mocks base method
+func (m *MockEnvironmentLogic) GetEnvironmentCapacity(arg0 string) (*models.EnvironmentCapacity, error) {
+	ret := m.ctrl.Call(m, "GetEnvironmentCapacity", arg0)
+	ret0, _ := ret[0].(*models.EnvironmentCapacity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnvironmentCapacity indicates an expected call of GetEnvironmentCapacity
+func (mr *MockEnvironmentLogicMockRecorder) GetEnvironmentCapacity(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvironmentCapacity", reflect.TypeOf((*MockEnvironmentLogic)(nil).GetEnvironmentCapacity), arg0)
+}
+
+// GetEnvironmentCost mocks base method
+func (m *MockEnvironmentLogic) GetEnvironmentCost(arg0 string, arg1 float64) (*models.EnvironmentCost, error) {
+	ret := m.ctrl.Call(m, "GetEnvironmentCost", arg0, arg1)
+	ret0, _ := ret[0].(*models.EnvironmentCost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnvironmentCost indicates an expected call of GetEnvironmentCost
+func (mr *MockEnvironmentLogicMockRecorder) GetEnvironmentCost(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvironmentCost", reflect.TypeOf((*MockEnvironmentLogic)(nil).GetEnvironmentCost), arg0, arg1)
+}
+
 // ListEnvironments mocks base method
 func (m *MockEnvironmentLogic) ListEnvironments() ([]models.EnvironmentSummary, error) {
 	ret := m.ctrl.Call(m, "ListEnvironments")
@@ -121,6 +160,33 @@ func (mr *MockEnvironmentLogicMockRecorder) ListEnvironments() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnvironments", reflect.TypeOf((*MockEnvironmentLogic)(nil).ListEnvironments))
 }
 
+// ListEnvironmentsPage mocks base method
+func (m *MockEnvironmentLogic) ListEnvironmentsPage(arg0 int, arg1 string) ([]models.EnvironmentSummary, string, error) {
+	ret := m.ctrl.Call(m, "ListEnvironmentsPage", arg0, arg1)
+	ret0, _ := ret[0].([]models.EnvironmentSummary)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListEnvironmentsPage indicates an expected call of ListEnvironmentsPage
+func (mr *MockEnvironmentLogicMockRecorder) ListEnvironmentsPage(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnvironmentsPage", reflect.TypeOf((*MockEnvironmentLogic)(nil).ListEnvironmentsPage), arg0, arg1)
+}
+
+// RevokeEnvironmentIngress mocks base method
+func (m *MockEnvironmentLogic) RevokeEnvironmentIngress(arg0 string, arg1 models.UpdateEnvironmentIngressRequest) (*models.Environment, error) {
+	ret := m.ctrl.Call(m, "RevokeEnvironmentIngress", arg0, arg1)
+	ret0, _ := ret[0].(*models.Environment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeEnvironmentIngress indicates an expected call of RevokeEnvironmentIngress
+func (mr *MockEnvironmentLogicMockRecorder) RevokeEnvironmentIngress(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeEnvironmentIngress", reflect.TypeOf((*MockEnvironmentLogic)(nil).RevokeEnvironmentIngress), arg0, arg1)
+}
+
 // UpdateEnvironment mocks base method
 func (m *MockEnvironmentLogic) UpdateEnvironment(arg0 string, arg1 int) (*models.Environment, error) {
 	ret := m.ctrl.Call(m, "UpdateEnvironment", arg0, arg1)
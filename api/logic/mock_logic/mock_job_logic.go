@@ -34,6 +34,18 @@ func (m *MockJobLogic) EXPECT() *MockJobLogicMockRecorder {
 	return m.recorder
 }
 
+// CancelJob mocks base method
+func (m *MockJobLogic) CancelJob(arg0 string) error {
+	ret := m.ctrl.Call(m, "CancelJob", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelJob indicates an expected call of CancelJob
+func (mr *MockJobLogicMockRecorder) CancelJob(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelJob", reflect.TypeOf((*MockJobLogic)(nil).CancelJob), arg0)
+}
+
 // CreateJob mocks base method
 func (m *MockJobLogic) CreateJob(arg0 types.JobType, arg1 interface{}) (*models.Job, error) {
 	ret := m.ctrl.Call(m, "CreateJob", arg0, arg1)
@@ -84,3 +96,29 @@ func (m *MockJobLogic) ListJobs() ([]*models.Job, error) {
 func (mr *MockJobLogicMockRecorder) ListJobs() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobs", reflect.TypeOf((*MockJobLogic)(nil).ListJobs))
 }
+
+// ListJobsPage mocks base method
+func (m *MockJobLogic) ListJobsPage(arg0 int, arg1 string) ([]*models.Job, string, error) {
+	ret := m.ctrl.Call(m, "ListJobsPage", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Job)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListJobsPage indicates an expected call of ListJobsPage
+func (mr *MockJobLogicMockRecorder) ListJobsPage(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobsPage", reflect.TypeOf((*MockJobLogic)(nil).ListJobsPage), arg0, arg1)
+}
+
+// ResumeJob mocks base method
+func (m *MockJobLogic) ResumeJob(arg0, arg1 string) error {
+	ret := m.ctrl.Call(m, "ResumeJob", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResumeJob indicates an expected call of ResumeJob
+func (mr *MockJobLogicMockRecorder) ResumeJob(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeJob", reflect.TypeOf((*MockJobLogic)(nil).ResumeJob), arg0, arg1)
+}
@@ -8,6 +8,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/quintilesims/layer0/common/models"
 	reflect "reflect"
+	time "time"
 )
 
 // MockLoadBalancerLogic is a mock of LoadBalancerLogic interface
@@ -71,6 +72,19 @@ func (mr *MockLoadBalancerLogicMockRecorder) GetLoadBalancer(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancer", reflect.TypeOf((*MockLoadBalancerLogic)(nil).GetLoadBalancer), arg0)
 }
 
+// GetLoadBalancerMetrics mocks base method
+func (m *MockLoadBalancerLogic) GetLoadBalancerMetrics(arg0 string, arg1, arg2 time.Time, arg3 int) (*models.LoadBalancerMetrics, error) {
+	ret := m.ctrl.Call(m, "GetLoadBalancerMetrics", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*models.LoadBalancerMetrics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoadBalancerMetrics indicates an expected call of GetLoadBalancerMetrics
+func (mr *MockLoadBalancerLogicMockRecorder) GetLoadBalancerMetrics(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerMetrics", reflect.TypeOf((*MockLoadBalancerLogic)(nil).GetLoadBalancerMetrics), arg0, arg1, arg2, arg3)
+}
+
 // ListLoadBalancers mocks base method
 func (m *MockLoadBalancerLogic) ListLoadBalancers() ([]*models.LoadBalancerSummary, error) {
 	ret := m.ctrl.Call(m, "ListLoadBalancers")
@@ -84,6 +98,33 @@ func (mr *MockLoadBalancerLogicMockRecorder) ListLoadBalancers() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoadBalancers", reflect.TypeOf((*MockLoadBalancerLogic)(nil).ListLoadBalancers))
 }
 
+// ListLoadBalancersPage mocks base method
+func (m *MockLoadBalancerLogic) ListLoadBalancersPage(arg0 int, arg1 string) ([]*models.LoadBalancerSummary, string, error) {
+	ret := m.ctrl.Call(m, "ListLoadBalancersPage", arg0, arg1)
+	ret0, _ := ret[0].([]*models.LoadBalancerSummary)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListLoadBalancersPage indicates an expected call of ListLoadBalancersPage
+func (mr *MockLoadBalancerLogicMockRecorder) ListLoadBalancersPage(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoadBalancersPage", reflect.TypeOf((*MockLoadBalancerLogic)(nil).ListLoadBalancersPage), arg0, arg1)
+}
+
+// UpdateLoadBalancerAccessLog mocks base method
+func (m *MockLoadBalancerLogic) UpdateLoadBalancerAccessLog(arg0 string, arg1 models.AccessLog) (*models.LoadBalancer, error) {
+	ret := m.ctrl.Call(m, "UpdateLoadBalancerAccessLog", arg0, arg1)
+	ret0, _ := ret[0].(*models.LoadBalancer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLoadBalancerAccessLog indicates an expected call of UpdateLoadBalancerAccessLog
+func (mr *MockLoadBalancerLogicMockRecorder) UpdateLoadBalancerAccessLog(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLoadBalancerAccessLog", reflect.TypeOf((*MockLoadBalancerLogic)(nil).UpdateLoadBalancerAccessLog), arg0, arg1)
+}
+
 // UpdateLoadBalancerCrossZone mocks base method
 func (m *MockLoadBalancerLogic) UpdateLoadBalancerCrossZone(arg0 string, arg1 bool) (*models.LoadBalancer, error) {
 	ret := m.ctrl.Call(m, "UpdateLoadBalancerCrossZone", arg0, arg1)
@@ -6,6 +6,7 @@ package mock_logic
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	backend "github.com/quintilesims/layer0/api/backend"
 	models "github.com/quintilesims/layer0/common/models"
 	reflect "reflect"
 )
@@ -58,6 +59,19 @@ func (mr *MockServiceLogicMockRecorder) DeleteService(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteService", reflect.TypeOf((*MockServiceLogic)(nil).DeleteService), arg0)
 }
 
+// FollowServiceLogs mocks base method
+func (m *MockServiceLogic) FollowServiceLogs(arg0, arg1, arg2 string, arg3 *backend.LogCursor) ([]*models.LogEvent, error) {
+	ret := m.ctrl.Call(m, "FollowServiceLogs", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*models.LogEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FollowServiceLogs indicates an expected call of FollowServiceLogs
+func (mr *MockServiceLogicMockRecorder) FollowServiceLogs(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowServiceLogs", reflect.TypeOf((*MockServiceLogic)(nil).FollowServiceLogs), arg0, arg1, arg2, arg3)
+}
+
 // GetEnvironmentServices mocks base method
 func (m *MockServiceLogic) GetEnvironmentServices(arg0 string) ([]*models.Service, error) {
 	ret := m.ctrl.Call(m, "GetEnvironmentServices", arg0)
@@ -85,16 +99,16 @@ func (mr *MockServiceLogicMockRecorder) GetService(arg0 interface{}) *gomock.Cal
 }
 
 // GetServiceLogs mocks base method
-func (m *MockServiceLogic) GetServiceLogs(arg0, arg1, arg2 string, arg3 int) ([]*models.LogFile, error) {
-	ret := m.ctrl.Call(m, "GetServiceLogs", arg0, arg1, arg2, arg3)
+func (m *MockServiceLogic) GetServiceLogs(arg0, arg1, arg2, arg3, arg4 string, arg5 int) ([]*models.LogFile, error) {
+	ret := m.ctrl.Call(m, "GetServiceLogs", arg0, arg1, arg2, arg3, arg4, arg5)
 	ret0, _ := ret[0].([]*models.LogFile)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetServiceLogs indicates an expected call of GetServiceLogs
-func (mr *MockServiceLogicMockRecorder) GetServiceLogs(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceLogs", reflect.TypeOf((*MockServiceLogic)(nil).GetServiceLogs), arg0, arg1, arg2, arg3)
+func (mr *MockServiceLogicMockRecorder) GetServiceLogs(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceLogs", reflect.TypeOf((*MockServiceLogic)(nil).GetServiceLogs), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
 // ListServices mocks base method
@@ -110,6 +124,20 @@ func (mr *MockServiceLogicMockRecorder) ListServices() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServices", reflect.TypeOf((*MockServiceLogic)(nil).ListServices))
 }
 
+// ListServicesPage mocks base method
+func (m *MockServiceLogic) ListServicesPage(arg0 int, arg1 string) ([]models.ServiceSummary, string, error) {
+	ret := m.ctrl.Call(m, "ListServicesPage", arg0, arg1)
+	ret0, _ := ret[0].([]models.ServiceSummary)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListServicesPage indicates an expected call of ListServicesPage
+func (mr *MockServiceLogicMockRecorder) ListServicesPage(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServicesPage", reflect.TypeOf((*MockServiceLogic)(nil).ListServicesPage), arg0, arg1)
+}
+
 // ScaleService mocks base method
 func (m *MockServiceLogic) ScaleService(arg0 string, arg1 int) (*models.Service, error) {
 	ret := m.ctrl.Call(m, "ScaleService", arg0, arg1)
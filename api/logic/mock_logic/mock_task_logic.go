@@ -6,6 +6,7 @@ package mock_logic
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	backend "github.com/quintilesims/layer0/api/backend"
 	models "github.com/quintilesims/layer0/common/models"
 	reflect "reflect"
 )
@@ -58,6 +59,19 @@ func (mr *MockTaskLogicMockRecorder) DeleteTask(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTaskLogic)(nil).DeleteTask), arg0)
 }
 
+// FollowTaskLogs mocks base method
+func (m *MockTaskLogic) FollowTaskLogs(arg0, arg1, arg2 string, arg3 *backend.LogCursor) ([]*models.LogEvent, error) {
+	ret := m.ctrl.Call(m, "FollowTaskLogs", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*models.LogEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FollowTaskLogs indicates an expected call of FollowTaskLogs
+func (mr *MockTaskLogicMockRecorder) FollowTaskLogs(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowTaskLogs", reflect.TypeOf((*MockTaskLogic)(nil).FollowTaskLogs), arg0, arg1, arg2, arg3)
+}
+
 // GetEnvironmentTasks mocks base method
 func (m *MockTaskLogic) GetEnvironmentTasks(arg0 string) ([]*models.Task, error) {
 	ret := m.ctrl.Call(m, "GetEnvironmentTasks", arg0)
@@ -85,16 +99,16 @@ func (mr *MockTaskLogicMockRecorder) GetTask(arg0 interface{}) *gomock.Call {
 }
 
 // GetTaskLogs mocks base method
-func (m *MockTaskLogic) GetTaskLogs(arg0, arg1, arg2 string, arg3 int) ([]*models.LogFile, error) {
-	ret := m.ctrl.Call(m, "GetTaskLogs", arg0, arg1, arg2, arg3)
+func (m *MockTaskLogic) GetTaskLogs(arg0, arg1, arg2, arg3, arg4 string, arg5 int) ([]*models.LogFile, error) {
+	ret := m.ctrl.Call(m, "GetTaskLogs", arg0, arg1, arg2, arg3, arg4, arg5)
 	ret0, _ := ret[0].([]*models.LogFile)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTaskLogs indicates an expected call of GetTaskLogs
-func (mr *MockTaskLogicMockRecorder) GetTaskLogs(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskLogs", reflect.TypeOf((*MockTaskLogic)(nil).GetTaskLogs), arg0, arg1, arg2, arg3)
+func (mr *MockTaskLogicMockRecorder) GetTaskLogs(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskLogs", reflect.TypeOf((*MockTaskLogic)(nil).GetTaskLogs), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
 // ListTasks mocks base method
@@ -109,3 +123,17 @@ func (m *MockTaskLogic) ListTasks() ([]*models.TaskSummary, error) {
 func (mr *MockTaskLogicMockRecorder) ListTasks() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasks", reflect.TypeOf((*MockTaskLogic)(nil).ListTasks))
 }
+
+// ListTasksPage mocks base method
+func (m *MockTaskLogic) ListTasksPage(arg0 int, arg1 string) ([]*models.TaskSummary, string, error) {
+	ret := m.ctrl.Call(m, "ListTasksPage", arg0, arg1)
+	ret0, _ := ret[0].([]*models.TaskSummary)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListTasksPage indicates an expected call of ListTasksPage
+func (mr *MockTaskLogicMockRecorder) ListTasksPage(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasksPage", reflect.TypeOf((*MockTaskLogic)(nil).ListTasksPage), arg0, arg1)
+}
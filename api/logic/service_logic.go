@@ -4,20 +4,22 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/quintilesims/layer0/api/backend/ecs/id"
+	"github.com/quintilesims/layer0/api/backend"
 	"github.com/quintilesims/layer0/common/errors"
 	"github.com/quintilesims/layer0/common/models"
 )
 
 type ServiceLogic interface {
 	ListServices() ([]models.ServiceSummary, error)
+	ListServicesPage(limit int, nextToken string) ([]models.ServiceSummary, string, error)
 	GetService(serviceID string) (*models.Service, error)
 	GetEnvironmentServices(environmentID string) ([]*models.Service, error)
 	CreateService(req models.CreateServiceRequest) (*models.Service, error)
 	DeleteService(serviceID string) error
 	UpdateService(serviceID string, req models.UpdateServiceRequest) (*models.Service, error)
 	ScaleService(serviceID string, size int) (*models.Service, error)
-	GetServiceLogs(serviceID, start, end string, tail int) ([]*models.LogFile, error)
+	GetServiceLogs(serviceID, start, end, filter, container string, tail int) ([]*models.LogFile, error)
+	FollowServiceLogs(serviceID, start, container string, cursor *backend.LogCursor) ([]*models.LogEvent, error)
 }
 
 type L0ServiceLogic struct {
@@ -31,12 +33,31 @@ func NewL0ServiceLogic(logic Logic) *L0ServiceLogic {
 }
 
 func (this *L0ServiceLogic) ListServices() ([]models.ServiceSummary, error) {
-	serviceIDs, err := this.Backend.ListServices()
+	summaries, _, err := this.ListServicesPage(0, "")
+	return summaries, err
+}
+
+func (this *L0ServiceLogic) ListServicesPage(limit int, nextToken string) ([]models.ServiceSummary, string, error) {
+	if err := validateLimit(limit); err != nil {
+		return nil, "", err
+	}
+
+	ecsServiceIDs, nextToken, err := this.Backend.ListServicesPage(limit, nextToken)
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+
+	serviceIDs := make([]string, len(ecsServiceIDs))
+	for i, ecsServiceID := range ecsServiceIDs {
+		serviceIDs[i] = ecsServiceID.L0ServiceID()
+	}
+
+	summaries, err := this.makeServiceSummaryModels(serviceIDs)
+	if err != nil {
+		return nil, "", err
 	}
 
-	return this.makeServiceSummaryModels(serviceIDs)
+	return summaries, nextToken, nil
 }
 
 func (this *L0ServiceLogic) GetService(serviceID string) (*models.Service, error) {
@@ -114,7 +135,12 @@ func (this *L0ServiceLogic) UpdateService(serviceID string, req models.UpdateSer
 		return nil, err
 	}
 
-	service, err := this.Backend.UpdateService(environmentID, serviceID, req.DeployID)
+	deployID, err := this.applyEnvironmentLogSinks(environmentID, req.DeployID)
+	if err != nil {
+		return nil, err
+	}
+
+	service, err := this.Backend.UpdateService(environmentID, serviceID, deployID)
 	if err != nil {
 		return nil, err
 	}
@@ -151,10 +177,15 @@ func (this *L0ServiceLogic) CreateService(req models.CreateServiceRequest) (*mod
 		return nil, errors.New(errors.InvalidServiceName, err)
 	}
 
+	deployID, err := this.applyEnvironmentLogSinks(req.EnvironmentID, req.DeployID)
+	if err != nil {
+		return nil, err
+	}
+
 	service, err := this.Backend.CreateService(
 		req.ServiceName,
 		req.EnvironmentID,
-		req.DeployID,
+		deployID,
 		req.LoadBalancerID)
 	if err != nil {
 		return service, err
@@ -185,13 +216,13 @@ func (this *L0ServiceLogic) CreateService(req models.CreateServiceRequest) (*mod
 	return service, nil
 }
 
-func (this *L0ServiceLogic) GetServiceLogs(serviceID, start, end string, tail int) ([]*models.LogFile, error) {
+func (this *L0ServiceLogic) GetServiceLogs(serviceID, start, end, filter, container string, tail int) ([]*models.LogFile, error) {
 	environmentID, err := this.getEnvironmentID(serviceID)
 	if err != nil {
 		return nil, err
 	}
 
-	logs, err := this.Backend.GetServiceLogs(environmentID, serviceID, start, end, tail)
+	logs, err := this.Backend.GetServiceLogs(environmentID, serviceID, start, end, filter, container, tail)
 	if err != nil {
 		return nil, err
 	}
@@ -199,6 +230,20 @@ func (this *L0ServiceLogic) GetServiceLogs(serviceID, start, end string, tail in
 	return logs, nil
 }
 
+func (this *L0ServiceLogic) FollowServiceLogs(serviceID, start, container string, cursor *backend.LogCursor) ([]*models.LogEvent, error) {
+	environmentID, err := this.getEnvironmentID(serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := this.Backend.FollowServiceLogs(environmentID, serviceID, start, container, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
 func (this *L0ServiceLogic) getEnvironmentID(serviceID string) (string, error) {
 	tags, err := this.TagStore.SelectByTypeAndID("service", serviceID)
 	if err != nil {
@@ -256,6 +301,8 @@ func (this *L0ServiceLogic) populateModel(model *models.Service) error {
 		model.ServiceName = tag.Value
 	}
 
+	model.Tags = tags.UserDefined()
+
 	if model.EnvironmentID != "" {
 		tags, err := this.TagStore.SelectByTypeAndID("environment", model.EnvironmentID)
 		if err != nil {
@@ -301,15 +348,24 @@ func (this *L0ServiceLogic) populateModel(model *models.Service) error {
 	return nil
 }
 
-func (s *L0ServiceLogic) makeServiceSummaryModels(ecsServiceIDs []id.ECSServiceID) ([]models.ServiceSummary, error) {
-	serviceTags, err := s.TagStore.SelectByType("service")
+func (s *L0ServiceLogic) makeServiceSummaryModels(serviceIDs []string) ([]models.ServiceSummary, error) {
+	serviceTags, err := s.TagStore.SelectByTypeAndIDs("service", serviceIDs)
 	if err != nil {
 		return nil, err
 	}
 
-	models := make([]models.ServiceSummary, len(ecsServiceIDs))
-	for i, ecsServiceID := range ecsServiceIDs {
-		serviceID := ecsServiceID.L0ServiceID()
+	environmentIDs := []string{}
+	for _, tag := range serviceTags.WithKey("environment_id") {
+		environmentIDs = append(environmentIDs, tag.Value)
+	}
+
+	environmentTags, err := s.TagStore.SelectByTypeAndIDs("environment", environmentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]models.ServiceSummary, len(serviceIDs))
+	for i, serviceID := range serviceIDs {
 		models[i].ServiceID = serviceID
 
 		if tag, ok := serviceTags.WithID(serviceID).WithKey("name").First(); ok {
@@ -320,12 +376,7 @@ func (s *L0ServiceLogic) makeServiceSummaryModels(ecsServiceIDs []id.ECSServiceI
 			environmentID := tag.Value
 			models[i].EnvironmentID = environmentID
 
-			environmentTags, err := s.TagStore.SelectByTypeAndID("environment", environmentID)
-			if err != nil {
-				return nil, err
-			}
-
-			if tag, ok := environmentTags.WithKey("name").First(); ok {
+			if tag, ok := environmentTags.WithID(environmentID).WithKey("name").First(); ok {
 				models[i].EnvironmentName = tag.Value
 			}
 		}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/quintilesims/layer0/api/backend"
 	"github.com/quintilesims/layer0/api/backend/ecs/id"
 	"github.com/quintilesims/layer0/common/models"
 	"github.com/quintilesims/layer0/common/testutils"
@@ -68,6 +69,8 @@ func TestGetService(t *testing.T) {
 
 	testLogic.AddTags(t, []*models.Tag{
 		{EntityID: "s1", EntityType: "service", Key: "environment_id", Value: "e1"},
+		{EntityID: "s1", EntityType: "service", Key: "name", Value: "svc"},
+		{EntityID: "s1", EntityType: "service", Key: "team", Value: "payments"},
 	})
 
 	serviceLogic := NewL0ServiceLogic(testLogic.Logic())
@@ -78,6 +81,7 @@ func TestGetService(t *testing.T) {
 
 	testutils.AssertEqual(t, service.ServiceID, "s1")
 	testutils.AssertEqual(t, service.EnvironmentID, "e1")
+	testutils.AssertEqual(t, service.Tags, map[string]string{"team": "payments"})
 }
 
 func TestListServices(t *testing.T) {
@@ -90,8 +94,8 @@ func TestListServices(t *testing.T) {
 	}
 
 	testLogic.Backend.EXPECT().
-		ListServices().
-		Return(ecsServiceIDs, nil)
+		ListServicesPage(0, "").
+		Return(ecsServiceIDs, "", nil)
 
 	testLogic.AddTags(t, []*models.Tag{
 		{EntityID: "env_id1", EntityType: "environment", Key: "name", Value: "env_name1"},
@@ -116,6 +120,48 @@ func TestListServices(t *testing.T) {
 	assert.Equal(t, expected, result)
 }
 
+func TestListServicesPage(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		testLogic.Backend.EXPECT().
+			ListServicesPage(2, "").
+			Return([]id.ECSServiceID{"svc_id1", "svc_id2"}, "token", nil),
+		testLogic.Backend.EXPECT().
+			ListServicesPage(2, "token").
+			Return([]id.ECSServiceID{"svc_id3"}, "", nil),
+	)
+
+	testLogic.AddTags(t, []*models.Tag{
+		{EntityID: "svc_id1", EntityType: "service", Key: "name", Value: "svc_name1"},
+		{EntityID: "svc_id2", EntityType: "service", Key: "name", Value: "svc_name2"},
+		{EntityID: "svc_id3", EntityType: "service", Key: "name", Value: "svc_name3"},
+	})
+
+	serviceLogic := NewL0ServiceLogic(testLogic.Logic())
+	result, nextToken, err := serviceLogic.ListServicesPage(2, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []models.ServiceSummary{
+		{ServiceID: "svc_id1", ServiceName: "svc_name1"},
+		{ServiceID: "svc_id2", ServiceName: "svc_name2"},
+	}
+
+	assert.Equal(t, expected, result)
+	assert.Equal(t, "token", nextToken)
+
+	result, nextToken, err = serviceLogic.ListServicesPage(2, nextToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []models.ServiceSummary{{ServiceID: "svc_id3", ServiceName: "svc_name3"}}, result)
+	assert.Equal(t, "", nextToken)
+}
+
 func TestDeleteService(t *testing.T) {
 	testLogic, ctrl := NewTestLogic(t)
 	defer ctrl.Finish()
@@ -285,12 +331,12 @@ func TestGetServiceLogs(t *testing.T) {
 	defer ctrl.Finish()
 
 	logs := []*models.LogFile{
-		{Name: "alpha", Lines: []string{"first", "second"}},
-		{Name: "beta", Lines: []string{"first", "second", "third"}},
+		{Name: "alpha", Events: []*models.LogEvent{{Message: "first"}, {Message: "second"}}},
+		{Name: "beta", Events: []*models.LogEvent{{Message: "first"}, {Message: "second"}, {Message: "third"}}},
 	}
 
 	testLogic.Backend.EXPECT().
-		GetServiceLogs("e1", "s1", "start", "end", 100).
+		GetServiceLogs("e1", "s1", "start", "end", "filter", "container", 100).
 		Return(logs, nil)
 
 	testLogic.AddTags(t, []*models.Tag{
@@ -298,10 +344,37 @@ func TestGetServiceLogs(t *testing.T) {
 	})
 
 	serviceLogic := NewL0ServiceLogic(testLogic.Logic())
-	received, err := serviceLogic.GetServiceLogs("s1", "start", "end", 100)
+	received, err := serviceLogic.GetServiceLogs("s1", "start", "end", "filter", "container", 100)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	testutils.AssertEqual(t, received, logs)
 }
+
+func TestFollowServiceLogs(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	events := []*models.LogEvent{
+		{ContainerName: "alpha", Message: "first"},
+		{ContainerName: "beta", Message: "second"},
+	}
+
+	cursor := backend.NewLogCursor()
+	testLogic.Backend.EXPECT().
+		FollowServiceLogs("e1", "s1", "start", "container", cursor).
+		Return(events, nil)
+
+	testLogic.AddTags(t, []*models.Tag{
+		{EntityID: "s1", EntityType: "service", Key: "environment_id", Value: "e1"},
+	})
+
+	serviceLogic := NewL0ServiceLogic(testLogic.Logic())
+	received, err := serviceLogic.FollowServiceLogs("s1", "start", "container", cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, received, events)
+}
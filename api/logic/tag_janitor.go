@@ -30,10 +30,13 @@ func NewTagJanitor(taskLogic TaskLogic, tagStore tag_store.TagStore) *TagJanitor
 }
 
 func (t *TagJanitor) Run() {
+	RegisterBackgroundLoop("tag_janitor", taskJanitorSleepDuration)
+
 	go func() {
 		for {
 			tagLogger.Info("Starting cleanup")
-			t.pulse()
+			err := t.pulse()
+			RecordBackgroundLoopRun("tag_janitor", err)
 			tagLogger.Infof("Finished cleanup")
 			t.Clock.Sleep(taskJanitorSleepDuration)
 		}
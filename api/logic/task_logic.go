@@ -3,6 +3,7 @@ package logic
 import (
 	"fmt"
 
+	"github.com/quintilesims/layer0/api/backend"
 	"github.com/quintilesims/layer0/api/backend/ecs/id"
 	"github.com/quintilesims/layer0/common/errors"
 	"github.com/quintilesims/layer0/common/models"
@@ -11,10 +12,12 @@ import (
 type TaskLogic interface {
 	CreateTask(models.CreateTaskRequest) (string, error)
 	ListTasks() ([]*models.TaskSummary, error)
+	ListTasksPage(limit int, nextToken string) ([]*models.TaskSummary, string, error)
 	GetTask(string) (*models.Task, error)
 	GetEnvironmentTasks(environmentID string) ([]*models.Task, error)
 	DeleteTask(string) error
-	GetTaskLogs(string, string, string, int) ([]*models.LogFile, error)
+	GetTaskLogs(taskID, start, end, filter, container string, tail int) ([]*models.LogFile, error)
+	FollowTaskLogs(taskID, start, container string, cursor *backend.LogCursor) ([]*models.LogEvent, error)
 }
 
 type L0TaskLogic struct {
@@ -28,12 +31,27 @@ func NewL0TaskLogic(logic Logic) *L0TaskLogic {
 }
 
 func (this *L0TaskLogic) ListTasks() ([]*models.TaskSummary, error) {
-	taskARNs, err := this.Backend.ListTasks()
+	summaries, _, err := this.ListTasksPage(0, "")
+	return summaries, err
+}
+
+// ListTasksPage pages through tasks by their ARNs, since tasks started outside of layer0 have no task id
+func (this *L0TaskLogic) ListTasksPage(limit int, nextToken string) ([]*models.TaskSummary, string, error) {
+	if err := validateLimit(limit); err != nil {
+		return nil, "", err
+	}
+
+	taskARNs, nextToken, err := this.Backend.ListTasksPage(limit, nextToken)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	return this.makeTaskSummaryModels(taskARNs)
+	summaries, err := this.makeTaskSummaryModels(taskARNs)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return summaries, nextToken, nil
 }
 
 func (this *L0TaskLogic) GetTask(taskID string) (*models.Task, error) {
@@ -121,7 +139,12 @@ func (this *L0TaskLogic) CreateTask(req models.CreateTaskRequest) (string, error
 		return "", errors.Newf(errors.MissingParameter, "TaskName not specified")
 	}
 
-	taskARN, err := this.Backend.CreateTask(req.EnvironmentID, req.DeployID, req.ContainerOverrides)
+	deployID, err := this.applyEnvironmentLogSinks(req.EnvironmentID, req.DeployID)
+	if err != nil {
+		return "", err
+	}
+
+	taskARN, err := this.Backend.CreateTask(req.EnvironmentID, deployID, req.ContainerOverrides)
 	if err != nil {
 		return "", err
 	}
@@ -130,7 +153,7 @@ func (this *L0TaskLogic) CreateTask(req models.CreateTaskRequest) (string, error
 	tags := []models.Tag{
 		{EntityID: taskID, EntityType: "task", Key: "name", Value: req.TaskName},
 		{EntityID: taskID, EntityType: "task", Key: "environment_id", Value: req.EnvironmentID},
-		{EntityID: taskID, EntityType: "task", Key: "deploy_id", Value: req.DeployID},
+		{EntityID: taskID, EntityType: "task", Key: "deploy_id", Value: deployID},
 		{EntityID: taskID, EntityType: "task", Key: "arn", Value: taskARN},
 	}
 
@@ -143,7 +166,7 @@ func (this *L0TaskLogic) CreateTask(req models.CreateTaskRequest) (string, error
 	return taskID, nil
 }
 
-func (this *L0TaskLogic) GetTaskLogs(taskID, start, end string, tail int) ([]*models.LogFile, error) {
+func (this *L0TaskLogic) GetTaskLogs(taskID, start, end, filter, container string, tail int) ([]*models.LogFile, error) {
 	environmentID, err := this.lookupTaskEnvironmentID(taskID)
 	if err != nil {
 		return nil, err
@@ -154,7 +177,7 @@ func (this *L0TaskLogic) GetTaskLogs(taskID, start, end string, tail int) ([]*mo
 		return nil, err
 	}
 
-	logs, err := this.Backend.GetTaskLogs(environmentID, taskARN, start, end, tail)
+	logs, err := this.Backend.GetTaskLogs(environmentID, taskARN, start, end, filter, container, tail)
 	if err != nil {
 		return nil, err
 	}
@@ -162,6 +185,25 @@ func (this *L0TaskLogic) GetTaskLogs(taskID, start, end string, tail int) ([]*mo
 	return logs, nil
 }
 
+func (this *L0TaskLogic) FollowTaskLogs(taskID, start, container string, cursor *backend.LogCursor) ([]*models.LogEvent, error) {
+	environmentID, err := this.lookupTaskEnvironmentID(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	taskARN, err := this.lookupTaskARN(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := this.Backend.FollowTaskLogs(environmentID, taskARN, start, container, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
 func (t *L0TaskLogic) getTaskARNFromID(taskARN string) (string, error) {
 	tags, err := t.TagStore.SelectByType("task")
 	if err != nil {
@@ -206,12 +248,18 @@ func (t *L0TaskLogic) lookupTaskARN(taskID string) (string, error) {
 }
 
 func (t *L0TaskLogic) makeTaskSummaryModels(taskARNs []string) ([]*models.TaskSummary, error) {
-	environmentTags, err := t.TagStore.SelectByType("environment")
+	// task tags are keyed by task id rather than arn, so they can't be read for just the tasks in the page
+	taskTags, err := t.TagStore.SelectByType("task")
 	if err != nil {
 		return nil, err
 	}
 
-	taskTags, err := t.TagStore.SelectByType("task")
+	environmentIDs := []string{}
+	for _, tag := range taskTags.WithKey("environment_id") {
+		environmentIDs = append(environmentIDs, tag.Value)
+	}
+
+	environmentTags, err := t.TagStore.SelectByTypeAndIDs("environment", environmentIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -267,6 +315,8 @@ func (this *L0TaskLogic) populateModel(taskID string, model *models.Task) error
 		model.TaskName = tag.Value
 	}
 
+	model.Tags = tags.UserDefined()
+
 	if model.EnvironmentID != "" {
 		tags, err := this.TagStore.SelectByTypeAndID("environment", model.EnvironmentID)
 		if err != nil {
@@ -3,6 +3,7 @@ package logic
 import (
 	"testing"
 
+	"github.com/quintilesims/layer0/api/backend"
 	"github.com/quintilesims/layer0/common/models"
 	"github.com/quintilesims/layer0/common/testutils"
 	"github.com/stretchr/testify/assert"
@@ -41,6 +42,34 @@ func TestGetTask(t *testing.T) {
 	testutils.AssertEqual(t, expected, result)
 }
 
+func TestFollowTaskLogs(t *testing.T) {
+	testLogic, ctrl := NewTestLogic(t)
+	defer ctrl.Finish()
+
+	expected := []*models.LogEvent{
+		{ContainerName: "alpha", Message: "first"},
+		{ContainerName: "beta", Message: "second"},
+	}
+
+	cursor := backend.NewLogCursor()
+	testLogic.Backend.EXPECT().
+		FollowTaskLogs("env_id", "tsk_arn", "start", "container", cursor).
+		Return(expected, nil)
+
+	testLogic.AddTags(t, []*models.Tag{
+		{EntityID: "tsk_id", EntityType: "task", Key: "environment_id", Value: "env_id"},
+		{EntityID: "tsk_id", EntityType: "task", Key: "arn", Value: "tsk_arn"},
+	})
+
+	taskLogic := NewL0TaskLogic(testLogic.Logic())
+	result, err := taskLogic.FollowTaskLogs("tsk_id", "start", "container", cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, expected, result)
+}
+
 func TestListTasks(t *testing.T) {
 	testLogic, ctrl := NewTestLogic(t)
 	defer ctrl.Finish()
@@ -52,8 +81,8 @@ func TestListTasks(t *testing.T) {
 	}
 
 	testLogic.Backend.EXPECT().
-		ListTasks().
-		Return(taskARNs, nil)
+		ListTasksPage(0, "").
+		Return(taskARNs, "", nil)
 
 	testLogic.AddTags(t, []*models.Tag{
 		{EntityID: "env_id1", EntityType: "environment", Key: "name", Value: "env_name1"},
@@ -174,12 +203,12 @@ func TestGetTaskLogs(t *testing.T) {
 	defer ctrl.Finish()
 
 	expected := []*models.LogFile{
-		{Name: "alpha", Lines: []string{"first", "second"}},
-		{Name: "beta", Lines: []string{"first", "second", "third"}},
+		{Name: "alpha", Events: []*models.LogEvent{{Message: "first"}, {Message: "second"}}},
+		{Name: "beta", Events: []*models.LogEvent{{Message: "first"}, {Message: "second"}, {Message: "third"}}},
 	}
 
 	testLogic.Backend.EXPECT().
-		GetTaskLogs("env_id", "tsk_arn", "start", "end", 100).
+		GetTaskLogs("env_id", "tsk_arn", "start", "end", "filter", "container", 100).
 		Return(expected, nil)
 
 	testLogic.AddTags(t, []*models.Tag{
@@ -188,7 +217,7 @@ func TestGetTaskLogs(t *testing.T) {
 	})
 
 	taskLogic := NewL0TaskLogic(testLogic.Logic())
-	result, err := taskLogic.GetTaskLogs("tsk_id", "start", "end", 100)
+	result, err := taskLogic.GetTaskLogs("tsk_id", "start", "end", "filter", "container", 100)
 	if err != nil {
 		t.Fatal(err)
 	}
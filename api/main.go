@@ -12,8 +12,11 @@ import (
 	"github.com/quintilesims/layer0/api/handlers"
 	"github.com/quintilesims/layer0/api/logic"
 	"github.com/quintilesims/layer0/common/aws/provider"
+	"github.com/quintilesims/layer0/common/aws/sts"
 	"github.com/quintilesims/layer0/common/config"
+	"github.com/quintilesims/layer0/common/errors"
 	"github.com/quintilesims/layer0/common/logutils"
+	"github.com/quintilesims/layer0/common/pricing"
 	"github.com/quintilesims/layer0/common/startup"
 )
 
@@ -21,22 +24,26 @@ const (
 	SCALER_SLEEP_DURATION = time.Hour
 )
 
-func setupRestful(lgc logic.Logic) {
+func setupRestful(lgc logic.Logic, stsProvider sts.Provider, priceTable pricing.PriceTable) {
 	adminLogic := logic.NewL0AdminLogic(lgc)
+	certificateLogic := logic.NewL0CertificateLogic(lgc)
 	deployLogic := logic.NewL0DeployLogic(lgc)
 	environmentLogic := logic.NewL0EnvironmentLogic(lgc)
-	healthLogic := logic.NewL0HealthLogic(lgc)
+	environmentLogic.PriceTable = priceTable
+	healthLogic := logic.NewL0HealthLogic(lgc, stsProvider)
 	loadBalancerLogic := logic.NewL0LoadBalancerLogic(lgc)
 	serviceLogic := logic.NewL0ServiceLogic(lgc)
 	taskLogic := logic.NewL0TaskLogic(lgc)
 	jobLogic := logic.NewL0JobLogic(lgc, taskLogic, deployLogic)
 
 	adminHandler := handlers.NewAdminHandler(adminLogic)
+	certificateHandler := handlers.NewCertificateHandler(certificateLogic)
 	deployHandler := handlers.NewDeployHandler(deployLogic)
 	environmentHandler := handlers.NewEnvironmentHandler(environmentLogic, jobLogic)
 	healthHandler := handlers.NewHealthHandler(healthLogic)
 	jobHandler := handlers.NewJobHandler(jobLogic)
 	loadBalancerHandler := handlers.NewLoadBalancerHandler(loadBalancerLogic, jobLogic)
+	metricsHandler := handlers.NewMetricsHandler()
 	serviceHandler := handlers.NewServiceHandler(serviceLogic, jobLogic)
 	tagHandler := handlers.NewTagHandler(lgc.TagStore)
 	taskHandler := handlers.NewTaskHandler(taskLogic, jobLogic)
@@ -48,9 +55,11 @@ func setupRestful(lgc logic.Logic) {
 	restful.Add(healthHandler.Routes())
 	restful.Add(tagHandler.Routes())
 	restful.Add(adminHandler.Routes())
+	restful.Add(certificateHandler.Routes())
 	restful.Add(loadBalancerHandler.Routes())
 	restful.Add(taskHandler.Routes())
 	restful.Add(jobHandler.Routes())
+	restful.Add(metricsHandler.Routes())
 
 	restful.Filter(handlers.LogRequest)
 	restful.Filter(handlers.AddVersionHeader)
@@ -130,34 +139,55 @@ func main() {
 	region := config.AWSRegion()
 	credProvider := config.NewConfigCredProvider()
 
-	backend, err := startup.GetBackend(credProvider, region)
+	rateLimiter, err := startup.GetRateLimiter()
 	if err != nil {
 		logrus.Fatal(err)
 	}
 
-	rateLimit, err := time.ParseDuration(config.AWSTimeBetweenRequests())
+	provider.SetRateLimiter(rateLimiter)
+
+	backend, err := startup.GetBackend(credProvider, region)
 	if err != nil {
 		logrus.Fatal(err)
 	}
 
-	provider.ResetRateLimiter(rateLimit)
-	defer provider.StopRateLimiter()
-
 	lgc, err := startup.GetLogic(backend)
 	if err != nil {
 		logrus.Fatal(err)
 	}
 
-	setupRestful(*lgc)
+	stsProvider, err := startup.GetSTS(credProvider, region)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	var priceTable pricing.PriceTable = pricing.DefaultPriceTable
+	if path := config.PriceTablePath(); path != "" {
+		table, err := pricing.Load(path)
+		if err != nil {
+			logrus.Fatal(err)
+		}
+
+		priceTable = table
+	}
+
+	if region != priceTable.PriceRegion() {
+		logrus.Warningf("The price table is for region '%s' but the api runs in '%s'; environment cost estimates will be inaccurate", priceTable.PriceRegion(), region)
+	}
+
+	setupRestful(*lgc, stsProvider, priceTable)
 
 	environmentLogic := logic.NewL0EnvironmentLogic(*lgc)
 	adminLogic := logic.NewL0AdminLogic(*lgc)
+	taskLogic := logic.NewL0TaskLogic(*lgc)
+	jobLogic := logic.NewL0JobLogic(*lgc, taskLogic, logic.NewL0DeployLogic(*lgc))
 
 	if err := adminLogic.UpdateSQL(); err != nil {
 		logrus.Errorf("Failed to update sql: %v", err)
 	}
 
 	go runEnvironmentScaler(environmentLogic)
+	logic.NewJobResumer(jobLogic, taskLogic).Run()
 
 	logrus.Print("Service on localhost" + port)
 	logrus.Fatal(http.ListenAndServe(port, nil))
@@ -165,25 +195,30 @@ func main() {
 
 func runEnvironmentScaler(environmentLogic *logic.L0EnvironmentLogic) {
 	logger := logutils.NewStandardLogger("AUTO Environment Scaler")
+	logic.RegisterBackgroundLoop("environment_scaler", SCALER_SLEEP_DURATION)
 
 	for {
 		environments, err := environmentLogic.ListEnvironments()
 		if err != nil {
 			logger.Errorf("Failed to list environments: %v", err)
+			logic.RecordBackgroundLoopRun("environment_scaler", err)
 			continue
 		}
 
+		var errs []error
 		for _, environment := range environments {
 			logger.Infof("Scaling Environment %s", environment.EnvironmentID)
 
 			if _, err := environmentLogic.Scaler.Scale(environment.EnvironmentID); err != nil {
 				logger.Errorf("Failed to scale environment %s: %v", environment.EnvironmentID, err)
+				errs = append(errs, err)
 				continue
 			}
 
 			logger.Infof("Finished scaling environment %s", environment.EnvironmentID)
 		}
 
+		logic.RecordBackgroundLoopRun("environment_scaler", errors.MultiError(errs))
 		time.Sleep(SCALER_SLEEP_DURATION)
 	}
 }
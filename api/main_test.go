@@ -12,6 +12,7 @@ import (
 	"github.com/quintilesims/layer0/api/backend/ecs"
 	"github.com/quintilesims/layer0/api/logic"
 	"github.com/quintilesims/layer0/common/config"
+	"github.com/quintilesims/layer0/common/pricing"
 )
 
 // Main test entrypoint
@@ -24,7 +25,7 @@ func TestMain(m *testing.M) {
 
 func TestAPIDocs(t *testing.T) {
 	logic := logic.NewLogic(nil, nil, &ecsbackend.ECSBackend{}, nil)
-	setupRestful(*logic)
+	setupRestful(*logic, nil, pricing.DefaultPriceTable)
 
 	httpRequest, _ := http.NewRequest("GET", "/apidocs.json", nil)
 	httpWriter := httptest.NewRecorder()
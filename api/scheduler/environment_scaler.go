@@ -5,13 +5,26 @@ import (
 	"time"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/quintilesims/layer0/api/scheduler/resource"
 	"github.com/quintilesims/layer0/common/errors"
 	"github.com/quintilesims/layer0/common/logutils"
 	"github.com/quintilesims/layer0/common/models"
 )
 
+var scalerRunCount = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "layer0_scaler_runs_total",
+		Help: "Total number of environment scaler runs by outcome (scale_up, scale_down, no_change, or error)",
+	},
+	[]string{"outcome"})
+
+func init() {
+	prometheus.MustRegister(scalerRunCount)
+}
+
 type EnvironmentScaler interface {
+	GetCapacity(environmentID string) (*models.EnvironmentCapacity, error)
 	Scale(environmentID string) (*models.ScalerRunInfo, error)
 	ScheduleRun(environmentID string, delay time.Duration)
 }
@@ -68,6 +81,13 @@ func (r *L0EnvironmentScaler) newScheduledRun(environmentID string, delay time.D
 }
 
 func (r *L0EnvironmentScaler) Scale(environmentID string) (*models.ScalerRunInfo, error) {
+	info, err := r.scale(environmentID)
+	scalerRunCount.WithLabelValues(scalerRunOutcome(info, err)).Inc()
+
+	return info, err
+}
+
+func (r *L0EnvironmentScaler) scale(environmentID string) (*models.ScalerRunInfo, error) {
 	resourceProviders, err := r.providerManager.GetProviders(environmentID)
 	if err != nil {
 		return nil, err
@@ -81,6 +101,71 @@ func (r *L0EnvironmentScaler) Scale(environmentID string) (*models.ScalerRunInfo
 	return RunBasicScaler(environmentID, resourceProviders, resourceConsumers, r.providerManager)
 }
 
+func (r *L0EnvironmentScaler) GetCapacity(environmentID string) (*models.EnvironmentCapacity, error) {
+	resourceProviders, err := r.providerManager.GetProviders(environmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceConsumers, err := r.consumerGetter.GetConsumers(environmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return CalculateCapacity(environmentID, resourceProviders, resourceConsumers), nil
+}
+
+// CalculateCapacity reports the current utilization of each provider. Pending consumers are
+// placed onto the existing providers in the same manner as RunBasicScaler; any provider left
+// unused after placement is considered reclaimable.
+func CalculateCapacity(environmentID string, providers []*resource.ResourceProvider, consumers []resource.ResourceConsumer) *models.EnvironmentCapacity {
+	capacity := &models.EnvironmentCapacity{
+		EnvironmentID:    environmentID,
+		Instances:        make([]models.InstanceCapacity, len(providers)),
+		PendingResources: resourceConsumerModels(consumers),
+	}
+
+	// take a snapshot of each provider before placement modifies their resources
+	indexes := map[*resource.ResourceProvider]int{}
+	for i, provider := range providers {
+		instance := provider.ToCapacityModel()
+		capacity.Instances[i] = instance
+		capacity.TotalMemoryMiB += instance.TotalMemoryMiB
+		capacity.UsedMemoryMiB += instance.UsedMemoryMiB
+		capacity.TotalCPU += instance.TotalCPU
+		capacity.UsedCPU += instance.UsedCPU
+		indexes[provider] = i
+	}
+
+	placed := make([]*resource.ResourceProvider, len(providers))
+	copy(placed, providers)
+
+	for _, consumer := range consumers {
+		resource.SortProvidersByMemory(placed)
+		resource.SortProvidersByUsage(placed)
+
+		for _, provider := range placed {
+			if provider.HasResourcesFor(consumer) {
+				provider.SubtractResourcesFor(consumer)
+				break
+			}
+		}
+	}
+
+	for _, provider := range placed {
+		if !provider.IsInUse() {
+			capacity.Instances[indexes[provider]].Reclaimable = true
+			capacity.ReclaimableInstances++
+		}
+	}
+
+	if len(providers) > 0 {
+		capacity.ReclaimableFraction = float64(capacity.ReclaimableInstances) / float64(len(providers))
+	}
+
+	return capacity
+}
+
 func RunBasicScaler(
 	environmentID string,
 	providers []*resource.ResourceProvider,
@@ -157,6 +242,19 @@ func RunBasicScaler(
 	return info, errors.MultiError(errs)
 }
 
+func scalerRunOutcome(info *models.ScalerRunInfo, err error) string {
+	switch {
+	case err != nil:
+		return "error"
+	case info.ActualScaleAfterRun > info.ScaleBeforeRun:
+		return "scale_up"
+	case info.ActualScaleAfterRun < info.ScaleBeforeRun:
+		return "scale_down"
+	default:
+		return "no_change"
+	}
+}
+
 func resourceConsumerModels(consumers []resource.ResourceConsumer) []models.ResourceConsumer {
 	models := make([]models.ResourceConsumer, len(consumers))
 	for i := 0; i < len(consumers); i++ {
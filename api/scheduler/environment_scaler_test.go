@@ -1,11 +1,14 @@
 package scheduler
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/quintilesims/layer0/api/scheduler/resource"
 	"github.com/quintilesims/layer0/api/scheduler/resource/mock_resource"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
 	"github.com/zpatrick/go-bytesize"
 )
 
@@ -346,3 +349,71 @@ func TestResourceManagerScaledown_complex(t *testing.T) {
 
 	test.Run(t)
 }
+
+func TestScalerRunOutcome(t *testing.T) {
+	cases := map[string]struct {
+		Info *models.ScalerRunInfo
+		Err  error
+	}{
+		"scale_up":   {Info: &models.ScalerRunInfo{ScaleBeforeRun: 1, ActualScaleAfterRun: 2}},
+		"scale_down": {Info: &models.ScalerRunInfo{ScaleBeforeRun: 2, ActualScaleAfterRun: 1}},
+		"no_change":  {Info: &models.ScalerRunInfo{ScaleBeforeRun: 2, ActualScaleAfterRun: 2}},
+		"error":      {Err: fmt.Errorf("some error")},
+	}
+
+	for expected, c := range cases {
+		testutils.AssertEqual(t, scalerRunOutcome(c.Info, c.Err), expected)
+	}
+}
+
+func TestGetCapacity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// p1 is in use, p2 has room for the pending consumer, and p3 is idle
+	p1 := resource.NewResourceProvider("p1", true, bytesize.MiB*512, []int{80})
+	p1.SetCapacity(bytesize.MiB*1024, 1024, 512)
+
+	p2 := resource.NewResourceProvider("p2", false, bytesize.MiB*1024, nil)
+	p2.SetCapacity(bytesize.MiB*1024, 1024, 1024)
+
+	p3 := resource.NewResourceProvider("p3", false, bytesize.MiB*1024, nil)
+	p3.SetCapacity(bytesize.MiB*1024, 1024, 1024)
+
+	consumers := []resource.ResourceConsumer{
+		{ID: "c1", Memory: bytesize.MiB * 256, Ports: []int{80}},
+	}
+
+	mockGetter := mock_resource.NewMockConsumerGetter(ctrl)
+	mockGetter.EXPECT().
+		GetConsumers("eid").
+		Return(consumers, nil)
+
+	mockProvider := mock_resource.NewMockProviderManager(ctrl)
+	mockProvider.EXPECT().
+		GetProviders("eid").
+		Return([]*resource.ResourceProvider{p1, p2, p3}, nil)
+
+	environmentScaler := NewL0EnvironmentScaler(mockGetter, mockProvider)
+	capacity, err := environmentScaler.GetCapacity("eid")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, capacity.EnvironmentID, "eid")
+	testutils.AssertEqual(t, len(capacity.PendingResources), 1)
+	testutils.AssertEqual(t, capacity.TotalMemoryMiB, int64(3072))
+	testutils.AssertEqual(t, capacity.UsedMemoryMiB, int64(512))
+	testutils.AssertEqual(t, capacity.TotalCPU, 3072)
+	testutils.AssertEqual(t, capacity.UsedCPU, 512)
+	testutils.AssertEqual(t, capacity.ReclaimableInstances, 1)
+	testutils.AssertEqual(t, capacity.ReclaimableFraction, float64(1)/3)
+
+	expected := []models.InstanceCapacity{
+		{InstanceID: "p1", InUse: true, TotalMemoryMiB: 1024, UsedMemoryMiB: 512, TotalCPU: 1024, UsedCPU: 512, UsedPorts: []int{80}},
+		{InstanceID: "p2", TotalMemoryMiB: 1024, TotalCPU: 1024, UsedPorts: []int{}},
+		{InstanceID: "p3", Reclaimable: true, TotalMemoryMiB: 1024, TotalCPU: 1024, UsedPorts: []int{}},
+	}
+
+	testutils.AssertEqual(t, capacity.Instances, expected)
+}
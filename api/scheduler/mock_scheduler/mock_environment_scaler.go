@@ -34,6 +34,19 @@ func (m *MockEnvironmentScaler) EXPECT() *MockEnvironmentScalerMockRecorder {
 	return m.recorder
 }
 
+// GetCapacity mocks base method
+func (m *MockEnvironmentScaler) GetCapacity(arg0 string) (*models.EnvironmentCapacity, error) {
+	ret := m.ctrl.Call(m, "GetCapacity", arg0)
+	ret0, _ := ret[0].(*models.EnvironmentCapacity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCapacity indicates an expected call of GetCapacity
+func (mr *MockEnvironmentScalerMockRecorder) GetCapacity(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCapacity", reflect.TypeOf((*MockEnvironmentScaler)(nil).GetCapacity), arg0)
+}
+
 // Scale mocks base method
 func (m *MockEnvironmentScaler) Scale(arg0 string) (*models.ScalerRunInfo, error) {
 	ret := m.ctrl.Call(m, "Scale", arg0)
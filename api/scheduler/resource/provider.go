@@ -19,6 +19,9 @@ type ResourceProvider struct {
 	inUse           bool
 	usedPorts       []int
 	availableMemory bytesize.Bytesize
+	totalMemory     bytesize.Bytesize
+	availableCPU    int
+	totalCPU        int
 }
 
 func NewResourceProvider(id string, inUse bool, availableMemory bytesize.Bytesize, usedPorts []int) *ResourceProvider {
@@ -62,6 +65,29 @@ func (r *ResourceProvider) IsInUse() bool {
 	return r.inUse
 }
 
+// SetCapacity records the provider's registered resources and remaining cpu units.
+// These are used for reporting only; placement decisions are based on memory and ports.
+func (r *ResourceProvider) SetCapacity(totalMemory bytesize.Bytesize, totalCPU, availableCPU int) {
+	r.totalMemory = totalMemory
+	r.totalCPU = totalCPU
+	r.availableCPU = availableCPU
+}
+
+func (r ResourceProvider) ToCapacityModel() models.InstanceCapacity {
+	usedPorts := make([]int, len(r.usedPorts))
+	copy(usedPorts, r.usedPorts)
+
+	return models.InstanceCapacity{
+		InstanceID:     r.ID,
+		InUse:          r.inUse,
+		UsedPorts:      usedPorts,
+		TotalMemoryMiB: int64(r.totalMemory / bytesize.MiB),
+		UsedMemoryMiB:  int64((r.totalMemory - r.availableMemory) / bytesize.MiB),
+		TotalCPU:       r.totalCPU,
+		UsedCPU:        r.totalCPU - r.availableCPU,
+	}
+}
+
 func (r ResourceProvider) ToModel() models.ResourceProvider {
 	return models.ResourceProvider{
 		ID:              r.ID,
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"encoding/json"
 	"io"
 	"io/ioutil"
 
@@ -11,11 +12,13 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/dghubble/sling"
 	"github.com/quintilesims/layer0/common/config"
+	"github.com/quintilesims/layer0/common/paging"
 	"github.com/quintilesims/layer0/common/waitutils"
 )
 
@@ -133,6 +136,34 @@ func (c *APIClient) ExecuteWithJob(sling *sling.Sling) (string, error) {
 	return "", fmt.Errorf("Failed to get job from response: Status was %v (expected %v)", resp.StatusCode, http.StatusAccepted)
 }
 
+// ExecuteWithPage sends a list request and returns the token of the next page, or an empty string if it was the last page
+func (c *APIClient) ExecuteWithPage(sling *sling.Sling, receive interface{}) (string, error) {
+	resp, err := c.execute(sling, receive)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Header.Get(paging.NextTokenHeader), nil
+}
+
+// pageQuery returns the query string for a page of a list request
+func pageQuery(limit int, nextToken string) string {
+	query := url.Values{}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	if nextToken != "" {
+		query.Set("next_token", nextToken)
+	}
+
+	if len(query) == 0 {
+		return ""
+	}
+
+	return "?" + query.Encode()
+}
+
 func (c *APIClient) execute(sling *sling.Sling, receive interface{}) (*http.Response, error) {
 	var serverError *ServerError
 	resp, err := sling.Receive(receive, &serverError)
@@ -168,6 +199,52 @@ func (c *APIClient) execute(sling *sling.Sling, receive interface{}) (*http.Resp
 	return resp, nil
 }
 
+// ExecuteStream sends the request and calls fn with a decoder for each json value
+// in the response body, until the body ends or fn returns an error.
+func (c *APIClient) ExecuteStream(sling *sling.Sling, fn func(decoder *json.Decoder) error) error {
+	req, err := sling.Request()
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("Sent: %s %s\n", req.Method, req.URL)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if strings.Contains(err.Error(), "x509: certificate is valid for") {
+			return sslError(err)
+		}
+
+		return fmt.Errorf("Unable to connect to API with error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 401 {
+		return fmt.Errorf("Invalid Auth Token. Have you tried running `l0-setup endpoint <prefix>`?")
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var serverError *ServerError
+		if err := decoder.Decode(&serverError); err != nil || serverError == nil {
+			return fmt.Errorf("Layer0 API returned invalid status code: %s", resp.Status)
+		}
+
+		return serverError.ToCommonError()
+	}
+
+	if err := c.verifyVersion(resp); err != nil {
+		return err
+	}
+
+	for decoder.More() {
+		if err := fn(decoder); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (c *APIClient) verifyVersion(resp *http.Response) error {
 	if !c.VerifyVersion {
 		return nil
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/paging"
+)
+
+func (c *APIClient) ListCertificates() ([]*models.Certificate, error) {
+	certificates := []*models.Certificate{}
+	fn := func(nextToken string) (string, error) {
+		page, nextToken, err := c.ListCertificatesPage(paging.DefaultLimit, nextToken)
+		if err != nil {
+			return "", err
+		}
+
+		certificates = append(certificates, page...)
+		return nextToken, nil
+	}
+
+	if err := paging.IteratePages(fn); err != nil {
+		return nil, err
+	}
+
+	return certificates, nil
+}
+
+func (c *APIClient) ListCertificatesPage(limit int, nextToken string) ([]*models.Certificate, string, error) {
+	var certificates []*models.Certificate
+	nextToken, err := c.ExecuteWithPage(c.Sling("certificate/").Get(pageQuery(limit, nextToken)), &certificates)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return certificates, nextToken, nil
+}
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func TestListCertificates(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "GET")
+		testutils.AssertEqual(t, r.URL.Path, "/certificate/")
+
+		certificates := []models.Certificate{
+			{CertificateID: "id1", Source: "iam"},
+			{CertificateID: "id2", Source: "acm"},
+		}
+
+		MarshalAndWrite(t, w, certificates, 200)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	certificates, err := client.ListCertificates()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, len(certificates), 2)
+	testutils.AssertEqual(t, certificates[0].CertificateID, "id1")
+	testutils.AssertEqual(t, certificates[1].CertificateID, "id2")
+}
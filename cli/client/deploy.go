@@ -2,6 +2,7 @@ package client
 
 import (
 	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/paging"
 )
 
 func (c *APIClient) CreateDeploy(name string, content []byte) (*models.Deploy, error) {
@@ -37,10 +38,30 @@ func (c *APIClient) GetDeploy(id string) (*models.Deploy, error) {
 }
 
 func (c *APIClient) ListDeploys() ([]*models.DeploySummary, error) {
-	var deploys []*models.DeploySummary
-	if err := c.Execute(c.Sling("deploy/").Get(""), &deploys); err != nil {
+	deploys := []*models.DeploySummary{}
+	fn := func(nextToken string) (string, error) {
+		page, nextToken, err := c.ListDeploysPage(paging.DefaultLimit, nextToken)
+		if err != nil {
+			return "", err
+		}
+
+		deploys = append(deploys, page...)
+		return nextToken, nil
+	}
+
+	if err := paging.IteratePages(fn); err != nil {
 		return nil, err
 	}
 
 	return deploys, nil
 }
+
+func (c *APIClient) ListDeploysPage(limit int, nextToken string) ([]*models.DeploySummary, string, error) {
+	var deploys []*models.DeploySummary
+	nextToken, err := c.ExecuteWithPage(c.Sling("deploy/").Get(pageQuery(limit, nextToken)), &deploys)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return deploys, nextToken, nil
+}
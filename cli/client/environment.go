@@ -1,17 +1,25 @@
 package client
 
 import (
+	"fmt"
+	"net/url"
+	"strconv"
+
 	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/paging"
 )
 
-func (c *APIClient) CreateEnvironment(name, instanceSize string, minCount int, userData []byte, os, amiID string) (*models.Environment, error) {
+func (c *APIClient) CreateEnvironment(name, instanceSize string, minCount int, userData []byte, os, amiID, spotPrice string, mixedInstancesPolicy *models.MixedInstancesPolicy, logSinks []models.LogSink) (*models.Environment, error) {
 	req := models.CreateEnvironmentRequest{
-		EnvironmentName:  name,
-		InstanceSize:     instanceSize,
-		MinClusterCount:  minCount,
-		UserDataTemplate: userData,
-		OperatingSystem:  os,
-		AMIID:            amiID,
+		EnvironmentName:      name,
+		InstanceSize:         instanceSize,
+		MinClusterCount:      minCount,
+		UserDataTemplate:     userData,
+		OperatingSystem:      os,
+		AMIID:                amiID,
+		SpotPrice:            spotPrice,
+		MixedInstancesPolicy: mixedInstancesPolicy,
+		LogSinks:             logSinks,
 	}
 
 	var environment *models.Environment
@@ -40,15 +48,60 @@ func (c *APIClient) GetEnvironment(id string) (*models.Environment, error) {
 	return environment, nil
 }
 
+func (c *APIClient) GetEnvironmentCapacity(id string) (*models.EnvironmentCapacity, error) {
+	var capacity *models.EnvironmentCapacity
+	if err := c.Execute(c.Sling("environment/").Get(id+"/capacity"), &capacity); err != nil {
+		return nil, err
+	}
+
+	return capacity, nil
+}
+
+func (c *APIClient) GetEnvironmentCost(id string, dataTransferGB float64) (*models.EnvironmentCost, error) {
+	query := url.Values{}
+	if dataTransferGB > 0 {
+		query.Set("data_transfer_gb", strconv.FormatFloat(dataTransferGB, 'f', -1, 64))
+	}
+
+	url := fmt.Sprintf("%s/cost?%s", id, query.Encode())
+
+	var cost *models.EnvironmentCost
+	if err := c.Execute(c.Sling("environment/").Get(url), &cost); err != nil {
+		return nil, err
+	}
+
+	return cost, nil
+}
+
 func (c *APIClient) ListEnvironments() ([]*models.EnvironmentSummary, error) {
-	var environments []*models.EnvironmentSummary
-	if err := c.Execute(c.Sling("environment/").Get(""), &environments); err != nil {
+	environments := []*models.EnvironmentSummary{}
+	fn := func(nextToken string) (string, error) {
+		page, nextToken, err := c.ListEnvironmentsPage(paging.DefaultLimit, nextToken)
+		if err != nil {
+			return "", err
+		}
+
+		environments = append(environments, page...)
+		return nextToken, nil
+	}
+
+	if err := paging.IteratePages(fn); err != nil {
 		return nil, err
 	}
 
 	return environments, nil
 }
 
+func (c *APIClient) ListEnvironmentsPage(limit int, nextToken string) ([]*models.EnvironmentSummary, string, error) {
+	var environments []*models.EnvironmentSummary
+	nextToken, err := c.ExecuteWithPage(c.Sling("environment/").Get(pageQuery(limit, nextToken)), &environments)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return environments, nextToken, nil
+}
+
 func (c *APIClient) UpdateEnvironment(id string, minCount int) (*models.Environment, error) {
 	req := models.UpdateEnvironmentRequest{
 		MinClusterCount: minCount,
@@ -62,9 +115,11 @@ func (c *APIClient) UpdateEnvironment(id string, minCount int) (*models.Environm
 	return environment, nil
 }
 
-func (c *APIClient) CreateLink(sourceID string, destinationID string) error {
+func (c *APIClient) CreateLink(sourceID, destinationID string, ports []models.EnvironmentLinkPort, oneWay bool) error {
 	req := models.CreateEnvironmentLinkRequest{
 		EnvironmentID: destinationID,
+		Ports:         ports,
+		OneWay:        oneWay,
 	}
 
 	var resp string
@@ -83,3 +138,29 @@ func (c *APIClient) DeleteLink(sourceID string, destinationID string) error {
 
 	return nil
 }
+
+func (c *APIClient) AuthorizeEnvironmentIngress(id string, rules []models.EnvironmentIngressRule) (*models.Environment, error) {
+	req := models.UpdateEnvironmentIngressRequest{
+		Rules: rules,
+	}
+
+	var environment *models.Environment
+	if err := c.Execute(c.Sling("environment/").Post(id+"/ingress").BodyJSON(req), &environment); err != nil {
+		return nil, err
+	}
+
+	return environment, nil
+}
+
+func (c *APIClient) RevokeEnvironmentIngress(id string, rules []models.EnvironmentIngressRule) (*models.Environment, error) {
+	req := models.UpdateEnvironmentIngressRequest{
+		Rules: rules,
+	}
+
+	var environment *models.Environment
+	if err := c.Execute(c.Sling("environment/").Delete(id+"/ingress").BodyJSON(req), &environment); err != nil {
+		return nil, err
+	}
+
+	return environment, nil
+}
@@ -22,6 +22,16 @@ func TestCreateEnvironment(t *testing.T) {
 		testutils.AssertEqual(t, req.UserDataTemplate, []byte("user_data"))
 		testutils.AssertEqual(t, req.OperatingSystem, "linux")
 		testutils.AssertEqual(t, req.AMIID, "ami")
+		testutils.AssertEqual(t, req.SpotPrice, "0.05")
+		testutils.AssertEqual(t, req.MixedInstancesPolicy, &models.MixedInstancesPolicy{
+			InstanceTypes:               []string{"m5.large", "m4.large"},
+			OnDemandBaseCapacity:        1,
+			OnDemandPercentageAboveBase: 50,
+		})
+
+		testutils.AssertEqual(t, req.LogSinks, []models.LogSink{
+			{Type: "syslog", Address: "tcp://logs:514"},
+		})
 
 		MarshalAndWrite(t, w, models.Environment{EnvironmentID: "id"}, 200)
 	}
@@ -29,7 +39,17 @@ func TestCreateEnvironment(t *testing.T) {
 	client, server := newClientAndServer(handler)
 	defer server.Close()
 
-	environment, err := client.CreateEnvironment("name", "m3.medium", 2, []byte("user_data"), "linux", "ami")
+	logSinks := []models.LogSink{
+		{Type: "syslog", Address: "tcp://logs:514"},
+	}
+
+	policy := &models.MixedInstancesPolicy{
+		InstanceTypes:               []string{"m5.large", "m4.large"},
+		OnDemandBaseCapacity:        1,
+		OnDemandPercentageAboveBase: 50,
+	}
+
+	environment, err := client.CreateEnvironment("name", "m3.medium", 2, []byte("user_data"), "linux", "ami", "0.05", policy, logSinks)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -80,6 +100,47 @@ func TestGetEnvironment(t *testing.T) {
 	testutils.AssertEqual(t, environment.EnvironmentID, "id")
 }
 
+func TestGetEnvironmentCapacity(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "GET")
+		testutils.AssertEqual(t, r.URL.Path, "/environment/id/capacity")
+
+		MarshalAndWrite(t, w, models.EnvironmentCapacity{EnvironmentID: "id", ReclaimableInstances: 1}, 200)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	capacity, err := client.GetEnvironmentCapacity("id")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, capacity.EnvironmentID, "id")
+	testutils.AssertEqual(t, capacity.ReclaimableInstances, 1)
+}
+
+func TestGetEnvironmentCost(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "GET")
+		testutils.AssertEqual(t, r.URL.Path, "/environment/id/cost")
+		testutils.AssertEqual(t, r.URL.Query().Get("data_transfer_gb"), "12.5")
+
+		MarshalAndWrite(t, w, models.EnvironmentCost{EnvironmentID: "id", TotalMonthlyCost: 10.5}, 200)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	cost, err := client.GetEnvironmentCost("id", 12.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, cost.EnvironmentID, "id")
+	testutils.AssertEqual(t, cost.TotalMonthlyCost, 10.5)
+}
+
 func TestListEnvironments(t *testing.T) {
 	handler := func(w http.ResponseWriter, r *http.Request) {
 		testutils.AssertEqual(t, r.Method, "GET")
@@ -131,6 +192,8 @@ func TestUpdateEnvironment(t *testing.T) {
 }
 
 func TestCreateLink(t *testing.T) {
+	ports := []models.EnvironmentLinkPort{{Protocol: "tcp", FromPort: 5432, ToPort: 5432}}
+
 	handler := func(w http.ResponseWriter, r *http.Request) {
 		testutils.AssertEqual(t, r.Method, "POST")
 		testutils.AssertEqual(t, r.URL.Path, "/environment/id1/link")
@@ -139,6 +202,8 @@ func TestCreateLink(t *testing.T) {
 		Unmarshal(t, r, &req)
 
 		testutils.AssertEqual(t, req.EnvironmentID, "id2")
+		testutils.AssertEqual(t, req.Ports, ports)
+		testutils.AssertEqual(t, req.OneWay, true)
 
 		MarshalAndWrite(t, w, "", 200)
 	}
@@ -146,7 +211,7 @@ func TestCreateLink(t *testing.T) {
 	client, server := newClientAndServer(handler)
 	defer server.Close()
 
-	if err := client.CreateLink("id1", "id2"); err != nil {
+	if err := client.CreateLink("id1", "id2", ports, true); err != nil {
 		t.Fatal(err)
 	}
 }
@@ -166,3 +231,59 @@ func TestCreateUnlink(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestAuthorizeEnvironmentIngress(t *testing.T) {
+	rules := []models.EnvironmentIngressRule{
+		{CIDR: "10.1.0.0/16", Protocol: "tcp", FromPort: 22, ToPort: 22},
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "POST")
+		testutils.AssertEqual(t, r.URL.Path, "/environment/id/ingress")
+
+		var req models.UpdateEnvironmentIngressRequest
+		Unmarshal(t, r, &req)
+
+		testutils.AssertEqual(t, req.Rules, rules)
+
+		MarshalAndWrite(t, w, models.Environment{EnvironmentID: "id"}, 200)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	environment, err := client.AuthorizeEnvironmentIngress("id", rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, environment.EnvironmentID, "id")
+}
+
+func TestRevokeEnvironmentIngress(t *testing.T) {
+	rules := []models.EnvironmentIngressRule{
+		{SecurityGroupID: "sg-123", Protocol: "tcp", FromPort: 443, ToPort: 443},
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "DELETE")
+		testutils.AssertEqual(t, r.URL.Path, "/environment/id/ingress")
+
+		var req models.UpdateEnvironmentIngressRequest
+		Unmarshal(t, r, &req)
+
+		testutils.AssertEqual(t, req.Rules, rules)
+
+		MarshalAndWrite(t, w, models.Environment{EnvironmentID: "id"}, 200)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	environment, err := client.RevokeEnvironmentIngress("id", rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, environment.EnvironmentID, "id")
+}
@@ -7,49 +7,69 @@ import (
 )
 
 type Client interface {
+	ListCertificates() ([]*models.Certificate, error)
+	ListCertificatesPage(limit int, nextToken string) ([]*models.Certificate, string, error)
+
 	CreateDeploy(name string, content []byte) (*models.Deploy, error)
 	DeleteDeploy(id string) error
 	GetDeploy(id string) (*models.Deploy, error)
 	ListDeploys() ([]*models.DeploySummary, error)
+	ListDeploysPage(limit int, nextToken string) ([]*models.DeploySummary, string, error)
 
-	CreateEnvironment(name, instanceSize string, minCount int, userData []byte, os, amiID string) (*models.Environment, error)
+	CreateEnvironment(name, instanceSize string, minCount int, userData []byte, os, amiID, spotPrice string, mixedInstancesPolicy *models.MixedInstancesPolicy, logSinks []models.LogSink) (*models.Environment, error)
 	DeleteEnvironment(id string) (string, error)
 	GetEnvironment(id string) (*models.Environment, error)
+	GetEnvironmentCapacity(id string) (*models.EnvironmentCapacity, error)
+	GetEnvironmentCost(id string, dataTransferGB float64) (*models.EnvironmentCost, error)
 	ListEnvironments() ([]*models.EnvironmentSummary, error)
+	ListEnvironmentsPage(limit int, nextToken string) ([]*models.EnvironmentSummary, string, error)
 	UpdateEnvironment(id string, minCount int) (*models.Environment, error)
-	CreateLink(sourceID string, destinationID string) error
+	CreateLink(sourceID, destinationID string, ports []models.EnvironmentLinkPort, oneWay bool) error
 	DeleteLink(sourceID string, destinationID string) error
+	AuthorizeEnvironmentIngress(id string, rules []models.EnvironmentIngressRule) (*models.Environment, error)
+	RevokeEnvironmentIngress(id string, rules []models.EnvironmentIngressRule) (*models.Environment, error)
 
+	CancelJob(id string) error
 	Delete(id string) error
 	GetJob(id string) (*models.Job, error)
 	ListJobs() ([]*models.Job, error)
+	ListJobsPage(limit int, nextToken string) ([]*models.Job, string, error)
 	WaitForJob(jobID string, timeout time.Duration) error
 
-	CreateLoadBalancer(name, environmentID string, healthCheck models.HealthCheck, ports []models.Port, isPublic bool, idleTimeout int, crossZone bool) (*models.LoadBalancer, error)
+	CreateLoadBalancer(name, environmentID string, healthCheck models.HealthCheck, ports []models.Port, isPublic bool, idleTimeout int, crossZone bool, dnsName string) (*models.LoadBalancer, error)
 	DeleteLoadBalancer(id string) (string, error)
 	GetLoadBalancer(id string) (*models.LoadBalancer, error)
 	ListLoadBalancers() ([]*models.LoadBalancerSummary, error)
+	ListLoadBalancersPage(limit int, nextToken string) ([]*models.LoadBalancerSummary, string, error)
 	UpdateLoadBalancerHealthCheck(id string, healthCheck models.HealthCheck) (*models.LoadBalancer, error)
 	UpdateLoadBalancerPorts(id string, ports []models.Port) (*models.LoadBalancer, error)
 	UpdateLoadBalancerIdleTimeout(id string, idleTimeout int) (*models.LoadBalancer, error)
 	UpdateLoadBalancerCrossZone(id string, crossZone bool) (*models.LoadBalancer, error)
+	UpdateLoadBalancerAccessLog(id string, accessLog models.AccessLog) (*models.LoadBalancer, error)
+	GetLoadBalancerMetrics(id, start, end string, period int) (*models.LoadBalancerMetrics, error)
 
 	CreateService(name, environmentID, deployID, loadBalancerID string) (*models.Service, error)
 	DeleteService(id string) (string, error)
 	UpdateService(serviceID, deployID string) (*models.Service, error)
 	GetService(id string) (*models.Service, error)
-	GetServiceLogs(id, start, end string, tail int) ([]*models.LogFile, error)
+	GetServiceLogs(id, start, end, filter, container string, tail int) ([]*models.LogFile, error)
+	FollowServiceLogs(id, start, container string, fn func(event *models.LogEvent) error) error
 	ListServices() ([]*models.ServiceSummary, error)
+	ListServicesPage(limit int, nextToken string) ([]*models.ServiceSummary, string, error)
 	ScaleService(id string, scale int) (*models.Service, error)
 	WaitForDeployment(serviceID string, timeout time.Duration) (*models.Service, error)
 
 	CreateTask(name, environmentID, deployID string, overrides []models.ContainerOverride) (string, error)
 	DeleteTask(id string) error
 	GetTask(id string) (*models.Task, error)
-	GetTaskLogs(id, start, end string, tail int) ([]*models.LogFile, error)
+	GetTaskLogs(id, start, end, filter, container string, tail int) ([]*models.LogFile, error)
+	FollowTaskLogs(id, start, container string, fn func(event *models.LogEvent) error) error
 	ListTasks() ([]*models.TaskSummary, error)
+	ListTasksPage(limit int, nextToken string) ([]*models.TaskSummary, string, error)
 
 	SelectByQuery(params map[string]string) ([]*models.EntityWithTags, error)
+	CreateTag(entityType, entityID, key, value string) error
+	DeleteTag(entityType, entityID, key string) error
 	GetVersion() (string, error)
 	GetConfig() (*models.APIConfig, error)
 	UpdateSQL() error
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/paging"
 	"github.com/quintilesims/layer0/common/types"
 	"github.com/quintilesims/layer0/common/waitutils"
 )
@@ -18,6 +19,15 @@ func (c *APIClient) Delete(id string) error {
 	return nil
 }
 
+func (c *APIClient) CancelJob(id string) error {
+	var response *string
+	if err := c.Execute(c.Sling("job/").Post(fmt.Sprintf("%s/cancel", id)), &response); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (c *APIClient) GetJob(id string) (*models.Job, error) {
 	var job *models.Job
 	if err := c.Execute(c.Sling("job/").Get(id), &job); err != nil {
@@ -28,14 +38,34 @@ func (c *APIClient) GetJob(id string) (*models.Job, error) {
 }
 
 func (c *APIClient) ListJobs() ([]*models.Job, error) {
-	var jobs []*models.Job
-	if err := c.Execute(c.Sling("job/").Get(""), &jobs); err != nil {
+	jobs := []*models.Job{}
+	fn := func(nextToken string) (string, error) {
+		page, nextToken, err := c.ListJobsPage(paging.DefaultLimit, nextToken)
+		if err != nil {
+			return "", err
+		}
+
+		jobs = append(jobs, page...)
+		return nextToken, nil
+	}
+
+	if err := paging.IteratePages(fn); err != nil {
 		return nil, err
 	}
 
 	return jobs, nil
 }
 
+func (c *APIClient) ListJobsPage(limit int, nextToken string) ([]*models.Job, string, error) {
+	var jobs []*models.Job
+	nextToken, err := c.ExecuteWithPage(c.Sling("job/").Get(pageQuery(limit, nextToken)), &jobs)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return jobs, nextToken, nil
+}
+
 func (c *APIClient) WaitForJob(jobID string, timeout time.Duration) error {
 	waiter := waitutils.Waiter{
 		Name:    "WaitForJob",
@@ -54,6 +84,10 @@ func (c *APIClient) WaitForJob(jobID string, timeout time.Duration) error {
 				return false, fmt.Errorf(text)
 			}
 
+			if types.JobStatus(job.JobStatus) == types.Cancelled {
+				return false, fmt.Errorf("Job '%s' was cancelled", job.JobID)
+			}
+
 			if types.JobStatus(job.JobStatus) == types.Completed {
 				return true, nil
 			}
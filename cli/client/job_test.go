@@ -25,6 +25,22 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+func TestCancelJob(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "POST")
+		testutils.AssertEqual(t, r.URL.Path, "/job/id/cancel")
+
+		MarshalAndWrite(t, w, "", 200)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	if err := client.CancelJob("id"); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestSelectByID(t *testing.T) {
 	handler := func(w http.ResponseWriter, r *http.Request) {
 		testutils.AssertEqual(t, r.Method, "GET")
@@ -114,3 +130,17 @@ func TestWaitForJobError(t *testing.T) {
 		t.Fatalf("Error was nil!")
 	}
 }
+
+func TestWaitForJobCancelled(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		job := models.Job{JobID: "id", JobStatus: int64(types.Cancelled)}
+		MarshalAndWrite(t, w, job, 200)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	if err := client.WaitForJob("id", 0); err == nil {
+		t.Fatalf("Error was nil!")
+	}
+}
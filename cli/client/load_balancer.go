@@ -1,10 +1,15 @@
 package client
 
 import (
+	"fmt"
+	"net/url"
+	"strconv"
+
 	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/paging"
 )
 
-func (c *APIClient) CreateLoadBalancer(name, environmentID string, healthCheck models.HealthCheck, ports []models.Port, isPublic bool, idleTimeout int, crossZone bool) (*models.LoadBalancer, error) {
+func (c *APIClient) CreateLoadBalancer(name, environmentID string, healthCheck models.HealthCheck, ports []models.Port, isPublic bool, idleTimeout int, crossZone bool, dnsName string) (*models.LoadBalancer, error) {
 	req := models.CreateLoadBalancerRequest{
 		LoadBalancerName: name,
 		EnvironmentID:    environmentID,
@@ -13,6 +18,7 @@ func (c *APIClient) CreateLoadBalancer(name, environmentID string, healthCheck m
 		IsPublic:         isPublic,
 		IdleTimeout:      idleTimeout,
 		CrossZone:        crossZone,
+		DNSName:          dnsName,
 	}
 
 	var loadBalancer *models.LoadBalancer
@@ -42,14 +48,34 @@ func (c *APIClient) GetLoadBalancer(id string) (*models.LoadBalancer, error) {
 }
 
 func (c *APIClient) ListLoadBalancers() ([]*models.LoadBalancerSummary, error) {
-	var loadBalancers []*models.LoadBalancerSummary
-	if err := c.Execute(c.Sling("loadbalancer/").Get(""), &loadBalancers); err != nil {
+	loadBalancers := []*models.LoadBalancerSummary{}
+	fn := func(nextToken string) (string, error) {
+		page, nextToken, err := c.ListLoadBalancersPage(paging.DefaultLimit, nextToken)
+		if err != nil {
+			return "", err
+		}
+
+		loadBalancers = append(loadBalancers, page...)
+		return nextToken, nil
+	}
+
+	if err := paging.IteratePages(fn); err != nil {
 		return nil, err
 	}
 
 	return loadBalancers, nil
 }
 
+func (c *APIClient) ListLoadBalancersPage(limit int, nextToken string) ([]*models.LoadBalancerSummary, string, error) {
+	var loadBalancers []*models.LoadBalancerSummary
+	nextToken, err := c.ExecuteWithPage(c.Sling("loadbalancer/").Get(pageQuery(limit, nextToken)), &loadBalancers)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return loadBalancers, nextToken, nil
+}
+
 func (c *APIClient) UpdateLoadBalancerHealthCheck(id string, healthCheck models.HealthCheck) (*models.LoadBalancer, error) {
 	req := models.UpdateLoadBalancerHealthCheckRequest{
 		HealthCheck: healthCheck,
@@ -101,3 +127,40 @@ func (c *APIClient) UpdateLoadBalancerCrossZone(id string, crossZone bool) (*mod
 
 	return loadBalancer, nil
 }
+
+func (c *APIClient) UpdateLoadBalancerAccessLog(id string, accessLog models.AccessLog) (*models.LoadBalancer, error) {
+	req := models.UpdateLoadBalancerAccessLogRequest{
+		AccessLog: accessLog,
+	}
+
+	var loadBalancer *models.LoadBalancer
+	if err := c.Execute(c.Sling("loadbalancer/").Put(id+"/accesslog").BodyJSON(req), &loadBalancer); err != nil {
+		return nil, err
+	}
+
+	return loadBalancer, nil
+}
+
+func (c *APIClient) GetLoadBalancerMetrics(id, start, end string, period int) (*models.LoadBalancerMetrics, error) {
+	query := url.Values{}
+	if start != "" {
+		query.Set("start", start)
+	}
+
+	if end != "" {
+		query.Set("end", end)
+	}
+
+	if period > 0 {
+		query.Set("period", strconv.Itoa(period))
+	}
+
+	url := fmt.Sprintf("%s/metrics?%s", id, query.Encode())
+
+	var metrics *models.LoadBalancerMetrics
+	if err := c.Execute(c.Sling("loadbalancer/").Get(url), &metrics); err != nil {
+		return nil, err
+	}
+
+	return metrics, nil
+}
@@ -45,6 +45,7 @@ func TestCreateLoadBalancer(t *testing.T) {
 		testutils.AssertEqual(t, req.HealthCheck, healthCheck)
 		testutils.AssertEqual(t, req.Ports, ports)
 		testutils.AssertEqual(t, req.IdleTimeout, 60)
+		testutils.AssertEqual(t, req.DNSName, "api.example.com")
 
 		MarshalAndWrite(t, w, models.LoadBalancer{LoadBalancerID: "id"}, 200)
 	}
@@ -52,7 +53,7 @@ func TestCreateLoadBalancer(t *testing.T) {
 	client, server := newClientAndServer(handler)
 	defer server.Close()
 
-	loadBalancer, err := client.CreateLoadBalancer("name", "environmentID", healthCheck, ports, true, 60, true)
+	loadBalancer, err := client.CreateLoadBalancer("name", "environmentID", healthCheck, ports, true, 60, true, "api.example.com")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -251,3 +252,58 @@ func TestUpdateLoadBalancerCrossZone(t *testing.T) {
 
 	testutils.AssertEqual(t, loadBalancer.LoadBalancerID, "id")
 }
+
+func TestUpdateLoadBalancerAccessLog(t *testing.T) {
+	accessLog := models.AccessLog{
+		Enabled:      true,
+		BucketName:   "bucket",
+		BucketPrefix: "prefix",
+		EmitInterval: 5,
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "PUT")
+		testutils.AssertEqual(t, r.URL.Path, "/loadbalancer/id/accesslog")
+
+		var req models.UpdateLoadBalancerAccessLogRequest
+		Unmarshal(t, r, &req)
+
+		testutils.AssertEqual(t, req.AccessLog, accessLog)
+
+		MarshalAndWrite(t, w, models.LoadBalancer{LoadBalancerID: "id"}, 200)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	loadBalancer, err := client.UpdateLoadBalancerAccessLog("id", accessLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, loadBalancer.LoadBalancerID, "id")
+}
+
+func TestGetLoadBalancerMetrics(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "GET")
+		testutils.AssertEqual(t, r.URL.Path, "/loadbalancer/id/metrics")
+
+		query := r.URL.Query()
+		testutils.AssertEqual(t, query.Get("start"), "2017-01-01 00:00")
+		testutils.AssertEqual(t, query.Get("end"), "2017-01-01 01:00")
+		testutils.AssertEqual(t, query.Get("period"), "300")
+
+		MarshalAndWrite(t, w, models.LoadBalancerMetrics{LoadBalancerID: "id"}, 200)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	metrics, err := client.GetLoadBalancerMetrics("id", "2017-01-01 00:00", "2017-01-01 01:00", 300)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, metrics.LoadBalancerID, "id")
+}
@@ -34,6 +34,31 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// AuthorizeEnvironmentIngress mocks base method
+func (m *MockClient) AuthorizeEnvironmentIngress(arg0 string, arg1 []models.EnvironmentIngressRule) (*models.Environment, error) {
+	ret := m.ctrl.Call(m, "AuthorizeEnvironmentIngress", arg0, arg1)
+	ret0, _ := ret[0].(*models.Environment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorizeEnvironmentIngress indicates an expected call of AuthorizeEnvironmentIngress
+func (mr *MockClientMockRecorder) AuthorizeEnvironmentIngress(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeEnvironmentIngress", reflect.TypeOf((*MockClient)(nil).AuthorizeEnvironmentIngress), arg0, arg1)
+}
+
+// CancelJob mocks base method
+func (m *MockClient) CancelJob(arg0 string) error {
+	ret := m.ctrl.Call(m, "CancelJob", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelJob indicates an expected call of CancelJob
+func (mr *MockClientMockRecorder) CancelJob(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelJob", reflect.TypeOf((*MockClient)(nil).CancelJob), arg0)
+}
+
 // CreateDeploy mocks base method
 func (m *MockClient) CreateDeploy(arg0 string, arg1 []byte) (*models.Deploy, error) {
 	ret := m.ctrl.Call(m, "CreateDeploy", arg0, arg1)
@@ -48,41 +73,41 @@ func (mr *MockClientMockRecorder) CreateDeploy(arg0, arg1 interface{}) *gomock.C
 }
 
 // CreateEnvironment mocks base method
-func (m *MockClient) CreateEnvironment(arg0, arg1 string, arg2 int, arg3 []byte, arg4, arg5 string) (*models.Environment, error) {
-	ret := m.ctrl.Call(m, "CreateEnvironment", arg0, arg1, arg2, arg3, arg4, arg5)
+func (m *MockClient) CreateEnvironment(arg0, arg1 string, arg2 int, arg3 []byte, arg4, arg5, arg6 string, arg7 *models.MixedInstancesPolicy, arg8 []models.LogSink) (*models.Environment, error) {
+	ret := m.ctrl.Call(m, "CreateEnvironment", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
 	ret0, _ := ret[0].(*models.Environment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateEnvironment indicates an expected call of CreateEnvironment
-func (mr *MockClientMockRecorder) CreateEnvironment(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEnvironment", reflect.TypeOf((*MockClient)(nil).CreateEnvironment), arg0, arg1, arg2, arg3, arg4, arg5)
+func (mr *MockClientMockRecorder) CreateEnvironment(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEnvironment", reflect.TypeOf((*MockClient)(nil).CreateEnvironment), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
 }
 
 // CreateLink mocks base method
-func (m *MockClient) CreateLink(arg0, arg1 string) error {
-	ret := m.ctrl.Call(m, "CreateLink", arg0, arg1)
+func (m *MockClient) CreateLink(arg0, arg1 string, arg2 []models.EnvironmentLinkPort, arg3 bool) error {
+	ret := m.ctrl.Call(m, "CreateLink", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateLink indicates an expected call of CreateLink
-func (mr *MockClientMockRecorder) CreateLink(arg0, arg1 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLink", reflect.TypeOf((*MockClient)(nil).CreateLink), arg0, arg1)
+func (mr *MockClientMockRecorder) CreateLink(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLink", reflect.TypeOf((*MockClient)(nil).CreateLink), arg0, arg1, arg2, arg3)
 }
 
 // CreateLoadBalancer mocks base method
-func (m *MockClient) CreateLoadBalancer(arg0, arg1 string, arg2 models.HealthCheck, arg3 []models.Port, arg4 bool, arg5 int, arg6 bool) (*models.LoadBalancer, error) {
-	ret := m.ctrl.Call(m, "CreateLoadBalancer", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+func (m *MockClient) CreateLoadBalancer(arg0, arg1 string, arg2 models.HealthCheck, arg3 []models.Port, arg4 bool, arg5 int, arg6 bool, arg7 string) (*models.LoadBalancer, error) {
+	ret := m.ctrl.Call(m, "CreateLoadBalancer", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
 	ret0, _ := ret[0].(*models.LoadBalancer)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateLoadBalancer indicates an expected call of CreateLoadBalancer
-func (mr *MockClientMockRecorder) CreateLoadBalancer(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoadBalancer", reflect.TypeOf((*MockClient)(nil).CreateLoadBalancer), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+func (mr *MockClientMockRecorder) CreateLoadBalancer(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoadBalancer", reflect.TypeOf((*MockClient)(nil).CreateLoadBalancer), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
 }
 
 // CreateService mocks base method
@@ -98,6 +123,18 @@ func (mr *MockClientMockRecorder) CreateService(arg0, arg1, arg2, arg3 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateService", reflect.TypeOf((*MockClient)(nil).CreateService), arg0, arg1, arg2, arg3)
 }
 
+// CreateTag mocks base method
+func (m *MockClient) CreateTag(arg0, arg1, arg2, arg3 string) error {
+	ret := m.ctrl.Call(m, "CreateTag", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTag indicates an expected call of CreateTag
+func (mr *MockClientMockRecorder) CreateTag(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTag", reflect.TypeOf((*MockClient)(nil).CreateTag), arg0, arg1, arg2, arg3)
+}
+
 // CreateTask mocks base method
 func (m *MockClient) CreateTask(arg0, arg1, arg2 string, arg3 []models.ContainerOverride) (string, error) {
 	ret := m.ctrl.Call(m, "CreateTask", arg0, arg1, arg2, arg3)
@@ -186,6 +223,18 @@ func (mr *MockClientMockRecorder) DeleteService(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteService", reflect.TypeOf((*MockClient)(nil).DeleteService), arg0)
 }
 
+// DeleteTag mocks base method
+func (m *MockClient) DeleteTag(arg0, arg1, arg2 string) error {
+	ret := m.ctrl.Call(m, "DeleteTag", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTag indicates an expected call of DeleteTag
+func (mr *MockClientMockRecorder) DeleteTag(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockClient)(nil).DeleteTag), arg0, arg1, arg2)
+}
+
 // DeleteTask mocks base method
 func (m *MockClient) DeleteTask(arg0 string) error {
 	ret := m.ctrl.Call(m, "DeleteTask", arg0)
@@ -198,6 +247,30 @@ func (mr *MockClientMockRecorder) DeleteTask(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockClient)(nil).DeleteTask), arg0)
 }
 
+// FollowServiceLogs mocks base method
+func (m *MockClient) FollowServiceLogs(arg0, arg1, arg2 string, arg3 func(*models.LogEvent) error) error {
+	ret := m.ctrl.Call(m, "FollowServiceLogs", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FollowServiceLogs indicates an expected call of FollowServiceLogs
+func (mr *MockClientMockRecorder) FollowServiceLogs(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowServiceLogs", reflect.TypeOf((*MockClient)(nil).FollowServiceLogs), arg0, arg1, arg2, arg3)
+}
+
+// FollowTaskLogs mocks base method
+func (m *MockClient) FollowTaskLogs(arg0, arg1, arg2 string, arg3 func(*models.LogEvent) error) error {
+	ret := m.ctrl.Call(m, "FollowTaskLogs", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FollowTaskLogs indicates an expected call of FollowTaskLogs
+func (mr *MockClientMockRecorder) FollowTaskLogs(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowTaskLogs", reflect.TypeOf((*MockClient)(nil).FollowTaskLogs), arg0, arg1, arg2, arg3)
+}
+
 // GetConfig mocks base method
 func (m *MockClient) GetConfig() (*models.APIConfig, error) {
 	ret := m.ctrl.Call(m, "GetConfig")
@@ -237,6 +310,32 @@ func (mr *MockClientMockRecorder) GetEnvironment(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvironment", reflect.TypeOf((*MockClient)(nil).GetEnvironment), arg0)
 }
 
+// GetEnvironmentCapacity mocks base method
+func (m *MockClient) GetEnvironmentCapacity(arg0 string) (*models.EnvironmentCapacity, error) {
+	ret := m.ctrl.Call(m, "GetEnvironmentCapacity", arg0)
+	ret0, _ := ret[0].(*models.EnvironmentCapacity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnvironmentCapacity indicates an expected call of GetEnvironmentCapacity
+func (mr *MockClientMockRecorder) GetEnvironmentCapacity(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvironmentCapacity", reflect.TypeOf((*MockClient)(nil).GetEnvironmentCapacity), arg0)
+}
+
+// GetEnvironmentCost mocks base method
+func (m *MockClient) GetEnvironmentCost(arg0 string, arg1 float64) (*models.EnvironmentCost, error) {
+	ret := m.ctrl.Call(m, "GetEnvironmentCost", arg0, arg1)
+	ret0, _ := ret[0].(*models.EnvironmentCost)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnvironmentCost indicates an expected call of GetEnvironmentCost
+func (mr *MockClientMockRecorder) GetEnvironmentCost(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvironmentCost", reflect.TypeOf((*MockClient)(nil).GetEnvironmentCost), arg0, arg1)
+}
+
 // GetJob mocks base method
 func (m *MockClient) GetJob(arg0 string) (*models.Job, error) {
 	ret := m.ctrl.Call(m, "GetJob", arg0)
@@ -263,6 +362,19 @@ func (mr *MockClientMockRecorder) GetLoadBalancer(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancer", reflect.TypeOf((*MockClient)(nil).GetLoadBalancer), arg0)
 }
 
+// GetLoadBalancerMetrics mocks base method
+func (m *MockClient) GetLoadBalancerMetrics(arg0, arg1, arg2 string, arg3 int) (*models.LoadBalancerMetrics, error) {
+	ret := m.ctrl.Call(m, "GetLoadBalancerMetrics", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*models.LoadBalancerMetrics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoadBalancerMetrics indicates an expected call of GetLoadBalancerMetrics
+func (mr *MockClientMockRecorder) GetLoadBalancerMetrics(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerMetrics", reflect.TypeOf((*MockClient)(nil).GetLoadBalancerMetrics), arg0, arg1, arg2, arg3)
+}
+
 // GetService mocks base method
 func (m *MockClient) GetService(arg0 string) (*models.Service, error) {
 	ret := m.ctrl.Call(m, "GetService", arg0)
@@ -277,16 +389,16 @@ func (mr *MockClientMockRecorder) GetService(arg0 interface{}) *gomock.Call {
 }
 
 // GetServiceLogs mocks base method
-func (m *MockClient) GetServiceLogs(arg0, arg1, arg2 string, arg3 int) ([]*models.LogFile, error) {
-	ret := m.ctrl.Call(m, "GetServiceLogs", arg0, arg1, arg2, arg3)
+func (m *MockClient) GetServiceLogs(arg0, arg1, arg2, arg3, arg4 string, arg5 int) ([]*models.LogFile, error) {
+	ret := m.ctrl.Call(m, "GetServiceLogs", arg0, arg1, arg2, arg3, arg4, arg5)
 	ret0, _ := ret[0].([]*models.LogFile)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetServiceLogs indicates an expected call of GetServiceLogs
-func (mr *MockClientMockRecorder) GetServiceLogs(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceLogs", reflect.TypeOf((*MockClient)(nil).GetServiceLogs), arg0, arg1, arg2, arg3)
+func (mr *MockClientMockRecorder) GetServiceLogs(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceLogs", reflect.TypeOf((*MockClient)(nil).GetServiceLogs), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
 // GetTask mocks base method
@@ -303,16 +415,16 @@ func (mr *MockClientMockRecorder) GetTask(arg0 interface{}) *gomock.Call {
 }
 
 // GetTaskLogs mocks base method
-func (m *MockClient) GetTaskLogs(arg0, arg1, arg2 string, arg3 int) ([]*models.LogFile, error) {
-	ret := m.ctrl.Call(m, "GetTaskLogs", arg0, arg1, arg2, arg3)
+func (m *MockClient) GetTaskLogs(arg0, arg1, arg2, arg3, arg4 string, arg5 int) ([]*models.LogFile, error) {
+	ret := m.ctrl.Call(m, "GetTaskLogs", arg0, arg1, arg2, arg3, arg4, arg5)
 	ret0, _ := ret[0].([]*models.LogFile)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTaskLogs indicates an expected call of GetTaskLogs
-func (mr *MockClientMockRecorder) GetTaskLogs(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskLogs", reflect.TypeOf((*MockClient)(nil).GetTaskLogs), arg0, arg1, arg2, arg3)
+func (mr *MockClientMockRecorder) GetTaskLogs(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskLogs", reflect.TypeOf((*MockClient)(nil).GetTaskLogs), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
 // GetVersion mocks base method
@@ -328,6 +440,33 @@ func (mr *MockClientMockRecorder) GetVersion() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersion", reflect.TypeOf((*MockClient)(nil).GetVersion))
 }
 
+// ListCertificates mocks base method
+func (m *MockClient) ListCertificates() ([]*models.Certificate, error) {
+	ret := m.ctrl.Call(m, "ListCertificates")
+	ret0, _ := ret[0].([]*models.Certificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCertificates indicates an expected call of ListCertificates
+func (mr *MockClientMockRecorder) ListCertificates() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCertificates", reflect.TypeOf((*MockClient)(nil).ListCertificates))
+}
+
+// ListCertificatesPage mocks base method
+func (m *MockClient) ListCertificatesPage(arg0 int, arg1 string) ([]*models.Certificate, string, error) {
+	ret := m.ctrl.Call(m, "ListCertificatesPage", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Certificate)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListCertificatesPage indicates an expected call of ListCertificatesPage
+func (mr *MockClientMockRecorder) ListCertificatesPage(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCertificatesPage", reflect.TypeOf((*MockClient)(nil).ListCertificatesPage), arg0, arg1)
+}
+
 // ListDeploys mocks base method
 func (m *MockClient) ListDeploys() ([]*models.DeploySummary, error) {
 	ret := m.ctrl.Call(m, "ListDeploys")
@@ -341,6 +480,20 @@ func (mr *MockClientMockRecorder) ListDeploys() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeploys", reflect.TypeOf((*MockClient)(nil).ListDeploys))
 }
 
+// ListDeploysPage mocks base method
+func (m *MockClient) ListDeploysPage(arg0 int, arg1 string) ([]*models.DeploySummary, string, error) {
+	ret := m.ctrl.Call(m, "ListDeploysPage", arg0, arg1)
+	ret0, _ := ret[0].([]*models.DeploySummary)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListDeploysPage indicates an expected call of ListDeploysPage
+func (mr *MockClientMockRecorder) ListDeploysPage(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeploysPage", reflect.TypeOf((*MockClient)(nil).ListDeploysPage), arg0, arg1)
+}
+
 // ListEnvironments mocks base method
 func (m *MockClient) ListEnvironments() ([]*models.EnvironmentSummary, error) {
 	ret := m.ctrl.Call(m, "ListEnvironments")
@@ -354,6 +507,20 @@ func (mr *MockClientMockRecorder) ListEnvironments() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnvironments", reflect.TypeOf((*MockClient)(nil).ListEnvironments))
 }
 
+// ListEnvironmentsPage mocks base method
+func (m *MockClient) ListEnvironmentsPage(arg0 int, arg1 string) ([]*models.EnvironmentSummary, string, error) {
+	ret := m.ctrl.Call(m, "ListEnvironmentsPage", arg0, arg1)
+	ret0, _ := ret[0].([]*models.EnvironmentSummary)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListEnvironmentsPage indicates an expected call of ListEnvironmentsPage
+func (mr *MockClientMockRecorder) ListEnvironmentsPage(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEnvironmentsPage", reflect.TypeOf((*MockClient)(nil).ListEnvironmentsPage), arg0, arg1)
+}
+
 // ListJobs mocks base method
 func (m *MockClient) ListJobs() ([]*models.Job, error) {
 	ret := m.ctrl.Call(m, "ListJobs")
@@ -367,6 +534,20 @@ func (mr *MockClientMockRecorder) ListJobs() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobs", reflect.TypeOf((*MockClient)(nil).ListJobs))
 }
 
+// ListJobsPage mocks base method
+func (m *MockClient) ListJobsPage(arg0 int, arg1 string) ([]*models.Job, string, error) {
+	ret := m.ctrl.Call(m, "ListJobsPage", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Job)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListJobsPage indicates an expected call of ListJobsPage
+func (mr *MockClientMockRecorder) ListJobsPage(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJobsPage", reflect.TypeOf((*MockClient)(nil).ListJobsPage), arg0, arg1)
+}
+
 // ListLoadBalancers mocks base method
 func (m *MockClient) ListLoadBalancers() ([]*models.LoadBalancerSummary, error) {
 	ret := m.ctrl.Call(m, "ListLoadBalancers")
@@ -380,6 +561,20 @@ func (mr *MockClientMockRecorder) ListLoadBalancers() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoadBalancers", reflect.TypeOf((*MockClient)(nil).ListLoadBalancers))
 }
 
+// ListLoadBalancersPage mocks base method
+func (m *MockClient) ListLoadBalancersPage(arg0 int, arg1 string) ([]*models.LoadBalancerSummary, string, error) {
+	ret := m.ctrl.Call(m, "ListLoadBalancersPage", arg0, arg1)
+	ret0, _ := ret[0].([]*models.LoadBalancerSummary)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListLoadBalancersPage indicates an expected call of ListLoadBalancersPage
+func (mr *MockClientMockRecorder) ListLoadBalancersPage(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoadBalancersPage", reflect.TypeOf((*MockClient)(nil).ListLoadBalancersPage), arg0, arg1)
+}
+
 // ListServices mocks base method
 func (m *MockClient) ListServices() ([]*models.ServiceSummary, error) {
 	ret := m.ctrl.Call(m, "ListServices")
@@ -393,6 +588,20 @@ func (mr *MockClientMockRecorder) ListServices() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServices", reflect.TypeOf((*MockClient)(nil).ListServices))
 }
 
+// ListServicesPage mocks base method
+func (m *MockClient) ListServicesPage(arg0 int, arg1 string) ([]*models.ServiceSummary, string, error) {
+	ret := m.ctrl.Call(m, "ListServicesPage", arg0, arg1)
+	ret0, _ := ret[0].([]*models.ServiceSummary)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListServicesPage indicates an expected call of ListServicesPage
+func (mr *MockClientMockRecorder) ListServicesPage(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServicesPage", reflect.TypeOf((*MockClient)(nil).ListServicesPage), arg0, arg1)
+}
+
 // ListTasks mocks base method
 func (m *MockClient) ListTasks() ([]*models.TaskSummary, error) {
 	ret := m.ctrl.Call(m, "ListTasks")
@@ -406,6 +615,33 @@ func (mr *MockClientMockRecorder) ListTasks() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasks", reflect.TypeOf((*MockClient)(nil).ListTasks))
 }
 
+// ListTasksPage mocks base method
+func (m *MockClient) ListTasksPage(arg0 int, arg1 string) ([]*models.TaskSummary, string, error) {
+	ret := m.ctrl.Call(m, "ListTasksPage", arg0, arg1)
+	ret0, _ := ret[0].([]*models.TaskSummary)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListTasksPage indicates an expected call of ListTasksPage
+func (mr *MockClientMockRecorder) ListTasksPage(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasksPage", reflect.TypeOf((*MockClient)(nil).ListTasksPage), arg0, arg1)
+}
+
+// RevokeEnvironmentIngress mocks base method
+func (m *MockClient) RevokeEnvironmentIngress(arg0 string, arg1 []models.EnvironmentIngressRule) (*models.Environment, error) {
+	ret := m.ctrl.Call(m, "RevokeEnvironmentIngress", arg0, arg1)
+	ret0, _ := ret[0].(*models.Environment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeEnvironmentIngress indicates an expected call of RevokeEnvironmentIngress
+func (mr *MockClientMockRecorder) RevokeEnvironmentIngress(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeEnvironmentIngress", reflect.TypeOf((*MockClient)(nil).RevokeEnvironmentIngress), arg0, arg1)
+}
+
 // RunScaler mocks base method
 func (m *MockClient) RunScaler(arg0 string) (*models.ScalerRunInfo, error) {
 	ret := m.ctrl.Call(m, "RunScaler", arg0)
@@ -458,6 +694,19 @@ func (mr *MockClientMockRecorder) UpdateEnvironment(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEnvironment", reflect.TypeOf((*MockClient)(nil).UpdateEnvironment), arg0, arg1)
 }
 
+// UpdateLoadBalancerAccessLog mocks base method
+func (m *MockClient) UpdateLoadBalancerAccessLog(arg0 string, arg1 models.AccessLog) (*models.LoadBalancer, error) {
+	ret := m.ctrl.Call(m, "UpdateLoadBalancerAccessLog", arg0, arg1)
+	ret0, _ := ret[0].(*models.LoadBalancer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLoadBalancerAccessLog indicates an expected call of UpdateLoadBalancerAccessLog
+func (mr *MockClientMockRecorder) UpdateLoadBalancerAccessLog(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLoadBalancerAccessLog", reflect.TypeOf((*MockClient)(nil).UpdateLoadBalancerAccessLog), arg0, arg1)
+}
+
 // UpdateLoadBalancerCrossZone mocks base method
 func (m *MockClient) UpdateLoadBalancerCrossZone(arg0 string, arg1 bool) (*models.LoadBalancer, error) {
 	ret := m.ctrl.Call(m, "UpdateLoadBalancerCrossZone", arg0, arg1)
@@ -1,12 +1,14 @@
 package client
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strconv"
 	"time"
 
 	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/paging"
 	"github.com/quintilesims/layer0/common/waitutils"
 )
 
@@ -59,7 +61,7 @@ func (c *APIClient) GetService(id string) (*models.Service, error) {
 	return service, nil
 }
 
-func (c *APIClient) GetServiceLogs(id, start, end string, tail int) ([]*models.LogFile, error) {
+func (c *APIClient) GetServiceLogs(id, start, end, filter, container string, tail int) ([]*models.LogFile, error) {
 	query := url.Values{}
 	if tail > 0 {
 		query.Set("tail", strconv.Itoa(tail))
@@ -73,6 +75,14 @@ func (c *APIClient) GetServiceLogs(id, start, end string, tail int) ([]*models.L
 		query.Set("end", end)
 	}
 
+	if filter != "" {
+		query.Set("filter", filter)
+	}
+
+	if container != "" {
+		query.Set("container", container)
+	}
+
 	url := fmt.Sprintf("%s/logs?%s", id, query.Encode())
 
 	var logFiles []*models.LogFile
@@ -83,15 +93,63 @@ func (c *APIClient) GetServiceLogs(id, start, end string, tail int) ([]*models.L
 	return logFiles, nil
 }
 
+// FollowServiceLogs streams new log events for the service to fn until the connection
+// is closed by the API or fn returns an error.
+func (c *APIClient) FollowServiceLogs(id, start, container string, fn func(event *models.LogEvent) error) error {
+	query := url.Values{}
+	query.Set("follow", "true")
+
+	if start != "" {
+		query.Set("start", start)
+	}
+
+	if container != "" {
+		query.Set("container", container)
+	}
+
+	url := fmt.Sprintf("%s/logs?%s", id, query.Encode())
+
+	decodef := func(decoder *json.Decoder) error {
+		var event *models.LogEvent
+		if err := decoder.Decode(&event); err != nil {
+			return err
+		}
+
+		return fn(event)
+	}
+
+	return c.ExecuteStream(c.Sling("service/").Get(url), decodef)
+}
+
 func (c *APIClient) ListServices() ([]*models.ServiceSummary, error) {
-	var services []*models.ServiceSummary
-	if err := c.Execute(c.Sling("service/").Get(""), &services); err != nil {
+	services := []*models.ServiceSummary{}
+	fn := func(nextToken string) (string, error) {
+		page, nextToken, err := c.ListServicesPage(paging.DefaultLimit, nextToken)
+		if err != nil {
+			return "", err
+		}
+
+		services = append(services, page...)
+		return nextToken, nil
+	}
+
+	if err := paging.IteratePages(fn); err != nil {
 		return nil, err
 	}
 
 	return services, nil
 }
 
+func (c *APIClient) ListServicesPage(limit int, nextToken string) ([]*models.ServiceSummary, string, error) {
+	var services []*models.ServiceSummary
+	nextToken, err := c.ExecuteWithPage(c.Sling("service/").Get(pageQuery(limit, nextToken)), &services)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return services, nextToken, nil
+}
+
 func (c *APIClient) ScaleService(id string, count int) (*models.Service, error) {
 	request := models.ScaleServiceRequest{
 		DesiredCount: int64(count),
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"encoding/json"
 	"net/http"
 	"testing"
 	"time"
@@ -86,6 +87,8 @@ func TestGetServiceLogs(t *testing.T) {
 		testutils.AssertEqual(t, r.URL.Query().Get("tail"), "100")
 		testutils.AssertEqual(t, r.URL.Query().Get("start"), "2001-01-01 01:01")
 		testutils.AssertEqual(t, r.URL.Query().Get("end"), "2012-12-12 12:12")
+		testutils.AssertEqual(t, r.URL.Query().Get("filter"), "ERROR")
+		testutils.AssertEqual(t, r.URL.Query().Get("container"), "api")
 
 		logs := []models.LogFile{
 			{Name: "name1"},
@@ -98,7 +101,7 @@ func TestGetServiceLogs(t *testing.T) {
 	client, server := newClientAndServer(handler)
 	defer server.Close()
 
-	logs, err := client.GetServiceLogs("id", "2001-01-01 01:01", "2012-12-12 12:12", 100)
+	logs, err := client.GetServiceLogs("id", "2001-01-01 01:01", "2012-12-12 12:12", "ERROR", "api", 100)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -108,6 +111,58 @@ func TestGetServiceLogs(t *testing.T) {
 	testutils.AssertEqual(t, logs[1].Name, "name2")
 }
 
+func TestFollowServiceLogs(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "GET")
+		testutils.AssertEqual(t, r.URL.Path, "/service/id/logs")
+		testutils.AssertEqual(t, r.URL.Query().Get("follow"), "true")
+		testutils.AssertEqual(t, r.URL.Query().Get("container"), "api")
+		testutils.AssertEqual(t, r.URL.Query().Get("start"), "2001-01-01 01:01")
+
+		encoder := json.NewEncoder(w)
+		encoder.Encode(models.LogEvent{ContainerName: "alpha", Message: "first"})
+		encoder.Encode(models.LogEvent{ContainerName: "beta", Message: "second"})
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	events := []*models.LogEvent{}
+	fn := func(event *models.LogEvent) error {
+		events = append(events, event)
+		return nil
+	}
+
+	if err := client.FollowServiceLogs("id", "2001-01-01 01:01", "api", fn); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*models.LogEvent{
+		{ContainerName: "alpha", Message: "first"},
+		{ContainerName: "beta", Message: "second"},
+	}
+
+	testutils.AssertEqual(t, events, expected)
+}
+
+func TestFollowServiceLogs_serverError(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		MarshalAndWrite(t, w, models.ServerError{ErrorCode: 1, Message: "some error"}, 404)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	fn := func(event *models.LogEvent) error {
+		t.Fatalf("Unexpected event: %#v", event)
+		return nil
+	}
+
+	if err := client.FollowServiceLogs("id", "", "", fn); err == nil {
+		t.Fatal("Error was nil!")
+	}
+}
+
 func TestListServices(t *testing.T) {
 	handler := func(w http.ResponseWriter, r *http.Request) {
 		testutils.AssertEqual(t, r.Method, "GET")
@@ -134,6 +189,58 @@ func TestListServices(t *testing.T) {
 	testutils.AssertEqual(t, services[1].ServiceID, "id2")
 }
 
+func TestListServices_multiplePages(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.URL.Query().Get("limit"), "100")
+
+		switch nextToken := r.URL.Query().Get("next_token"); nextToken {
+		case "":
+			headers := map[string]string{"X-Next-Token": "token"}
+			MarshalAndWriteHeader(t, w, []models.ServiceSummary{{ServiceID: "id1"}}, headers, 200)
+		case "token":
+			MarshalAndWrite(t, w, []models.ServiceSummary{{ServiceID: "id2"}}, 200)
+		default:
+			t.Fatalf("Unexpected next token '%s'", nextToken)
+		}
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	services, err := client.ListServices()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, len(services), 2)
+	testutils.AssertEqual(t, services[0].ServiceID, "id1")
+	testutils.AssertEqual(t, services[1].ServiceID, "id2")
+}
+
+func TestListServicesPage(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "GET")
+		testutils.AssertEqual(t, r.URL.Path, "/service/")
+		testutils.AssertEqual(t, r.URL.Query().Get("limit"), "1")
+		testutils.AssertEqual(t, r.URL.Query().Get("next_token"), "token1")
+
+		headers := map[string]string{"X-Next-Token": "token2"}
+		MarshalAndWriteHeader(t, w, []models.ServiceSummary{{ServiceID: "id1"}}, headers, 200)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	services, nextToken, err := client.ListServicesPage(1, "token1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, len(services), 1)
+	testutils.AssertEqual(t, services[0].ServiceID, "id1")
+	testutils.AssertEqual(t, nextToken, "token2")
+}
+
 func TestScaleService(t *testing.T) {
 	handler := func(w http.ResponseWriter, r *http.Request) {
 		testutils.AssertEqual(t, r.Method, "PUT")
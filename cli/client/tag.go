@@ -1,21 +1,83 @@
 package client
 
 import (
-	"fmt"
+	"net/url"
+	"strconv"
 
 	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/paging"
 )
 
+// SelectByQuery returns the entities that match the query params.
+// Every page of results is returned unless the params include 'limit'.
 func (c *APIClient) SelectByQuery(params map[string]string) ([]*models.EntityWithTags, error) {
-	query := "?"
+	query := url.Values{}
 	for k, v := range params {
-		query += fmt.Sprintf("&%s=%s", k, v)
+		query.Set(k, v)
 	}
 
+	if _, ok := params["limit"]; ok {
+		return c.selectByQueryPage(query)
+	}
+
+	ewts := []*models.EntityWithTags{}
+	fn := func(nextToken string) (string, error) {
+		query.Set("limit", strconv.Itoa(paging.DefaultLimit))
+		if nextToken != "" {
+			query.Set("next_token", nextToken)
+		}
+
+		var page []*models.EntityWithTags
+		nextToken, err := c.ExecuteWithPage(c.Sling("/tag").Get("?"+query.Encode()), &page)
+		if err != nil {
+			return "", err
+		}
+
+		ewts = append(ewts, page...)
+		return nextToken, nil
+	}
+
+	if err := paging.IteratePages(fn); err != nil {
+		return nil, err
+	}
+
+	return ewts, nil
+}
+
+func (c *APIClient) selectByQueryPage(query url.Values) ([]*models.EntityWithTags, error) {
 	var response []*models.EntityWithTags
-	if err := c.Execute(c.Sling("/tag").Get(query), &response); err != nil {
+	if err := c.Execute(c.Sling("/tag").Get("?"+query.Encode()), &response); err != nil {
 		return nil, err
 	}
 
 	return response, nil
 }
+
+func (c *APIClient) CreateTag(entityType, entityID, key, value string) error {
+	tag := models.Tag{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Key:        key,
+		Value:      value,
+	}
+
+	if err := c.Execute(c.Sling("/tag").Post("").BodyJSON(tag), nil); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *APIClient) DeleteTag(entityType, entityID, key string) error {
+	tag := models.Tag{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Key:        key,
+	}
+
+	if err := c.Execute(c.Sling("/tag").Delete("").BodyJSON(tag), nil); err != nil {
+		return err
+	}
+
+	return nil
+}
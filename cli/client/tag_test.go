@@ -18,6 +18,8 @@ func TestSelectByQuery(t *testing.T) {
 		testutils.AssertEqual(t, query.Get("fuzz"), "some_fuzz")
 		testutils.AssertEqual(t, query.Get("version"), "some_version")
 		testutils.AssertEqual(t, query.Get("key"), "val")
+		testutils.AssertEqual(t, query.Get("filter"), "team=payments,env!=prod;owner in (a,b)")
+		testutils.AssertEqual(t, query.Get("limit"), "100")
 
 		tags := []models.EntityWithTags{
 			{EntityID: "id1"},
@@ -35,6 +37,7 @@ func TestSelectByQuery(t *testing.T) {
 		"fuzz":    "some_fuzz",
 		"version": "some_version",
 		"key":     "val",
+		"filter":  "team=payments,env!=prod;owner in (a,b)",
 	}
 
 	tags, err := client.SelectByQuery(params)
@@ -46,3 +49,67 @@ func TestSelectByQuery(t *testing.T) {
 	testutils.AssertEqual(t, tags[0].EntityID, "id1")
 	testutils.AssertEqual(t, tags[1].EntityID, "id2")
 }
+
+func TestSelectByQuery_limit(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.URL.Query().Get("limit"), "1")
+
+		// the next token is ignored when the caller specifies a limit
+		headers := map[string]string{"X-Next-Token": "token"}
+		MarshalAndWriteHeader(t, w, []models.EntityWithTags{{EntityID: "id1"}}, headers, 200)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	tags, err := client.SelectByQuery(map[string]string{"type": "service", "limit": "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, len(tags), 1)
+}
+
+func TestCreateTag(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "POST")
+		testutils.AssertEqual(t, r.URL.Path, "/tag")
+
+		var tag models.Tag
+		Unmarshal(t, r, &tag)
+
+		expected := models.Tag{EntityType: "service", EntityID: "id", Key: "team", Value: "payments"}
+		testutils.AssertEqual(t, tag, expected)
+
+		w.WriteHeader(http.StatusCreated)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	if err := client.CreateTag("service", "id", "team", "payments"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeleteTag(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testutils.AssertEqual(t, r.Method, "DELETE")
+		testutils.AssertEqual(t, r.URL.Path, "/tag")
+
+		var tag models.Tag
+		Unmarshal(t, r, &tag)
+
+		expected := models.Tag{EntityType: "service", EntityID: "id", Key: "team"}
+		testutils.AssertEqual(t, tag, expected)
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	client, server := newClientAndServer(handler)
+	defer server.Close()
+
+	if err := client.DeleteTag("service", "id", "team"); err != nil {
+		t.Fatal(err)
+	}
+}
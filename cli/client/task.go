@@ -1,11 +1,13 @@
 package client
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strconv"
 
 	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/paging"
 )
 
 func (c *APIClient) CreateTask(
@@ -47,7 +49,7 @@ func (c *APIClient) GetTask(id string) (*models.Task, error) {
 	return task, nil
 }
 
-func (c *APIClient) GetTaskLogs(id, start, end string, tail int) ([]*models.LogFile, error) {
+func (c *APIClient) GetTaskLogs(id, start, end, filter, container string, tail int) ([]*models.LogFile, error) {
 	query := url.Values{}
 	if tail > 0 {
 		query.Set("tail", strconv.Itoa(tail))
@@ -61,6 +63,14 @@ func (c *APIClient) GetTaskLogs(id, start, end string, tail int) ([]*models.LogF
 		query.Set("end", end)
 	}
 
+	if filter != "" {
+		query.Set("filter", filter)
+	}
+
+	if container != "" {
+		query.Set("container", container)
+	}
+
 	url := fmt.Sprintf("%s/logs?%s", id, query.Encode())
 
 	var logFiles []*models.LogFile
@@ -71,11 +81,59 @@ func (c *APIClient) GetTaskLogs(id, start, end string, tail int) ([]*models.LogF
 	return logFiles, nil
 }
 
+// FollowTaskLogs streams new log events for the task to fn until the connection
+// is closed by the API or fn returns an error.
+func (c *APIClient) FollowTaskLogs(id, start, container string, fn func(event *models.LogEvent) error) error {
+	query := url.Values{}
+	query.Set("follow", "true")
+
+	if start != "" {
+		query.Set("start", start)
+	}
+
+	if container != "" {
+		query.Set("container", container)
+	}
+
+	url := fmt.Sprintf("%s/logs?%s", id, query.Encode())
+
+	decodef := func(decoder *json.Decoder) error {
+		var event *models.LogEvent
+		if err := decoder.Decode(&event); err != nil {
+			return err
+		}
+
+		return fn(event)
+	}
+
+	return c.ExecuteStream(c.Sling("task/").Get(url), decodef)
+}
+
 func (c *APIClient) ListTasks() ([]*models.TaskSummary, error) {
-	var tasks []*models.TaskSummary
-	if err := c.Execute(c.Sling("task/").Get(""), &tasks); err != nil {
+	tasks := []*models.TaskSummary{}
+	fn := func(nextToken string) (string, error) {
+		page, nextToken, err := c.ListTasksPage(paging.DefaultLimit, nextToken)
+		if err != nil {
+			return "", err
+		}
+
+		tasks = append(tasks, page...)
+		return nextToken, nil
+	}
+
+	if err := paging.IteratePages(fn); err != nil {
 		return nil, err
 	}
 
 	return tasks, nil
 }
+
+func (c *APIClient) ListTasksPage(limit int, nextToken string) ([]*models.TaskSummary, string, error) {
+	var tasks []*models.TaskSummary
+	nextToken, err := c.ExecuteWithPage(c.Sling("task/").Get(pageQuery(limit, nextToken)), &tasks)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return tasks, nextToken, nil
+}
@@ -87,6 +87,8 @@ func TestGetTaskLogs(t *testing.T) {
 		testutils.AssertEqual(t, r.URL.Query().Get("tail"), "100")
 		testutils.AssertEqual(t, r.URL.Query().Get("start"), "2001-01-01 01:01")
 		testutils.AssertEqual(t, r.URL.Query().Get("end"), "2012-12-12 12:12")
+		testutils.AssertEqual(t, r.URL.Query().Get("filter"), "ERROR")
+		testutils.AssertEqual(t, r.URL.Query().Get("container"), "api")
 
 		logs := []models.LogFile{
 			{Name: "name1"},
@@ -99,7 +101,7 @@ func TestGetTaskLogs(t *testing.T) {
 	client, server := newClientAndServer(handler)
 	defer server.Close()
 
-	logs, err := client.GetTaskLogs("id", "2001-01-01 01:01", "2012-12-12 12:12", 100)
+	logs, err := client.GetTaskLogs("id", "2001-01-01 01:01", "2012-12-12 12:12", "ERROR", "api", 100)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,51 @@
+package command
+
+import (
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/urfave/cli"
+)
+
+type CertificateCommand struct {
+	*Command
+}
+
+func NewCertificateCommand(command *Command) *CertificateCommand {
+	return &CertificateCommand{command}
+}
+
+func (cc *CertificateCommand) GetCommand() cli.Command {
+	return cli.Command{
+		Name:  "certificate",
+		Usage: "view iam and acm certificates",
+		Subcommands: []cli.Command{
+			{
+				Name:      "list",
+				Usage:     "list all certificates",
+				Action:    wrapAction(cc.Command, cc.List),
+				ArgsUsage: " ",
+				Flags:     listFlags,
+			},
+		},
+	}
+}
+
+func (cc *CertificateCommand) List(c *cli.Context) error {
+	certificates, err := cc.Client.ListCertificates()
+	if err != nil {
+		return err
+	}
+
+	include, err := cc.filterIDs(c, "certificate")
+	if err != nil {
+		return err
+	}
+
+	filtered := []*models.Certificate{}
+	for _, certificate := range certificates {
+		if include(certificate.CertificateID) {
+			filtered = append(filtered, certificate)
+		}
+	}
+
+	return cc.Printer.PrintCertificates(filtered...)
+}
@@ -0,0 +1,23 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func TestListCertificates(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewCertificateCommand(tc.Command())
+
+	tc.Client.EXPECT().
+		ListCertificates().
+		Return([]*models.Certificate{}, nil)
+
+	c := testutils.GetCLIContext(t, nil, nil)
+	if err := command.List(c); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,6 +1,8 @@
 package command
 
 import (
+	"strings"
+
 	"github.com/quintilesims/layer0/cli/client"
 	"github.com/quintilesims/layer0/cli/printer"
 	"github.com/urfave/cli"
@@ -30,6 +32,61 @@ func (cm *Command) resolveSingleID(entityType, target string) (string, error) {
 	return assertSingleID(entityType, target, ids)
 }
 
+// listFlags are shared by the list commands; see filterIDs
+var listFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "filter",
+		Usage: "only list entities with tags that match the filter, e.g. 'team=payments,env!=prod' (see the /tag API for the full syntax)",
+	},
+	cli.StringSliceFlag{
+		Name:  "tag",
+		Usage: "only list entities with the tag KEY=VALUE (can be specified multiple times)",
+	},
+}
+
+// filterIDs returns a function that reports whether an entity of the specified type matches
+// the 'filter' and 'tag' flags; if neither flag is specified, every entity matches
+func (cm *Command) filterIDs(c *cli.Context, entityType string) (func(id string) bool, error) {
+	filters := []string{}
+	if filter := c.String("filter"); filter != "" {
+		filters = append(filters, filter)
+	}
+
+	if tags := c.StringSlice("tag"); len(tags) > 0 {
+		for _, tag := range tags {
+			if split := strings.SplitN(tag, "=", 2); len(split) != 2 || split[0] == "" {
+				return nil, NewUsageError("Tag '%s' is not in format KEY=VALUE", tag)
+			}
+		}
+
+		filters = append(filters, strings.Join(tags, ","))
+	}
+
+	if len(filters) == 0 {
+		return func(string) bool { return true }, nil
+	}
+
+	// the filter may contain OR groups, so it is queried separately from the tags
+	matches := map[string]int{}
+	for _, filter := range filters {
+		params := map[string]string{
+			"type":   entityType,
+			"filter": filter,
+		}
+
+		tags, err := cm.Client.SelectByQuery(params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range extractIDs(tags, matchAnything()) {
+			matches[id]++
+		}
+	}
+
+	return func(id string) bool { return matches[id] == len(filters) }, nil
+}
+
 func (cm *Command) handleError(c *cli.Context, err error) {
 	if _, ok := err.(*UsageError); ok {
 		handleUsageError(c, err)
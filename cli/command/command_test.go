@@ -9,6 +9,8 @@ import (
 	"github.com/quintilesims/layer0/cli/client/mock_client"
 	"github.com/quintilesims/layer0/cli/command/mock_command"
 	"github.com/quintilesims/layer0/cli/printer"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
 )
 
 type TestCommand struct {
@@ -50,3 +52,67 @@ func tempFile(t *testing.T, content string) (*os.File, func()) {
 
 	return file, func() { os.Remove(file.Name()) }
 }
+
+func TestFilterIDs(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := tc.Command()
+
+	params := map[string]string{
+		"type":   "service",
+		"filter": "team in (payments,core)",
+	}
+
+	tc.Client.EXPECT().
+		SelectByQuery(params).
+		Return([]*models.EntityWithTags{{EntityID: "s1"}}, nil)
+
+	flags := map[string]interface{}{"filter": "team in (payments,core)"}
+	include, err := command.filterIDs(testutils.GetCLIContext(t, nil, flags), "service")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, include("s1"), true)
+	testutils.AssertEqual(t, include("s2"), false)
+
+	include, err = command.filterIDs(testutils.GetCLIContext(t, nil, nil), "service")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, include("s2"), true)
+}
+
+func TestFilterIDs_tags(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := tc.Command()
+
+	tc.Client.EXPECT().
+		SelectByQuery(map[string]string{"type": "service", "filter": "env=prod;env=staging"}).
+		Return([]*models.EntityWithTags{{EntityID: "s1"}, {EntityID: "s2"}}, nil)
+
+	tc.Client.EXPECT().
+		SelectByQuery(map[string]string{"type": "service", "filter": "team=payments,owner=alice"}).
+		Return([]*models.EntityWithTags{{EntityID: "s2"}, {EntityID: "s3"}}, nil)
+
+	flags := map[string]interface{}{
+		"filter": "env=prod;env=staging",
+		"tag":    []string{"team=payments", "owner=alice"},
+	}
+
+	include, err := command.filterIDs(testutils.GetCLIContext(t, nil, flags), "service")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, include("s1"), false)
+	testutils.AssertEqual(t, include("s2"), true)
+	testutils.AssertEqual(t, include("s3"), false)
+
+	flags = map[string]interface{}{"tag": []string{"team"}}
+	if _, err := command.filterIDs(testutils.GetCLIContext(t, nil, flags), "service"); err == nil {
+		t.Fatal("error was unexpectedly nil")
+	}
+}
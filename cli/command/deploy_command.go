@@ -44,12 +44,12 @@ func (d *DeployCommand) GetCommand() cli.Command {
 				Usage:     "list all deploys (only the latest versions of each family will be shown)",
 				Action:    wrapAction(d.Command, d.List),
 				ArgsUsage: " ",
-				Flags: []cli.Flag{
+				Flags: append([]cli.Flag{
 					cli.BoolFlag{
 						Name:  "all",
 						Usage: "list all versions of all deploys",
 					},
-				},
+				}, listFlags...),
 			},
 		},
 	}
@@ -103,14 +103,26 @@ func (d *DeployCommand) List(c *cli.Context) error {
 		return err
 	}
 
+	include, err := d.filterIDs(c, "deploy")
+	if err != nil {
+		return err
+	}
+
+	filtered := []*models.DeploySummary{}
+	for _, summary := range deploySummaries {
+		if include(summary.DeployID) {
+			filtered = append(filtered, summary)
+		}
+	}
+
 	if !c.Bool("all") {
-		deploySummaries, err = filterDeploySummaries(deploySummaries)
+		filtered, err = filterDeploySummaries(filtered)
 		if err != nil {
 			return err
 		}
 	}
 
-	return d.Printer.PrintDeploySummaries(deploySummaries...)
+	return d.Printer.PrintDeploySummaries(filtered...)
 }
 
 func filterDeploySummaries(deploys []*models.DeploySummary) ([]*models.DeploySummary, error) {
@@ -3,6 +3,7 @@ package command
 import (
 	"io/ioutil"
 	"strconv"
+	"strings"
 
 	"github.com/quintilesims/layer0/common/models"
 	"github.com/urfave/cli"
@@ -12,6 +13,21 @@ type EnvironmentCommand struct {
 	*Command
 }
 
+var ingressFlags = []cli.Flag{
+	cli.StringSliceFlag{
+		Name:  "cidr",
+		Usage: "cidr block to allow traffic from (can be specified multiple times)",
+	},
+	cli.StringSliceFlag{
+		Name:  "security-group",
+		Usage: "id of a security group to allow traffic from (can be specified multiple times)",
+	},
+	cli.StringSliceFlag{
+		Name:  "port",
+		Usage: "port or port range to allow in the format PORT[-PORT][/PROTOCOL], where PROTOCOL is tcp (default) or udp (can be specified multiple times)",
+	},
+}
+
 func NewEnvironmentCommand(command *Command) *EnvironmentCommand {
 	return &EnvironmentCommand{command}
 }
@@ -50,6 +66,28 @@ func (e *EnvironmentCommand) GetCommand() cli.Command {
 						Name:  "ami",
 						Usage: "specifies a custom AMI ID to use in the environment",
 					},
+					cli.StringFlag{
+						Name:  "spot-price",
+						Usage: "maximum hourly price to pay for spot instances in the environment cluster",
+					},
+					cli.StringSliceFlag{
+						Name:  "mixed-instance-type",
+						Usage: "instance type the environment cluster may launch (can be specified multiple times)",
+					},
+					cli.IntFlag{
+						Name:  "on-demand-base-capacity",
+						Value: 0,
+						Usage: "number of on-demand instances to launch before using spot instances (requires --mixed-instance-type)",
+					},
+					cli.IntFlag{
+						Name:  "on-demand-percentage",
+						Value: 100,
+						Usage: "percentage of instances above the base capacity that are on-demand (requires --mixed-instance-type)",
+					},
+					cli.StringSliceFlag{
+						Name:  "log-sink",
+						Usage: "additional log destination in the format TYPE=ADDRESS, where TYPE is syslog, http, or s3 (can be specified multiple times)",
+					},
 				},
 			},
 			{
@@ -70,11 +108,30 @@ func (e *EnvironmentCommand) GetCommand() cli.Command {
 				Action:    wrapAction(e.Command, e.Get),
 				ArgsUsage: "NAME",
 			},
+			{
+				Name:      "capacity",
+				Usage:     "show the resource utilization of an environment's instances",
+				Action:    wrapAction(e.Command, e.Capacity),
+				ArgsUsage: "NAME",
+			},
+			{
+				Name:      "cost",
+				Usage:     "estimate the monthly cost of an environment",
+				Action:    wrapAction(e.Command, e.Cost),
+				ArgsUsage: "NAME",
+				Flags: []cli.Flag{
+					cli.Float64Flag{
+						Name:  "data-transfer-gb",
+						Usage: "estimated GB of data transferred out of the environment each month",
+					},
+				},
+			},
 			{
 				Name:      "list",
 				Usage:     "list all environments",
 				Action:    wrapAction(e.Command, e.List),
 				ArgsUsage: " ",
+				Flags:     listFlags,
 			},
 			{
 				Name:      "setmincount",
@@ -87,6 +144,16 @@ func (e *EnvironmentCommand) GetCommand() cli.Command {
 				Usage:     "links two environments together",
 				Action:    wrapAction(e.Command, e.Link),
 				ArgsUsage: "SOURCE DESTINATION",
+				Flags: []cli.Flag{
+					cli.StringSliceFlag{
+						Name:  "port",
+						Usage: "port or port range to allow in the format PORT[-PORT][/PROTOCOL], where PROTOCOL is tcp (default) or udp (can be specified multiple times; default is all traffic)",
+					},
+					cli.BoolFlag{
+						Name:  "one-way",
+						Usage: "only allow traffic from SOURCE to DESTINATION",
+					},
+				},
 			},
 			{
 				Name:      "unlink",
@@ -94,6 +161,20 @@ func (e *EnvironmentCommand) GetCommand() cli.Command {
 				Action:    wrapAction(e.Command, e.Unlink),
 				ArgsUsage: "SOURCE DESTINATION",
 			},
+			{
+				Name:      "allow",
+				Usage:     "allow traffic to an environment's instances from cidr blocks or security groups",
+				Action:    wrapAction(e.Command, e.Allow),
+				ArgsUsage: "NAME",
+				Flags:     ingressFlags,
+			},
+			{
+				Name:      "revoke",
+				Usage:     "revoke traffic previously allowed with 'allow'",
+				Action:    wrapAction(e.Command, e.Revoke),
+				ArgsUsage: "NAME",
+				Flags:     ingressFlags,
+			},
 		},
 	}
 }
@@ -114,7 +195,35 @@ func (e *EnvironmentCommand) Create(c *cli.Context) error {
 		userData = content
 	}
 
-	environment, err := e.Client.CreateEnvironment(args["NAME"], c.String("size"), c.Int("min-count"), userData, c.String("os"), c.String("ami"))
+	var mixedInstancesPolicy *models.MixedInstancesPolicy
+	if instanceTypes := c.StringSlice("mixed-instance-type"); len(instanceTypes) > 0 {
+		mixedInstancesPolicy = &models.MixedInstancesPolicy{
+			InstanceTypes:               instanceTypes,
+			OnDemandBaseCapacity:        c.Int("on-demand-base-capacity"),
+			OnDemandPercentageAboveBase: c.Int("on-demand-percentage"),
+		}
+	}
+
+	var logSinks []models.LogSink
+	for _, sink := range c.StringSlice("log-sink") {
+		split := strings.SplitN(sink, "=", 2)
+		if len(split) != 2 || split[0] == "" || split[1] == "" {
+			return NewUsageError("Log sink '%s' is not in format TYPE=ADDRESS", sink)
+		}
+
+		logSinks = append(logSinks, models.LogSink{Type: split[0], Address: split[1]})
+	}
+
+	environment, err := e.Client.CreateEnvironment(
+		args["NAME"],
+		c.String("size"),
+		c.Int("min-count"),
+		userData,
+		c.String("os"),
+		c.String("ami"),
+		c.String("spot-price"),
+		mixedInstancesPolicy,
+		logSinks)
 	if err != nil {
 		return err
 	}
@@ -145,13 +254,68 @@ func (e *EnvironmentCommand) Get(c *cli.Context) error {
 	return e.Printer.PrintEnvironments(environments...)
 }
 
+func (e *EnvironmentCommand) Capacity(c *cli.Context) error {
+	args, err := extractArgs(c.Args(), "NAME")
+	if err != nil {
+		return err
+	}
+
+	id, err := e.resolveSingleID("environment", args["NAME"])
+	if err != nil {
+		return err
+	}
+
+	capacity, err := e.Client.GetEnvironmentCapacity(id)
+	if err != nil {
+		return err
+	}
+
+	return e.Printer.PrintEnvironmentCapacity(capacity)
+}
+
+func (e *EnvironmentCommand) Cost(c *cli.Context) error {
+	args, err := extractArgs(c.Args(), "NAME")
+	if err != nil {
+		return err
+	}
+
+	dataTransferGB := c.Float64("data-transfer-gb")
+	if dataTransferGB < 0 {
+		return NewUsageError("--data-transfer-gb must not be negative")
+	}
+
+	id, err := e.resolveSingleID("environment", args["NAME"])
+	if err != nil {
+		return err
+	}
+
+	cost, err := e.Client.GetEnvironmentCost(id, dataTransferGB)
+	if err != nil {
+		return err
+	}
+
+	return e.Printer.PrintEnvironmentCost(cost)
+}
+
 func (e *EnvironmentCommand) List(c *cli.Context) error {
 	environmentSummaries, err := e.Client.ListEnvironments()
 	if err != nil {
 		return err
 	}
 
-	return e.Printer.PrintEnvironmentSummaries(environmentSummaries...)
+	include, err := e.filterIDs(c, "environment")
+	if err != nil {
+		return err
+	}
+
+	filtered := []*models.EnvironmentSummary{}
+	for _, summary := range environmentSummaries {
+		if include(summary.EnvironmentID) {
+			filtered = append(filtered, summary)
+		}
+	}
+
+	return e.Printer.PrintEnvironmentSummaries(filtered...)
 }
 
 func (e *EnvironmentCommand) SetMinCount(c *cli.Context) error {
@@ -198,7 +362,17 @@ func (e *EnvironmentCommand) Link(c *cli.Context) error {
 		return NewUsageError("Cannot link an environment to itself")
 	}
 
-	if err := e.Client.CreateLink(id1, id2); err != nil {
+	ports := []models.EnvironmentLinkPort{}
+	for _, p := range c.StringSlice("port") {
+		port, err := parseLinkPort(p)
+		if err != nil {
+			return err
+		}
+
+		ports = append(ports, port)
+	}
+
+	if err := e.Client.CreateLink(id1, id2, ports, c.Bool("one-way")); err != nil {
 		return err
 	}
 
@@ -233,3 +407,123 @@ func (e *EnvironmentCommand) Unlink(c *cli.Context) error {
 	e.Printer.Printf("Environment successfully unlinked\n")
 	return nil
 }
+
+func (e *EnvironmentCommand) Allow(c *cli.Context) error {
+	id, rules, err := e.parseIngressRules(c)
+	if err != nil {
+		return err
+	}
+
+	environment, err := e.Client.AuthorizeEnvironmentIngress(id, rules)
+	if err != nil {
+		return err
+	}
+
+	return e.Printer.PrintEnvironments(environment)
+}
+
+func (e *EnvironmentCommand) Revoke(c *cli.Context) error {
+	id, rules, err := e.parseIngressRules(c)
+	if err != nil {
+		return err
+	}
+
+	environment, err := e.Client.RevokeEnvironmentIngress(id, rules)
+	if err != nil {
+		return err
+	}
+
+	return e.Printer.PrintEnvironments(environment)
+}
+
+// parseIngressRules returns a rule for each combination of source and port in the context's flags
+func (e *EnvironmentCommand) parseIngressRules(c *cli.Context) (string, []models.EnvironmentIngressRule, error) {
+	args, err := extractArgs(c.Args(), "NAME")
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(c.StringSlice("cidr")) == 0 && len(c.StringSlice("security-group")) == 0 {
+		return "", nil, NewUsageError("At least one of --cidr or --security-group is required")
+	}
+
+	if len(c.StringSlice("port")) == 0 {
+		return "", nil, NewUsageError("At least one --port is required")
+	}
+
+	ports := []models.EnvironmentLinkPort{}
+	for _, p := range c.StringSlice("port") {
+		port, err := parseLinkPort(p)
+		if err != nil {
+			return "", nil, err
+		}
+
+		ports = append(ports, port)
+	}
+
+	rules := []models.EnvironmentIngressRule{}
+	for _, port := range ports {
+		for _, cidr := range c.StringSlice("cidr") {
+			rule := models.EnvironmentIngressRule{
+				CIDR:     cidr,
+				Protocol: port.Protocol,
+				FromPort: port.FromPort,
+				ToPort:   port.ToPort,
+			}
+
+			rules = append(rules, rule)
+		}
+
+		for _, groupID := range c.StringSlice("security-group") {
+			rule := models.EnvironmentIngressRule{
+				SecurityGroupID: groupID,
+				Protocol:        port.Protocol,
+				FromPort:        port.FromPort,
+				ToPort:          port.ToPort,
+			}
+
+			rules = append(rules, rule)
+		}
+	}
+
+	id, err := e.resolveSingleID("environment", args["NAME"])
+	if err != nil {
+		return "", nil, err
+	}
+
+	return id, rules, nil
+}
+
+func parseLinkPort(port string) (models.EnvironmentLinkPort, error) {
+	protocol := "tcp"
+	if split := strings.SplitN(port, "/", 2); len(split) == 2 {
+		port = split[0]
+		protocol = strings.ToLower(split[1])
+	}
+
+	if protocol != "tcp" && protocol != "udp" {
+		return models.EnvironmentLinkPort{}, NewUsageError("Protocol '%s' is not supported; must be tcp or udp", protocol)
+	}
+
+	split := strings.SplitN(port, "-", 2)
+	fromPort, err := strconv.Atoi(split[0])
+	if err != nil {
+		return models.EnvironmentLinkPort{}, NewUsageError("'%s' is not a valid integer", split[0])
+	}
+
+	toPort := fromPort
+	if len(split) == 2 {
+		toPort, err = strconv.Atoi(split[1])
+		if err != nil {
+			return models.EnvironmentLinkPort{}, NewUsageError("'%s' is not a valid integer", split[1])
+		}
+	}
+
+	model := models.EnvironmentLinkPort{
+		Protocol: protocol,
+		FromPort: fromPort,
+		ToPort:   toPort,
+	}
+
+	return model, nil
+}
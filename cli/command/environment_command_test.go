@@ -18,7 +18,7 @@ func TestCreateEnvironment(t *testing.T) {
 	defer close()
 
 	tc.Client.EXPECT().
-		CreateEnvironment("name", "m3.large", 2, []byte("user_data"), "linux", "ami").
+		CreateEnvironment("name", "m3.large", 2, []byte("user_data"), "linux", "ami", "", nil, nil).
 		Return(&models.Environment{}, nil)
 
 	flags := map[string]interface{}{
@@ -35,6 +35,60 @@ func TestCreateEnvironment(t *testing.T) {
 	}
 }
 
+func TestCreateEnvironment_mixedInstancesPolicy(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewEnvironmentCommand(tc.Command())
+
+	policy := &models.MixedInstancesPolicy{
+		InstanceTypes:               []string{"m5.large", "m4.large"},
+		OnDemandBaseCapacity:        1,
+		OnDemandPercentageAboveBase: 25,
+	}
+
+	tc.Client.EXPECT().
+		CreateEnvironment("name", "m5.large", 0, nil, "linux", "", "0.05", policy, nil).
+		Return(&models.Environment{}, nil)
+
+	flags := map[string]interface{}{
+		"size":                    "m5.large",
+		"os":                      "linux",
+		"spot-price":              "0.05",
+		"mixed-instance-type":     []string{"m5.large", "m4.large"},
+		"on-demand-base-capacity": 1,
+		"on-demand-percentage":    25,
+	}
+
+	c := testutils.GetCLIContext(t, []string{"name"}, flags)
+	if err := command.Create(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCreateEnvironment_logSinks(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewEnvironmentCommand(tc.Command())
+
+	logSinks := []models.LogSink{
+		{Type: "syslog", Address: "tcp://logs:514"},
+		{Type: "s3", Address: "s3://bucket/prefix"},
+	}
+
+	tc.Client.EXPECT().
+		CreateEnvironment("name", "", 0, nil, "", "", "", nil, logSinks).
+		Return(&models.Environment{}, nil)
+
+	flags := map[string]interface{}{
+		"log-sink": []string{"syslog=tcp://logs:514", "s3=s3://bucket/prefix"},
+	}
+
+	c := testutils.GetCLIContext(t, []string{"name"}, flags)
+	if err := command.Create(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestCreateEnvironment_userInputErrors(t *testing.T) {
 	tc, ctrl := newTestCommand(t)
 	defer ctrl.Finish()
@@ -42,6 +96,9 @@ func TestCreateEnvironment_userInputErrors(t *testing.T) {
 
 	contexts := map[string]*cli.Context{
 		"Missing NAME arg": testutils.GetCLIContext(t, nil, nil),
+		"Invalid log sink": testutils.GetCLIContext(t, []string{"name"}, map[string]interface{}{
+			"log-sink": []string{"tcp://logs:514"},
+		}),
 	}
 
 	for name, c := range contexts {
@@ -159,6 +216,113 @@ func TestListEnvironments(t *testing.T) {
 	}
 }
 
+func TestListEnvironments_filter(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewEnvironmentCommand(tc.Command())
+
+	tc.Client.EXPECT().
+		ListEnvironments().
+		Return([]*models.EnvironmentSummary{
+			{EnvironmentID: "e1"},
+			{EnvironmentID: "e2"},
+			{EnvironmentID: "e3"},
+		}, nil)
+
+	params := map[string]string{
+		"type":   "environment",
+		"filter": "team=payments,env!=prod",
+	}
+
+	tc.Client.EXPECT().
+		SelectByQuery(params).
+		Return([]*models.EntityWithTags{
+			{EntityID: "e1"},
+			{EntityID: "e3"},
+		}, nil)
+
+	flags := map[string]interface{}{"filter": "team=payments,env!=prod"}
+	c := testutils.GetCLIContext(t, nil, flags)
+	if err := command.List(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEnvironmentCapacity(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewEnvironmentCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("environment", "name").
+		Return([]string{"id"}, nil)
+
+	tc.Client.EXPECT().
+		GetEnvironmentCapacity("id").
+		Return(&models.EnvironmentCapacity{}, nil)
+
+	c := testutils.GetCLIContext(t, []string{"name"}, nil)
+	if err := command.Capacity(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEnvironmentCapacity_userInputErrors(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewEnvironmentCommand(tc.Command())
+
+	contexts := map[string]*cli.Context{
+		"Missing NAME arg": testutils.GetCLIContext(t, nil, nil),
+	}
+
+	for name, c := range contexts {
+		if err := command.Capacity(c); err == nil {
+			t.Fatalf("%s: error was nil!", name)
+		}
+	}
+}
+
+func TestEnvironmentCost(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewEnvironmentCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("environment", "name").
+		Return([]string{"id"}, nil)
+
+	tc.Client.EXPECT().
+		GetEnvironmentCost("id", 100.0).
+		Return(&models.EnvironmentCost{}, nil)
+
+	flags := map[string]interface{}{
+		"data-transfer-gb": 100.0,
+	}
+
+	c := testutils.GetCLIContext(t, []string{"name"}, flags)
+	if err := command.Cost(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEnvironmentCost_userInputErrors(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewEnvironmentCommand(tc.Command())
+
+	contexts := map[string]*cli.Context{
+		"Missing NAME arg":          testutils.GetCLIContext(t, nil, nil),
+		"Negative data-transfer-gb": testutils.GetCLIContext(t, []string{"name"}, map[string]interface{}{"data-transfer-gb": -1.0}),
+	}
+
+	for name, c := range contexts {
+		if err := command.Cost(c); err == nil {
+			t.Fatalf("%s: error was nil!", name)
+		}
+	}
+}
+
 func TestEnvironmentSetMinCount(t *testing.T) {
 	tc, ctrl := newTestCommand(t)
 	defer ctrl.Finish()
@@ -210,7 +374,7 @@ func TestEnvironmentLink(t *testing.T) {
 		Return([]string{"id2"}, nil)
 
 	tc.Client.EXPECT().
-		CreateLink("id1", "id2").
+		CreateLink("id1", "id2", []models.EnvironmentLinkPort{}, false).
 		Return(nil)
 
 	c := testutils.GetCLIContext(t, []string{"name1", "name2"}, nil)
@@ -219,14 +383,57 @@ func TestEnvironmentLink(t *testing.T) {
 	}
 }
 
+func TestEnvironmentLink_ports(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewEnvironmentCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("environment", "app").
+		Return([]string{"id1"}, nil)
+
+	tc.Resolver.EXPECT().
+		Resolve("environment", "db").
+		Return([]string{"id2"}, nil)
+
+	ports := []models.EnvironmentLinkPort{
+		{Protocol: "tcp", FromPort: 5432, ToPort: 5432},
+		{Protocol: "udp", FromPort: 8000, ToPort: 8100},
+	}
+
+	tc.Client.EXPECT().
+		CreateLink("id1", "id2", ports, true).
+		Return(nil)
+
+	flags := map[string]interface{}{
+		"port":    []string{"5432", "8000-8100/UDP"},
+		"one-way": true,
+	}
+
+	c := testutils.GetCLIContext(t, []string{"app", "db"}, flags)
+	if err := command.Link(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestEnvironmentLink_userInputErrors(t *testing.T) {
 	tc, ctrl := newTestCommand(t)
 	defer ctrl.Finish()
 	command := NewEnvironmentCommand(tc.Command())
 
+	tc.Resolver.EXPECT().
+		Resolve("environment", gomock.Any()).
+		DoAndReturn(func(entityType, target string) ([]string, error) {
+			return []string{target}, nil
+		}).
+		AnyTimes()
+
 	contexts := map[string]*cli.Context{
 		"Missing SOURCE arg":      testutils.GetCLIContext(t, []string{}, nil),
 		"Missing DESTINATION arg": testutils.GetCLIContext(t, []string{"name"}, nil),
+		"Invalid port":            testutils.GetCLIContext(t, []string{"name1", "name2"}, map[string]interface{}{"port": []string{"abc"}}),
+		"Invalid port range":      testutils.GetCLIContext(t, []string{"name1", "name2"}, map[string]interface{}{"port": []string{"80-x"}}),
+		"Invalid protocol":        testutils.GetCLIContext(t, []string{"name1", "name2"}, map[string]interface{}{"port": []string{"80/icmp"}}),
 	}
 
 	for name, c := range contexts {
@@ -307,3 +514,84 @@ func TestEnvironmentUnlink_duplicateEnvironmentID(t *testing.T) {
 		t.Fatal("error was nil!")
 	}
 }
+
+func TestEnvironmentAllow(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewEnvironmentCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("environment", "name").
+		Return([]string{"id"}, nil)
+
+	rules := []models.EnvironmentIngressRule{
+		{CIDR: "10.1.0.0/16", Protocol: "tcp", FromPort: 22, ToPort: 22},
+		{CIDR: "192.168.0.0/24", Protocol: "tcp", FromPort: 22, ToPort: 22},
+		{SecurityGroupID: "sg-123", Protocol: "tcp", FromPort: 22, ToPort: 22},
+		{CIDR: "10.1.0.0/16", Protocol: "udp", FromPort: 8000, ToPort: 8100},
+		{CIDR: "192.168.0.0/24", Protocol: "udp", FromPort: 8000, ToPort: 8100},
+		{SecurityGroupID: "sg-123", Protocol: "udp", FromPort: 8000, ToPort: 8100},
+	}
+
+	tc.Client.EXPECT().
+		AuthorizeEnvironmentIngress("id", rules).
+		Return(&models.Environment{}, nil)
+
+	flags := map[string]interface{}{
+		"cidr":           []string{"10.1.0.0/16", "192.168.0.0/24"},
+		"security-group": []string{"sg-123"},
+		"port":           []string{"22", "8000-8100/udp"},
+	}
+
+	c := testutils.GetCLIContext(t, []string{"name"}, flags)
+	if err := command.Allow(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEnvironmentAllow_userInputErrors(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewEnvironmentCommand(tc.Command())
+
+	contexts := map[string]*cli.Context{
+		"Missing NAME arg": testutils.GetCLIContext(t, []string{}, map[string]interface{}{"cidr": []string{"10.0.0.0/8"}, "port": []string{"22"}}),
+		"Missing source":   testutils.GetCLIContext(t, []string{"name"}, map[string]interface{}{"port": []string{"22"}}),
+		"Missing port":     testutils.GetCLIContext(t, []string{"name"}, map[string]interface{}{"cidr": []string{"10.0.0.0/8"}}),
+		"Invalid port":     testutils.GetCLIContext(t, []string{"name"}, map[string]interface{}{"cidr": []string{"10.0.0.0/8"}, "port": []string{"ssh"}}),
+	}
+
+	for name, c := range contexts {
+		if err := command.Allow(c); err == nil {
+			t.Fatalf("%s: error was nil!", name)
+		}
+	}
+}
+
+func TestEnvironmentRevoke(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewEnvironmentCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("environment", "name").
+		Return([]string{"id"}, nil)
+
+	rules := []models.EnvironmentIngressRule{
+		{SecurityGroupID: "sg-123", Protocol: "tcp", FromPort: 443, ToPort: 443},
+	}
+
+	tc.Client.EXPECT().
+		RevokeEnvironmentIngress("id", rules).
+		Return(&models.Environment{}, nil)
+
+	flags := map[string]interface{}{
+		"security-group": []string{"sg-123"},
+		"port":           []string{"443/tcp"},
+	}
+
+	c := testutils.GetCLIContext(t, []string{"name"}, flags)
+	if err := command.Revoke(c); err != nil {
+		t.Fatal(err)
+	}
+}
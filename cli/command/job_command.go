@@ -19,6 +19,12 @@ func (j *JobCommand) GetCommand() cli.Command {
 		Usage:    "manage layer0 jobs",
 		HideHelp: true,
 		Subcommands: []cli.Command{
+			{
+				Name:      "cancel",
+				Usage:     "stop a pending or running job after its current step",
+				Action:    wrapAction(j.Command, j.Cancel),
+				ArgsUsage: "NAME",
+			},
 			{
 				Name:      "delete",
 				Usage:     "delete a job",
@@ -36,6 +42,7 @@ func (j *JobCommand) GetCommand() cli.Command {
 				Usage:     "list all jobs",
 				Action:    wrapAction(j.Command, j.List),
 				ArgsUsage: " ",
+				Flags:     listFlags,
 			},
 			{
 				Name:      "logs",
@@ -55,12 +62,43 @@ func (j *JobCommand) GetCommand() cli.Command {
 						Name:  "end",
 						Usage: "the end of the time range to fetch logs (format: YYYY-MM-DD HH:MM)",
 					},
+					cli.StringFlag{
+						Name:  "since",
+						Usage: "only return logs newer than a relative duration (e.g. 30s, 15m, 2h)",
+					},
+					cli.StringFlag{
+						Name:  "grep",
+						Usage: "only return log lines matching this CloudWatch Logs filter pattern",
+					},
+					cli.StringFlag{
+						Name:  "container",
+						Usage: "only return logs from the container with this name",
+					},
 				},
 			},
 		},
 	}
 }
 
+func (j *JobCommand) Cancel(c *cli.Context) error {
+	args, err := extractArgs(c.Args(), "NAME")
+	if err != nil {
+		return err
+	}
+
+	id, err := j.resolveSingleID("job", args["NAME"])
+	if err != nil {
+		return err
+	}
+
+	if err := j.Client.CancelJob(id); err != nil {
+		return err
+	}
+
+	j.Printer.Printf("Job cancellation requested\n")
+	return nil
+}
+
 func (j *JobCommand) Delete(c *cli.Context) error {
 	return j.delete(c, "job", j.Client.Delete)
 }
@@ -90,7 +128,19 @@ func (j *JobCommand) List(c *cli.Context) error {
 		return err
 	}
 
-	return j.Printer.PrintJobs(jobs...)
+	include, err := j.filterIDs(c, "job")
+	if err != nil {
+		return err
+	}
+
+	filtered := []*models.Job{}
+	for _, job := range jobs {
+		if include(job.JobID) {
+			filtered = append(filtered, job)
+		}
+	}
+
+	return j.Printer.PrintJobs(filtered...)
 }
 
 func (j *JobCommand) Logs(c *cli.Context) error {
@@ -104,12 +154,17 @@ func (j *JobCommand) Logs(c *cli.Context) error {
 		return err
 	}
 
+	start, err := getLogStart(c)
+	if err != nil {
+		return err
+	}
+
 	job, err := j.Client.GetJob(id)
 	if err != nil {
 		return err
 	}
 
-	logs, err := j.Client.GetTaskLogs(job.TaskID, c.String("start"), c.String("end"), c.Int("tail"))
+	logs, err := j.Client.GetTaskLogs(job.TaskID, start, c.String("end"), c.String("grep"), c.String("container"), c.Int("tail"))
 	if err != nil {
 		return err
 	}
@@ -8,6 +8,41 @@ import (
 	"github.com/urfave/cli"
 )
 
+func TestCancelJob(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewJobCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("job", "name").
+		Return([]string{"id"}, nil)
+
+	tc.Client.EXPECT().
+		CancelJob("id").
+		Return(nil)
+
+	c := testutils.GetCLIContext(t, []string{"name"}, nil)
+	if err := command.Cancel(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCancelJob_userInputErrors(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewJobCommand(tc.Command())
+
+	contexts := map[string]*cli.Context{
+		"Missing NAME arg": testutils.GetCLIContext(t, nil, nil),
+	}
+
+	for name, c := range contexts {
+		if err := command.Cancel(c); err == nil {
+			t.Fatalf("%s: error was nil!", name)
+		}
+	}
+}
+
 func TestDelete(t *testing.T) {
 	tc, ctrl := newTestCommand(t)
 	defer ctrl.Finish()
@@ -107,13 +142,15 @@ func TestGetJobLogs(t *testing.T) {
 		Return(&models.Job{TaskID: "task-id"}, nil)
 
 	tc.Client.EXPECT().
-		GetTaskLogs("task-id", "start", "end", 100).
+		GetTaskLogs("task-id", "start", "end", "ERROR", "api", 100).
 		Return([]*models.LogFile{}, nil)
 
 	flags := map[string]interface{}{
-		"tail":  100,
-		"start": "start",
-		"end":   "end",
+		"tail":      100,
+		"start":     "start",
+		"end":       "end",
+		"grep":      "ERROR",
+		"container": "api",
 	}
 
 	c := testutils.GetCLIContext(t, []string{"name"}, flags)
@@ -22,6 +22,45 @@ func (l *LoadBalancerCommand) GetCommand() cli.Command {
 		Name:  "loadbalancer",
 		Usage: "manage layer0 load balancers",
 		Subcommands: []cli.Command{
+			{
+				Name:  "accesslogs",
+				Usage: "view or update the access logs for a load balancer",
+				Subcommands: []cli.Command{
+					{
+						Name:      "enable",
+						Usage:     "enable access logs for a load balancer",
+						Action:    wrapAction(l.Command, l.EnableAccessLogs),
+						ArgsUsage: "NAME",
+						Flags: []cli.Flag{
+							cli.StringFlag{
+								Name:  "bucket",
+								Usage: "name of the s3 bucket to store access logs in",
+							},
+							cli.StringFlag{
+								Name:  "prefix",
+								Usage: "prefix of the s3 keys access logs are stored under (default is the bucket root)",
+							},
+							cli.IntFlag{
+								Name:  "interval",
+								Value: 60,
+								Usage: "interval in minutes at which access logs are published (5 or 60)",
+							},
+						},
+					},
+					{
+						Name:      "disable",
+						Usage:     "disable access logs for a load balancer",
+						Action:    wrapAction(l.Command, l.DisableAccessLogs),
+						ArgsUsage: "NAME",
+					},
+					{
+						Name:      "get",
+						Usage:     "view the access logs configuration for a load balancer",
+						Action:    wrapAction(l.Command, l.GetAccessLogs),
+						ArgsUsage: "NAME",
+					},
+				},
+			},
 			{
 				Name:      "addport",
 				Usage:     "add a new listener port on a load balancer",
@@ -32,6 +71,18 @@ func (l *LoadBalancerCommand) GetCommand() cli.Command {
 						Name:  "certificate",
 						Usage: "name of certificate to use for port configuration (only required for https)",
 					},
+					cli.StringFlag{
+						Name:  "certificate-domain",
+						Usage: "domain of an issued acm certificate to use for port configuration (alternative to --certificate)",
+					},
+					cli.StringSliceFlag{
+						Name:  "source-cidr",
+						Usage: "cidr block allowed to reach the port(s); may be specified multiple times (default is 0.0.0.0/0, public load balancers only)",
+					},
+					cli.StringSliceFlag{
+						Name:  "source-security-group",
+						Usage: "id of a security group allowed to reach the port(s); may be specified multiple times (public load balancers only)",
+					},
 				},
 			},
 			{
@@ -48,6 +99,18 @@ func (l *LoadBalancerCommand) GetCommand() cli.Command {
 						Name:  "certificate",
 						Usage: "name or arn of certificate to use for port configuration (only required for https)",
 					},
+					cli.StringFlag{
+						Name:  "certificate-domain",
+						Usage: "domain of an issued acm certificate to use for port configuration (alternative to --certificate)",
+					},
+					cli.StringSliceFlag{
+						Name:  "source-cidr",
+						Usage: "cidr block allowed to reach the port(s); may be specified multiple times (default is 0.0.0.0/0, public load balancers only)",
+					},
+					cli.StringSliceFlag{
+						Name:  "source-security-group",
+						Usage: "id of a security group allowed to reach the port(s); may be specified multiple times (public load balancers only)",
+					},
 					cli.BoolFlag{
 						Name:  "private",
 						Usage: "if specified, creates a private load balancer (default is public)",
@@ -86,6 +149,10 @@ func (l *LoadBalancerCommand) GetCommand() cli.Command {
 						Name:  "disable-cross-zone",
 						Usage: "if specified, disables cross-zone load balancing (default is enabled)",
 					},
+					cli.StringFlag{
+						Name:  "dns-name",
+						Usage: "if specified, creates an alias record with this name for the load balancer in the configured Route53 hosted zone",
+					},
 				},
 			},
 			{
@@ -167,22 +234,116 @@ func (l *LoadBalancerCommand) GetCommand() cli.Command {
 				Usage:     "list all load balancers",
 				Action:    wrapAction(l.Command, l.List),
 				ArgsUsage: " ",
+				Flags:     listFlags,
+			},
+			{
+				Name:      "metrics",
+				Usage:     "view request count, latency and 5xx metrics for a load balancer",
+				Action:    wrapAction(l.Command, l.Metrics),
+				ArgsUsage: "NAME",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "start",
+						Usage: "the start of the time range to fetch metrics (format: YYYY-MM-DD HH:MM, default is 1 hour before end)",
+					},
+					cli.StringFlag{
+						Name:  "end",
+						Usage: "the end of the time range to fetch metrics (format: YYYY-MM-DD HH:MM, default is now)",
+					},
+					cli.IntFlag{
+						Name:  "period",
+						Value: 60,
+						Usage: "granularity of the returned metrics in seconds",
+					},
+				},
 			},
 		},
 	}
 }
 
+func (l *LoadBalancerCommand) EnableAccessLogs(c *cli.Context) error {
+	args, err := extractArgs(c.Args(), "NAME")
+	if err != nil {
+		return err
+	}
+
+	bucket := c.String("bucket")
+	if bucket == "" {
+		return NewUsageError("Flag '--bucket' is required")
+	}
+
+	id, err := l.resolveSingleID("load_balancer", args["NAME"])
+	if err != nil {
+		return err
+	}
+
+	accessLog := models.AccessLog{
+		Enabled:      true,
+		BucketName:   bucket,
+		BucketPrefix: c.String("prefix"),
+		EmitInterval: c.Int("interval"),
+	}
+
+	loadBalancer, err := l.Client.UpdateLoadBalancerAccessLog(id, accessLog)
+	if err != nil {
+		return err
+	}
+
+	return l.Printer.PrintLoadBalancerAccessLog(loadBalancer)
+}
+
+func (l *LoadBalancerCommand) DisableAccessLogs(c *cli.Context) error {
+	args, err := extractArgs(c.Args(), "NAME")
+	if err != nil {
+		return err
+	}
+
+	id, err := l.resolveSingleID("load_balancer", args["NAME"])
+	if err != nil {
+		return err
+	}
+
+	loadBalancer, err := l.Client.UpdateLoadBalancerAccessLog(id, models.AccessLog{Enabled: false})
+	if err != nil {
+		return err
+	}
+
+	return l.Printer.PrintLoadBalancerAccessLog(loadBalancer)
+}
+
+func (l *LoadBalancerCommand) GetAccessLogs(c *cli.Context) error {
+	args, err := extractArgs(c.Args(), "NAME")
+	if err != nil {
+		return err
+	}
+
+	id, err := l.resolveSingleID("load_balancer", args["NAME"])
+	if err != nil {
+		return err
+	}
+
+	loadBalancer, err := l.Client.GetLoadBalancer(id)
+	if err != nil {
+		return err
+	}
+
+	return l.Printer.PrintLoadBalancerAccessLog(loadBalancer)
+}
+
 func (l *LoadBalancerCommand) AddPort(c *cli.Context) error {
 	args, err := extractArgs(c.Args(), "NAME", "PORT")
 	if err != nil {
 		return err
 	}
 
-	port, err := parsePort(args["PORT"], c.String("certificate"))
+	port, err := parsePort(args["PORT"], c.String("certificate"), c.String("certificate-domain"))
 	if err != nil {
 		return err
 	}
 
+	port.SourceCIDRs = c.StringSlice("source-cidr")
+	port.SourceSecurityGroups = c.StringSlice("source-security-group")
+
 	id, err := l.resolveSingleID("load_balancer", args["NAME"])
 	if err != nil {
 		return err
@@ -210,7 +371,7 @@ func (l *LoadBalancerCommand) Create(c *cli.Context) error {
 
 	ports := []models.Port{}
 	for _, p := range c.StringSlice("port") {
-		port, err := parsePort(p, c.String("certificate"))
+		port, err := parsePort(p, c.String("certificate"), c.String("certificate-domain"))
 		if err != nil {
 			return err
 		}
@@ -228,6 +389,11 @@ func (l *LoadBalancerCommand) Create(c *cli.Context) error {
 		ports = append(ports, port)
 	}
 
+	for i := range ports {
+		ports[i].SourceCIDRs = c.StringSlice("source-cidr")
+		ports[i].SourceSecurityGroups = c.StringSlice("source-security-group")
+	}
+
 	healthCheck := models.HealthCheck{
 		Target:             c.String("healthcheck-target"),
 		Interval:           c.Int("healthcheck-interval"),
@@ -243,7 +409,7 @@ func (l *LoadBalancerCommand) Create(c *cli.Context) error {
 
 	idleTimeout := c.Int("idle-timeout")
 	crossZone := !c.Bool("disable-cross-zone")
-	loadBalancer, err := l.Client.CreateLoadBalancer(args["NAME"], environmentID, healthCheck, ports, !c.Bool("private"), idleTimeout, crossZone)
+	loadBalancer, err := l.Client.CreateLoadBalancer(args["NAME"], environmentID, healthCheck, ports, !c.Bool("private"), idleTimeout, crossZone, c.String("dns-name"))
 	if err != nil {
 		return err
 	}
@@ -484,10 +650,41 @@ func (l *LoadBalancerCommand) List(c *cli.Context) error {
 		return err
 	}
 
-	return l.Printer.PrintLoadBalancerSummaries(loadBalancerSummaries...)
+	include, err := l.filterIDs(c, "load_balancer")
+	if err != nil {
+		return err
+	}
+
+	filtered := []*models.LoadBalancerSummary{}
+	for _, summary := range loadBalancerSummaries {
+		if include(summary.LoadBalancerID) {
+			filtered = append(filtered, summary)
+		}
+	}
+
+	return l.Printer.PrintLoadBalancerSummaries(filtered...)
+}
+
+func (l *LoadBalancerCommand) Metrics(c *cli.Context) error {
+	args, err := extractArgs(c.Args(), "NAME")
+	if err != nil {
+		return err
+	}
+
+	id, err := l.resolveSingleID("load_balancer", args["NAME"])
+	if err != nil {
+		return err
+	}
+
+	metrics, err := l.Client.GetLoadBalancerMetrics(id, c.String("start"), c.String("end"), c.Int("period"))
+	if err != nil {
+		return err
+	}
+
+	return l.Printer.PrintLoadBalancerMetrics(metrics)
 }
 
-func parsePort(port, certificate string) (*models.Port, error) {
+func parsePort(port, certificate, domain string) (*models.Port, error) {
 	split := strings.FieldsFunc(port, func(r rune) bool {
 		return r == ':' || r == '/'
 	})
@@ -509,21 +706,26 @@ func parsePort(port, certificate string) (*models.Port, error) {
 	protocol := split[2]
 	var certificateName string
 	var certificateARN string
+	var certificateDomain string
 
 	if strings.ToLower(protocol) == "https" {
-		if strings.HasPrefix(strings.ToLower(certificate), "arn:") {
+		switch {
+		case strings.HasPrefix(strings.ToLower(certificate), "arn:"):
 			certificateARN = certificate
-		} else {
+		case certificate != "":
 			certificateName = certificate
+		default:
+			certificateDomain = domain
 		}
 	}
 
 	model := &models.Port{
-		HostPort:        hostPort,
-		ContainerPort:   containerPort,
-		Protocol:        protocol,
-		CertificateName: certificateName,
-		CertificateARN:  certificateARN,
+		HostPort:          hostPort,
+		ContainerPort:     containerPort,
+		Protocol:          protocol,
+		CertificateName:   certificateName,
+		CertificateARN:    certificateARN,
+		CertificateDomain: certificateDomain,
 	}
 
 	return model, nil
@@ -10,9 +10,10 @@ import (
 
 func TestParsePort(t *testing.T) {
 	cases := []struct {
-		Target      string
-		Certificate string
-		Expected    models.Port
+		Target            string
+		Certificate       string
+		CertificateDomain string
+		Expected          models.Port
 	}{
 		{
 			Target: "80:80/tcp",
@@ -58,10 +59,20 @@ func TestParsePort(t *testing.T) {
 				CertificateARN: "arn:aws:iam::12345:server-certificate/crt_name",
 			},
 		},
+		{
+			Target:            "80:80/https",
+			CertificateDomain: "example.com",
+			Expected: models.Port{
+				HostPort:          80,
+				ContainerPort:     80,
+				Protocol:          "https",
+				CertificateDomain: "example.com",
+			},
+		},
 	}
 
 	for _, c := range cases {
-		result, err := parsePort(c.Target, c.Certificate)
+		result, err := parsePort(c.Target, c.Certificate, c.CertificateDomain)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -80,7 +91,7 @@ func TestParsePortErrors(t *testing.T) {
 	}
 
 	for name, input := range cases {
-		if _, err := parsePort(input, ""); err == nil {
+		if _, err := parsePort(input, "", ""); err == nil {
 			t.Fatalf("%s: error was nil!", name)
 		}
 	}
@@ -117,6 +128,42 @@ func TestLoadBalancerAddPort(t *testing.T) {
 	}
 }
 
+func TestLoadBalancerAddPort_sources(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewLoadBalancerCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("load_balancer", "name").
+		Return([]string{"id"}, nil)
+
+	tc.Client.EXPECT().
+		GetLoadBalancer("id").
+		Return(&models.LoadBalancer{}, nil)
+
+	port := models.Port{
+		HostPort:             8080,
+		ContainerPort:        80,
+		Protocol:             "http",
+		SourceCIDRs:          []string{"10.0.0.0/8", "192.168.0.0/16"},
+		SourceSecurityGroups: []string{"sg-123"},
+	}
+
+	tc.Client.EXPECT().
+		UpdateLoadBalancerPorts("id", []models.Port{port}).
+		Return(&models.LoadBalancer{}, nil)
+
+	flags := map[string]interface{}{
+		"source-cidr":           []string{"10.0.0.0/8", "192.168.0.0/16"},
+		"source-security-group": []string{"sg-123"},
+	}
+
+	c := testutils.GetCLIContext(t, []string{"name", "8080:80/http"}, flags)
+	if err := command.AddPort(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestLoadBalancerAddPort_userInputErrors(t *testing.T) {
 	tc, ctrl := newTestCommand(t)
 	defer ctrl.Finish()
@@ -167,7 +214,7 @@ func TestCreateLoadBalancer(t *testing.T) {
 	}
 
 	tc.Client.EXPECT().
-		CreateLoadBalancer("name", "environmentID", healthCheck, ports, false, 60, true).
+		CreateLoadBalancer("name", "environmentID", healthCheck, ports, false, 60, true, "api.example.com").
 		Return(&models.LoadBalancer{}, nil)
 
 	flags := map[string]interface{}{
@@ -181,6 +228,7 @@ func TestCreateLoadBalancer(t *testing.T) {
 		"healthcheck-unhealthy-threshold": 2,
 		"idle-timeout":                    60,
 		"disable-cross-zone":              false,
+		"dns-name":                        "api.example.com",
 	}
 
 	c := testutils.GetCLIContext(t, []string{"environment", "name"}, flags)
@@ -518,3 +566,95 @@ func TestLoadBalancerCrossZone_userInputErrors(t *testing.T) {
 		}
 	}
 }
+
+func TestLoadBalancerEnableAccessLogs(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewLoadBalancerCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("load_balancer", "name").
+		Return([]string{"id"}, nil)
+
+	accessLog := models.AccessLog{
+		Enabled:      true,
+		BucketName:   "bucket",
+		BucketPrefix: "prefix",
+		EmitInterval: 5,
+	}
+
+	tc.Client.EXPECT().
+		UpdateLoadBalancerAccessLog("id", accessLog).
+		Return(&models.LoadBalancer{}, nil)
+
+	flags := map[string]interface{}{
+		"bucket":   "bucket",
+		"prefix":   "prefix",
+		"interval": 5,
+	}
+
+	c := testutils.GetCLIContext(t, []string{"name"}, flags)
+	if err := command.EnableAccessLogs(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadBalancerEnableAccessLogs_userInputErrors(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewLoadBalancerCommand(tc.Command())
+
+	contexts := map[string]*cli.Context{
+		"Missing NAME arg":        testutils.GetCLIContext(t, nil, map[string]interface{}{"bucket": "bucket"}),
+		"Missing '--bucket' flag": testutils.GetCLIContext(t, []string{"name"}, nil),
+	}
+
+	for name, c := range contexts {
+		if err := command.EnableAccessLogs(c); err == nil {
+			t.Fatalf("%s: error was nil!", name)
+		}
+	}
+}
+
+func TestLoadBalancerDisableAccessLogs(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewLoadBalancerCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("load_balancer", "name").
+		Return([]string{"id"}, nil)
+
+	tc.Client.EXPECT().
+		UpdateLoadBalancerAccessLog("id", models.AccessLog{Enabled: false}).
+		Return(&models.LoadBalancer{}, nil)
+
+	c := testutils.GetCLIContext(t, []string{"name"}, nil)
+	if err := command.DisableAccessLogs(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadBalancerMetrics(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewLoadBalancerCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("load_balancer", "name").
+		Return([]string{"id"}, nil)
+
+	tc.Client.EXPECT().
+		GetLoadBalancerMetrics("id", "2017-01-01 00:00", "", 300).
+		Return(&models.LoadBalancerMetrics{}, nil)
+
+	flags := map[string]interface{}{
+		"start":  "2017-01-01 00:00",
+		"period": 300,
+	}
+
+	c := testutils.GetCLIContext(t, []string{"name"}, flags)
+	if err := command.Metrics(c); err != nil {
+		t.Fatal(err)
+	}
+}
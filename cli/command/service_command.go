@@ -71,6 +71,7 @@ func (s *ServiceCommand) GetCommand() cli.Command {
 				Usage:     "list all services",
 				Action:    wrapAction(s.Command, s.List),
 				ArgsUsage: " ",
+				Flags:     listFlags,
 			},
 			{
 				Name:      "logs",
@@ -90,6 +91,22 @@ func (s *ServiceCommand) GetCommand() cli.Command {
 						Name:  "end",
 						Usage: "the end of the time range to fetch logs (format: YYYY-MM-DD HH:MM)",
 					},
+					cli.StringFlag{
+						Name:  "since",
+						Usage: "only return logs newer than a relative duration (e.g. 30s, 15m, 2h)",
+					},
+					cli.StringFlag{
+						Name:  "grep",
+						Usage: "only return log lines matching this CloudWatch Logs filter pattern",
+					},
+					cli.StringFlag{
+						Name:  "container",
+						Usage: "only return logs from the container with this name",
+					},
+					cli.BoolFlag{
+						Name:  "follow",
+						Usage: "stream new log lines as they are written",
+					},
 				},
 			},
 			{
@@ -225,7 +242,19 @@ func (s *ServiceCommand) List(c *cli.Context) error {
 		return err
 	}
 
-	return s.Printer.PrintServiceSummaries(serviceSummaries...)
+	include, err := s.filterIDs(c, "service")
+	if err != nil {
+		return err
+	}
+
+	filtered := []*models.ServiceSummary{}
+	for _, summary := range serviceSummaries {
+		if include(summary.ServiceID) {
+			filtered = append(filtered, summary)
+		}
+	}
+
+	return s.Printer.PrintServiceSummaries(filtered...)
 }
 
 func (s *ServiceCommand) Logs(c *cli.Context) error {
@@ -239,7 +268,24 @@ func (s *ServiceCommand) Logs(c *cli.Context) error {
 		return err
 	}
 
-	logs, err := s.Client.GetServiceLogs(id, c.String("start"), c.String("end"), c.Int("tail"))
+	start, err := getLogStart(c)
+	if err != nil {
+		return err
+	}
+
+	if c.Bool("follow") {
+		if c.String("end") != "" || c.Int("tail") != 0 || c.String("grep") != "" {
+			return NewUsageError("Flags --end, --tail, and --grep cannot be used with --follow")
+		}
+
+		printf := func(event *models.LogEvent) error {
+			return s.Printer.PrintLogEvents(event)
+		}
+
+		return s.Client.FollowServiceLogs(id, start, c.String("container"), printf)
+	}
+
+	logs, err := s.Client.GetServiceLogs(id, start, c.String("end"), c.String("grep"), c.String("container"), c.Int("tail"))
 	if err != nil {
 		return err
 	}
@@ -2,7 +2,9 @@ package command
 
 import (
 	"testing"
+	"time"
 
+	"github.com/golang/mock/gomock"
 	"github.com/quintilesims/layer0/common/models"
 	"github.com/quintilesims/layer0/common/testutils"
 	"github.com/urfave/cli"
@@ -278,12 +280,14 @@ func TestGetServiceLogs(t *testing.T) {
 		Return([]string{"id"}, nil)
 
 	tc.Client.EXPECT().
-		GetServiceLogs("id", "start", "end", 100)
+		GetServiceLogs("id", "start", "end", "ERROR", "api", 100)
 
 	flags := map[string]interface{}{
-		"tail":  100,
-		"start": "start",
-		"end":   "end",
+		"tail":      100,
+		"start":     "start",
+		"end":       "end",
+		"grep":      "ERROR",
+		"container": "api",
 	}
 
 	c := testutils.GetCLIContext(t, []string{"name"}, flags)
@@ -292,6 +296,83 @@ func TestGetServiceLogs(t *testing.T) {
 	}
 }
 
+func TestGetServiceLogs_follow(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewServiceCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("service", "name").
+		Return([]string{"id"}, nil).
+		Times(2)
+
+	tc.Client.EXPECT().
+		FollowServiceLogs("id", "start", "api", gomock.Any()).
+		Return(nil)
+
+	flags := map[string]interface{}{
+		"start":     "start",
+		"container": "api",
+		"follow":    true,
+	}
+
+	c := testutils.GetCLIContext(t, []string{"name"}, flags)
+	if err := command.Logs(c); err != nil {
+		t.Fatal(err)
+	}
+
+	flags["end"] = "end"
+	c = testutils.GetCLIContext(t, []string{"name"}, flags)
+	if err := command.Logs(c); err == nil {
+		t.Fatal("Error was nil when using --end with --follow!")
+	}
+}
+
+func TestGetServiceLogs_since(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewServiceCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("service", "name").
+		Return([]string{"id"}, nil).
+		AnyTimes()
+
+	expected := time.Now().UTC().Add(-15 * time.Minute)
+	tc.Client.EXPECT().
+		GetServiceLogs("id", gomock.Any(), "", "", "", 0).
+		Do(func(id, start, end, filter, container string, tail int) {
+			received, err := time.Parse(time.RFC3339, start)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := received.Sub(expected); diff < -time.Minute || diff > time.Minute {
+				t.Errorf("Start was %v, expected about %v", received, expected)
+			}
+		}).
+		Return([]*models.LogFile{}, nil)
+
+	c := testutils.GetCLIContext(t, []string{"name"}, map[string]interface{}{"since": "15m"})
+	if err := command.Logs(c); err != nil {
+		t.Fatal(err)
+	}
+
+	contexts := map[string]*cli.Context{
+		"Invalid --since": testutils.GetCLIContext(t, []string{"name"}, map[string]interface{}{"since": "15"}),
+		"--since with --start": testutils.GetCLIContext(t, []string{"name"}, map[string]interface{}{
+			"since": "15m",
+			"start": "2001-01-01 01:01",
+		}),
+	}
+
+	for name, c := range contexts {
+		if err := command.Logs(c); err == nil {
+			t.Fatalf("%s: error was nil!", name)
+		}
+	}
+}
+
 func TestGetServiceLogs_userInputErrors(t *testing.T) {
 	tc, ctrl := newTestCommand(t)
 	defer ctrl.Finish()
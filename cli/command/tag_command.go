@@ -0,0 +1,169 @@
+package command
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/urfave/cli"
+)
+
+var taggableEntityTypes = []string{
+	"certificate",
+	"deploy",
+	"environment",
+	"job",
+	"load_balancer",
+	"service",
+	"task",
+}
+
+type TagCommand struct {
+	*Command
+}
+
+func NewTagCommand(command *Command) *TagCommand {
+	return &TagCommand{command}
+}
+
+func (t *TagCommand) GetCommand() cli.Command {
+	return cli.Command{
+		Name:  "tag",
+		Usage: "manage user-defined tags on layer0 entities",
+		Description: "ENTITY_TYPE must be one of: " + strings.Join(taggableEntityTypes, ", ") +
+			". Keys used by Layer0 (e.g. 'name', 'environment_id', 'version', 'link') are reserved.",
+		Subcommands: []cli.Command{
+			{
+				Name:      "add",
+				Usage:     "add or update tags on an entity",
+				Action:    wrapAction(t.Command, t.Add),
+				ArgsUsage: "ENTITY_TYPE NAME KEY=VALUE [KEY=VALUE...]",
+			},
+			{
+				Name:      "remove",
+				Usage:     "remove tags from an entity",
+				Action:    wrapAction(t.Command, t.Remove),
+				ArgsUsage: "ENTITY_TYPE NAME KEY [KEY...]",
+			},
+			{
+				Name:      "list",
+				Usage:     "list the user-defined tags on an entity",
+				Action:    wrapAction(t.Command, t.List),
+				ArgsUsage: "ENTITY_TYPE NAME",
+			},
+		},
+	}
+}
+
+func (t *TagCommand) Add(c *cli.Context) error {
+	entityType, id, err := t.resolveEntity(c)
+	if err != nil {
+		return err
+	}
+
+	if len(c.Args()) < 3 {
+		return NewUsageError("At least one KEY=VALUE argument is required")
+	}
+
+	tags := map[string]string{}
+	for _, arg := range c.Args()[2:] {
+		split := strings.SplitN(arg, "=", 2)
+		if len(split) != 2 || split[0] == "" {
+			return NewUsageError("Tag '%s' is not in format KEY=VALUE", arg)
+		}
+
+		tags[split[0]] = split[1]
+	}
+
+	for key, value := range tags {
+		if err := t.Client.CreateTag(entityType, id, key, value); err != nil {
+			return err
+		}
+	}
+
+	return t.printTags(entityType, id)
+}
+
+func (t *TagCommand) Remove(c *cli.Context) error {
+	entityType, id, err := t.resolveEntity(c)
+	if err != nil {
+		return err
+	}
+
+	if len(c.Args()) < 3 {
+		return NewUsageError("At least one KEY argument is required")
+	}
+
+	for _, key := range c.Args()[2:] {
+		if err := t.Client.DeleteTag(entityType, id, key); err != nil {
+			return err
+		}
+	}
+
+	return t.printTags(entityType, id)
+}
+
+func (t *TagCommand) List(c *cli.Context) error {
+	entityType, id, err := t.resolveEntity(c)
+	if err != nil {
+		return err
+	}
+
+	return t.printTags(entityType, id)
+}
+
+// resolveEntity returns the entity type and the id of the entity specified by the ENTITY_TYPE and NAME args
+func (t *TagCommand) resolveEntity(c *cli.Context) (string, string, error) {
+	args, err := extractArgs(c.Args(), "ENTITY_TYPE", "NAME")
+	if err != nil {
+		return "", "", err
+	}
+
+	entityType := strings.Replace(strings.ToLower(args["ENTITY_TYPE"]), "-", "_", -1)
+	if entityType == "loadbalancer" {
+		entityType = "load_balancer"
+	}
+
+	if !isTaggableEntityType(entityType) {
+		return "", "", NewUsageError("ENTITY_TYPE must be one of: %s", strings.Join(taggableEntityTypes, ", "))
+	}
+
+	id, err := t.resolveSingleID(entityType, args["NAME"])
+	if err != nil {
+		return "", "", err
+	}
+
+	return entityType, id, nil
+}
+
+func (t *TagCommand) printTags(entityType, id string) error {
+	params := map[string]string{
+		"type": entityType,
+		"id":   id,
+	}
+
+	ewts, err := t.Client.SelectByQuery(params)
+	if err != nil {
+		return err
+	}
+
+	tags := []*models.Tag{}
+	for _, ewt := range ewts {
+		for key, value := range ewt.Tags.UserDefined() {
+			tags = append(tags, &models.Tag{EntityType: ewt.EntityType, EntityID: ewt.EntityID, Key: key, Value: value})
+		}
+	}
+
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Key < tags[j].Key })
+	return t.Printer.PrintTags(tags...)
+}
+
+func isTaggableEntityType(entityType string) bool {
+	for _, t := range taggableEntityTypes {
+		if t == entityType {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,107 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
+	"github.com/urfave/cli"
+)
+
+func expectPrintTags(tc *TestCommand, entityType, id string) {
+	tc.Client.EXPECT().
+		SelectByQuery(map[string]string{"type": entityType, "id": id}).
+		Return([]*models.EntityWithTags{}, nil)
+}
+
+func TestAddTags(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewTagCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("load_balancer", "name").
+		Return([]string{"id"}, nil)
+
+	tc.Client.EXPECT().
+		CreateTag("load_balancer", "id", "team", "payments").
+		Return(nil)
+
+	tc.Client.EXPECT().
+		CreateTag("load_balancer", "id", "owner", "alice=bob").
+		Return(nil)
+
+	expectPrintTags(tc, "load_balancer", "id")
+
+	c := testutils.GetCLIContext(t, []string{"load-balancer", "name", "team=payments", "owner=alice=bob"}, nil)
+	if err := command.Add(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAddTags_userInputErrors(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewTagCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("service", "name").
+		Return([]string{"id"}, nil).
+		Times(2)
+
+	contexts := map[string]*cli.Context{
+		"Missing ENTITY_TYPE arg": testutils.GetCLIContext(t, nil, nil),
+		"Missing NAME arg":        testutils.GetCLIContext(t, []string{"service"}, nil),
+		"Invalid ENTITY_TYPE":     testutils.GetCLIContext(t, []string{"container", "name", "k=v"}, nil),
+		"Missing KEY=VALUE arg":   testutils.GetCLIContext(t, []string{"service", "name"}, nil),
+		"Invalid KEY=VALUE arg":   testutils.GetCLIContext(t, []string{"service", "name", "team"}, nil),
+	}
+
+	for name, c := range contexts {
+		if err := command.Add(c); err == nil {
+			t.Fatalf("%s: error was nil!", name)
+		}
+	}
+}
+
+func TestRemoveTags(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewTagCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("service", "name").
+		Return([]string{"id"}, nil)
+
+	tc.Client.EXPECT().
+		DeleteTag("service", "id", "team").
+		Return(nil)
+
+	tc.Client.EXPECT().
+		DeleteTag("service", "id", "owner").
+		Return(nil)
+
+	expectPrintTags(tc, "service", "id")
+
+	c := testutils.GetCLIContext(t, []string{"service", "name", "team", "owner"}, nil)
+	if err := command.Remove(c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListTags(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewTagCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("environment", "name").
+		Return([]string{"id"}, nil)
+
+	expectPrintTags(tc, "environment", "id")
+
+	c := testutils.GetCLIContext(t, []string{"environment", "name"}, nil)
+	if err := command.List(c); err != nil {
+		t.Fatal(err)
+	}
+}
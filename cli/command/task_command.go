@@ -60,12 +60,12 @@ func (t *TaskCommand) GetCommand() cli.Command {
 				Usage:     "list all tasks",
 				Action:    wrapAction(t.Command, t.List),
 				ArgsUsage: " ",
-				Flags: []cli.Flag{
+				Flags: append([]cli.Flag{
 					cli.BoolFlag{
 						Name:  "all",
 						Usage: "included deleted tasks",
 					},
-				},
+				}, listFlags...),
 			},
 			{
 				Name:      "logs",
@@ -85,6 +85,22 @@ func (t *TaskCommand) GetCommand() cli.Command {
 						Name:  "end",
 						Usage: "the end of the time range to fetch logs (format: YYYY-MM-DD HH:MM)",
 					},
+					cli.StringFlag{
+						Name:  "since",
+						Usage: "only return logs newer than a relative duration (e.g. 30s, 15m, 2h)",
+					},
+					cli.StringFlag{
+						Name:  "grep",
+						Usage: "only return log lines matching this CloudWatch Logs filter pattern",
+					},
+					cli.StringFlag{
+						Name:  "container",
+						Usage: "only return logs from the container with this name",
+					},
+					cli.BoolFlag{
+						Name:  "follow",
+						Usage: "stream new log lines as they are written",
+					},
 				},
 			},
 		},
@@ -219,11 +235,23 @@ func (t *TaskCommand) List(c *cli.Context) error {
 		return err
 	}
 
+	include, err := t.filterIDs(c, "task")
+	if err != nil {
+		return err
+	}
+
+	filtered := []*models.TaskSummary{}
+	for _, summary := range taskSummaries {
+		if include(summary.TaskID) {
+			filtered = append(filtered, summary)
+		}
+	}
+
 	if !c.Bool("all") {
-		taskSummaries = filterTaskSummaries(taskSummaries)
+		filtered = filterTaskSummaries(filtered)
 	}
 
-	return t.Printer.PrintTaskSummaries(taskSummaries...)
+	return t.Printer.PrintTaskSummaries(filtered...)
 }
 
 func (t *TaskCommand) Logs(c *cli.Context) error {
@@ -237,7 +265,24 @@ func (t *TaskCommand) Logs(c *cli.Context) error {
 		return err
 	}
 
-	logs, err := t.Client.GetTaskLogs(id, c.String("start"), c.String("end"), c.Int("tail"))
+	start, err := getLogStart(c)
+	if err != nil {
+		return err
+	}
+
+	if c.Bool("follow") {
+		if c.String("end") != "" || c.Int("tail") != 0 || c.String("grep") != "" {
+			return NewUsageError("Flags --end, --tail, and --grep cannot be used with --follow")
+		}
+
+		printf := func(event *models.LogEvent) error {
+			return t.Printer.PrintLogEvents(event)
+		}
+
+		return t.Client.FollowTaskLogs(id, start, c.String("container"), printf)
+	}
+
+	logs, err := t.Client.GetTaskLogs(id, start, c.String("end"), c.String("grep"), c.String("container"), c.Int("tail"))
 	if err != nil {
 		return err
 	}
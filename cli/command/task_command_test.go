@@ -292,12 +292,14 @@ func TestGetTaskLogs(t *testing.T) {
 		Return([]string{"id"}, nil)
 
 	tc.Client.EXPECT().
-		GetTaskLogs("id", "start", "end", 100)
+		GetTaskLogs("id", "start", "end", "ERROR", "api", 100)
 
 	flags := map[string]interface{}{
-		"tail":  100,
-		"start": "start",
-		"end":   "end",
+		"tail":      100,
+		"start":     "start",
+		"end":       "end",
+		"grep":      "ERROR",
+		"container": "api",
 	}
 
 	c := testutils.GetCLIContext(t, []string{"name"}, flags)
@@ -306,6 +308,38 @@ func TestGetTaskLogs(t *testing.T) {
 	}
 }
 
+func TestGetTaskLogs_follow(t *testing.T) {
+	tc, ctrl := newTestCommand(t)
+	defer ctrl.Finish()
+	command := NewTaskCommand(tc.Command())
+
+	tc.Resolver.EXPECT().
+		Resolve("task", "name").
+		Return([]string{"id"}, nil).
+		Times(2)
+
+	tc.Client.EXPECT().
+		FollowTaskLogs("id", "start", "api", gomock.Any()).
+		Return(nil)
+
+	flags := map[string]interface{}{
+		"start":     "start",
+		"container": "api",
+		"follow":    true,
+	}
+
+	c := testutils.GetCLIContext(t, []string{"name"}, flags)
+	if err := command.Logs(c); err != nil {
+		t.Fatal(err)
+	}
+
+	flags["end"] = "end"
+	c = testutils.GetCLIContext(t, []string{"name"}, flags)
+	if err := command.Logs(c); err == nil {
+		t.Fatal("Error was nil when using --end with --follow!")
+	}
+}
+
 func TestGetTaskLogs_userInputErrors(t *testing.T) {
 	tc, ctrl := newTestCommand(t)
 	defer ctrl.Finish()
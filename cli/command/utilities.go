@@ -48,3 +48,25 @@ func extractArgs(received []string, names ...string) (map[string]string, error)
 func getTimeout(c *cli.Context) (time.Duration, error) {
 	return time.ParseDuration(c.GlobalString("timeout"))
 }
+
+// getLogStart returns the start of the time range to fetch logs from, using either
+// the --start flag or the --since flag relative to the current time
+func getLogStart(c *cli.Context) (string, error) {
+	start := c.String("start")
+	since := c.String("since")
+
+	if since == "" {
+		return start, nil
+	}
+
+	if start != "" {
+		return "", NewUsageError("Flags --start and --since cannot be used together")
+	}
+
+	duration, err := time.ParseDuration(since)
+	if err != nil || duration <= 0 {
+		return "", NewUsageError("'%s' is not a valid duration (e.g. 30s, 15m, 2h)", since)
+	}
+
+	return time.Now().UTC().Add(-duration).Format(time.RFC3339), nil
+}
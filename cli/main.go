@@ -124,11 +124,13 @@ func getCommands(client *client.APIClient) []command.CommandGroup {
 
 	return []command.CommandGroup{
 		command.NewAdminCommand(cmd),
+		command.NewCertificateCommand(cmd),
 		command.NewDeployCommand(cmd),
 		command.NewEnvironmentCommand(cmd),
 		command.NewJobCommand(cmd),
 		command.NewLoadBalancerCommand(cmd),
 		command.NewServiceCommand(cmd),
+		command.NewTagCommand(cmd),
 		command.NewTaskCommand(cmd),
 	}
 }
@@ -7,22 +7,48 @@ import (
 type Printer interface {
 	StartSpinner(message string)
 	StopSpinner()
+	PrintCertificates(certificates ...*models.Certificate) error
 	PrintDeploys(deploys ...*models.Deploy) error
 	PrintDeploySummaries(deploys ...*models.DeploySummary) error
 	PrintEnvironments(environments ...*models.Environment) error
 	PrintEnvironmentSummaries(environments ...*models.EnvironmentSummary) error
+	PrintEnvironmentCapacity(capacity *models.EnvironmentCapacity) error
+	PrintEnvironmentCost(cost *models.EnvironmentCost) error
 	PrintJobs(jobs ...*models.Job) error
 	PrintLoadBalancers(loadBalancers ...*models.LoadBalancer) error
 	PrintLoadBalancerSummaries(loadBalancers ...*models.LoadBalancerSummary) error
 	PrintLoadBalancerHealthCheck(loadBalancer *models.LoadBalancer) error
 	PrintLoadBalancerIdleTimeout(loadBalancer *models.LoadBalancer) error
 	PrintLoadBalancerCrossZone(loadBalancer *models.LoadBalancer) error
+	PrintLoadBalancerAccessLog(loadBalancer *models.LoadBalancer) error
+	PrintLoadBalancerMetrics(metrics *models.LoadBalancerMetrics) error
 	PrintLogs(logs ...*models.LogFile) error
+	PrintLogEvents(events ...*models.LogEvent) error
 	PrintScalerRunInfo(*models.ScalerRunInfo) error
 	PrintServices(services ...*models.Service) error
 	PrintServiceSummaries(services ...*models.ServiceSummary) error
+	PrintTags(tags ...*models.Tag) error
 	PrintTasks(tasks ...*models.Task) error
 	PrintTaskSummaries(tasks ...*models.TaskSummary) error
 	Printf(format string, tokens ...interface{})
 	Fatalf(code int64, format string, tokens ...interface{})
 }
+
+// logFileEvents returns the log file's events; apis that predate events only return
+// the message of each line, so those lines are returned as events without timestamps
+func logFileEvents(logFile *models.LogFile) []*models.LogEvent {
+	if len(logFile.Events) > 0 || len(logFile.Lines) == 0 {
+		return logFile.Events
+	}
+
+	events := make([]*models.LogEvent, len(logFile.Lines))
+	for i, line := range logFile.Lines {
+		events[i] = &models.LogEvent{
+			ContainerName: logFile.Name,
+			Message:       line,
+			TaskID:        logFile.TaskID,
+		}
+	}
+
+	return events
+}
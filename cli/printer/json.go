@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 
 	"github.com/quintilesims/layer0/common/models"
 )
@@ -51,6 +52,10 @@ func (j *JSONPrinter) print(obj interface{}) error {
 	return nil
 }
 
+func (j *JSONPrinter) PrintCertificates(certificates ...*models.Certificate) error {
+	return j.print(certificates)
+}
+
 func (j *JSONPrinter) PrintDeploys(deploys ...*models.Deploy) error {
 	return j.print(deploys)
 }
@@ -67,6 +72,14 @@ func (j *JSONPrinter) PrintEnvironmentSummaries(environments ...*models.Environm
 	return j.print(environments)
 }
 
+func (j *JSONPrinter) PrintEnvironmentCapacity(capacity *models.EnvironmentCapacity) error {
+	return j.print(capacity)
+}
+
+func (j *JSONPrinter) PrintEnvironmentCost(cost *models.EnvironmentCost) error {
+	return j.print(cost)
+}
+
 func (j *JSONPrinter) PrintJobs(jobs ...*models.Job) error {
 	return j.print(jobs)
 }
@@ -91,8 +104,40 @@ func (j *JSONPrinter) PrintLoadBalancerCrossZone(loadBalancer *models.LoadBalanc
 	return j.print(loadBalancer)
 }
 
+func (j *JSONPrinter) PrintLoadBalancerAccessLog(loadBalancer *models.LoadBalancer) error {
+	return j.print(loadBalancer)
+}
+
+func (j *JSONPrinter) PrintLoadBalancerMetrics(metrics *models.LoadBalancerMetrics) error {
+	return j.print(metrics)
+}
+
+// PrintLogs prints the lines of all the logs as json lines, ordered by timestamp
 func (j *JSONPrinter) PrintLogs(logs ...*models.LogFile) error {
-	return j.print(logs)
+	events := []*models.LogEvent{}
+	for _, l := range logs {
+		events = append(events, logFileEvents(l)...)
+	}
+
+	sort.SliceStable(events, func(i, k int) bool {
+		return events[i].Timestamp.Before(events[k].Timestamp)
+	})
+
+	return j.PrintLogEvents(events...)
+}
+
+// PrintLogEvents prints each event as a single line of json so followed logs can be streamed
+func (j *JSONPrinter) PrintLogEvents(events ...*models.LogEvent) error {
+	for _, event := range events {
+		js, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(js))
+	}
+
+	return nil
 }
 
 func (j *JSONPrinter) PrintScalerRunInfo(runInfo *models.ScalerRunInfo) error {
@@ -107,6 +152,10 @@ func (j *JSONPrinter) PrintServiceSummaries(services ...*models.ServiceSummary)
 	return j.print(services)
 }
 
+func (j *JSONPrinter) PrintTags(tags ...*models.Tag) error {
+	return j.print(tags)
+}
+
 func (j *JSONPrinter) PrintTasks(tasks ...*models.Task) error {
 	return j.print(tasks)
 }
@@ -3,6 +3,8 @@ package printer
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -46,11 +48,67 @@ func (t *TextPrinter) Fatalf(code int64, format string, tokens ...interface{}) {
 	os.Exit(1)
 }
 
+func (t *TextPrinter) PrintCertificates(certificates ...*models.Certificate) error {
+	getName := func(c *models.Certificate) string {
+		if c.CertificateName != "" {
+			return c.CertificateName
+		}
+
+		return c.DomainName
+	}
+
+	getExpiration := func(c *models.Certificate) string {
+		if c.Expiration.IsZero() {
+			return ""
+		}
+
+		return c.Expiration.Format(TIME_FORMAT)
+	}
+
+	rows := []string{"CERTIFICATE ID | NAME | SOURCE | STATUS | EXPIRES"}
+	for _, c := range certificates {
+		row := fmt.Sprintf("%s | %s | %s | %s | %s",
+			c.CertificateID,
+			getName(c),
+			strings.ToUpper(c.Source),
+			c.Status,
+			getExpiration(c))
+
+		rows = append(rows, row)
+	}
+
+	fmt.Println(columnize.SimpleFormat(rows))
+	return nil
+}
+
 func (t *TextPrinter) PrintDeploys(deploys ...*models.Deploy) error {
-	rows := []string{"DEPLOY ID | DEPLOY NAME | VERSION"}
+	// only show tags if a deploy has any
+	var showTags bool
 	for _, d := range deploys {
+		if len(d.Tags) > 0 {
+			showTags = true
+		}
+	}
+
+	header := "DEPLOY ID | DEPLOY NAME | VERSION"
+	if showTags {
+		header += " | TAGS"
+	}
+
+	rows := []string{header}
+	for _, d := range deploys {
+		tags := formatTags(d.Tags)
 		row := fmt.Sprintf("%s | %s |  %s", d.DeployID, d.DeployName, d.Version)
+		if showTags {
+			row += " | " + getItem(tags, 0)
+		}
+
 		rows = append(rows, row)
+
+		// add the extra tag rows
+		for i := 1; i < len(tags); i++ {
+			rows = append(rows, " | | | "+tags[i])
+		}
 	}
 
 	fmt.Println(columnize.SimpleFormat(rows))
@@ -69,29 +127,102 @@ func (t *TextPrinter) PrintDeploySummaries(deploys ...*models.DeploySummary) err
 }
 
 func (t *TextPrinter) PrintEnvironments(environments ...*models.Environment) error {
-	getLink := func(e *models.Environment, i int) string {
-		if i > len(e.Links)-1 {
-			return ""
+	getLinks := func(e *models.Environment) []string {
+		// older apis do not return link details
+		if len(e.LinkDetails) == 0 {
+			return e.Links
+		}
+
+		links := make([]string, len(e.LinkDetails))
+		for i, link := range e.LinkDetails {
+			links[i] = formatEnvironmentLink(e.EnvironmentID, link)
+		}
+
+		return links
+	}
+
+	getIngressRules := func(e *models.Environment) []string {
+		rules := make([]string, len(e.IngressRules))
+		for i, rule := range e.IngressRules {
+			source := rule.CIDR
+			if rule.SecurityGroupID != "" {
+				source = rule.SecurityGroupID
+			}
+
+			rules[i] = fmt.Sprintf("%s (%s)", source, formatPortRange(rule.Protocol, rule.FromPort, rule.ToPort))
 		}
 
-		return e.Links[i]
+		return rules
 	}
 
-	rows := []string{"ENVIRONMENT ID | ENVIRONMENT NAME | OS | CLUSTER COUNT | INSTANCE SIZE | LINKS"}
+	// only show ingress rules and tags if an environment has any
+	var showIngress, showTags bool
 	for _, e := range environments {
+		if len(e.IngressRules) > 0 {
+			showIngress = true
+		}
+
+		if len(e.Tags) > 0 {
+			showTags = true
+		}
+	}
+
+	getInstanceSize := func(e *models.Environment) string {
+		instanceSize := e.InstanceSize
+		if e.MixedInstancesPolicy != nil {
+			instanceSize = strings.Join(e.MixedInstancesPolicy.InstanceTypes, ",")
+		}
+
+		if e.SpotPrice != "" {
+			instanceSize = fmt.Sprintf("%s (spot %s)", instanceSize, e.SpotPrice)
+		}
+
+		return instanceSize
+	}
+
+	header := "ENVIRONMENT ID | ENVIRONMENT NAME | OS | CLUSTER COUNT | INSTANCE SIZE | LINKS"
+	if showIngress {
+		header += " | INGRESS"
+	}
+
+	if showTags {
+		header += " | TAGS"
+	}
+
+	rows := []string{header}
+	for _, e := range environments {
+		links := getLinks(e)
+		ingressRules := getIngressRules(e)
+		tags := formatTags(e.Tags)
 		row := fmt.Sprintf("%s | %s | %s | %d | %s | %s",
 			e.EnvironmentID,
 			e.EnvironmentName,
 			e.OperatingSystem,
 			e.ClusterCount,
-			e.InstanceSize,
-			getLink(e, 0))
+			getInstanceSize(e),
+			getItem(links, 0))
+
+		if showIngress {
+			row += " | " + getItem(ingressRules, 0)
+		}
+
+		if showTags {
+			row += " | " + getItem(tags, 0)
+		}
 
 		rows = append(rows, row)
 
-		// add the extra link rows
-		for i := 1; i < len(e.Links); i++ {
-			row := fmt.Sprintf(" | | | | | %s", getLink(e, i))
+		// add the extra link, ingress, and tag rows
+		for i := 1; i < len(links) || i < len(ingressRules) || i < len(tags); i++ {
+			row := fmt.Sprintf(" | | | | | %s", getItem(links, i))
+			if showIngress {
+				row += " | " + getItem(ingressRules, i)
+			}
+
+			if showTags {
+				row += " | " + getItem(tags, i)
+			}
+
 			rows = append(rows, row)
 		}
 	}
@@ -100,6 +231,68 @@ func (t *TextPrinter) PrintEnvironments(environments ...*models.Environment) err
 	return nil
 }
 
+// formatEnvironmentLink describes a link from the perspective of one of its environments, e.g.
+// 'env2' for a link allowing all traffic, or '-> env2 (5432/tcp)' for a one-way link to env2
+func formatEnvironmentLink(environmentID string, link models.EnvironmentLink) string {
+	peer := link.DestEnvironmentID
+	if peer == environmentID {
+		peer = link.SourceEnvironmentID
+	}
+
+	text := peer
+	if link.OneWay {
+		if link.SourceEnvironmentID == environmentID {
+			text = "-> " + text
+		} else {
+			text = "<- " + text
+		}
+	}
+
+	if len(link.Ports) > 0 {
+		ports := make([]string, len(link.Ports))
+		for i, port := range link.Ports {
+			ports[i] = formatPortRange(port.Protocol, port.FromPort, port.ToPort)
+		}
+
+		text = fmt.Sprintf("%s (%s)", text, strings.Join(ports, ", "))
+	}
+
+	return text
+}
+
+// formatTags returns the tags as 'key=value' strings sorted by key
+func formatTags(tags map[string]string) []string {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	formatted := make([]string, len(keys))
+	for i, key := range keys {
+		formatted[i] = fmt.Sprintf("%s=%s", key, tags[key])
+	}
+
+	return formatted
+}
+
+func getItem(items []string, i int) string {
+	if i > len(items)-1 {
+		return ""
+	}
+
+	return items[i]
+}
+
+func formatPortRange(protocol string, fromPort, toPort int) string {
+	if toPort != fromPort {
+		return fmt.Sprintf("%d-%d/%s", fromPort, toPort, protocol)
+	}
+
+	return fmt.Sprintf("%d/%s", fromPort, protocol)
+}
+
 func (t *TextPrinter) PrintEnvironmentSummaries(environments ...*models.EnvironmentSummary) error {
 	rows := []string{"ENVIRONMENT ID | ENVIRONMENT NAME | OS "}
 	for _, e := range environments {
@@ -111,6 +304,79 @@ func (t *TextPrinter) PrintEnvironmentSummaries(environments ...*models.Environm
 	return nil
 }
 
+func (t *TextPrinter) PrintEnvironmentCapacity(capacity *models.EnvironmentCapacity) error {
+	getPorts := func(ports []int) string {
+		display := make([]string, len(ports))
+		for i, port := range ports {
+			display[i] = strconv.Itoa(port)
+		}
+
+		return strings.Join(display, ",")
+	}
+
+	rows := []string{"INSTANCE ID | MEMORY (MiB) | CPU | USED PORTS | RECLAIMABLE"}
+	for _, i := range capacity.Instances {
+		row := fmt.Sprintf("%s | %d/%d | %d/%d | %s | %t",
+			i.InstanceID,
+			i.UsedMemoryMiB,
+			i.TotalMemoryMiB,
+			i.UsedCPU,
+			i.TotalCPU,
+			getPorts(i.UsedPorts),
+			i.Reclaimable)
+
+		rows = append(rows, row)
+	}
+
+	rows = append(rows, fmt.Sprintf("TOTAL | %d/%d | %d/%d | | %d",
+		capacity.UsedMemoryMiB,
+		capacity.TotalMemoryMiB,
+		capacity.UsedCPU,
+		capacity.TotalCPU,
+		capacity.ReclaimableInstances))
+
+	fmt.Println(columnize.SimpleFormat(rows))
+	fmt.Printf("Pending resources: %d\n", len(capacity.PendingResources))
+	fmt.Printf("Reclaimable: %.0f%%\n", capacity.ReclaimableFraction*100)
+	return nil
+}
+
+func (t *TextPrinter) PrintEnvironmentCost(cost *models.EnvironmentCost) error {
+	getService := func(s models.ServiceCost) string {
+		if s.ServiceName != "" {
+			return s.ServiceName
+		}
+
+		return s.ServiceID
+	}
+
+	rows := []string{"ITEM | DETAIL | MONTHLY COST"}
+	rows = append(rows, fmt.Sprintf("Instances | %d x %s | %.2f", cost.InstanceCount, cost.InstanceSize, cost.InstanceMonthlyCost))
+	rows = append(rows, fmt.Sprintf("Load Balancers | %d | %.2f", cost.LoadBalancerCount, cost.LoadBalancerMonthlyCost))
+	if cost.DataTransferGB > 0 {
+		rows = append(rows, fmt.Sprintf("Data Transfer | %g GB | %.2f", cost.DataTransferGB, cost.DataTransferMonthlyCost))
+	}
+
+	rows = append(rows, fmt.Sprintf("TOTAL (%s) | | %.2f", cost.Currency, cost.TotalMonthlyCost))
+	fmt.Println(columnize.SimpleFormat(rows))
+	fmt.Println()
+
+	rows = []string{"SERVICE | MEMORY (MiB) | SHARE | MONTHLY COST"}
+	for _, s := range cost.Services {
+		row := fmt.Sprintf("%s | %d | %.1f%% | %.2f", getService(s), s.MemoryMiB, s.MemoryShare*100, s.TotalMonthlyCost)
+		rows = append(rows, row)
+	}
+
+	rows = append(rows, fmt.Sprintf("(unallocated) | | | %.2f", cost.UnallocatedMonthlyCost))
+	fmt.Println(columnize.SimpleFormat(rows))
+
+	for _, warning := range cost.Warnings {
+		fmt.Printf("Warning: %s\n", warning)
+	}
+
+	return nil
+}
+
 func (t *TextPrinter) PrintJobs(jobs ...*models.Job) error {
 	getType := func(j *models.Job) string {
 		jobType := types.JobType(j.JobType).String()
@@ -122,8 +388,26 @@ func (t *TextPrinter) PrintJobs(jobs ...*models.Job) error {
 		return strings.Title(jobStatus)
 	}
 
-	rows := []string{"JOB ID | TASK ID | TYPE | STATUS | CREATED"}
+	// only show steps if a job has any
+	var showSteps bool
 	for _, j := range jobs {
+		if len(j.Steps) > 0 {
+			showSteps = true
+		}
+	}
+
+	header := "JOB ID | TASK ID | TYPE | STATUS | CREATED"
+	if showSteps {
+		header += " | STEPS"
+	}
+
+	rows := []string{header}
+	for _, j := range jobs {
+		steps := make([]string, len(j.Steps))
+		for i, step := range j.Steps {
+			steps[i] = formatJobStep(step)
+		}
+
 		row := fmt.Sprintf("%s | %s | %s | %s | %s",
 			j.JobID,
 			j.TaskID,
@@ -131,13 +415,47 @@ func (t *TextPrinter) PrintJobs(jobs ...*models.Job) error {
 			getStatus(j),
 			j.TimeCreated.Format(TIME_FORMAT))
 
+		if showSteps {
+			row += " | " + getItem(steps, 0)
+		}
+
 		rows = append(rows, row)
+
+		// add the extra step rows
+		for i := 1; i < len(steps); i++ {
+			rows = append(rows, fmt.Sprintf(" | | | | | %s", steps[i]))
+		}
 	}
 
 	fmt.Println(columnize.SimpleFormat(rows))
 	return nil
 }
 
+// formatJobStep describes the progress of a job step, e.g. 'Delete Services: completed in 1m5s'
+// or 'Create Task: in progress, 2 warnings (last: some error)'
+func formatJobStep(step models.JobStep) string {
+	var text string
+	switch {
+	case step.Error != "":
+		duration := step.TimeFinished.Sub(step.TimeStarted).Round(time.Second)
+		text = fmt.Sprintf("%s: failed after %v: %s", step.Name, duration, step.Error)
+	case !step.TimeFinished.IsZero():
+		duration := step.TimeFinished.Sub(step.TimeStarted).Round(time.Second)
+		text = fmt.Sprintf("%s: completed in %v", step.Name, duration)
+	case !step.TimeStarted.IsZero():
+		text = fmt.Sprintf("%s: in progress since %s", step.Name, step.TimeStarted.Format(TIME_FORMAT))
+	default:
+		return fmt.Sprintf("%s: pending", step.Name)
+	}
+
+	if count := len(step.Warnings); count > 0 {
+		text += fmt.Sprintf(", %d warning(s) (last: %s)", count, step.Warnings[count-1])
+	}
+
+	// pipes would break the column formatting
+	return strings.Replace(text, "|", "/", -1)
+}
+
 func (t *TextPrinter) PrintLoadBalancers(loadBalancers ...*models.LoadBalancer) error {
 	getEnvironment := func(l *models.LoadBalancer) string {
 		if l.EnvironmentName != "" {
@@ -164,8 +482,22 @@ func (t *TextPrinter) PrintLoadBalancers(loadBalancers ...*models.LoadBalancer)
 		return fmt.Sprintf("%d:%d/%s", p.HostPort, p.ContainerPort, strings.ToUpper(p.Protocol))
 	}
 
-	rows := []string{"LOADBALANCER ID | LOADBALANCER NAME | ENVIRONMENT | SERVICE | PORTS | PUBLIC | URL | IDLE TIMEOUT "}
+	// only show tags if a load balancer has any
+	var showTags bool
 	for _, l := range loadBalancers {
+		if len(l.Tags) > 0 {
+			showTags = true
+		}
+	}
+
+	header := "LOADBALANCER ID | LOADBALANCER NAME | ENVIRONMENT | SERVICE | PORTS | PUBLIC | URL | IDLE TIMEOUT "
+	if showTags {
+		header += "| TAGS"
+	}
+
+	rows := []string{header}
+	for _, l := range loadBalancers {
+		tags := formatTags(l.Tags)
 		row := fmt.Sprintf("%s | %s | %s | %s | %s | %t | %s | %d",
 			l.LoadBalancerID,
 			l.LoadBalancerName,
@@ -176,11 +508,19 @@ func (t *TextPrinter) PrintLoadBalancers(loadBalancers ...*models.LoadBalancer)
 			l.URL,
 			l.IdleTimeout)
 
+		if showTags {
+			row += " | " + getItem(tags, 0)
+		}
+
 		rows = append(rows, row)
 
-		// add the extra port rows
-		for i := 1; i < len(l.Ports); i++ {
+		// add the extra port and tag rows
+		for i := 1; i < len(l.Ports) || i < len(tags); i++ {
 			row := fmt.Sprintf(" | | | | %s | |", getPort(l, i))
+			if showTags {
+				row += fmt.Sprintf(" | | %s", getItem(tags, i))
+			}
+
 			rows = append(rows, row)
 		}
 	}
@@ -282,16 +622,91 @@ func (t *TextPrinter) PrintLoadBalancerCrossZone(loadBalancer *models.LoadBalanc
 	return nil
 }
 
+func (t *TextPrinter) PrintLoadBalancerAccessLog(loadBalancer *models.LoadBalancer) error {
+	getEnvironment := func(l *models.LoadBalancer) string {
+		if l.EnvironmentName != "" {
+			return l.EnvironmentName
+		}
+
+		return l.EnvironmentID
+	}
+
+	getBucket := func(l *models.LoadBalancer) string {
+		if l.AccessLog.BucketPrefix != "" {
+			return fmt.Sprintf("%s/%s", l.AccessLog.BucketName, l.AccessLog.BucketPrefix)
+		}
+
+		return l.AccessLog.BucketName
+	}
+
+	getInterval := func(l *models.LoadBalancer) string {
+		if l.AccessLog.EmitInterval == 0 {
+			return ""
+		}
+
+		return fmt.Sprintf("%dm", l.AccessLog.EmitInterval)
+	}
+
+	rows := []string{"LOADBALANCER ID | LOADBALANCER NAME | ENVIRONMENT | ACCESS LOGS | BUCKET | INTERVAL "}
+	row := fmt.Sprintf("%s | %s | %s | %t | %s | %s",
+		loadBalancer.LoadBalancerID,
+		loadBalancer.LoadBalancerName,
+		getEnvironment(loadBalancer),
+		loadBalancer.AccessLog.Enabled,
+		getBucket(loadBalancer),
+		getInterval(loadBalancer))
+
+	rows = append(rows, row)
+
+	fmt.Println(columnize.SimpleFormat(rows))
+	return nil
+}
+
+func (t *TextPrinter) PrintLoadBalancerMetrics(metrics *models.LoadBalancerMetrics) error {
+	rows := []string{"TIME | REQUESTS | LATENCY | ELB 5XX | BACKEND 5XX "}
+	for _, d := range metrics.Datapoints {
+		row := fmt.Sprintf("%s | %d | %.3fs | %d | %d",
+			d.Timestamp.Format(TIME_FORMAT),
+			d.RequestCount,
+			d.Latency,
+			d.HTTPCodeELB5XX,
+			d.HTTPCodeBackend5XX)
+
+		rows = append(rows, row)
+	}
+
+	row := fmt.Sprintf("TOTAL | %d | %.3fs | %d | %d",
+		metrics.RequestCount,
+		metrics.Latency,
+		metrics.HTTPCodeELB5XX,
+		metrics.HTTPCodeBackend5XX)
+
+	rows = append(rows, row)
+
+	fmt.Println(columnize.SimpleFormat(rows))
+	return nil
+}
+
 func (t *TextPrinter) PrintLogs(logs ...*models.LogFile) error {
 	for _, l := range logs {
-		fmt.Println(l.Name)
-		for i := 0; i < len(l.Name); i++ {
+		title := l.Name
+		if l.TaskID != "" {
+			title = fmt.Sprintf("%s (%s)", l.Name, l.TaskID)
+		}
+
+		fmt.Println(title)
+		for i := 0; i < len(title); i++ {
 			fmt.Printf("-")
 		}
 
 		fmt.Println()
-		for _, line := range l.Lines {
-			fmt.Println(line)
+		for _, event := range logFileEvents(l) {
+			if event.Timestamp.IsZero() {
+				fmt.Println(event.Message)
+				continue
+			}
+
+			fmt.Printf("%s %s\n", event.Timestamp.Format(TIME_FORMAT), event.Message)
 		}
 		fmt.Println()
 	}
@@ -299,6 +714,14 @@ func (t *TextPrinter) PrintLogs(logs ...*models.LogFile) error {
 	return nil
 }
 
+func (t *TextPrinter) PrintLogEvents(events ...*models.LogEvent) error {
+	for _, e := range events {
+		fmt.Printf("%s %s | %s\n", e.Timestamp.Format(TIME_FORMAT), e.ContainerName, e.Message)
+	}
+
+	return nil
+}
+
 func (t *TextPrinter) PrintScalerRunInfo(runInfo *models.ScalerRunInfo) error {
 	rows := []string{
 		"ENVIRONMENT | CURRENT SCALE | DESIRED SCALE",
@@ -353,8 +776,22 @@ func (t *TextPrinter) PrintServices(services ...*models.Service) error {
 		return scale
 	}
 
-	rows := []string{"SERVICE ID | SERVICE NAME | ENVIRONMENT | LOADBALANCER | DEPLOYMENTS | SCALE "}
+	// only show tags if a service has any
+	var showTags bool
 	for _, s := range services {
+		if len(s.Tags) > 0 {
+			showTags = true
+		}
+	}
+
+	header := "SERVICE ID | SERVICE NAME | ENVIRONMENT | LOADBALANCER | DEPLOYMENTS | SCALE "
+	if showTags {
+		header += "| TAGS"
+	}
+
+	rows := []string{header}
+	for _, s := range services {
+		tags := formatTags(s.Tags)
 		row := fmt.Sprintf("%s | %s | %s | %s | %s | %s",
 			s.ServiceID,
 			s.ServiceName,
@@ -363,11 +800,19 @@ func (t *TextPrinter) PrintServices(services ...*models.Service) error {
 			getDeployment(s, 0),
 			getScale(s))
 
+		if showTags {
+			row += " | " + getItem(tags, 0)
+		}
+
 		rows = append(rows, row)
 
-		// add the extra deployment rows
-		for i := 1; i < len(s.Deployments); i++ {
+		// add the extra deployment and tag rows
+		for i := 1; i < len(s.Deployments) || i < len(tags); i++ {
 			row := fmt.Sprintf(" | | | | %s | ", getDeployment(s, i))
+			if showTags {
+				row += " | " + getItem(tags, i)
+			}
+
 			rows = append(rows, row)
 		}
 	}
@@ -399,6 +844,22 @@ func (t *TextPrinter) PrintServiceSummaries(services ...*models.ServiceSummary)
 	return nil
 }
 
+func (t *TextPrinter) PrintTags(tags ...*models.Tag) error {
+	rows := []string{"ENTITY TYPE | ENTITY ID | KEY | VALUE"}
+	for _, tag := range tags {
+		row := fmt.Sprintf("%s | %s | %s | %s",
+			tag.EntityType,
+			tag.EntityID,
+			tag.Key,
+			tag.Value)
+
+		rows = append(rows, row)
+	}
+
+	fmt.Println(columnize.SimpleFormat(rows))
+	return nil
+}
+
 func (t *TextPrinter) PrintTasks(tasks ...*models.Task) error {
 	getEnvironment := func(t *models.Task) string {
 		if t.EnvironmentName != "" {
@@ -425,8 +886,22 @@ func (t *TextPrinter) PrintTasks(tasks ...*models.Task) error {
 		return scale
 	}
 
-	rows := []string{"TASK ID | TASK NAME | ENVIRONMENT | DEPLOY | COUNT "}
+	// only show tags if a task has any
+	var showTags bool
 	for _, t := range tasks {
+		if len(t.Tags) > 0 {
+			showTags = true
+		}
+	}
+
+	header := "TASK ID | TASK NAME | ENVIRONMENT | DEPLOY | COUNT "
+	if showTags {
+		header += "| TAGS"
+	}
+
+	rows := []string{header}
+	for _, t := range tasks {
+		tags := formatTags(t.Tags)
 		row := fmt.Sprintf("%s | %s | %s | %s | %s",
 			t.TaskID,
 			t.TaskName,
@@ -434,7 +909,16 @@ func (t *TextPrinter) PrintTasks(tasks ...*models.Task) error {
 			getDeploy(t),
 			getScale(t))
 
+		if showTags {
+			row += " | " + getItem(tags, 0)
+		}
+
 		rows = append(rows, row)
+
+		// add the extra tag rows
+		for i := 1; i < len(tags); i++ {
+			rows = append(rows, " | | | | | "+tags[i])
+		}
 	}
 
 	fmt.Println(columnize.SimpleFormat(rows))
@@ -9,6 +9,22 @@ import (
 
 // testing stdout: https://blog.golang.org/examples
 
+func ExampleTextPrintCertificates() {
+	printer := &TextPrinter{}
+	certificates := []*models.Certificate{
+		{CertificateID: "id1", CertificateName: "name1", Source: "iam", Expiration: time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{CertificateID: "id2", DomainName: "example.com", Source: "acm", Status: "ISSUED", Expiration: time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{CertificateID: "id3", DomainName: "pending.example.com", Source: "acm", Status: "PENDING_VALIDATION"},
+	}
+
+	printer.PrintCertificates(certificates...)
+	// Output:
+	// CERTIFICATE ID  NAME                 SOURCE  STATUS              EXPIRES
+	// id1             name1                IAM                         2018-01-02 03:04:05
+	// id2             example.com          ACM     ISSUED              2019-01-02 03:04:05
+	// id3             pending.example.com  ACM     PENDING_VALIDATION
+}
+
 func ExampleTextPrintDeploys() {
 	printer := &TextPrinter{}
 	deploys := []*models.Deploy{
@@ -56,14 +72,117 @@ func ExampleTextPrintEnvironments() {
 			InstanceSize:    "m3.xlarge",
 			Links:           []string{"id1", "api"},
 		},
+		{
+			EnvironmentID:   "id3",
+			EnvironmentName: "name3",
+			OperatingSystem: "linux",
+			ClusterCount:    3,
+			InstanceSize:    "m5.large",
+			SpotPrice:       "0.05",
+			MixedInstancesPolicy: &models.MixedInstancesPolicy{
+				InstanceTypes: []string{"m5.large", "m4.large"},
+			},
+		},
 	}
 
 	printer.PrintEnvironments(environments...)
 	// Output:
-	// ENVIRONMENT ID  ENVIRONMENT NAME  OS       CLUSTER COUNT  INSTANCE SIZE  LINKS
-	// id1             name1             linux    1              m3.medium      id2
-	// id2             name2             windows  2              m3.xlarge      id1
-	//                                                                          api
+	// ENVIRONMENT ID  ENVIRONMENT NAME  OS       CLUSTER COUNT  INSTANCE SIZE                  LINKS
+	// id1             name1             linux    1              m3.medium                      id2
+	// id2             name2             windows  2              m3.xlarge                      id1
+	//                                                                                          api
+	// id3             name3             linux    3              m5.large,m4.large (spot 0.05)
+}
+
+func ExampleTextPrintEnvironments_linkDetails() {
+	printer := &TextPrinter{}
+	environments := []*models.Environment{
+		{
+			EnvironmentID:   "app",
+			EnvironmentName: "app",
+			OperatingSystem: "linux",
+			ClusterCount:    1,
+			InstanceSize:    "m5.large",
+			Links:           []string{"db", "api", "cache"},
+			LinkDetails: []models.EnvironmentLink{
+				{
+					SourceEnvironmentID: "app",
+					DestEnvironmentID:   "db",
+					Ports:               []models.EnvironmentLinkPort{{Protocol: "tcp", FromPort: 5432, ToPort: 5432}},
+					OneWay:              true,
+				},
+				{SourceEnvironmentID: "api", DestEnvironmentID: "app", OneWay: true},
+				{
+					SourceEnvironmentID: "app",
+					DestEnvironmentID:   "cache",
+					Ports: []models.EnvironmentLinkPort{
+						{Protocol: "tcp", FromPort: 6379, ToPort: 6379},
+						{Protocol: "udp", FromPort: 8000, ToPort: 8100},
+					},
+				},
+			},
+		},
+	}
+
+	printer.PrintEnvironments(environments...)
+	// Output:
+	// ENVIRONMENT ID  ENVIRONMENT NAME  OS     CLUSTER COUNT  INSTANCE SIZE  LINKS
+	// app             app               linux  1              m5.large       -> db (5432/tcp)
+	//                                                                        <- api
+	//                                                                        cache (6379/tcp, 8000-8100/udp)
+}
+
+func ExampleTextPrintEnvironments_ingressRules() {
+	printer := &TextPrinter{}
+	environments := []*models.Environment{
+		{
+			EnvironmentID:   "id1",
+			EnvironmentName: "name1",
+			OperatingSystem: "linux",
+			ClusterCount:    1,
+			InstanceSize:    "m5.large",
+			Links:           []string{"id2"},
+			IngressRules: []models.EnvironmentIngressRule{
+				{CIDR: "10.1.0.0/16", Protocol: "tcp", FromPort: 22, ToPort: 22},
+				{SecurityGroupID: "sg-123", Protocol: "udp", FromPort: 8000, ToPort: 8100},
+			},
+		},
+	}
+
+	printer.PrintEnvironments(environments...)
+	// Output:
+	// ENVIRONMENT ID  ENVIRONMENT NAME  OS     CLUSTER COUNT  INSTANCE SIZE  LINKS  INGRESS
+	// id1             name1             linux  1              m5.large       id2    10.1.0.0/16 (22/tcp)
+	//                                                                               sg-123 (8000-8100/udp)
+}
+
+func ExampleTextPrintEnvironments_tags() {
+	printer := &TextPrinter{}
+	environments := []*models.Environment{
+		{
+			EnvironmentID:   "id1",
+			EnvironmentName: "name1",
+			OperatingSystem: "linux",
+			ClusterCount:    1,
+			InstanceSize:    "m5.large",
+			Links:           []string{"id2"},
+			Tags:            map[string]string{"team": "payments", "cost_center": "123"},
+		},
+		{
+			EnvironmentID:   "id2",
+			EnvironmentName: "name2",
+			OperatingSystem: "linux",
+			ClusterCount:    2,
+			InstanceSize:    "m5.large",
+		},
+	}
+
+	printer.PrintEnvironments(environments...)
+	// Output:
+	// ENVIRONMENT ID  ENVIRONMENT NAME  OS     CLUSTER COUNT  INSTANCE SIZE  LINKS  TAGS
+	// id1             name1             linux  1              m5.large       id2    cost_center=123
+	//                                                                               team=payments
+	// id2             name2             linux  2              m5.large
 }
 
 func ExampleTextPrintEnvironmentSummaries() {
@@ -123,6 +242,60 @@ func ExampleTextPrintJobs() {
 
 }
 
+func ExampleTextPrintJobs_steps() {
+	printer := &TextPrinter{}
+	started := time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC)
+	jobs := []*models.Job{
+		{
+			JobID:       "id1",
+			TaskID:      "t1",
+			JobType:     int64(types.DeleteEnvironmentJob),
+			JobStatus:   int64(types.Error),
+			TimeCreated: time.Time{},
+			CurrentStep: "Delete Environment",
+			Steps: []models.JobStep{
+				{
+					Name:         "Delete Services",
+					TimeStarted:  started,
+					TimeFinished: started.Add(time.Second * 65),
+				},
+				{
+					Name:         "Delete Environment",
+					TimeStarted:  started.Add(time.Second * 65),
+					TimeFinished: started.Add(time.Second * 70),
+					Error:        "some error",
+					Warnings:     []string{"throttled", "some error"},
+				},
+				{
+					Name: "Delete Tags",
+				},
+			},
+		},
+		{
+			JobID:       "id2",
+			TaskID:      "t2",
+			JobType:     int64(types.CreateTaskJob),
+			JobStatus:   int64(types.InProgress),
+			TimeCreated: time.Time{},
+			CurrentStep: "Create Task",
+			Steps: []models.JobStep{
+				{
+					Name:        "Create Task",
+					TimeStarted: started,
+				},
+			},
+		},
+	}
+
+	printer.PrintJobs(jobs...)
+	// Output:
+	// JOB ID  TASK ID  TYPE                STATUS       CREATED              STEPS
+	// id1     t1       Delete Environment  Error        0001-01-01 00:00:00  Delete Services: completed in 1m5s
+	//                                                                        Delete Environment: failed after 5s: some error, 2 warning(s) (last: some error)
+	//                                                                        Delete Tags: pending
+	// id2     t2       Create Task         In Progress  0001-01-01 00:00:00  Create Task: in progress since 2018-01-02 03:04:05
+}
+
 func ExampleTextPrintLoadBalancers() {
 	printer := &TextPrinter{}
 	loadBalancers := []*models.LoadBalancer{
@@ -310,20 +483,63 @@ func ExampleTextPrintLoadBalancerCrossZone() {
 	// id2              lb2                eid1         false
 }
 
+func ExampleTextPrintLoadBalancerAccessLog() {
+	printer := &TextPrinter{}
+	loadBalancer1 := &models.LoadBalancer{
+		LoadBalancerID:   "id1",
+		LoadBalancerName: "lb1",
+		EnvironmentID:    "eid1",
+		EnvironmentName:  "ename1",
+		AccessLog: models.AccessLog{
+			Enabled:      true,
+			BucketName:   "bucket",
+			BucketPrefix: "prefix",
+			EmitInterval: 5,
+		},
+	}
+
+	loadBalancer2 := &models.LoadBalancer{
+		LoadBalancerID:   "id2",
+		LoadBalancerName: "lb2",
+		EnvironmentID:    "eid1",
+	}
+
+	printer.PrintLoadBalancerAccessLog(loadBalancer1)
+	printer.PrintLoadBalancerAccessLog(loadBalancer2)
+	// Output:
+	// LOADBALANCER ID  LOADBALANCER NAME  ENVIRONMENT  ACCESS LOGS  BUCKET         INTERVAL
+	// id1              lb1                ename1       true         bucket/prefix  5m
+	// LOADBALANCER ID  LOADBALANCER NAME  ENVIRONMENT  ACCESS LOGS  BUCKET  INTERVAL
+	// id2              lb2                eid1         false
+}
+
 func ExampleTextPrintLogs() {
 	printer := &TextPrinter{}
+	timestamp := time.Date(2001, 1, 1, 1, 1, 1, 0, time.UTC)
 	logs := []*models.LogFile{
-		{Name: "file1", Lines: []string{"line1", "line2", "line3"}},
-		{Name: "file2", Lines: []string{"lineA", "lineB", "lineC"}},
+		{
+			Name:   "file1",
+			TaskID: "task1",
+			Events: []*models.LogEvent{
+				{Message: "line1", Timestamp: timestamp},
+				{Message: "line2", Timestamp: timestamp},
+				{Message: "line3", Timestamp: timestamp},
+			},
+		},
+		{
+			// returned by an api without log events
+			Name:  "file2",
+			Lines: []string{"lineA", "lineB", "lineC"},
+		},
 	}
 
 	printer.PrintLogs(logs...)
 	// Output:
-	//file1
-	//-----
-	//line1
-	//line2
-	//line3
+	//file1 (task1)
+	//-------------
+	//2001-01-01 01:01:01 line1
+	//2001-01-01 01:01:01 line2
+	//2001-01-01 01:01:01 line3
 	//
 	//file2
 	//-----
@@ -332,6 +548,19 @@ func ExampleTextPrintLogs() {
 	//lineC
 }
 
+func ExampleTextPrintLogEvents() {
+	printer := &TextPrinter{}
+	events := []*models.LogEvent{
+		{ContainerName: "api", Message: "line1", Timestamp: time.Date(2001, 1, 1, 1, 1, 1, 0, time.UTC)},
+		{ContainerName: "worker", Message: "lineA", Timestamp: time.Date(2001, 1, 1, 1, 1, 2, 0, time.UTC)},
+	}
+
+	printer.PrintLogEvents(events...)
+	// Output:
+	// 2001-01-01 01:01:01 api | line1
+	// 2001-01-01 01:01:02 worker | lineA
+}
+
 func ExampleTextPrintScalerRunInfo() {
 	printer := &TextPrinter{}
 	runInfo := &models.ScalerRunInfo{
@@ -478,6 +707,40 @@ func ExampleTextPrintTasks() {
 	// id4      tsk4       eid4         d4:1    1/1
 }
 
+func ExampleTextPrintTasks_tags() {
+	printer := &TextPrinter{}
+	tasks := []*models.Task{
+		{
+			TaskID:          "id1",
+			TaskName:        "tsk1",
+			EnvironmentName: "ename1",
+			RunningCount:    1,
+			DeployID:        "d1.1",
+			Tags:            map[string]string{"team": "payments", "owner": "alice"},
+		},
+	}
+
+	printer.PrintTasks(tasks...)
+	// Output:
+	// TASK ID  TASK NAME  ENVIRONMENT  DEPLOY  COUNT  TAGS
+	// id1      tsk1       ename1       d1:1    1/1    owner=alice
+	//                                                 team=payments
+}
+
+func ExampleTextPrintTags() {
+	printer := &TextPrinter{}
+	tags := []*models.Tag{
+		{EntityType: "service", EntityID: "id1", Key: "owner", Value: "alice"},
+		{EntityType: "service", EntityID: "id1", Key: "team", Value: "payments"},
+	}
+
+	printer.PrintTags(tags...)
+	// Output:
+	// ENTITY TYPE  ENTITY ID  KEY    VALUE
+	// service      id1        owner  alice
+	// service      id1        team   payments
+}
+
 func ExampleTextPrintTaskSummaries() {
 	printer := &TextPrinter{}
 	tasks := []*models.TaskSummary{
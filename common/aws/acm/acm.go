@@ -0,0 +1,88 @@
+package acm
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/quintilesims/layer0/common/aws/provider"
+)
+
+type Provider interface {
+	ListCertificates() ([]*CertificateSummary, error)
+}
+
+type ACM struct {
+	credProvider provider.CredProvider
+	region       string
+	Connect      func() (ACMInternal, error)
+}
+
+type ACMInternal interface {
+	ListCertificates(input *acm.ListCertificatesInput) (*acm.ListCertificatesOutput, error)
+}
+
+type CertificateSummary struct {
+	*acm.CertificateSummary
+}
+
+func NewCertificateSummary(arn, domainName string) *CertificateSummary {
+	return &CertificateSummary{
+		&acm.CertificateSummary{
+			CertificateArn: aws.String(arn),
+			DomainName:     aws.String(domainName),
+		},
+	}
+}
+
+func NewACM(credProvider provider.CredProvider, region string) (Provider, error) {
+	acm := ACM{
+		credProvider,
+		region,
+		func() (ACMInternal, error) {
+			return Connect(credProvider, region)
+		},
+	}
+
+	_, err := acm.Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	return &acm, nil
+}
+
+func Connect(credProvider provider.CredProvider, region string) (ACMInternal, error) {
+	connection, err := provider.GetACMConnection(credProvider, region)
+	if err != nil {
+		return nil, err
+	}
+
+	return connection, nil
+}
+
+func (this *ACM) ListCertificates() ([]*CertificateSummary, error) {
+	connection, err := this.Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	certificates := []*CertificateSummary{}
+	input := &acm.ListCertificatesInput{}
+	for {
+		output, err := connection.ListCertificates(input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, summary := range output.CertificateSummaryList {
+			certificates = append(certificates, &CertificateSummary{summary})
+		}
+
+		if output.NextToken == nil {
+			break
+		}
+
+		input.NextToken = output.NextToken
+	}
+
+	return certificates, nil
+}
@@ -10,8 +10,9 @@ import (
 
 type Provider interface {
 	AttachLoadBalancer(autoScalingGroupName, loadBalancerName string) error
-	CreateLaunchConfiguration(name, amiID, iamInstanceProfile, instanceType, keyName, userData *string, securityGroups []*string, volSize map[string]int) error
+	CreateLaunchConfiguration(name, amiID, iamInstanceProfile, instanceType, keyName, userData, spotPrice *string, securityGroups []*string, volSize map[string]int) error
 	CreateAutoScalingGroup(name, launchConfigName, subnets string, minCount, maxCount int) error
+	CreateMixedInstancesAutoScalingGroup(name, launchTemplateName, subnets string, minCount, maxCount int, instanceTypes []string, onDemandBaseCapacity, onDemandPercentageAboveBase int, spotMaxPrice string) error
 	SetDesiredCapacity(name string, size int) error
 	UpdateAutoScalingGroupMaxSize(name string, size int) error
 	UpdateAutoScalingGroupMinSize(name string, size int) error
@@ -115,6 +116,7 @@ func (this *AutoScaling) CreateLaunchConfiguration(
 	instanceType *string,
 	keyName *string,
 	userData *string,
+	spotPrice *string,
 	securityGroups []*string,
 	volSizes map[string]int,
 ) error {
@@ -122,6 +124,10 @@ func (this *AutoScaling) CreateLaunchConfiguration(
 		keyName = nil
 	}
 
+	if spotPrice != nil && *spotPrice == "" {
+		spotPrice = nil
+	}
+
 	blocks := []*autoscaling.BlockDeviceMapping{}
 	for vol, size := range volSizes {
 		block := &autoscaling.BlockDeviceMapping{
@@ -146,6 +152,7 @@ func (this *AutoScaling) CreateLaunchConfiguration(
 		LaunchConfigurationName: name,
 		SecurityGroups:          securityGroups,
 		BlockDeviceMappings:     blocks,
+		SpotPrice:               spotPrice,
 	}
 
 	connection, err := this.Connect()
@@ -181,6 +188,68 @@ func (this *AutoScaling) CreateAutoScalingGroup(name, launchConfigName, subnets
 	return err
 }
 
+func (this *AutoScaling) CreateMixedInstancesAutoScalingGroup(
+	name string,
+	launchTemplateName string,
+	subnets string,
+	minSize int,
+	maxSize int,
+	instanceTypes []string,
+	onDemandBaseCapacity int,
+	onDemandPercentageAboveBase int,
+	spotMaxPrice string,
+) error {
+	overrides := make([]*autoscaling.LaunchTemplateOverrides, len(instanceTypes))
+	for i, instanceType := range instanceTypes {
+		overrides[i] = &autoscaling.LaunchTemplateOverrides{
+			InstanceType: aws.String(instanceType),
+		}
+	}
+
+	distribution := &autoscaling.InstancesDistribution{
+		OnDemandBaseCapacity:                aws.Int64(int64(onDemandBaseCapacity)),
+		OnDemandPercentageAboveBaseCapacity: aws.Int64(int64(onDemandPercentageAboveBase)),
+		SpotAllocationStrategy:              aws.String("lowest-price"),
+	}
+
+	// an empty max price defaults to the on-demand price
+	if spotMaxPrice != "" {
+		distribution.SpotMaxPrice = aws.String(spotMaxPrice)
+	}
+
+	input := &autoscaling.CreateAutoScalingGroupInput{
+		AutoScalingGroupName: aws.String(name),
+		DesiredCapacity:      aws.Int64(int64(maxSize)),
+		MinSize:              aws.Int64(int64(minSize)),
+		MaxSize:              aws.Int64(int64(maxSize)),
+		MixedInstancesPolicy: &autoscaling.MixedInstancesPolicy{
+			InstancesDistribution: distribution,
+			LaunchTemplate: &autoscaling.LaunchTemplate{
+				LaunchTemplateSpecification: &autoscaling.LaunchTemplateSpecification{
+					LaunchTemplateName: aws.String(launchTemplateName),
+					Version:            aws.String("$Default"),
+				},
+				Overrides: overrides,
+			},
+		},
+		VPCZoneIdentifier: aws.String(subnets),
+		Tags: []*autoscaling.Tag{
+			{
+				Key:               aws.String("Name"),
+				Value:             aws.String(name),
+				PropagateAtLaunch: aws.Bool(true),
+			},
+		},
+	}
+
+	connection, err := this.Connect()
+	if err != nil {
+		return err
+	}
+	_, err = connection.CreateAutoScalingGroup(input)
+	return err
+}
+
 func (this *AutoScaling) SetDesiredCapacity(name string, size int) error {
 	size64 := int64(size)
 	input := &autoscaling.SetDesiredCapacityInput{
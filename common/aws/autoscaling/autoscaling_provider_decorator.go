@@ -17,10 +17,10 @@ func (this *ProviderDecorator) AttachLoadBalancer(p0 string, p1 string) (err err
 	err = this.Decorator("AttachLoadBalancer", call)
 	return err
 }
-func (this *ProviderDecorator) CreateLaunchConfiguration(p0 *string, p1 *string, p2 *string, p3 *string, p4 *string, p5 *string, p6 []*string, p7 map[string]int) (err error) {
+func (this *ProviderDecorator) CreateLaunchConfiguration(p0 *string, p1 *string, p2 *string, p3 *string, p4 *string, p5 *string, p6 *string, p7 []*string, p8 map[string]int) (err error) {
 	call := func() error {
 		var err error
-		err = this.Inner.CreateLaunchConfiguration(p0, p1, p2, p3, p4, p5, p6, p7)
+		err = this.Inner.CreateLaunchConfiguration(p0, p1, p2, p3, p4, p5, p6, p7, p8)
 		return err
 	}
 	err = this.Decorator("CreateLaunchConfiguration", call)
@@ -35,6 +35,15 @@ func (this *ProviderDecorator) CreateAutoScalingGroup(p0 string, p1 string, p2 s
 	err = this.Decorator("CreateAutoScalingGroup", call)
 	return err
 }
+func (this *ProviderDecorator) CreateMixedInstancesAutoScalingGroup(p0 string, p1 string, p2 string, p3 int, p4 int, p5 []string, p6 int, p7 int, p8 string) (err error) {
+	call := func() error {
+		var err error
+		err = this.Inner.CreateMixedInstancesAutoScalingGroup(p0, p1, p2, p3, p4, p5, p6, p7, p8)
+		return err
+	}
+	err = this.Decorator("CreateMixedInstancesAutoScalingGroup", call)
+	return err
+}
 func (this *ProviderDecorator) SetDesiredCapacity(p0 string, p1 int) (err error) {
 	call := func() error {
 		var err error
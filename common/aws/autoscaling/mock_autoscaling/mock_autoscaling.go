@@ -58,15 +58,27 @@ func (mr *MockProviderMockRecorder) CreateAutoScalingGroup(arg0, arg1, arg2, arg
 }
 
 // CreateLaunchConfiguration mocks base method
-func (m *MockProvider) CreateLaunchConfiguration(arg0, arg1, arg2, arg3, arg4, arg5 *string, arg6 []*string, arg7 map[string]int) error {
-	ret := m.ctrl.Call(m, "CreateLaunchConfiguration", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+func (m *MockProvider) CreateLaunchConfiguration(arg0, arg1, arg2, arg3, arg4, arg5, arg6 *string, arg7 []*string, arg8 map[string]int) error {
+	ret := m.ctrl.Call(m, "CreateLaunchConfiguration", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateLaunchConfiguration indicates an expected call of CreateLaunchConfiguration
-func (mr *MockProviderMockRecorder) CreateLaunchConfiguration(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLaunchConfiguration", reflect.TypeOf((*MockProvider)(nil).CreateLaunchConfiguration), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+func (mr *MockProviderMockRecorder) CreateLaunchConfiguration(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLaunchConfiguration", reflect.TypeOf((*MockProvider)(nil).CreateLaunchConfiguration), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+}
+
+// CreateMixedInstancesAutoScalingGroup mocks base method
+func (m *MockProvider) CreateMixedInstancesAutoScalingGroup(arg0, arg1, arg2 string, arg3, arg4 int, arg5 []string, arg6, arg7 int, arg8 string) error {
+	ret := m.ctrl.Call(m, "CreateMixedInstancesAutoScalingGroup", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMixedInstancesAutoScalingGroup indicates an expected call of CreateMixedInstancesAutoScalingGroup
+func (mr *MockProviderMockRecorder) CreateMixedInstancesAutoScalingGroup(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMixedInstancesAutoScalingGroup", reflect.TypeOf((*MockProvider)(nil).CreateMixedInstancesAutoScalingGroup), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
 }
 
 // DeleteAutoScalingGroup mocks base method
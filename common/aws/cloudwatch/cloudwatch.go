@@ -48,15 +48,13 @@ func Connect(credProvider provider.CredProvider, region string) (CloudWatchInter
 }
 
 func (this *CloudWatch) GetMetricStatistics(namespace, metricName string, period int64, statistics []string, dimensions []*cloudwatch.Dimension, startTime, endTime time.Time) ([]cloudwatch.Datapoint, error) {
-	//panic("This is broken until provider.ToStrPtrList is reimplemented")
 	input := &cloudwatch.GetMetricStatisticsInput{
 		Namespace:  aws.String(namespace),
 		MetricName: aws.String(metricName),
 		Period:     aws.Int64(period),
-		StartTime:  aws.Time(endTime),
-		EndTime:    aws.Time(startTime),
-		Statistics: []*string{},
-		//Statistics: provider.ToStrPtrList(statistics),
+		StartTime:  aws.Time(startTime),
+		EndTime:    aws.Time(endTime),
+		Statistics: aws.StringSlice(statistics),
 		Dimensions: dimensions,
 	}
 
@@ -15,6 +15,10 @@ const (
 	MAX_DESCRIBE_STREAMS_COUNT = 1000
 	// 'YYYY-MM-DD HH:MM' time layout as described by https://golang.org/src/time/format.go
 	TIME_LAYOUT = "2006-01-02 15:04"
+	// FilterLogEvents can page through an entire log group, so results are capped
+	MAX_FILTER_EVENTS_COUNT = 10000
+	// FilterLogEvents accepts at most 100 stream names per request
+	MAX_FILTER_STREAM_NAMES = 100
 )
 
 type Provider interface {
@@ -23,7 +27,8 @@ type Provider interface {
 	DescribeLogGroups(logGroupNamePrefix string, nextToken *string) ([]*LogGroup, error)
 	DescribeLogStreams(logGroupName, orderBy string) ([]*LogStream, error)
 	GetLogEvents(logGroupName, logStreamName, start, stop string, limit int64) ([]*OutputLogEvent, error)
-	FilterLogEvents(filterPattern, logGroupName, nextToken *string, logStreamNames []*string, endTime, startTime *int64, interleaved *bool) ([]*FilteredLogEvent, []*SearchedLogStream, error)
+	GetNextLogEvents(logGroupName, logStreamName, start string, nextToken *string) ([]*OutputLogEvent, *string, error)
+	FilterLogEvents(logGroupName, filterPattern, start, end string, logStreamNames []string) ([]*FilteredLogEvent, error)
 }
 
 type CloudWatchLogs struct {
@@ -37,6 +42,7 @@ type CloudWatchLogsInternal interface {
 	DeleteLogGroup(input *cloudwatchlogs.DeleteLogGroupInput) (*cloudwatchlogs.DeleteLogGroupOutput, error)
 	DescribeLogGroups(input *cloudwatchlogs.DescribeLogGroupsInput) (*cloudwatchlogs.DescribeLogGroupsOutput, error)
 	DescribeLogStreamsPages(input *cloudwatchlogs.DescribeLogStreamsInput, fn func(p *cloudwatchlogs.DescribeLogStreamsOutput, lastPage bool) (shouldContinue bool)) error
+	GetLogEvents(input *cloudwatchlogs.GetLogEventsInput) (*cloudwatchlogs.GetLogEventsOutput, error)
 	GetLogEventsPages(input *cloudwatchlogs.GetLogEventsInput, fn func(p *cloudwatchlogs.GetLogEventsOutput, lastPage bool) (shouldContinue bool)) error
 	FilterLogEvents(input *cloudwatchlogs.FilterLogEventsInput) (*cloudwatchlogs.FilterLogEventsOutput, error)
 }
@@ -61,6 +67,15 @@ type FilteredLogEvent struct {
 	*cloudwatchlogs.FilteredLogEvent
 }
 
+func NewFilteredLogEvent(logStreamName, message string) *FilteredLogEvent {
+	return &FilteredLogEvent{
+		&cloudwatchlogs.FilteredLogEvent{
+			LogStreamName: aws.String(logStreamName),
+			Message:       aws.String(message),
+		},
+	}
+}
+
 type OutputLogEvent struct {
 	*cloudwatchlogs.OutputLogEvent
 }
@@ -73,10 +88,6 @@ func NewOutputLogEvent(message string) *OutputLogEvent {
 	}
 }
 
-type SearchedLogStream struct {
-	*cloudwatchlogs.SearchedLogStream
-}
-
 func NewCloudWatchLogs(credProvider provider.CredProvider, region string) (Provider, error) {
 	cloudwatchlogs := CloudWatchLogs{
 		credProvider,
@@ -202,13 +213,26 @@ func (this *CloudWatchLogs) DescribeLogStreams(logGroupName, orderBy string) ([]
 	return streams, nil
 }
 
-func timeToMilliseconds(v string) (int64, error) {
+// ParseTime parses v as either TIME_LAYOUT (in UTC) or RFC3339, truncated to the second
+func ParseTime(v string) (time.Time, error) {
 	t, err := time.Parse(TIME_LAYOUT, v)
 	if err != nil {
-		return 0, fmt.Errorf("Invalid time: must be in format YYYY-MM-DD HH:MM")
+		if t, err = time.Parse(time.RFC3339, v); err != nil {
+			return time.Time{}, fmt.Errorf("Invalid time: must be in format YYYY-MM-DD HH:MM or RFC3339")
+		}
+
+		t = t.UTC()
 	}
 
-	date := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC), nil
+}
+
+// timeToMilliseconds parses v with ParseTime
+func timeToMilliseconds(v string) (int64, error) {
+	date, err := ParseTime(v)
+	if err != nil {
+		return 0, err
+	}
 
 	// convert ns to ms
 	return date.UnixNano() / int64(time.Millisecond), nil
@@ -276,75 +300,111 @@ func (this *CloudWatchLogs) GetLogEvents(
 	return result, nil
 }
 
-func (this *CloudWatchLogs) FilterLogEvents(
-	filterPattern,
-	logGroupName,
+// GetNextLogEvents returns a single page of events from the specified stream, oldest first.
+// The returned token should be passed in as nextToken to get the events that come after them.
+// If nextToken is nil, events are read from start, or from the beginning of the stream if start is empty.
+func (this *CloudWatchLogs) GetNextLogEvents(
+	logGroupName string,
+	logStreamName string,
+	start string,
 	nextToken *string,
-	logStreamNames []*string,
-	endTime,
-	startTime *int64,
-	interleaved *bool) ([]*FilteredLogEvent, []*SearchedLogStream, error) {
-
-	if *nextToken == "" {
-		nextToken = nil
+) ([]*OutputLogEvent, *string, error) {
+	input := &cloudwatchlogs.GetLogEventsInput{
+		LogGroupName:  aws.String(logGroupName),
+		LogStreamName: aws.String(logStreamName),
+		NextToken:     nextToken,
+		StartFromHead: aws.Bool(true),
 	}
 
-	input := &cloudwatchlogs.FilterLogEventsInput{
-		// A point in time expressed as the number of milliseconds since Jan 1, 1970
-		// 00:00:00 UTC. If provided, events with a timestamp later than this time are
-		// not returned.
-		EndTime: endTime,
-
-		// A valid CloudWatch Logs filter pattern to use for filtering the response.
-		// If not provided, all the events are matched.
-		FilterPattern: filterPattern,
-
-		// If provided, the API will make a best effort to provide responses that contain
-		// events from multiple log streams within the log group interleaved in a single
-		// response. If not provided, all the matched log events in the first log stream
-		// will be searched first, then those in the next log stream, etc.
-		Interleaved: interleaved,
-
-		// The maximum number of events to return in a page of results. Default is 10,000
-		// events.
-		// Limit: limit,
-
-		// The name of the log group to query.
-		LogGroupName: logGroupName,
-
-		// Optional list of log stream names within the specified log group to search.
-		// Defaults to all the log streams in the log group.
-		LogStreamNames: logStreamNames,
-
-		// A pagination token obtained from a FilterLogEvents response to continue paginating
-		// the FilterLogEvents results. This token is omitted from the response when
-		// there are no other events to display.
-		NextToken: nextToken,
+	if nextToken == nil && start != "" {
+		startTime, err := timeToMilliseconds(start)
+		if err != nil {
+			return nil, nil, err
+		}
 
-		// A point in time expressed as the number of milliseconds since Jan 1, 1970
-		// 00:00:00 UTC. If provided, events with a timestamp prior to this time are
-		// not returned.
-		StartTime: startTime,
+		input.SetStartTime(startTime)
 	}
 
 	connection, err := this.Connect()
 	if err != nil {
 		return nil, nil, err
 	}
-	resp, err := connection.FilterLogEvents(input)
+
+	output, err := connection.GetLogEvents(input)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	resultFiltered := []*FilteredLogEvent{}
-	for _, svc := range resp.Events {
-		resultFiltered = append(resultFiltered, &FilteredLogEvent{svc})
+	result := []*OutputLogEvent{}
+	for _, event := range output.Events {
+		result = append(result, &OutputLogEvent{event})
 	}
 
-	resultSearched := []*SearchedLogStream{}
-	for _, svc := range resp.SearchedLogStreams {
-		resultSearched = append(resultSearched, &SearchedLogStream{svc})
+	return result, output.NextForwardToken, nil
+}
+
+// FilterLogEvents returns the events in the specified streams that match filterPattern,
+// oldest first. An empty filterPattern matches all events, and an empty start or end
+// leaves that side of the time range open. At most MAX_FILTER_EVENTS_COUNT events are returned.
+func (this *CloudWatchLogs) FilterLogEvents(
+	logGroupName string,
+	filterPattern string,
+	start string,
+	end string,
+	logStreamNames []string,
+) ([]*FilteredLogEvent, error) {
+	input := &cloudwatchlogs.FilterLogEventsInput{
+		LogGroupName: aws.String(logGroupName),
 	}
 
-	return resultFiltered, resultSearched, nil
+	if filterPattern != "" {
+		input.SetFilterPattern(filterPattern)
+	}
+
+	if len(logStreamNames) > 0 {
+		input.SetLogStreamNames(aws.StringSlice(logStreamNames))
+	}
+
+	if start != "" {
+		startTime, err := timeToMilliseconds(start)
+		if err != nil {
+			return nil, err
+		}
+
+		input.SetStartTime(startTime)
+	}
+
+	if end != "" {
+		endTime, err := timeToMilliseconds(end)
+		if err != nil {
+			return nil, err
+		}
+
+		input.SetEndTime(endTime)
+	}
+
+	connection, err := this.Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*FilteredLogEvent{}
+	for {
+		output, err := connection.FilterLogEvents(input)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range output.Events {
+			result = append(result, &FilteredLogEvent{event})
+		}
+
+		if output.NextToken == nil || len(result) >= MAX_FILTER_EVENTS_COUNT {
+			break
+		}
+
+		input.NextToken = output.NextToken
+	}
+
+	return result, nil
 }
@@ -53,13 +53,21 @@ func (this *ProviderDecorator) GetLogEvents(p0 string, p1 string, p2 string, p3
 	err = this.Decorator("GetLogEvents", call)
 	return v0, err
 }
-func (this *ProviderDecorator) FilterLogEvents(p0 *string, p1 *string, p2 *string, p3 []*string, p4 *int64, p5 *int64, p6 *bool) (v0 []*FilteredLogEvent, v1 []*SearchedLogStream, err error) {
+func (this *ProviderDecorator) GetNextLogEvents(p0 string, p1 string, p2 string, p3 *string) (v0 []*OutputLogEvent, v1 *string, err error) {
 	call := func() error {
 		var err error
-		v0, v1, err = this.Inner.FilterLogEvents(p0, p1, p2, p3, p4, p5, p6)
+		v0, v1, err = this.Inner.GetNextLogEvents(p0, p1, p2, p3)
 		return err
 	}
-	err = this.Decorator("FilterLogEvents", call)
+	err = this.Decorator("GetNextLogEvents", call)
 	return v0, v1, err
 }
-
+func (this *ProviderDecorator) FilterLogEvents(p0 string, p1 string, p2 string, p3 string, p4 []string) (v0 []*FilteredLogEvent, err error) {
+	call := func() error {
+		var err error
+		v0, err = this.Inner.FilterLogEvents(p0, p1, p2, p3, p4)
+		return err
+	}
+	err = this.Decorator("FilterLogEvents", call)
+	return v0, err
+}
@@ -84,17 +84,16 @@ func (mr *MockProviderMockRecorder) DescribeLogStreams(arg0, arg1 interface{}) *
 }
 
 // FilterLogEvents mocks base method
-func (m *MockProvider) FilterLogEvents(arg0, arg1, arg2 *string, arg3 []*string, arg4, arg5 *int64, arg6 *bool) ([]*cloudwatchlogs.FilteredLogEvent, []*cloudwatchlogs.SearchedLogStream, error) {
-	ret := m.ctrl.Call(m, "FilterLogEvents", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+func (m *MockProvider) FilterLogEvents(arg0, arg1, arg2, arg3 string, arg4 []string) ([]*cloudwatchlogs.FilteredLogEvent, error) {
+	ret := m.ctrl.Call(m, "FilterLogEvents", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].([]*cloudwatchlogs.FilteredLogEvent)
-	ret1, _ := ret[1].([]*cloudwatchlogs.SearchedLogStream)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // FilterLogEvents indicates an expected call of FilterLogEvents
-func (mr *MockProviderMockRecorder) FilterLogEvents(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilterLogEvents", reflect.TypeOf((*MockProvider)(nil).FilterLogEvents), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+func (mr *MockProviderMockRecorder) FilterLogEvents(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilterLogEvents", reflect.TypeOf((*MockProvider)(nil).FilterLogEvents), arg0, arg1, arg2, arg3, arg4)
 }
 
 // GetLogEvents mocks base method
@@ -109,3 +108,17 @@ func (m *MockProvider) GetLogEvents(arg0, arg1, arg2, arg3 string, arg4 int64) (
 func (mr *MockProviderMockRecorder) GetLogEvents(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogEvents", reflect.TypeOf((*MockProvider)(nil).GetLogEvents), arg0, arg1, arg2, arg3, arg4)
 }
+
+// GetNextLogEvents mocks base method
+func (m *MockProvider) GetNextLogEvents(arg0, arg1, arg2 string, arg3 *string) ([]*cloudwatchlogs.OutputLogEvent, *string, error) {
+	ret := m.ctrl.Call(m, "GetNextLogEvents", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*cloudwatchlogs.OutputLogEvent)
+	ret1, _ := ret[1].(*string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetNextLogEvents indicates an expected call of GetNextLogEvents
+func (mr *MockProviderMockRecorder) GetNextLogEvents(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextLogEvents", reflect.TypeOf((*MockProvider)(nil).GetNextLogEvents), arg0, arg1, arg2, arg3)
+}
@@ -2,6 +2,7 @@ package ec2
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
@@ -24,6 +25,9 @@ type Provider interface {
 	DescribeVPCSubnets(vpcId string) ([]*Subnet, error)
 	DescribeVPCGateways(vpcId string) ([]*InternetGateway, error)
 	DescribeVPCRoutes(vpcId string) ([]*RouteTable, error)
+	CreateLaunchTemplate(name, amiID, iamInstanceProfile, instanceType, keyName, userData string, securityGroups []*string, volSizes map[string]int) error
+	DescribeLaunchTemplateData(name string) (*LaunchTemplateData, error)
+	DeleteLaunchTemplate(name string) error
 }
 
 type EC2 struct {
@@ -43,6 +47,9 @@ type EC2Internal interface {
 	DescribeVpcs(input *ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error)
 	DescribeInternetGateways(input *ec2.DescribeInternetGatewaysInput) (*ec2.DescribeInternetGatewaysOutput, error)
 	DescribeRouteTables(input *ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error)
+	CreateLaunchTemplate(input *ec2.CreateLaunchTemplateInput) (*ec2.CreateLaunchTemplateOutput, error)
+	DescribeLaunchTemplateVersions(input *ec2.DescribeLaunchTemplateVersionsInput) (*ec2.DescribeLaunchTemplateVersionsOutput, error)
+	DeleteLaunchTemplate(input *ec2.DeleteLaunchTemplateInput) (*ec2.DeleteLaunchTemplateOutput, error)
 }
 
 // https://aws.amazon.com/ec2/instance-types/
@@ -311,6 +318,36 @@ func NewSecurityGroupIngress(groupID, cidrIP, protocol string, fromPort, toPort
 	}
 }
 
+// ALL_PROTOCOLS allows traffic on every protocol and port; rules using it do not specify ports
+const ALL_PROTOCOLS = "-1"
+
+func NewSecurityGroupIngressFromGroup(groupID, sourceGroupID, protocol string, fromPort, toPort int) *SecurityGroupIngress {
+	permission := &ec2.IpPermission{
+		UserIdGroupPairs: []*ec2.UserIdGroupPair{
+			{GroupId: aws.String(sourceGroupID)},
+		},
+		IpProtocol: aws.String(protocol),
+	}
+
+	if protocol != ALL_PROTOCOLS {
+		permission.FromPort = aws.Int64(int64(fromPort))
+		permission.ToPort = aws.Int64(int64(toPort))
+	}
+
+	permissions := []*ec2.IpPermission{permission}
+
+	return &SecurityGroupIngress{
+		AuthorizeSecurityGroupIngressInput: &ec2.AuthorizeSecurityGroupIngressInput{
+			GroupId:       aws.String(groupID),
+			IpPermissions: permissions,
+		},
+		RevokeSecurityGroupIngressInput: &ec2.RevokeSecurityGroupIngressInput{
+			GroupId:       aws.String(groupID),
+			IpPermissions: permissions,
+		},
+	}
+}
+
 type IpPermission struct {
 	*ec2.IpPermission
 }
@@ -359,6 +396,17 @@ func NewRouteTable() *RouteTable {
 	return &RouteTable{&ec2.RouteTable{}}
 }
 
+type LaunchTemplateData struct {
+	*ec2.ResponseLaunchTemplateData
+}
+
+func NewLaunchTemplateData(size, ami string) *LaunchTemplateData {
+	return &LaunchTemplateData{&ec2.ResponseLaunchTemplateData{
+		InstanceType: aws.String(size),
+		ImageId:      aws.String(ami),
+	}}
+}
+
 func NewEC2(credProvider provider.CredProvider, region string) (Provider, error) {
 	ec2 := EC2{
 		credProvider,
@@ -678,3 +726,99 @@ func (this *EC2) DescribeVPCRoutes(vpcId string) ([]*RouteTable, error) {
 	}
 	return result, err
 }
+
+func (this *EC2) CreateLaunchTemplate(
+	name string,
+	amiID string,
+	iamInstanceProfile string,
+	instanceType string,
+	keyName string,
+	userData string,
+	securityGroups []*string,
+	volSizes map[string]int,
+) error {
+	blocks := []*ec2.LaunchTemplateBlockDeviceMappingRequest{}
+	for vol, size := range volSizes {
+		block := &ec2.LaunchTemplateBlockDeviceMappingRequest{
+			DeviceName: aws.String(vol),
+			Ebs: &ec2.LaunchTemplateEbsBlockDeviceRequest{
+				DeleteOnTermination: aws.Bool(true),
+				VolumeSize:          aws.Int64(int64(size)),
+				VolumeType:          aws.String("gp2"),
+			},
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	// unlike launch configurations, launch templates need to know if the profile is a name or an arn
+	profile := &ec2.LaunchTemplateIamInstanceProfileSpecificationRequest{}
+	if strings.HasPrefix(iamInstanceProfile, "arn:") {
+		profile.Arn = aws.String(iamInstanceProfile)
+	} else {
+		profile.Name = aws.String(iamInstanceProfile)
+	}
+
+	data := &ec2.RequestLaunchTemplateData{
+		ImageId:             aws.String(amiID),
+		IamInstanceProfile:  profile,
+		InstanceType:        aws.String(instanceType),
+		UserData:            aws.String(userData),
+		SecurityGroupIds:    securityGroups,
+		BlockDeviceMappings: blocks,
+	}
+
+	if keyName != "" {
+		data.KeyName = aws.String(keyName)
+	}
+
+	input := &ec2.CreateLaunchTemplateInput{
+		LaunchTemplateName: aws.String(name),
+		LaunchTemplateData: data,
+	}
+
+	connection, err := this.Connect()
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.CreateLaunchTemplate(input)
+	return err
+}
+
+func (this *EC2) DescribeLaunchTemplateData(name string) (*LaunchTemplateData, error) {
+	input := &ec2.DescribeLaunchTemplateVersionsInput{
+		LaunchTemplateName: aws.String(name),
+		Versions:           []*string{aws.String("$Default")},
+	}
+
+	connection, err := this.Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := connection.DescribeLaunchTemplateVersions(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(output.LaunchTemplateVersions) == 0 {
+		return nil, fmt.Errorf("Launch template '%s' not found", name)
+	}
+
+	return &LaunchTemplateData{output.LaunchTemplateVersions[0].LaunchTemplateData}, nil
+}
+
+func (this *EC2) DeleteLaunchTemplate(name string) error {
+	input := &ec2.DeleteLaunchTemplateInput{
+		LaunchTemplateName: aws.String(name),
+	}
+
+	connection, err := this.Connect()
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.DeleteLaunchTemplate(input)
+	return err
+}
@@ -135,3 +135,30 @@ func (this *ProviderDecorator) DescribeVPCRoutes(p0 string) (v0 []*RouteTable, e
 	return v0, err
 }
 
+func (this *ProviderDecorator) CreateLaunchTemplate(p0 string, p1 string, p2 string, p3 string, p4 string, p5 string, p6 []*string, p7 map[string]int) (err error) {
+	call := func() error {
+		var err error
+		err = this.Inner.CreateLaunchTemplate(p0, p1, p2, p3, p4, p5, p6, p7)
+		return err
+	}
+	err = this.Decorator("CreateLaunchTemplate", call)
+	return err
+}
+func (this *ProviderDecorator) DescribeLaunchTemplateData(p0 string) (v0 *LaunchTemplateData, err error) {
+	call := func() error {
+		var err error
+		v0, err = this.Inner.DescribeLaunchTemplateData(p0)
+		return err
+	}
+	err = this.Decorator("DescribeLaunchTemplateData", call)
+	return v0, err
+}
+func (this *ProviderDecorator) DeleteLaunchTemplate(p0 string) (err error) {
+	call := func() error {
+		var err error
+		err = this.Inner.DeleteLaunchTemplate(p0)
+		return err
+	}
+	err = this.Decorator("DeleteLaunchTemplate", call)
+	return err
+}
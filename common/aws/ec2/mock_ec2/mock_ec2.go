@@ -57,6 +57,18 @@ func (mr *MockProviderMockRecorder) AuthorizeSecurityGroupIngressFromGroup(arg0,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeSecurityGroupIngressFromGroup", reflect.TypeOf((*MockProvider)(nil).AuthorizeSecurityGroupIngressFromGroup), arg0, arg1)
 }
 
+// CreateLaunchTemplate mocks base method
+func (m *MockProvider) CreateLaunchTemplate(arg0, arg1, arg2, arg3, arg4, arg5 string, arg6 []*string, arg7 map[string]int) error {
+	ret := m.ctrl.Call(m, "CreateLaunchTemplate", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateLaunchTemplate indicates an expected call of CreateLaunchTemplate
+func (mr *MockProviderMockRecorder) CreateLaunchTemplate(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLaunchTemplate", reflect.TypeOf((*MockProvider)(nil).CreateLaunchTemplate), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+}
+
 // CreateSecurityGroup mocks base method
 func (m *MockProvider) CreateSecurityGroup(arg0, arg1, arg2 string) (*string, error) {
 	ret := m.ctrl.Call(m, "CreateSecurityGroup", arg0, arg1, arg2)
@@ -70,6 +82,18 @@ func (mr *MockProviderMockRecorder) CreateSecurityGroup(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecurityGroup", reflect.TypeOf((*MockProvider)(nil).CreateSecurityGroup), arg0, arg1, arg2)
 }
 
+// DeleteLaunchTemplate mocks base method
+func (m *MockProvider) DeleteLaunchTemplate(arg0 string) error {
+	ret := m.ctrl.Call(m, "DeleteLaunchTemplate", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLaunchTemplate indicates an expected call of DeleteLaunchTemplate
+func (mr *MockProviderMockRecorder) DeleteLaunchTemplate(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLaunchTemplate", reflect.TypeOf((*MockProvider)(nil).DeleteLaunchTemplate), arg0)
+}
+
 // DeleteSecurityGroup mocks base method
 func (m *MockProvider) DeleteSecurityGroup(arg0 *ec2.SecurityGroup) error {
 	ret := m.ctrl.Call(m, "DeleteSecurityGroup", arg0)
@@ -95,6 +119,19 @@ func (mr *MockProviderMockRecorder) DescribeInstance(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeInstance", reflect.TypeOf((*MockProvider)(nil).DescribeInstance), arg0)
 }
 
+// DescribeLaunchTemplateData mocks base method
+func (m *MockProvider) DescribeLaunchTemplateData(arg0 string) (*ec2.LaunchTemplateData, error) {
+	ret := m.ctrl.Call(m, "DescribeLaunchTemplateData", arg0)
+	ret0, _ := ret[0].(*ec2.LaunchTemplateData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeLaunchTemplateData indicates an expected call of DescribeLaunchTemplateData
+func (mr *MockProviderMockRecorder) DescribeLaunchTemplateData(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeLaunchTemplateData", reflect.TypeOf((*MockProvider)(nil).DescribeLaunchTemplateData), arg0)
+}
+
 // DescribeSecurityGroup mocks base method
 func (m *MockProvider) DescribeSecurityGroup(arg0 string) (*ec2.SecurityGroup, error) {
 	ret := m.ctrl.Call(m, "DescribeSecurityGroup", arg0)
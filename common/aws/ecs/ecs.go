@@ -48,6 +48,9 @@ type Provider interface {
 
 	ListClusterTaskARNs(clusterName, startedBy string) ([]string, error)
 	ListClusterServiceNames(clusterName, prefix string) ([]string, error)
+	ListClusterNamesPage(prefix string, maxResults int64, nextToken *string) ([]string, *string, error)
+	ListClusterServiceNamesPage(clusterName, prefix string, maxResults int64, nextToken *string) ([]string, *string, error)
+	ListClusterTaskARNsPage(clusterName, startedBy, desiredStatus string, maxResults int64, nextToken *string) ([]string, *string, error)
 	ListTasks(clusterName string, serviceName, desiredStatus, startedBy, containerInstance *string) ([]*string, error)
 
 	ListTaskDefinitions(familyName string, nextToken *string) ([]*string, *string, error)
@@ -84,6 +87,9 @@ type ECSInternal interface {
 
 	DescribeTasks(input *ecs.DescribeTasksInput) (*ecs.DescribeTasksOutput, error)
 
+	ListClusters(input *ecs.ListClustersInput) (*ecs.ListClustersOutput, error)
+	ListServices(input *ecs.ListServicesInput) (*ecs.ListServicesOutput, error)
+	ListTasks(input *ecs.ListTasksInput) (*ecs.ListTasksOutput, error)
 	ListTasksPages(input *ecs.ListTasksInput, fn func(*ecs.ListTasksOutput, bool) bool) error
 	ListClustersPages(input *ecs.ListClustersInput, fn func(*ecs.ListClustersOutput, bool) bool) error
 	ListContainerInstancesPages(input *ecs.ListContainerInstancesInput, fn func(*ecs.ListContainerInstancesOutput, bool) bool) error
@@ -779,6 +785,86 @@ func (this *ECS) ListClusterServiceNames(clusterName, prefix string) ([]string,
 	return serviceNames, nil
 }
 
+// ListClusterNamesPage returns the names of the clusters with the specified prefix in a single
+// page of at most maxResults clusters, and the token of the following page.
+// Since clusters without the prefix are skipped, the page can be smaller than maxResults.
+func (this *ECS) ListClusterNamesPage(prefix string, maxResults int64, nextToken *string) ([]string, *string, error) {
+	connection, err := this.Connect()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	input := &ecs.ListClustersInput{NextToken: nextToken}
+	input.SetMaxResults(maxResults)
+
+	output, err := connection.ListClusters(input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clusterNames := []string{}
+	for _, arn := range output.ClusterArns {
+		// cluster arn format: arn:aws:ecs:region:012345678910:cluster/name
+		clusterName := strings.Split(aws.StringValue(arn), "/")[1]
+
+		if strings.HasPrefix(clusterName, prefix) {
+			clusterNames = append(clusterNames, clusterName)
+		}
+	}
+
+	return clusterNames, output.NextToken, nil
+}
+
+// ListClusterServiceNamesPage returns the names of the services with the specified prefix in a single
+// page of at most maxResults services, and the token of the following page
+func (this *ECS) ListClusterServiceNamesPage(clusterName, prefix string, maxResults int64, nextToken *string) ([]string, *string, error) {
+	connection, err := this.Connect()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	input := &ecs.ListServicesInput{NextToken: nextToken}
+	input.SetCluster(clusterName)
+	input.SetMaxResults(maxResults)
+
+	output, err := connection.ListServices(input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serviceNames := []string{}
+	for _, serviceARN := range output.ServiceArns {
+		serviceName := strings.Split(aws.StringValue(serviceARN), "/")[1]
+		if strings.HasPrefix(serviceName, prefix) {
+			serviceNames = append(serviceNames, serviceName)
+		}
+	}
+
+	return serviceNames, output.NextToken, nil
+}
+
+// ListClusterTaskARNsPage returns the arns of the tasks with the specified desired status in a single
+// page of at most maxResults tasks, and the token of the following page
+func (this *ECS) ListClusterTaskARNsPage(clusterName, startedBy, desiredStatus string, maxResults int64, nextToken *string) ([]string, *string, error) {
+	connection, err := this.Connect()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	input := &ecs.ListTasksInput{NextToken: nextToken}
+	input.SetCluster(clusterName)
+	input.SetDesiredStatus(desiredStatus)
+	input.SetStartedBy(startedBy)
+	input.SetMaxResults(maxResults)
+
+	output, err := connection.ListTasks(input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return aws.StringValueSlice(output.TaskArns), output.NextToken, nil
+}
+
 func (this *ECS) DescribeCluster(clusterName string) (*Cluster, error) {
 	connection, err := this.Connect()
 	if err != nil {
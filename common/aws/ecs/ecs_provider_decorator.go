@@ -224,6 +224,33 @@ func (this *ProviderDecorator) ListClusterServiceNames(p0 string, p1 string) (v0
 	err = this.Decorator("ListClusterServiceNames", call)
 	return v0, err
 }
+func (this *ProviderDecorator) ListClusterNamesPage(p0 string, p1 int64, p2 *string) (v0 []string, v1 *string, err error) {
+	call := func() error {
+		var err error
+		v0, v1, err = this.Inner.ListClusterNamesPage(p0, p1, p2)
+		return err
+	}
+	err = this.Decorator("ListClusterNamesPage", call)
+	return v0, v1, err
+}
+func (this *ProviderDecorator) ListClusterServiceNamesPage(p0 string, p1 string, p2 int64, p3 *string) (v0 []string, v1 *string, err error) {
+	call := func() error {
+		var err error
+		v0, v1, err = this.Inner.ListClusterServiceNamesPage(p0, p1, p2, p3)
+		return err
+	}
+	err = this.Decorator("ListClusterServiceNamesPage", call)
+	return v0, v1, err
+}
+func (this *ProviderDecorator) ListClusterTaskARNsPage(p0 string, p1 string, p2 string, p3 int64, p4 *string) (v0 []string, v1 *string, err error) {
+	call := func() error {
+		var err error
+		v0, v1, err = this.Inner.ListClusterTaskARNsPage(p0, p1, p2, p3, p4)
+		return err
+	}
+	err = this.Decorator("ListClusterTaskARNsPage", call)
+	return v0, v1, err
+}
 func (this *ProviderDecorator) ListTasks(p0 string, p1 *string, p2 *string, p3 *string, p4 *string) (v0 []*string, err error) {
 	call := func() error {
 		var err error
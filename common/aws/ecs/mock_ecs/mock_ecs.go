@@ -290,6 +290,20 @@ func (mr *MockProviderMockRecorder) ListClusterNames(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClusterNames", reflect.TypeOf((*MockProvider)(nil).ListClusterNames), arg0)
 }
 
+// ListClusterNamesPage mocks base method
+func (m *MockProvider) ListClusterNamesPage(arg0 string, arg1 int64, arg2 *string) ([]string, *string, error) {
+	ret := m.ctrl.Call(m, "ListClusterNamesPage", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(*string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListClusterNamesPage indicates an expected call of ListClusterNamesPage
+func (mr *MockProviderMockRecorder) ListClusterNamesPage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClusterNamesPage", reflect.TypeOf((*MockProvider)(nil).ListClusterNamesPage), arg0, arg1, arg2)
+}
+
 // ListClusterServiceNames mocks base method
 func (m *MockProvider) ListClusterServiceNames(arg0, arg1 string) ([]string, error) {
 	ret := m.ctrl.Call(m, "ListClusterServiceNames", arg0, arg1)
@@ -303,6 +317,20 @@ func (mr *MockProviderMockRecorder) ListClusterServiceNames(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClusterServiceNames", reflect.TypeOf((*MockProvider)(nil).ListClusterServiceNames), arg0, arg1)
 }
 
+// ListClusterServiceNamesPage mocks base method
+func (m *MockProvider) ListClusterServiceNamesPage(arg0, arg1 string, arg2 int64, arg3 *string) ([]string, *string, error) {
+	ret := m.ctrl.Call(m, "ListClusterServiceNamesPage", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(*string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListClusterServiceNamesPage indicates an expected call of ListClusterServiceNamesPage
+func (mr *MockProviderMockRecorder) ListClusterServiceNamesPage(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClusterServiceNamesPage", reflect.TypeOf((*MockProvider)(nil).ListClusterServiceNamesPage), arg0, arg1, arg2, arg3)
+}
+
 // ListClusterTaskARNs mocks base method
 func (m *MockProvider) ListClusterTaskARNs(arg0, arg1 string) ([]string, error) {
 	ret := m.ctrl.Call(m, "ListClusterTaskARNs", arg0, arg1)
@@ -316,6 +344,20 @@ func (mr *MockProviderMockRecorder) ListClusterTaskARNs(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClusterTaskARNs", reflect.TypeOf((*MockProvider)(nil).ListClusterTaskARNs), arg0, arg1)
 }
 
+// ListClusterTaskARNsPage mocks base method
+func (m *MockProvider) ListClusterTaskARNsPage(arg0, arg1, arg2 string, arg3 int64, arg4 *string) ([]string, *string, error) {
+	ret := m.ctrl.Call(m, "ListClusterTaskARNsPage", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(*string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListClusterTaskARNsPage indicates an expected call of ListClusterTaskARNsPage
+func (mr *MockProviderMockRecorder) ListClusterTaskARNsPage(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClusterTaskARNsPage", reflect.TypeOf((*MockProvider)(nil).ListClusterTaskARNsPage), arg0, arg1, arg2, arg3, arg4)
+}
+
 // ListClusters mocks base method
 func (m *MockProvider) ListClusters() ([]*string, error) {
 	ret := m.ctrl.Call(m, "ListClusters")
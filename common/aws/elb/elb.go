@@ -22,6 +22,7 @@ type Provider interface {
 	DeleteLoadBalancerListeners(loadBalancerName string, listeners []*Listener) error
 	SetIdleTimeout(loadBalancerName string, idleTimeout int) error
 	SetCrossZone(loadBalancerName string, crossZone bool) error
+	SetAccessLog(loadBalancerName string, enabled bool, bucketName, bucketPrefix string, emitInterval int) error
 }
 
 type Listener struct {
@@ -109,6 +110,9 @@ func NewLoadBalancerAttributes() *LoadBalancerAttributes {
 			CrossZoneLoadBalancing: &elb.CrossZoneLoadBalancing{
 				Enabled: aws.Bool(true),
 			},
+			AccessLog: &elb.AccessLog{
+				Enabled: aws.Bool(false),
+			},
 		},
 	}
 }
@@ -449,3 +453,32 @@ func (this *ELB) SetCrossZone(loadBalancerName string, crossZone bool) error {
 	_, err = connection.ModifyLoadBalancerAttributes(input)
 	return err
 }
+
+func (this *ELB) SetAccessLog(loadBalancerName string, enabled bool, bucketName, bucketPrefix string, emitInterval int) error {
+	accessLog := &elb.AccessLog{}
+	accessLog.SetEnabled(enabled)
+
+	if enabled {
+		accessLog.SetS3BucketName(bucketName)
+		accessLog.SetEmitInterval(int64(emitInterval))
+
+		if bucketPrefix != "" {
+			accessLog.SetS3BucketPrefix(bucketPrefix)
+		}
+	}
+
+	loadBalancerAttributes := &elb.LoadBalancerAttributes{}
+	loadBalancerAttributes.SetAccessLog(accessLog)
+
+	input := &elb.ModifyLoadBalancerAttributesInput{}
+	input.SetLoadBalancerName(loadBalancerName)
+	input.SetLoadBalancerAttributes(loadBalancerAttributes)
+
+	connection, err := this.Connect()
+	if err != nil {
+		return err
+	}
+
+	_, err = connection.ModifyLoadBalancerAttributes(input)
+	return err
+}
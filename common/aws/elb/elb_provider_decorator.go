@@ -125,4 +125,13 @@ func (this *ProviderDecorator) SetCrossZone(p0 string, p1 bool) (err error) {
 	err = this.Decorator("SetCrossZone", call)
 	return err
 }
+func (this *ProviderDecorator) SetAccessLog(p0 string, p1 bool, p2 string, p3 string, p4 int) (err error) {
+	call := func() error {
+		var err error
+		err = this.Inner.SetAccessLog(p0, p1, p2, p3, p4)
+		return err
+	}
+	err = this.Decorator("SetAccessLog", call)
+	return err
+}
 
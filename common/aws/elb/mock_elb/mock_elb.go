@@ -170,6 +170,18 @@ func (mr *MockProviderMockRecorder) RegisterInstancesWithLoadBalancer(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterInstancesWithLoadBalancer", reflect.TypeOf((*MockProvider)(nil).RegisterInstancesWithLoadBalancer), arg0, arg1)
 }
 
+// SetAccessLog mocks base method
+func (m *MockProvider) SetAccessLog(arg0 string, arg1 bool, arg2, arg3 string, arg4 int) error {
+	ret := m.ctrl.Call(m, "SetAccessLog", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAccessLog indicates an expected call of SetAccessLog
+func (mr *MockProviderMockRecorder) SetAccessLog(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAccessLog", reflect.TypeOf((*MockProvider)(nil).SetAccessLog), arg0, arg1, arg2, arg3, arg4)
+}
+
 // SetCrossZone mocks base method
 func (m *MockProvider) SetCrossZone(arg0 string, arg1 bool) error {
 	ret := m.ctrl.Call(m, "SetCrossZone", arg0, arg1)
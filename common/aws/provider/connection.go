@@ -7,6 +7,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/acm"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
@@ -16,8 +17,11 @@ import (
 	"github.com/aws/aws-sdk-go/service/elasticbeanstalk"
 	"github.com/aws/aws-sdk-go/service/elb"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/quintilesims/layer0/common/config"
+	"github.com/quintilesims/layer0/common/ratelimit"
 )
 
 const (
@@ -57,15 +61,23 @@ var regionIsValid = func(region string) (isValid bool) {
 
 const DefaultRateLimit = time.Millisecond * 200
 
-var rateLimiter = time.NewTicker(DefaultRateLimit)
+// DefaultActionLimits are the rate limits for API actions that AWS throttles more aggressively than
+// the rest of their service. Keys are the service's endpoint prefix followed by the action name.
+var DefaultActionLimits = map[string]ratelimit.Limit{
+	"logs.DescribeLogStreams": {Rate: 5, Burst: 5},
+	"logs.FilterLogEvents":    {Rate: 5, Burst: 5},
+	"logs.GetLogEvents":       {Rate: 10, Burst: 10},
+}
+
+var rateLimiter = ratelimit.NewLimiter(ratelimit.Limit{Rate: float64(time.Second / DefaultRateLimit), Burst: 1}, DefaultActionLimits)
 
-func ResetRateLimiter(rate time.Duration) {
-	rateLimiter.Stop()
-	rateLimiter = time.NewTicker(rate)
+func SetRateLimiter(limiter *ratelimit.Limiter) {
+	rateLimiter = limiter
 }
 
-func StopRateLimiter() {
-	rateLimiter.Stop()
+// WaitForRateLimit blocks until the request is allowed by the rate limiter for its service and action
+func WaitForRateLimit(r *request.Request) {
+	rateLimiter.Wait(r.ClientInfo.ServiceName, r.Operation.Name)
 }
 
 var getConfig = func(credProvider CredProvider, region string) (sess *session.Session, err error) {
@@ -85,10 +97,10 @@ var getConfig = func(credProvider CredProvider, region string) (sess *session.Se
 	}
 
 	creds := credentials.NewStaticCredentials(access_key, secret_key, "")
-	sess = session.New(config.GetAWSConfig(creds, config.AWSRegion()))
-	sess.Handlers.Send.PushBack(func(r *request.Request) {
-		<-rateLimiter.C
-	})
+	// retries are handled by the retry policy each provider is decorated with
+	awsConfig := config.GetAWSConfig(creds, config.AWSRegion()).WithMaxRetries(0)
+	sess = session.New(awsConfig)
+	sess.Handlers.Send.PushBack(WaitForRateLimit)
 
 	return
 }
@@ -192,3 +204,33 @@ var GetAutoScalingConnection = func(credProvider CredProvider, region string) (c
 	connection = autoscaling.New(sess)
 	return
 }
+
+var GetRoute53Connection = func(credProvider CredProvider, region string) (connection *route53.Route53, err error) {
+	sess, err := getConfig(credProvider, region)
+	if err != nil {
+		return
+	}
+
+	connection = route53.New(sess)
+	return
+}
+
+var GetACMConnection = func(credProvider CredProvider, region string) (connection *acm.ACM, err error) {
+	sess, err := getConfig(credProvider, region)
+	if err != nil {
+		return
+	}
+
+	connection = acm.New(sess)
+	return
+}
+
+var GetSTSConnection = func(credProvider CredProvider, region string) (connection *sts.STS, err error) {
+	sess, err := getConfig(credProvider, region)
+	if err != nil {
+		return
+	}
+
+	connection = sts.New(sess)
+	return
+}
@@ -0,0 +1,140 @@
+package route53
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/quintilesims/layer0/common/aws/provider"
+)
+
+type Provider interface {
+	CreateAliasRecord(hostedZoneID, name, targetDNSName, targetHostedZoneID string) error
+	DeleteAliasRecord(hostedZoneID, name, targetDNSName string) error
+}
+
+type Route53 struct {
+	credProvider provider.CredProvider
+	region       string
+	Connect      func() (Route53Internal, error)
+}
+
+type Route53Internal interface {
+	ChangeResourceRecordSets(input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error)
+	ListResourceRecordSets(input *route53.ListResourceRecordSetsInput) (*route53.ListResourceRecordSetsOutput, error)
+}
+
+func NewRoute53(credProvider provider.CredProvider, region string) (Provider, error) {
+	route53 := Route53{
+		credProvider,
+		region,
+		func() (Route53Internal, error) {
+			return Connect(credProvider, region)
+		},
+	}
+
+	_, err := route53.Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	return &route53, nil
+}
+
+func Connect(credProvider provider.CredProvider, region string) (Route53Internal, error) {
+	connection, err := provider.GetRoute53Connection(credProvider, region)
+	if err != nil {
+		return nil, err
+	}
+
+	return connection, nil
+}
+
+// CreateAliasRecord creates an A record aliased to the specified target.
+// An error is returned if a record with the same name and type already exists.
+func (this *Route53) CreateAliasRecord(hostedZoneID, name, targetDNSName, targetHostedZoneID string) error {
+	record := &route53.ResourceRecordSet{
+		Name: aws.String(name),
+		Type: aws.String("A"),
+		AliasTarget: &route53.AliasTarget{
+			DNSName:              aws.String(targetDNSName),
+			HostedZoneId:         aws.String(targetHostedZoneID),
+			EvaluateTargetHealth: aws.Bool(false),
+		},
+	}
+
+	return this.changeRecord(hostedZoneID, route53.ChangeActionCreate, record)
+}
+
+// DeleteAliasRecord deletes the A record with the specified name only if it is aliased to the specified target.
+// No error is returned if the record does not exist or is aliased to a different target.
+func (this *Route53) DeleteAliasRecord(hostedZoneID, name, targetDNSName string) error {
+	input := &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(hostedZoneID),
+		StartRecordName: aws.String(name),
+		StartRecordType: aws.String("A"),
+		MaxItems:        aws.String("1"),
+	}
+
+	connection, err := this.Connect()
+	if err != nil {
+		return err
+	}
+
+	output, err := connection.ListResourceRecordSets(input)
+	if err != nil {
+		return err
+	}
+
+	// record sets are listed starting at the specified name and type,
+	// so the first result is the record we want only if both match
+	for _, record := range output.ResourceRecordSets {
+		if normalizeRecordName(aws.StringValue(record.Name)) != normalizeRecordName(name) ||
+			aws.StringValue(record.Type) != "A" {
+			continue
+		}
+
+		if record.AliasTarget == nil ||
+			normalizeAliasTarget(aws.StringValue(record.AliasTarget.DNSName)) != normalizeAliasTarget(targetDNSName) {
+			return nil
+		}
+
+		return this.changeRecord(hostedZoneID, route53.ChangeActionDelete, record)
+	}
+
+	return nil
+}
+
+func (this *Route53) changeRecord(hostedZoneID, action string, record *route53.ResourceRecordSet) error {
+	input := &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(hostedZoneID),
+		ChangeBatch: &route53.ChangeBatch{
+			Changes: []*route53.Change{
+				{
+					Action:            aws.String(action),
+					ResourceRecordSet: record,
+				},
+			},
+		},
+	}
+
+	connection, err := this.Connect()
+	if err != nil {
+		return err
+	}
+
+	if _, err := connection.ChangeResourceRecordSets(input); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func normalizeRecordName(name string) string {
+	return strings.TrimSuffix(strings.ToLower(name), ".")
+}
+
+// Route53 may prefix alias targets for load balancers with 'dualstack.'
+func normalizeAliasTarget(dnsName string) string {
+	return strings.TrimPrefix(normalizeRecordName(dnsName), "dualstack.")
+}
@@ -0,0 +1,68 @@
+package sts
+
+import (
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/quintilesims/layer0/common/aws/provider"
+)
+
+type Provider interface {
+	GetCallerIdentity() (*CallerIdentity, error)
+}
+
+type STS struct {
+	credProvider provider.CredProvider
+	region       string
+	Connect      func() (STSInternal, error)
+}
+
+type STSInternal interface {
+	GetCallerIdentity(input *sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error)
+}
+
+type CallerIdentity struct {
+	*sts.GetCallerIdentityOutput
+}
+
+func NewCallerIdentity() *CallerIdentity {
+	return &CallerIdentity{&sts.GetCallerIdentityOutput{}}
+}
+
+func NewSTS(credProvider provider.CredProvider, region string) (Provider, error) {
+	sts := STS{
+		credProvider,
+		region,
+		func() (STSInternal, error) {
+			return Connect(credProvider, region)
+		},
+	}
+
+	_, err := sts.Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	return &sts, nil
+}
+
+func Connect(credProvider provider.CredProvider, region string) (STSInternal, error) {
+	connection, err := provider.GetSTSConnection(credProvider, region)
+	if err != nil {
+		return nil, err
+	}
+
+	return connection, nil
+}
+
+func (this *STS) GetCallerIdentity() (*CallerIdentity, error) {
+	connection, err := this.Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := connection.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CallerIdentity{output}, nil
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 )
 
@@ -39,6 +40,18 @@ const (
 	TEST_AWS_TAG_DYNAMO_TABLE = "LAYER0_TEST_AWS_TAG_DYNAMO_TABLE"
 	TEST_AWS_JOB_DYNAMO_TABLE = "LAYER0_TEST_AWS_JOB_DYNAMO_TABLE"
 	AWS_TIME_BETWEEN_REQUESTS = "LAYER0_AWS_TIME_BETWEEN_REQUESTS"
+	AWS_ROUTE53_HOSTED_ZONE   = "LAYER0_AWS_ROUTE53_HOSTED_ZONE_ID"
+	LOG_ROUTER_IMAGE          = "LAYER0_LOG_ROUTER_IMAGE"
+	PRICE_TABLE               = "LAYER0_PRICE_TABLE"
+	AWS_RETRY_MAX_ATTEMPTS    = "LAYER0_AWS_RETRY_MAX_ATTEMPTS"
+	AWS_RETRY_BASE_DELAY      = "LAYER0_AWS_RETRY_BASE_DELAY"
+	AWS_RETRY_MAX_DELAY       = "LAYER0_AWS_RETRY_MAX_DELAY"
+	AWS_RETRY_MAX_ELAPSED     = "LAYER0_AWS_RETRY_MAX_ELAPSED_TIME"
+	AWS_RETRY_MULTIPLIER      = "LAYER0_AWS_RETRY_MULTIPLIER"
+	AWS_RETRY_JITTER          = "LAYER0_AWS_RETRY_JITTER"
+	AWS_RATE_LIMITS           = "LAYER0_AWS_RATE_LIMITS"
+	BACKEND_CACHE_TTL         = "LAYER0_BACKEND_CACHE_TTL"
+	JOB_MAX_ATTEMPTS          = "LAYER0_JOB_MAX_ATTEMPTS"
 )
 
 // defaults
@@ -49,6 +62,14 @@ const (
 	DEFAULT_API_PORT              = "9090"
 	DEFAULT_TIME_BETWEEN_REQUESTS = "10ms"
 	DEFAULT_MAX_RETRIES           = 999
+	DEFAULT_LOG_ROUTER_IMAGE      = "amazon/aws-for-fluent-bit:stable"
+	DEFAULT_RETRY_MAX_ATTEMPTS    = "20"
+	DEFAULT_RETRY_BASE_DELAY      = "500ms"
+	DEFAULT_RETRY_MAX_DELAY       = "30s"
+	DEFAULT_RETRY_MAX_ELAPSED     = "5m"
+	DEFAULT_RETRY_MULTIPLIER      = "2"
+	DEFAULT_RETRY_JITTER          = "0.5"
+	DEFAULT_JOB_MAX_ATTEMPTS      = 3
 )
 
 // api resource tags
@@ -173,6 +194,10 @@ func AWSRegion() string {
 	return get(AWS_REGION)
 }
 
+func AWSRoute53HostedZoneID() string {
+	return get(AWS_ROUTE53_HOSTED_ZONE)
+}
+
 func AWSVPCID() string {
 	return get(AWS_VPC_ID)
 }
@@ -237,6 +262,48 @@ func AWSTimeBetweenRequests() string {
 	return getOr(AWS_TIME_BETWEEN_REQUESTS, DEFAULT_TIME_BETWEEN_REQUESTS)
 }
 
+func AWSRetryMaxAttempts() string {
+	return getOr(AWS_RETRY_MAX_ATTEMPTS, DEFAULT_RETRY_MAX_ATTEMPTS)
+}
+
+func AWSRetryBaseDelay() string {
+	return getOr(AWS_RETRY_BASE_DELAY, DEFAULT_RETRY_BASE_DELAY)
+}
+
+func AWSRetryMaxDelay() string {
+	return getOr(AWS_RETRY_MAX_DELAY, DEFAULT_RETRY_MAX_DELAY)
+}
+
+func AWSRetryMaxElapsedTime() string {
+	return getOr(AWS_RETRY_MAX_ELAPSED, DEFAULT_RETRY_MAX_ELAPSED)
+}
+
+func AWSRetryMultiplier() string {
+	return getOr(AWS_RETRY_MULTIPLIER, DEFAULT_RETRY_MULTIPLIER)
+}
+
+func AWSRetryJitter() string {
+	return getOr(AWS_RETRY_JITTER, DEFAULT_RETRY_JITTER)
+}
+
+func AWSRateLimits() string {
+	return getOr(AWS_RATE_LIMITS, "")
+}
+
+func BackendCacheTTL() string {
+	return getOr(BACKEND_CACHE_TTL, "")
+}
+
+// JobMaxAttempts is the number of times a job's runner is launched before the job is marked as failed
+func JobMaxAttempts() int {
+	attempts, err := strconv.Atoi(get(JOB_MAX_ATTEMPTS))
+	if err != nil || attempts < 1 {
+		return DEFAULT_JOB_MAX_ATTEMPTS
+	}
+
+	return attempts
+}
+
 func Prefix() string {
 	return getOr(PREFIX, "l0")
 }
@@ -253,6 +320,14 @@ func AWSECSInstanceProfile() string {
 	return get(AWS_ECS_INSTANCE_PROFILE)
 }
 
+func LogRouterImage() string {
+	return getOr(LOG_ROUTER_IMAGE, DEFAULT_LOG_ROUTER_IMAGE)
+}
+
+func PriceTablePath() string {
+	return getOr(PRICE_TABLE, "")
+}
+
 func ShouldVerifySSL() bool {
 	val := strings.ToLower(getOr(SKIP_SSL_VERIFY, ""))
 	if val == "1" || val == "true" {
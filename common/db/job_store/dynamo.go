@@ -1,10 +1,13 @@
 package job_store
 
 import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/guregu/dynamo"
 	"github.com/quintilesims/layer0/common/errors"
 	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/paging"
 	"github.com/quintilesims/layer0/common/types"
 )
 
@@ -55,6 +58,23 @@ func (d *DynamoJobStore) UpdateJobStatus(jobID string, status types.JobStatus) e
 	return nil
 }
 
+// UpdateUnfinishedJobStatus sets the job's status only if it is still Pending or InProgress.
+// It returns false if the job had already finished or was cancelled.
+func (d *DynamoJobStore) UpdateUnfinishedJobStatus(jobID string, status types.JobStatus) (bool, error) {
+	if err := d.table.Update("JobID", jobID).
+		Set("JobStatus", int64(status)).
+		If("JobStatus = ? OR JobStatus = ?", int64(types.Pending), int64(types.InProgress)).
+		Run(); err != nil {
+		if err, ok := err.(awserr.Error); ok && err.Code() == "ConditionalCheckFailedException" {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (d *DynamoJobStore) SetJobMeta(jobID string, meta map[string]string) error {
 	if err := d.table.Update("JobID", jobID).Set("Meta", meta).Run(); err != nil {
 		return err
@@ -63,6 +83,57 @@ func (d *DynamoJobStore) SetJobMeta(jobID string, meta map[string]string) error
 	return nil
 }
 
+func (d *DynamoJobStore) SetJobSteps(jobID, currentStep string, steps []models.JobStep) error {
+	update := d.table.Update("JobID", jobID).Set("Steps", steps)
+
+	// dynamo does not allow empty string attributes
+	if currentStep == "" {
+		update = update.Remove("CurrentStep")
+	} else {
+		update = update.Set("CurrentStep", currentStep)
+	}
+
+	if err := update.Run(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (d *DynamoJobStore) SetJobCheckpoint(jobID string, checkpoint int) error {
+	if err := d.table.Update("JobID", jobID).Set("Checkpoint", int64(checkpoint)).Run(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (d *DynamoJobStore) SetJobTask(jobID, taskID string) error {
+	if err := d.table.Update("JobID", jobID).Set("TaskID", taskID).Run(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// IncrementJobAttempts increments the job's Attempts and clears its TaskID, but only if
+// the job's runner is still taskID. It returns false if the runner was already replaced.
+func (d *DynamoJobStore) IncrementJobAttempts(jobID, taskID string) (bool, error) {
+	if err := d.table.Update("JobID", jobID).
+		Add("Attempts", 1).
+		Remove("TaskID").
+		If("TaskID = ?", taskID).
+		Run(); err != nil {
+		if err, ok := err.(awserr.Error); ok && err.Code() == "ConditionalCheckFailedException" {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (d *DynamoJobStore) SelectAll() ([]*models.Job, error) {
 	jobs := []*models.Job{}
 	if err := d.table.Scan().
@@ -74,6 +145,35 @@ func (d *DynamoJobStore) SelectAll() ([]*models.Job, error) {
 	return jobs, nil
 }
 
+// SelectPage scans at most limit jobs, starting after the job encoded in nextToken,
+// and returns the token of the following page; a limit of 0 means no limit
+func (d *DynamoJobStore) SelectPage(limit int, nextToken string) ([]*models.Job, string, error) {
+	scan := d.table.Scan().
+		Consistent(false).
+		SearchLimit(int64(limit))
+
+	if nextToken != "" {
+		jobID, err := paging.DecodeToken(nextToken)
+		if err != nil {
+			return nil, "", errors.New(errors.InvalidRequest, err)
+		}
+
+		scan = scan.StartFrom(dynamo.PagingKey{"JobID": {S: aws.String(jobID)}})
+	}
+
+	jobs := []*models.Job{}
+	lastEvaluatedKey, err := scan.AllWithLastEvaluatedKey(&jobs)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if key, ok := lastEvaluatedKey["JobID"]; ok && limit > 0 {
+		return jobs, paging.EncodeToken(aws.StringValue(key.S)), nil
+	}
+
+	return jobs, "", nil
+}
+
 func (d *DynamoJobStore) SelectByID(jobID string) (*models.Job, error) {
 	var job *models.Job
 
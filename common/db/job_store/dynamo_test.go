@@ -83,6 +83,47 @@ func TestDynamoJobStoreSelectAll(t *testing.T) {
 	}
 }
 
+func TestDynamoJobStoreSelectPage(t *testing.T) {
+	store := NewTestJobStore(t)
+
+	for _, jobID := range []string{"1", "2", "3", "4", "5"} {
+		if err := store.Insert(&models.Job{JobID: jobID}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	jobIDs := map[string]bool{}
+	var nextToken string
+	for i := 0; i == 0 || nextToken != ""; i++ {
+		if i > 5 {
+			t.Fatalf("Too many pages")
+		}
+
+		page, token, err := store.SelectPage(2, nextToken)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(page) > 2 {
+			t.Fatalf("Page had %d jobs, expected at most 2", len(page))
+		}
+
+		for _, job := range page {
+			if jobIDs[job.JobID] {
+				t.Fatalf("Job %s was returned twice", job.JobID)
+			}
+
+			jobIDs[job.JobID] = true
+		}
+
+		nextToken = token
+	}
+
+	if r, e := len(jobIDs), 5; r != e {
+		t.Fatalf("Pages had %d jobs, expected %d", r, e)
+	}
+}
+
 func TestDynamoJobStoreSelectByID(t *testing.T) {
 	store := NewTestJobStore(t)
 
@@ -132,6 +173,43 @@ func TestDynamoJobStoreUpdateStatus(t *testing.T) {
 	}
 }
 
+func TestDynamoJobStoreUpdateUnfinishedStatus(t *testing.T) {
+	store := NewTestJobStore(t)
+
+	job := &models.Job{JobID: "1", JobStatus: int64(types.InProgress)}
+	if err := store.Insert(job); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := store.UpdateUnfinishedJobStatus(job.JobID, types.Cancelled)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !updated {
+		t.Fatalf("Status of an unfinished job was not updated")
+	}
+
+	// a cancelled job must not be marked as completed
+	updated, err = store.UpdateUnfinishedJobStatus(job.JobID, types.Completed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updated {
+		t.Fatalf("Status of a cancelled job was updated")
+	}
+
+	result, err := store.SelectByID(job.JobID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r, e := types.JobStatus(result.JobStatus), types.Cancelled; r != e {
+		t.Fatalf("Status was '%s', expected '%s'", r, e)
+	}
+}
+
 func TestDynamoJobStoreSetMeta(t *testing.T) {
 	store := NewTestJobStore(t)
 
@@ -155,3 +233,91 @@ func TestDynamoJobStoreSetMeta(t *testing.T) {
 	}
 
 }
+
+func TestDynamoJobStoreSetSteps(t *testing.T) {
+	store := NewTestJobStore(t)
+
+	job := &models.Job{JobID: "1"}
+	if err := store.Insert(job); err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []models.JobStep{
+		{Name: "step1", Error: "some error", Warnings: []string{"some warning"}},
+		{Name: "step2"},
+	}
+
+	if err := store.SetJobSteps(job.JobID, "step1", steps); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := store.SelectByID(job.JobID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r, e := result.CurrentStep, "step1"; r != e {
+		t.Fatalf("Current step was '%s', expected '%s'", r, e)
+	}
+
+	if r, e := len(result.Steps), len(steps); r != e {
+		t.Fatalf("Step count was %d, expected %d", r, e)
+	}
+
+	if r, e := result.Steps[0].Error, "some error"; r != e {
+		t.Fatalf("Step error was '%s', expected '%s'", r, e)
+	}
+}
+
+func TestDynamoJobStoreSetCheckpointAndTask(t *testing.T) {
+	store := NewTestJobStore(t)
+
+	job := &models.Job{JobID: "1", TaskID: "t1", Attempts: 1}
+	if err := store.Insert(job); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.SetJobCheckpoint(job.JobID, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	ok, err := store.IncrementJobAttempts(job.JobID, "t1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !ok {
+		t.Fatalf("Attempts were not incremented")
+	}
+
+	// the runner t1 has already been replaced
+	ok, err = store.IncrementJobAttempts(job.JobID, "t1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ok {
+		t.Fatalf("Attempts were incremented for a replaced runner")
+	}
+
+	if err := store.SetJobTask(job.JobID, "t2"); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := store.SelectByID(job.JobID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r, e := result.Checkpoint, int64(2); r != e {
+		t.Fatalf("Checkpoint was %d, expected %d", r, e)
+	}
+
+	if r, e := result.TaskID, "t2"; r != e {
+		t.Fatalf("Task was '%s', expected '%s'", r, e)
+	}
+
+	if r, e := result.Attempts, int64(2); r != e {
+		t.Fatalf("Attempts was %d, expected %d", r, e)
+	}
+}
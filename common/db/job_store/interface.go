@@ -10,7 +10,13 @@ type JobStore interface {
 	Delete(string) error
 	Insert(*models.Job) error
 	SelectAll() ([]*models.Job, error)
+	SelectPage(int, string) ([]*models.Job, string, error)
 	SelectByID(string) (*models.Job, error)
 	UpdateJobStatus(string, types.JobStatus) error
+	UpdateUnfinishedJobStatus(string, types.JobStatus) (bool, error)
 	SetJobMeta(string, map[string]string) error
+	SetJobSteps(string, string, []models.JobStep) error
+	SetJobCheckpoint(string, int) error
+	SetJobTask(string, string) error
+	IncrementJobAttempts(string, string) (bool, error)
 }
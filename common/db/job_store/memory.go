@@ -1,9 +1,9 @@
 package job_store
 
 import (
-	"fmt"
-
+	"github.com/quintilesims/layer0/common/errors"
 	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/paging"
 	"github.com/quintilesims/layer0/common/types"
 )
 
@@ -41,6 +41,29 @@ func (m *MemoryJobStore) SelectAll() ([]*models.Job, error) {
 	return m.jobs, nil
 }
 
+func (m *MemoryJobStore) SelectPage(limit int, nextToken string) ([]*models.Job, string, error) {
+	jobs := m.jobs
+	if nextToken != "" {
+		jobID, err := paging.DecodeToken(nextToken)
+		if err != nil {
+			return nil, "", errors.New(errors.InvalidRequest, err)
+		}
+
+		for i, job := range jobs {
+			if job.JobID == jobID {
+				jobs = jobs[i+1:]
+				break
+			}
+		}
+	}
+
+	if limit == 0 || len(jobs) <= limit {
+		return jobs, "", nil
+	}
+
+	return jobs[:limit], paging.EncodeToken(jobs[limit-1].JobID), nil
+}
+
 func (m *MemoryJobStore) SelectByID(jobID string) (*models.Job, error) {
 	for _, job := range m.jobs {
 		if job.JobID == jobID {
@@ -48,7 +71,7 @@ func (m *MemoryJobStore) SelectByID(jobID string) (*models.Job, error) {
 		}
 	}
 
-	return nil, fmt.Errorf("Job with id '%s' does not exist", jobID)
+	return nil, errors.Newf(errors.JobDoesNotExist, "Job with id '%s' does not exist", jobID)
 }
 
 func (m *MemoryJobStore) UpdateJobStatus(jobID string, status types.JobStatus) error {
@@ -61,6 +84,21 @@ func (m *MemoryJobStore) UpdateJobStatus(jobID string, status types.JobStatus) e
 	return nil
 }
 
+func (m *MemoryJobStore) UpdateUnfinishedJobStatus(jobID string, status types.JobStatus) (bool, error) {
+	job, err := m.SelectByID(jobID)
+	if err != nil {
+		return false, err
+	}
+
+	switch types.JobStatus(job.JobStatus) {
+	case types.Pending, types.InProgress:
+		job.JobStatus = int64(status)
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
 func (m *MemoryJobStore) SetJobMeta(jobID string, meta map[string]string) error {
 	job, err := m.SelectByID(jobID)
 	if err != nil {
@@ -70,3 +108,49 @@ func (m *MemoryJobStore) SetJobMeta(jobID string, meta map[string]string) error
 	job.Meta = meta
 	return nil
 }
+
+func (m *MemoryJobStore) SetJobSteps(jobID, currentStep string, steps []models.JobStep) error {
+	job, err := m.SelectByID(jobID)
+	if err != nil {
+		return err
+	}
+
+	job.CurrentStep = currentStep
+	job.Steps = steps
+	return nil
+}
+
+func (m *MemoryJobStore) SetJobCheckpoint(jobID string, checkpoint int) error {
+	job, err := m.SelectByID(jobID)
+	if err != nil {
+		return err
+	}
+
+	job.Checkpoint = int64(checkpoint)
+	return nil
+}
+
+func (m *MemoryJobStore) SetJobTask(jobID, taskID string) error {
+	job, err := m.SelectByID(jobID)
+	if err != nil {
+		return err
+	}
+
+	job.TaskID = taskID
+	return nil
+}
+
+func (m *MemoryJobStore) IncrementJobAttempts(jobID, taskID string) (bool, error) {
+	job, err := m.SelectByID(jobID)
+	if err != nil {
+		return false, err
+	}
+
+	if job.TaskID == "" || job.TaskID != taskID {
+		return false, nil
+	}
+
+	job.Attempts++
+	job.TaskID = ""
+	return true, nil
+}
@@ -46,6 +46,19 @@ func (mr *MockJobStoreMockRecorder) Delete(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockJobStore)(nil).Delete), arg0)
 }
 
+// IncrementJobAttempts mocks base method
+func (m *MockJobStore) IncrementJobAttempts(arg0, arg1 string) (bool, error) {
+	ret := m.ctrl.Call(m, "IncrementJobAttempts", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementJobAttempts indicates an expected call of IncrementJobAttempts
+func (mr *MockJobStoreMockRecorder) IncrementJobAttempts(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementJobAttempts", reflect.TypeOf((*MockJobStore)(nil).IncrementJobAttempts), arg0, arg1)
+}
+
 // Init mocks base method
 func (m *MockJobStore) Init() error {
 	ret := m.ctrl.Call(m, "Init")
@@ -96,6 +109,32 @@ func (mr *MockJobStoreMockRecorder) SelectByID(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectByID", reflect.TypeOf((*MockJobStore)(nil).SelectByID), arg0)
 }
 
+// SelectPage mocks base method
+func (m *MockJobStore) SelectPage(arg0 int, arg1 string) ([]*models.Job, string, error) {
+	ret := m.ctrl.Call(m, "SelectPage", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Job)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SelectPage indicates an expected call of SelectPage
+func (mr *MockJobStoreMockRecorder) SelectPage(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectPage", reflect.TypeOf((*MockJobStore)(nil).SelectPage), arg0, arg1)
+}
+
+// SetJobCheckpoint mocks base method
+func (m *MockJobStore) SetJobCheckpoint(arg0 string, arg1 int) error {
+	ret := m.ctrl.Call(m, "SetJobCheckpoint", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetJobCheckpoint indicates an expected call of SetJobCheckpoint
+func (mr *MockJobStoreMockRecorder) SetJobCheckpoint(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetJobCheckpoint", reflect.TypeOf((*MockJobStore)(nil).SetJobCheckpoint), arg0, arg1)
+}
+
 // SetJobMeta mocks base method
 func (m *MockJobStore) SetJobMeta(arg0 string, arg1 map[string]string) error {
 	ret := m.ctrl.Call(m, "SetJobMeta", arg0, arg1)
@@ -108,6 +147,30 @@ func (mr *MockJobStoreMockRecorder) SetJobMeta(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetJobMeta", reflect.TypeOf((*MockJobStore)(nil).SetJobMeta), arg0, arg1)
 }
 
+// SetJobSteps mocks base method
+func (m *MockJobStore) SetJobSteps(arg0, arg1 string, arg2 []models.JobStep) error {
+	ret := m.ctrl.Call(m, "SetJobSteps", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetJobSteps indicates an expected call of SetJobSteps
+func (mr *MockJobStoreMockRecorder) SetJobSteps(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetJobSteps", reflect.TypeOf((*MockJobStore)(nil).SetJobSteps), arg0, arg1, arg2)
+}
+
+// SetJobTask mocks base method
+func (m *MockJobStore) SetJobTask(arg0, arg1 string) error {
+	ret := m.ctrl.Call(m, "SetJobTask", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetJobTask indicates an expected call of SetJobTask
+func (mr *MockJobStoreMockRecorder) SetJobTask(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetJobTask", reflect.TypeOf((*MockJobStore)(nil).SetJobTask), arg0, arg1)
+}
+
 // UpdateJobStatus mocks base method
 func (m *MockJobStore) UpdateJobStatus(arg0 string, arg1 types.JobStatus) error {
 	ret := m.ctrl.Call(m, "UpdateJobStatus", arg0, arg1)
@@ -119,3 +182,16 @@ func (m *MockJobStore) UpdateJobStatus(arg0 string, arg1 types.JobStatus) error
 func (mr *MockJobStoreMockRecorder) UpdateJobStatus(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJobStatus", reflect.TypeOf((*MockJobStore)(nil).UpdateJobStatus), arg0, arg1)
 }
+
+// UpdateUnfinishedJobStatus mocks base method
+func (m *MockJobStore) UpdateUnfinishedJobStatus(arg0 string, arg1 types.JobStatus) (bool, error) {
+	ret := m.ctrl.Call(m, "UpdateUnfinishedJobStatus", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUnfinishedJobStatus indicates an expected call of UpdateUnfinishedJobStatus
+func (mr *MockJobStoreMockRecorder) UpdateUnfinishedJobStatus(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUnfinishedJobStatus", reflect.TypeOf((*MockJobStore)(nil).UpdateUnfinishedJobStatus), arg0, arg1)
+}
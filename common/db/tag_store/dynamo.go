@@ -202,6 +202,43 @@ func (d *DynamoTagStore) SelectByType(entityType string) (models.Tags, error) {
 	return tags, nil
 }
 
+// SelectByTypeAndIDs reads the tags of the specified entities in batches,
+// so only the tags of those entities are read from the table
+func (d *DynamoTagStore) SelectByTypeAndIDs(entityType string, entityIDs []string) (models.Tags, error) {
+	tags := models.Tags{}
+	if len(entityIDs) == 0 {
+		return tags, nil
+	}
+
+	// batch gets fail if the same key is requested more than once
+	keys := []dynamo.Keyed{}
+	seen := map[string]bool{}
+	for _, entityID := range entityIDs {
+		if !seen[entityID] {
+			keys = append(keys, dynamo.Keys{entityType, entityID})
+			seen[entityID] = true
+		}
+	}
+
+	var schemas []*DynamoTagSchema
+	if err := d.table.Batch("EntityType", "EntityID").
+		Get(keys...).
+		Consistent(true).
+		All(&schemas); err != nil {
+		if err.Error() == "dynamo: no item found" {
+			return tags, nil
+		}
+
+		return nil, err
+	}
+
+	for _, schema := range schemas {
+		tags = append(tags, schema.ToTags()...)
+	}
+
+	return tags, nil
+}
+
 func (d *DynamoTagStore) selectByType(entityType string) ([]*DynamoTagSchema, error) {
 	var schemas []*DynamoTagSchema
 
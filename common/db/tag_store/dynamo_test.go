@@ -161,6 +161,33 @@ func TestDynamoTagStoreSelectByTypeAndID(t *testing.T) {
 	}
 }
 
+func TestDynamoTagStoreSelectByTypeAndIDs(t *testing.T) {
+	store := NewTestTagStore(t)
+
+	for _, tag := range TestTags {
+		if err := store.Insert(tag); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	results, err := store.SelectByTypeAndIDs("service", []string{"s1", "s2", "s1", "invalid"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, results, 4)
+	for _, e := range TestTags[10:14] {
+		assert.Contains(t, results, e)
+	}
+
+	results, err = store.SelectByTypeAndIDs("service", []string{"invalid"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, results, 0)
+}
+
 func TestDynamoTagStoreSelectByType(t *testing.T) {
 	store := NewTestTagStore(t)
 
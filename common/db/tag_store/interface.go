@@ -10,4 +10,5 @@ type TagStore interface {
 	Insert(tag models.Tag) error
 	SelectByType(entityType string) (models.Tags, error)
 	SelectByTypeAndID(entityType, entityID string) (models.Tags, error)
+	SelectByTypeAndIDs(entityType string, entityIDs []string) (models.Tags, error)
 }
@@ -42,3 +42,19 @@ func (m *MemoryTagStore) SelectByType(entityType string) (models.Tags, error) {
 func (m *MemoryTagStore) SelectByTypeAndID(entityType, entityID string) (models.Tags, error) {
 	return m.tags.WithType(entityType).WithID(entityID), nil
 }
+
+func (m *MemoryTagStore) SelectByTypeAndIDs(entityType string, entityIDs []string) (models.Tags, error) {
+	ids := map[string]bool{}
+	for _, entityID := range entityIDs {
+		ids[entityID] = true
+	}
+
+	tags := models.Tags{}
+	for _, tag := range m.tags.WithType(entityType) {
+		if ids[tag.EntityID] {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags, nil
+}
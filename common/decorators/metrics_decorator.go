@@ -0,0 +1,64 @@
+package decorators
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	awsCallCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "layer0_aws_calls_total",
+			Help: "Total number of AWS calls by provider and operation",
+		},
+		[]string{"provider", "operation"})
+
+	awsCallErrorCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "layer0_aws_call_errors_total",
+			Help: "Total number of failed AWS calls by provider and operation",
+		},
+		[]string{"provider", "operation"})
+
+	awsCallThrottleCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "layer0_aws_call_throttles_total",
+			Help: "Total number of throttled AWS calls by provider and operation",
+		},
+		[]string{"provider", "operation"})
+
+	awsCallDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "layer0_aws_call_duration_seconds",
+			Help: "Latency of AWS calls by provider",
+		},
+		[]string{"provider"})
+)
+
+func init() {
+	prometheus.MustRegister(awsCallCount, awsCallErrorCount, awsCallThrottleCount, awsCallDuration)
+}
+
+// Metrics records call counts, errors, and throttles for each call made through a provider.
+// It should wrap the provider directly so each retried attempt is recorded.
+type Metrics struct {
+	Provider string
+}
+
+func (this *Metrics) CallWithMetrics(name string, call func() error) error {
+	startTime := time.Now()
+	err := call()
+	awsCallDuration.WithLabelValues(this.Provider).Observe(time.Since(startTime).Seconds())
+
+	awsCallCount.WithLabelValues(this.Provider, name).Inc()
+	if err != nil {
+		awsCallErrorCount.WithLabelValues(this.Provider, name).Inc()
+
+		if isThrottleError(err) {
+			awsCallThrottleCount.WithLabelValues(this.Provider, name).Inc()
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,55 @@
+package decorators
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/quintilesims/layer0/common/aws/ecs"
+	"github.com/quintilesims/layer0/common/aws/ecs/mock_ecs"
+)
+
+func TestMetrics_callWithMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockECS := mock_ecs.NewMockProvider(ctrl)
+	gomock.InOrder(
+		mockECS.EXPECT().DeleteCluster("c1").Return(nil),
+		mockECS.EXPECT().DeleteCluster("c1").Return(fmt.Errorf("some error")),
+		mockECS.EXPECT().DeleteCluster("c1").Return(getRetryTrigger()),
+	)
+
+	wrap := &ecs.ProviderDecorator{
+		Inner:     mockECS,
+		Decorator: (&Metrics{Provider: "test_ecs"}).CallWithMetrics,
+	}
+
+	for i := 0; i < 3; i++ {
+		wrap.DeleteCluster("c1")
+	}
+
+	counters := map[*prometheus.CounterVec]float64{
+		awsCallCount:         3,
+		awsCallErrorCount:    2,
+		awsCallThrottleCount: 1,
+	}
+
+	for counter, expected := range counters {
+		if v := testutil.ToFloat64(counter.WithLabelValues("test_ecs", "DeleteCluster")); v != expected {
+			t.Errorf("Counter value was %v, expected %v", v, expected)
+		}
+	}
+
+	var histogram dto.Metric
+	if err := awsCallDuration.WithLabelValues("test_ecs").(prometheus.Histogram).Write(&histogram); err != nil {
+		t.Fatal(err)
+	}
+
+	if count := histogram.GetHistogram().GetSampleCount(); count != 3 {
+		t.Errorf("Histogram sample count was %d, expected 3", count)
+	}
+}
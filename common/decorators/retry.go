@@ -1,56 +1,230 @@
 package decorators
 
 import (
+	"fmt"
+	"math"
+	"math/rand"
+	"net"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/quintilesims/layer0/common/config"
 	"github.com/quintilesims/layer0/common/waitutils"
 )
 
-type Retry struct {
-	Clock waitutils.Clock
+const (
+	RetryReasonThrottle = "throttle"
+	RetryReasonServer   = "server_error"
+	RetryReasonNetwork  = "network"
+)
+
+var (
+	awsCallRetryCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "layer0_aws_call_retries_total",
+			Help: "Total number of retried AWS calls by provider, operation, and reason",
+		},
+		[]string{"provider", "operation", "reason"})
+
+	awsCallRetriesExhaustedCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "layer0_aws_call_retries_exhausted_total",
+			Help: "Total number of AWS calls that failed after exhausting the retry policy by provider and operation",
+		},
+		[]string{"provider", "operation"})
+)
+
+func init() {
+	prometheus.MustRegister(awsCallRetryCount, awsCallRetriesExhaustedCount)
 }
 
-func (this *Retry) shouldRetry(err error) bool {
-	// As we discover more errors that indicate throttling, they should be added here
-	if err != nil {
-		if awsErr, ok := err.(awserr.Error); ok {
-			code := awsErr.Code()
+var throttleCodes = map[string]bool{
+	"BandwidthLimitExceeded":                 true,
+	"EC2ThrottledException":                  true,
+	"PriorRequestNotComplete":                true,
+	"ProvisionedThroughputExceededException": true,
+	"RequestLimitExceeded":                   true,
+	"RequestThrottled":                       true,
+	"RequestThrottledException":              true,
+	"SlowDown":                               true,
+	"Throttling":                             true,
+	"ThrottlingException":                    true,
+	"TooManyRequestsException":               true,
+}
 
-			if code == "Throttling" || code == "ThrottlingException" {
-				return true
-			}
+var serverErrorCodes = map[string]bool{
+	"InternalError":               true,
+	"InternalFailure":             true,
+	"InternalServerError":         true,
+	"ServerException":             true,
+	"ServiceUnavailable":          true,
+	"ServiceUnavailableException": true,
+}
+
+var networkErrorCodes = map[string]bool{
+	"ReadError":       true,
+	"RequestError":    true,
+	"RequestTimeout":  true,
+	"ResponseTimeout": true,
+}
+
+// RetryReason returns the reason err should be retried, or an empty string if it should not be
+func RetryReason(err error) string {
+	switch err := err.(type) {
+	case nil:
+		return ""
+	case awserr.Error:
+		code := err.Code()
+		if throttleCodes[code] {
+			return RetryReasonThrottle
+		}
+
+		message := strings.ToLower(err.Message())
+		if code == "ClientException" && strings.Contains(message, "too many concurrent attempts") {
+			return RetryReasonThrottle
+		}
+
+		if serverErrorCodes[code] {
+			return RetryReasonServer
+		}
 
-			message := strings.ToLower(awsErr.Message())
-			if code == "ClientException" && strings.Contains(message, "too many concurrent attempts") {
-				return true
+		if failure, ok := err.(awserr.RequestFailure); ok {
+			// 501 Not Implemented will never succeed
+			if failure.StatusCode() >= 500 && failure.StatusCode() != 501 {
+				return RetryReasonServer
 			}
 		}
+
+		if networkErrorCodes[code] {
+			return RetryReasonNetwork
+		}
+	case *url.Error, net.Error:
+		return RetryReasonNetwork
+	}
+
+	return ""
+}
+
+func isThrottleError(err error) bool {
+	return RetryReason(err) == RetryReasonThrottle
+}
+
+// RetryPolicy describes how many times, and how often, a failed call is retried.
+// The delay before attempt n+1 is BaseDelay * Multiplier^(n-1), capped at MaxDelay;
+// Jitter is the fraction of each delay that is randomized.
+type RetryPolicy struct {
+	MaxAttempts    int
+	BaseDelay      time.Duration
+	MaxDelay       time.Duration
+	MaxElapsedTime time.Duration
+	Multiplier     float64
+	Jitter         float64
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    20,
+		BaseDelay:      time.Millisecond * 500,
+		MaxDelay:       time.Second * 30,
+		MaxElapsedTime: time.Minute * 5,
+		Multiplier:     2,
+		Jitter:         0.5,
+	}
+}
+
+// NewRetryPolicyFromConfig returns the default retry policy with any values set in the config applied
+func NewRetryPolicyFromConfig() (RetryPolicy, error) {
+	policy := DefaultRetryPolicy()
+
+	maxAttempts, err := strconv.Atoi(config.AWSRetryMaxAttempts())
+	if err != nil {
+		return policy, fmt.Errorf("Invalid retry max attempts '%s': %v", config.AWSRetryMaxAttempts(), err)
+	}
+
+	baseDelay, err := time.ParseDuration(config.AWSRetryBaseDelay())
+	if err != nil {
+		return policy, err
+	}
+
+	maxDelay, err := time.ParseDuration(config.AWSRetryMaxDelay())
+	if err != nil {
+		return policy, err
+	}
+
+	maxElapsedTime, err := time.ParseDuration(config.AWSRetryMaxElapsedTime())
+	if err != nil {
+		return policy, err
+	}
+
+	multiplier, err := strconv.ParseFloat(config.AWSRetryMultiplier(), 64)
+	if err != nil || multiplier < 1 {
+		return policy, fmt.Errorf("Invalid retry multiplier '%s': must be a number of at least 1", config.AWSRetryMultiplier())
+	}
+
+	jitter, err := strconv.ParseFloat(config.AWSRetryJitter(), 64)
+	if err != nil || jitter < 0 || jitter > 1 {
+		return policy, fmt.Errorf("Invalid retry jitter '%s': must be a number between 0 and 1", config.AWSRetryJitter())
+	}
+
+	policy.MaxAttempts = maxAttempts
+	policy.BaseDelay = baseDelay
+	policy.MaxDelay = maxDelay
+	policy.MaxElapsedTime = maxElapsedTime
+	policy.Multiplier = multiplier
+	policy.Jitter = jitter
+	return policy, nil
+}
+
+// Delay returns how long to wait after the specified (1-indexed) attempt fails.
+// random must return a value in [0, 1).
+func (p RetryPolicy) Delay(attempt int, random func() float64) time.Duration {
+	delay := float64(p.BaseDelay) * math.Pow(p.Multiplier, float64(attempt-1))
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
 	}
 
-	return false
+	jitter := delay * p.Jitter
+	return time.Duration(delay - jitter + jitter*random())
+}
+
+type Retry struct {
+	Clock    waitutils.Clock
+	Policy   RetryPolicy
+	Provider string
+	Random   func() float64
 }
 
 func (this *Retry) CallWithRetries(name string, call func() error) error {
-	check := func() (bool, error) {
+	random := this.Random
+	if random == nil {
+		random = rand.Float64
+	}
+
+	start := this.Clock.Now()
+	for attempt := 1; ; attempt++ {
 		err := call()
 		if err == nil {
-			return true, nil
-		} else if this.shouldRetry(err) {
-			return false, nil
+			return nil
 		}
 
-		return false, err
-	}
+		reason := RetryReason(err)
+		if reason == "" {
+			return err
+		}
 
-	callObject := &waitutils.Waiter{
-		Name:    name,
-		Retries: 20,
-		Delay:   5 * time.Second,
-		Check:   check,
-		Clock:   this.Clock,
-	}
+		delay := this.Policy.Delay(attempt, random)
+		exceedsElapsedTime := this.Policy.MaxElapsedTime > 0 && this.Clock.Since(start)+delay > this.Policy.MaxElapsedTime
+		if attempt >= this.Policy.MaxAttempts || exceedsElapsedTime {
+			awsCallRetriesExhaustedCount.WithLabelValues(this.Provider, name).Inc()
+			return err
+		}
 
-	return callObject.Wait()
+		awsCallRetryCount.WithLabelValues(this.Provider, name, reason).Inc()
+		log.Debugf("Retrying %s.%s in %v after attempt %d (%s): %v", this.Provider, name, delay, attempt, reason, err)
+		this.Clock.Sleep(delay)
+	}
 }
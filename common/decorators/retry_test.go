@@ -2,12 +2,16 @@ package decorators
 
 import (
 	"fmt"
+	"net/url"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/golang/mock/gomock"
 	"github.com/quintilesims/layer0/common/aws/ecs"
 	"github.com/quintilesims/layer0/common/aws/ecs/mock_ecs"
+	"github.com/quintilesims/layer0/common/config"
 	"github.com/quintilesims/layer0/common/testutils"
 )
 
@@ -77,9 +81,142 @@ func TestRetry_otherError(t *testing.T) {
 	}
 }
 
+func TestRetry_maxElapsedTime(t *testing.T) {
+	ctrl := gomock.NewController(testutils.NewReporter(t, "TestRetry_maxElapsedTime"))
+	defer ctrl.Finish()
+
+	mockECS := mock_ecs.NewMockProvider(ctrl)
+
+	// delays of 1s, 2s, 4s exceed the 5s limit after the third attempt
+	mockECS.EXPECT().CreateCluster(gomock.Any()).
+		Return(nil, getRetryTrigger()).
+		Times(3)
+
+	retry := &Retry{
+		Clock: &testutils.StubClock{},
+		Policy: RetryPolicy{
+			MaxAttempts:    20,
+			BaseDelay:      time.Second,
+			MaxElapsedTime: time.Second * 5,
+			Multiplier:     2,
+		},
+		Provider: "ecs",
+	}
+
+	wrap := &ecs.ProviderDecorator{
+		Inner:     mockECS,
+		Decorator: retry.CallWithRetries,
+	}
+
+	if _, err := wrap.CreateCluster("test"); err == nil {
+		t.Errorf("Error was unexpectedly nil")
+	}
+}
+
+func TestRetryReason(t *testing.T) {
+	cases := map[string]struct {
+		Err    error
+		Reason string
+	}{
+		"nil":            {nil, ""},
+		"other":          {fmt.Errorf("Some error"), ""},
+		"request limit":  {testAwsError{code: "RequestLimitExceeded"}, RetryReasonThrottle},
+		"throttling":     {testAwsError{code: "Throttling"}, RetryReasonThrottle},
+		"ecs concurrent": {testAwsError{code: "ClientException", message: "Too many concurrent attempts"}, RetryReasonThrottle},
+		"client error":   {testAwsError{code: "ClientException", message: "Cluster not found"}, ""},
+		"internal error": {testAwsError{code: "InternalError"}, RetryReasonServer},
+		"503":            {awserr.NewRequestFailure(testAwsError{code: "Unknown"}, 503, ""), RetryReasonServer},
+		"501":            {awserr.NewRequestFailure(testAwsError{code: "Unknown"}, 501, ""), ""},
+		"400":            {awserr.NewRequestFailure(testAwsError{code: "ValidationError"}, 400, ""), ""},
+		"request error":  {awserr.New("RequestError", "send request failed", nil), RetryReasonNetwork},
+		"url error":      {&url.Error{Op: "Get", URL: "http://localhost", Err: fmt.Errorf("connection refused")}, RetryReasonNetwork},
+	}
+
+	for name, c := range cases {
+		if reason := RetryReason(c.Err); reason != c.Reason {
+			t.Errorf("%s: reason was '%s', expected '%s'", name, reason, c.Reason)
+		}
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{
+		BaseDelay:  time.Second,
+		MaxDelay:   time.Second * 10,
+		Multiplier: 2,
+		Jitter:     0.5,
+	}
+
+	cases := []struct {
+		Attempt  int
+		Random   float64
+		Expected time.Duration
+	}{
+		{1, 1, time.Second},
+		{1, 0, time.Millisecond * 500},
+		{2, 1, time.Second * 2},
+		{3, 0.5, time.Second * 3},
+		{4, 1, time.Second * 8},
+		{5, 1, time.Second * 10},
+		{10, 0, time.Second * 5},
+	}
+
+	for _, c := range cases {
+		delay := policy.Delay(c.Attempt, func() float64 { return c.Random })
+		if delay != c.Expected {
+			t.Errorf("Attempt %d (random %v): delay was %v, expected %v", c.Attempt, c.Random, delay, c.Expected)
+		}
+	}
+}
+
+func TestNewRetryPolicyFromConfig(t *testing.T) {
+	defer os.Unsetenv(config.AWS_RETRY_MULTIPLIER)
+	defer os.Unsetenv(config.AWS_RETRY_JITTER)
+
+	os.Setenv(config.AWS_RETRY_MULTIPLIER, "1.5")
+	os.Setenv(config.AWS_RETRY_JITTER, "0.2")
+
+	policy, err := NewRetryPolicyFromConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, policy.Multiplier, 1.5)
+	testutils.AssertEqual(t, policy.Jitter, 0.2)
+}
+
+func TestNewRetryPolicyFromConfig_invalid(t *testing.T) {
+	defer os.Unsetenv(config.AWS_RETRY_MULTIPLIER)
+	defer os.Unsetenv(config.AWS_RETRY_JITTER)
+
+	cases := map[string]map[string]string{
+		"Multiplier not a number": {config.AWS_RETRY_MULTIPLIER: "abc"},
+		"Multiplier below 1":      {config.AWS_RETRY_MULTIPLIER: "0.5"},
+		"Jitter not a number":     {config.AWS_RETRY_JITTER: "abc"},
+		"Jitter above 1":          {config.AWS_RETRY_JITTER: "1.5"},
+	}
+
+	for name, env := range cases {
+		os.Unsetenv(config.AWS_RETRY_MULTIPLIER)
+		os.Unsetenv(config.AWS_RETRY_JITTER)
+		for key, val := range env {
+			os.Setenv(key, val)
+		}
+
+		if _, err := NewRetryPolicyFromConfig(); err == nil {
+			t.Errorf("Case %s: error was nil!", name)
+		}
+	}
+}
+
 func prepareRetry(mockECS ecs.Provider) ecs.Provider {
+	policy := DefaultRetryPolicy()
+	policy.MaxElapsedTime = 0
+
 	retry := &Retry{
-		&testutils.StubClock{},
+		Clock:    &testutils.StubClock{},
+		Policy:   policy,
+		Provider: "ecs",
 	}
 
 	wrap := &ecs.ProviderDecorator{
@@ -32,4 +32,5 @@ const (
 	LoadBalancerAttributeNotFound
 	ServiceDoesNotExist
 	TaskDoesNotExist
+	InvalidRequest
 )
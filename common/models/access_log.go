@@ -0,0 +1,8 @@
+package models
+
+type AccessLog struct {
+	Enabled      bool   `json:"enabled"`
+	BucketName   string `json:"bucket_name"`
+	BucketPrefix string `json:"bucket_prefix"`
+	EmitInterval int    `json:"emit_interval"`
+}
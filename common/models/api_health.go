@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+)
+
+type APIHealth struct {
+	Status     string            `json:"status"`
+	Components []ComponentHealth `json:"components,omitempty"`
+}
+
+type ComponentHealth struct {
+	Name     string     `json:"name"`
+	Status   string     `json:"status"`
+	Critical bool       `json:"critical"`
+	Message  string     `json:"message"`
+	LastRun  *time.Time `json:"last_run,omitempty"`
+}
@@ -0,0 +1,16 @@
+package models
+
+type BackendCacheStats struct {
+	Enabled       bool                               `json:"enabled"`
+	TTL           string                             `json:"ttl"`
+	Entries       int                                `json:"entries"`
+	Hits          int64                              `json:"hits"`
+	Misses        int64                              `json:"misses"`
+	Invalidations int64                              `json:"invalidations"`
+	Methods       map[string]BackendCacheMethodStats `json:"methods"`
+}
+
+type BackendCacheMethodStats struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+}
@@ -1,7 +1,15 @@
 package models
 
+import (
+	"time"
+)
+
 type Certificate struct {
-	CertificateARN  string `json:"certificate_arn"`
-	CertificateID   string `json:"certificate_id"`
-	CertificateName string `json:"certificate_name"`
+	CertificateARN  string    `json:"certificate_arn"`
+	CertificateID   string    `json:"certificate_id"`
+	CertificateName string    `json:"certificate_name"`
+	DomainName      string    `json:"domain_name"`
+	Expiration      time.Time `json:"expiration"`
+	Source          string    `json:"source"`
+	Status          string    `json:"status"`
 }
@@ -1,5 +1,7 @@
 package models
 
 type CreateEnvironmentLinkRequest struct {
-	EnvironmentID string `json:"environment_id"`
+	EnvironmentID string                `json:"environment_id"`
+	Ports         []EnvironmentLinkPort `json:"ports"`
+	OneWay        bool                  `json:"one_way"`
 }
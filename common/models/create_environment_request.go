@@ -1,10 +1,13 @@
 package models
 
 type CreateEnvironmentRequest struct {
-	EnvironmentName  string `json:"environment_name"`
-	InstanceSize     string `json:"instance_size"`
-	UserDataTemplate []byte `json:"user_data_template"`
-	MinClusterCount  int    `json:"min_cluster_count"`
-	OperatingSystem  string `json:"operating_system"`
-	AMIID            string `json:"ami_id"`
+	EnvironmentName      string                `json:"environment_name"`
+	InstanceSize         string                `json:"instance_size"`
+	UserDataTemplate     []byte                `json:"user_data_template"`
+	MinClusterCount      int                   `json:"min_cluster_count"`
+	OperatingSystem      string                `json:"operating_system"`
+	AMIID                string                `json:"ami_id"`
+	SpotPrice            string                `json:"spot_price"`
+	MixedInstancesPolicy *MixedInstancesPolicy `json:"mixed_instances_policy"`
+	LogSinks             []LogSink             `json:"log_sinks"`
 }
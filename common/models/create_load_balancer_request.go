@@ -8,4 +8,5 @@ type CreateLoadBalancerRequest struct {
 	HealthCheck      HealthCheck `json:"health_check"`
 	IdleTimeout      int         `json:"idle_timeout"`
 	CrossZone        bool        `json:"cross_zone"`
+	DNSName          string      `json:"dns_name"`
 }
@@ -1,12 +1,18 @@
 package models
 
 type Environment struct {
-	EnvironmentID   string   `json:"environment_id"`
-	EnvironmentName string   `json:"environment_name"`
-	ClusterCount    int      `json:"cluster_count"`
-	InstanceSize    string   `json:"instance_size"`
-	SecurityGroupID string   `json:"security_group_id"`
-	OperatingSystem string   `json:"operating_system"`
-	AMIID           string   `json:"ami_id"`
-	Links           []string `json:"links"`
+	EnvironmentID        string                   `json:"environment_id"`
+	EnvironmentName      string                   `json:"environment_name"`
+	ClusterCount         int                      `json:"cluster_count"`
+	InstanceSize         string                   `json:"instance_size"`
+	SecurityGroupID      string                   `json:"security_group_id"`
+	OperatingSystem      string                   `json:"operating_system"`
+	AMIID                string                   `json:"ami_id"`
+	Links                []string                 `json:"links"`
+	LinkDetails          []EnvironmentLink        `json:"link_details"`
+	SpotPrice            string                   `json:"spot_price"`
+	MixedInstancesPolicy *MixedInstancesPolicy    `json:"mixed_instances_policy"`
+	LogSinks             []LogSink                `json:"log_sinks"`
+	IngressRules         []EnvironmentIngressRule `json:"ingress_rules"`
+	Tags                 map[string]string        `json:"tags"`
 }
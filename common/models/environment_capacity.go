@@ -0,0 +1,24 @@
+package models
+
+type EnvironmentCapacity struct {
+	EnvironmentID        string             `json:"environment_id"`
+	Instances            []InstanceCapacity `json:"instances"`
+	PendingResources     []ResourceConsumer `json:"pending_resources"`
+	TotalMemoryMiB       int64              `json:"total_memory_mib"`
+	UsedMemoryMiB        int64              `json:"used_memory_mib"`
+	TotalCPU             int                `json:"total_cpu"`
+	UsedCPU              int                `json:"used_cpu"`
+	ReclaimableInstances int                `json:"reclaimable_instances"`
+	ReclaimableFraction  float64            `json:"reclaimable_fraction"`
+}
+
+type InstanceCapacity struct {
+	InstanceID     string `json:"instance_id"`
+	InUse          bool   `json:"in_use"`
+	Reclaimable    bool   `json:"reclaimable"`
+	TotalMemoryMiB int64  `json:"total_memory_mib"`
+	UsedMemoryMiB  int64  `json:"used_memory_mib"`
+	TotalCPU       int    `json:"total_cpu"`
+	UsedCPU        int    `json:"used_cpu"`
+	UsedPorts      []int  `json:"used_ports"`
+}
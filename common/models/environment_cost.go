@@ -0,0 +1,28 @@
+package models
+
+type EnvironmentCost struct {
+	EnvironmentID           string        `json:"environment_id"`
+	EnvironmentName         string        `json:"environment_name"`
+	Currency                string        `json:"currency"`
+	InstanceSize            string        `json:"instance_size"`
+	InstanceCount           int           `json:"instance_count"`
+	InstanceMonthlyCost     float64       `json:"instance_monthly_cost"`
+	LoadBalancerCount       int           `json:"load_balancer_count"`
+	LoadBalancerMonthlyCost float64       `json:"load_balancer_monthly_cost"`
+	DataTransferGB          float64       `json:"data_transfer_gb"`
+	DataTransferMonthlyCost float64       `json:"data_transfer_monthly_cost"`
+	UnallocatedMonthlyCost  float64       `json:"unallocated_monthly_cost"`
+	TotalMonthlyCost        float64       `json:"total_monthly_cost"`
+	Services                []ServiceCost `json:"services"`
+	Warnings                []string      `json:"warnings"`
+}
+
+type ServiceCost struct {
+	ServiceID               string  `json:"service_id"`
+	ServiceName             string  `json:"service_name"`
+	MemoryMiB               int64   `json:"memory_mib"`
+	MemoryShare             float64 `json:"memory_share"`
+	InstanceMonthlyCost     float64 `json:"instance_monthly_cost"`
+	LoadBalancerMonthlyCost float64 `json:"load_balancer_monthly_cost"`
+	TotalMonthlyCost        float64 `json:"total_monthly_cost"`
+}
@@ -0,0 +1,11 @@
+package models
+
+// EnvironmentIngressRule allows traffic to an environment's instances from a CIDR block or from
+// a security group outside of Layer0. Exactly one of CIDR or SecurityGroupID is set.
+type EnvironmentIngressRule struct {
+	CIDR            string `json:"cidr"`
+	SecurityGroupID string `json:"security_group_id"`
+	Protocol        string `json:"protocol"`
+	FromPort        int    `json:"from_port"`
+	ToPort          int    `json:"to_port"`
+}
@@ -0,0 +1,17 @@
+package models
+
+// EnvironmentLink allows traffic from the source environment to the destination environment.
+// Unless the link is one-way, traffic is also allowed from the destination back to the source.
+// A link without ports allows traffic on all ports and protocols.
+type EnvironmentLink struct {
+	SourceEnvironmentID string                `json:"source_environment_id"`
+	DestEnvironmentID   string                `json:"dest_environment_id"`
+	Ports               []EnvironmentLinkPort `json:"ports"`
+	OneWay              bool                  `json:"one_way"`
+}
+
+type EnvironmentLinkPort struct {
+	Protocol string `json:"protocol"`
+	FromPort int    `json:"from_port"`
+	ToPort   int    `json:"to_port"`
+}
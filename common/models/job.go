@@ -13,4 +13,8 @@ type Job struct {
 	TimeCreated time.Time         `json:"time_created"`
 	TimeToExist int64             `json:"time_to_exist"`
 	Meta        map[string]string `json:"meta"`
+	CurrentStep string            `json:"current_step"`
+	Steps       []JobStep         `json:"steps"`
+	Checkpoint  int64             `json:"checkpoint"`
+	Attempts    int64             `json:"attempts"`
 }
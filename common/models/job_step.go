@@ -0,0 +1,14 @@
+package models
+
+import (
+	"time"
+)
+
+type JobStep struct {
+	Name         string    `json:"name"`
+	TimeStarted  time.Time `json:"time_started"`
+	TimeFinished time.Time `json:"time_finished"`
+	Error        string    `json:"error"`
+	Warnings     []string  `json:"warnings"`
+	Resumable    bool      `json:"resumable"`
+}
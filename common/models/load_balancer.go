@@ -1,16 +1,19 @@
 package models
 
 type LoadBalancer struct {
-	CrossZone        bool        `json:"cross_zone"`
-	EnvironmentID    string      `json:"environment_id"`
-	EnvironmentName  string      `json:"environment_name"`
-	HealthCheck      HealthCheck `json:"health_check"`
-	IdleTimeout      int         `json:"idle_timeout"`
-	IsPublic         bool        `json:"is_public"`
-	LoadBalancerID   string      `json:"load_balancer_id"`
-	LoadBalancerName string      `json:"load_balancer_name"`
-	Ports            []Port      `json:"ports"`
-	ServiceID        string      `json:"service_id"`
-	ServiceName      string      `json:"service_name"`
-	URL              string      `json:"url"`
+	AccessLog        AccessLog         `json:"access_log"`
+	CrossZone        bool              `json:"cross_zone"`
+	DNSName          string            `json:"dns_name"`
+	EnvironmentID    string            `json:"environment_id"`
+	EnvironmentName  string            `json:"environment_name"`
+	HealthCheck      HealthCheck       `json:"health_check"`
+	IdleTimeout      int               `json:"idle_timeout"`
+	IsPublic         bool              `json:"is_public"`
+	LoadBalancerID   string            `json:"load_balancer_id"`
+	LoadBalancerName string            `json:"load_balancer_name"`
+	Ports            []Port            `json:"ports"`
+	ServiceID        string            `json:"service_id"`
+	ServiceName      string            `json:"service_name"`
+	Tags             map[string]string `json:"tags"`
+	URL              string            `json:"url"`
 }
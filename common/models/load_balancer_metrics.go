@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+)
+
+type LoadBalancerMetrics struct {
+	LoadBalancerID     string                         `json:"load_balancer_id"`
+	StartTime          time.Time                      `json:"start_time"`
+	EndTime            time.Time                      `json:"end_time"`
+	Period             int                            `json:"period"`
+	RequestCount       int64                          `json:"request_count"`
+	Latency            float64                        `json:"latency"`
+	HTTPCodeELB5XX     int64                          `json:"http_code_elb_5xx"`
+	HTTPCodeBackend5XX int64                          `json:"http_code_backend_5xx"`
+	Datapoints         []LoadBalancerMetricsDatapoint `json:"datapoints"`
+}
+
+type LoadBalancerMetricsDatapoint struct {
+	Timestamp          time.Time `json:"timestamp"`
+	RequestCount       int64     `json:"request_count"`
+	Latency            float64   `json:"latency"`
+	HTTPCodeELB5XX     int64     `json:"http_code_elb_5xx"`
+	HTTPCodeBackend5XX int64     `json:"http_code_backend_5xx"`
+}
@@ -0,0 +1,12 @@
+package models
+
+import (
+	"time"
+)
+
+type LogEvent struct {
+	ContainerName string    `json:"container_name"`
+	Message       string    `json:"message"`
+	TaskID        string    `json:"task_id"`
+	Timestamp     time.Time `json:"timestamp"`
+}
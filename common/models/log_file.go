@@ -1,6 +1,10 @@
 package models
 
+// LogFile holds the events of a single log stream. Lines holds the message of each event
+// and is kept for clients written before Events was added.
 type LogFile struct {
-	Lines []string `json:"lines"`
-	Name  string   `json:"name"`
+	Events []*LogEvent `json:"events"`
+	Lines  []string    `json:"lines"`
+	Name   string      `json:"name"`
+	TaskID string      `json:"task_id"`
 }
@@ -0,0 +1,6 @@
+package models
+
+type LogSink struct {
+	Type    string `json:"type"`
+	Address string `json:"address"`
+}
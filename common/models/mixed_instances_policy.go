@@ -0,0 +1,7 @@
+package models
+
+type MixedInstancesPolicy struct {
+	InstanceTypes               []string `json:"instance_types"`
+	OnDemandBaseCapacity        int      `json:"on_demand_base_capacity"`
+	OnDemandPercentageAboveBase int      `json:"on_demand_percentage_above_base"`
+}
@@ -1,9 +1,12 @@
 package models
 
 type Port struct {
-	CertificateName string `json:"certificate_name"`
-	CertificateARN  string `json:"certificate_arn"`
-	ContainerPort   int64  `json:"container_port"`
-	HostPort        int64  `json:"host_port"`
-	Protocol        string `json:"protocol"`
+	CertificateName      string   `json:"certificate_name"`
+	CertificateARN       string   `json:"certificate_arn"`
+	CertificateDomain    string   `json:"certificate_domain"`
+	ContainerPort        int64    `json:"container_port"`
+	HostPort             int64    `json:"host_port"`
+	Protocol             string   `json:"protocol"`
+	SourceCIDRs          []string `json:"source_cidrs"`
+	SourceSecurityGroups []string `json:"source_security_groups"`
 }
@@ -1,8 +1,46 @@
 package models
 
+import (
+	"strings"
+)
+
 type Tag struct {
 	EntityID   string `json:"entity_id"`
 	EntityType string `json:"entity_type"`
 	Key        string `json:"key"`
 	Value      string `json:"value"`
 }
+
+// reservedTagKeys are used by layer0 to track the state of its entities
+var reservedTagKeys = map[string]bool{
+	"arn":              true,
+	"deploy_id":        true,
+	"dns_name":         true,
+	"environment_id":   true,
+	"link":             true,
+	"load_balancer_id": true,
+	"log_sink":         true,
+	"log_sink_source":  true,
+	"name":             true,
+	"os":               true,
+	"task_id":          true,
+	"version":          true,
+}
+
+var reservedTagKeyPrefixes = []string{"link:", "ingress:", "log_sink_deploy:"}
+
+// IsReservedTagKey returns true if the key is used internally by layer0;
+// users cannot create or delete tags with reserved keys
+func IsReservedTagKey(key string) bool {
+	if reservedTagKeys[key] {
+		return true
+	}
+
+	for _, prefix := range reservedTagKeyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -64,6 +64,25 @@ func (t Tags) Any(f filter) bool {
 	return false
 }
 
+// UserDefined returns the keys and values of the tags that are not reserved by layer0,
+// or nil if there are none
+func (t Tags) UserDefined() map[string]string {
+	var userDefined map[string]string
+	for _, tag := range t {
+		if IsReservedTagKey(tag.Key) {
+			continue
+		}
+
+		if userDefined == nil {
+			userDefined = map[string]string{}
+		}
+
+		userDefined[tag.Key] = tag.Value
+	}
+
+	return userDefined
+}
+
 func (t Tags) GroupByEntity() EntitiesWithTags {
 	catalog := map[string]Tags{}
 
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func TestTagsUserDefined(t *testing.T) {
+	tags := Tags{
+		{EntityID: "d1", EntityType: "deploy", Key: "name", Value: "dpl"},
+		{EntityID: "d1", EntityType: "deploy", Key: "version", Value: "1"},
+		{EntityID: "d1", EntityType: "deploy", Key: "link:svc", Value: "s1"},
+		{EntityID: "d1", EntityType: "deploy", Key: "log_sink_deploy:abc123", Value: "d2"},
+		{EntityID: "d1", EntityType: "deploy", Key: "log_sink_source", Value: "d0"},
+		{EntityID: "d1", EntityType: "deploy", Key: "team", Value: "platform"},
+	}
+
+	testutils.AssertEqual(t, tags.UserDefined(), map[string]string{"team": "platform"})
+}
+
+func TestTagsUserDefined_empty(t *testing.T) {
+	tags := Tags{
+		{EntityID: "d1", EntityType: "deploy", Key: "log_sink_deploy:abc123", Value: "d2"},
+	}
+
+	testutils.AssertEqual(t, len(tags.UserDefined()), 0)
+}
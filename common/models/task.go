@@ -1,14 +1,15 @@
 package models
 
 type Task struct {
-	Copies          []TaskCopy `json:"copies"`
-	DeployID        string     `json:"deploy_id"`
-	DeployName      string     `json:"deploy_name"`
-	DeployVersion   string     `json:"deploy_version"`
-	EnvironmentID   string     `json:"environment_id"`
-	EnvironmentName string     `json:"environment_name"`
-	PendingCount    int64      `json:"pending_count"`
-	RunningCount    int64      `json:"running_count"`
-	TaskID          string     `json:"task_id"`
-	TaskName        string     `json:"task_name"`
+	Copies          []TaskCopy        `json:"copies"`
+	DeployID        string            `json:"deploy_id"`
+	DeployName      string            `json:"deploy_name"`
+	DeployVersion   string            `json:"deploy_version"`
+	EnvironmentID   string            `json:"environment_id"`
+	EnvironmentName string            `json:"environment_name"`
+	PendingCount    int64             `json:"pending_count"`
+	RunningCount    int64             `json:"running_count"`
+	Tags            map[string]string `json:"tags"`
+	TaskID          string            `json:"task_id"`
+	TaskName        string            `json:"task_name"`
 }
@@ -0,0 +1,5 @@
+package models
+
+type UpdateEnvironmentIngressRequest struct {
+	Rules []EnvironmentIngressRule `json:"rules"`
+}
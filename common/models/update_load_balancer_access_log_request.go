@@ -0,0 +1,5 @@
+package models
+
+type UpdateLoadBalancerAccessLogRequest struct {
+	AccessLog AccessLog `json:"access_log"`
+}
@@ -0,0 +1,6 @@
+package models
+
+type UpdateServiceJobRequest struct {
+	ServiceID string `json:"service_id"`
+	DeployID  string `json:"deploy_id"`
+}
@@ -0,0 +1,92 @@
+package paging
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+)
+
+// NextTokenHeader is the response header list routes use to return the token of the next page
+const NextTokenHeader = "X-Next-Token"
+
+// MaxLimit is the largest page size list routes accept
+const MaxLimit = 1000
+
+// DefaultLimit is the page size clients use when iterating over every page of a list route
+const DefaultLimit = 100
+
+// EncodeToken returns an opaque token for the page that starts after value
+func EncodeToken(value string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+// DecodeToken returns the value encoded in a token created by EncodeToken
+func DecodeToken(token string) (string, error) {
+	value, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", fmt.Errorf("Invalid next token '%s'", token)
+	}
+
+	return string(value), nil
+}
+
+// ValidateLimit returns an error if limit is not between 0 and MaxLimit; a limit of 0 means no limit
+func ValidateLimit(limit int) error {
+	if limit < 0 || limit > MaxLimit {
+		return fmt.Errorf("Limit must be between 0 and %d, where 0 means no limit", MaxLimit)
+	}
+
+	return nil
+}
+
+// Page sorts ids and returns at most limit of them, starting after the position encoded in nextToken.
+// The second return value is the token of the following page, or an empty string if there are no more ids.
+// Since the token holds the last id of the page rather than an offset, ids that are created or deleted
+// between requests do not cause other ids to be skipped or repeated.
+func Page(ids []string, limit int, nextToken string) ([]string, string, error) {
+	if err := ValidateLimit(limit); err != nil {
+		return nil, "", err
+	}
+
+	sorted := make([]string, len(ids))
+	copy(sorted, ids)
+	sort.Strings(sorted)
+
+	if nextToken != "" {
+		after, err := DecodeToken(nextToken)
+		if err != nil {
+			return nil, "", err
+		}
+
+		i := sort.Search(len(sorted), func(i int) bool { return sorted[i] > after })
+		sorted = sorted[i:]
+	}
+
+	if limit == 0 || len(sorted) <= limit {
+		return sorted, "", nil
+	}
+
+	page := sorted[:limit]
+	return page, EncodeToken(page[limit-1]), nil
+}
+
+// Pagef fetches the page of a list with the specified token and returns the token of the following page
+type Pagef func(nextToken string) (string, error)
+
+// IteratePages performs a do-while loop on a Pagef,
+// starting with an empty token until the returned token is empty or an error is returned
+func IteratePages(fn Pagef) error {
+	nextToken, err := fn("")
+	if err != nil {
+		return err
+	}
+
+	for nextToken != "" {
+		nextToken, err = fn(nextToken)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package paging
+
+import (
+	"testing"
+
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func TestPage(t *testing.T) {
+	ids := []string{"d", "b", "a", "e", "c"}
+
+	page, nextToken, err := Page(ids, 2, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, page, []string{"a", "b"})
+	testutils.AssertEqual(t, nextToken, EncodeToken("b"))
+
+	page, nextToken, err = Page(ids, 2, nextToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, page, []string{"c", "d"})
+
+	page, nextToken, err = Page(ids, 2, nextToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, page, []string{"e"})
+	testutils.AssertEqual(t, nextToken, "")
+}
+
+func TestPage_noLimit(t *testing.T) {
+	page, nextToken, err := Page([]string{"b", "a"}, 0, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, page, []string{"a", "b"})
+	testutils.AssertEqual(t, nextToken, "")
+}
+
+func TestPage_deletedCursor(t *testing.T) {
+	// the id in the token no longer exists, so the page should start at the next id
+	page, _, err := Page([]string{"a", "c", "d"}, 1, EncodeToken("b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, page, []string{"c"})
+}
+
+func TestPage_errors(t *testing.T) {
+	if _, _, err := Page(nil, -1, ""); err == nil {
+		t.Error("negative limit: error was unexpectedly nil")
+	}
+
+	if _, _, err := Page(nil, MaxLimit+1, ""); err == nil {
+		t.Error("large limit: error was unexpectedly nil")
+	}
+
+	if _, _, err := Page(nil, 1, "!!!"); err == nil {
+		t.Error("invalid token: error was unexpectedly nil")
+	}
+}
+
+func TestIteratePages(t *testing.T) {
+	ids := []string{"a", "b", "c", "d", "e"}
+
+	result := []string{}
+	fn := func(nextToken string) (string, error) {
+		page, nextToken, err := Page(ids, 2, nextToken)
+		if err != nil {
+			return "", err
+		}
+
+		result = append(result, page...)
+		return nextToken, nil
+	}
+
+	if err := IteratePages(fn); err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, result, ids)
+}
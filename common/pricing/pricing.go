@@ -0,0 +1,126 @@
+// Package pricing provides the prices used to estimate the monthly cost of Layer0 resources.
+// A default table of us-east-1 on-demand prices is bundled with Layer0; it can be replaced
+// with a json file using the LAYER0_PRICE_TABLE environment variable.
+package pricing
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// HoursPerMonth is the number of hours AWS uses when quoting monthly prices
+const HoursPerMonth = 730
+
+type PriceTable interface {
+	InstanceHourly(instanceType string) (float64, bool)
+	LoadBalancerHourly() float64
+	DataTransferPerGB() float64
+	// PriceRegion is the AWS region the prices apply to
+	PriceRegion() string
+}
+
+type StaticPriceTable struct {
+	Region          string             `json:"region"`
+	Instances       map[string]float64 `json:"instances"`
+	LoadBalancer    float64            `json:"load_balancer_hourly"`
+	DataTransferOut float64            `json:"data_transfer_per_gb"`
+}
+
+func (s *StaticPriceTable) InstanceHourly(instanceType string) (float64, bool) {
+	price, ok := s.Instances[instanceType]
+	return price, ok
+}
+
+func (s *StaticPriceTable) LoadBalancerHourly() float64 {
+	return s.LoadBalancer
+}
+
+func (s *StaticPriceTable) DataTransferPerGB() float64 {
+	return s.DataTransferOut
+}
+
+func (s *StaticPriceTable) PriceRegion() string {
+	return s.Region
+}
+
+// Load reads a price table from the json file at path
+func Load(path string) (*StaticPriceTable, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var table *StaticPriceTable
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, fmt.Errorf("Failed to decode price table '%s': %v", path, err)
+	}
+
+	if table == nil || len(table.Instances) == 0 {
+		return nil, fmt.Errorf("Price table '%s' does not contain any instance prices", path)
+	}
+
+	return table, nil
+}
+
+// DefaultPriceTable contains linux on-demand prices in us-east-1, in USD
+var DefaultPriceTable = &StaticPriceTable{
+	Region:          "us-east-1",
+	LoadBalancer:    0.025,
+	DataTransferOut: 0.09,
+	Instances: map[string]float64{
+		"t2.nano":     0.0058,
+		"t2.micro":    0.0116,
+		"t2.small":    0.023,
+		"t2.medium":   0.0464,
+		"t2.large":    0.0928,
+		"t2.xlarge":   0.1856,
+		"t2.2xlarge":  0.3712,
+		"t3.nano":     0.0052,
+		"t3.micro":    0.0104,
+		"t3.small":    0.0208,
+		"t3.medium":   0.0416,
+		"t3.large":    0.0832,
+		"t3.xlarge":   0.1664,
+		"t3.2xlarge":  0.3328,
+		"m3.medium":   0.067,
+		"m3.large":    0.133,
+		"m3.xlarge":   0.266,
+		"m3.2xlarge":  0.532,
+		"m4.large":    0.10,
+		"m4.xlarge":   0.20,
+		"m4.2xlarge":  0.40,
+		"m4.4xlarge":  0.80,
+		"m4.10xlarge": 2.00,
+		"m4.16xlarge": 3.20,
+		"m5.large":    0.096,
+		"m5.xlarge":   0.192,
+		"m5.2xlarge":  0.384,
+		"m5.4xlarge":  0.768,
+		"m5.12xlarge": 2.304,
+		"m5.24xlarge": 4.608,
+		"c4.large":    0.10,
+		"c4.xlarge":   0.199,
+		"c4.2xlarge":  0.398,
+		"c4.4xlarge":  0.796,
+		"c4.8xlarge":  1.591,
+		"c5.large":    0.085,
+		"c5.xlarge":   0.17,
+		"c5.2xlarge":  0.34,
+		"c5.4xlarge":  0.68,
+		"c5.9xlarge":  1.53,
+		"c5.18xlarge": 3.06,
+		"r4.large":    0.133,
+		"r4.xlarge":   0.266,
+		"r4.2xlarge":  0.532,
+		"r4.4xlarge":  1.064,
+		"r4.8xlarge":  2.128,
+		"r4.16xlarge": 4.256,
+		"r5.large":    0.126,
+		"r5.xlarge":   0.252,
+		"r5.2xlarge":  0.504,
+		"r5.4xlarge":  1.008,
+		"r5.12xlarge": 3.024,
+		"r5.24xlarge": 6.048,
+	},
+}
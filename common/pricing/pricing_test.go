@@ -0,0 +1,63 @@
+package pricing
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func TestLoad(t *testing.T) {
+	file, err := ioutil.TempFile("", "price_table")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+
+	body := `{"region": "eu-west-1", "instances": {"m5.large": 0.107}, "load_balancer_hourly": 0.028, "data_transfer_per_gb": 0.09}`
+	if _, err := file.WriteString(body); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	table, err := Load(file.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	price, ok := table.InstanceHourly("m5.large")
+	testutils.AssertEqual(t, ok, true)
+	testutils.AssertEqual(t, price, 0.107)
+
+	_, ok = table.InstanceHourly("t2.micro")
+	testutils.AssertEqual(t, ok, false)
+
+	testutils.AssertEqual(t, table.LoadBalancerHourly(), 0.028)
+	testutils.AssertEqual(t, table.DataTransferPerGB(), 0.09)
+	testutils.AssertEqual(t, table.PriceRegion(), "eu-west-1")
+}
+
+func TestLoadErrors(t *testing.T) {
+	file, err := ioutil.TempFile("", "price_table")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.WriteString(`{"region": "us-east-1"}`); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	cases := map[string]string{
+		"Missing file":       file.Name() + ".missing",
+		"No instance prices": file.Name(),
+	}
+
+	for name, path := range cases {
+		if _, err := Load(path); err == nil {
+			t.Errorf("%s: error was nil!", name)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package ratelimit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/quintilesims/layer0/common/waitutils"
+)
+
+var rateLimitWaitSeconds = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "layer0_aws_rate_limit_wait_seconds_total",
+		Help: "Total time AWS calls spent waiting on a rate limiter by limiter key",
+	},
+	[]string{"limiter"})
+
+func init() {
+	prometheus.MustRegister(rateLimitWaitSeconds)
+}
+
+// Limit is a sustained rate, in requests per second, and the number of requests allowed in a burst
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// ParseLimits parses a comma-separated list of limits in the form 'key=rate[:burst]', e.g.
+// 'ecs=20:40,logs.DescribeLogStreams=5'. Keys are AWS service names, optionally followed by an API action.
+func ParseLimits(s string) (map[string]Limit, error) {
+	limits := map[string]Limit{}
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		split := strings.SplitN(entry, "=", 2)
+		if len(split) != 2 || split[0] == "" {
+			return nil, fmt.Errorf("Invalid rate limit '%s': expected format 'key=rate[:burst]'", entry)
+		}
+
+		limit, err := ParseLimit(split[1])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid rate limit '%s': %v", entry, err)
+		}
+
+		limits[split[0]] = limit
+	}
+
+	return limits, nil
+}
+
+// ParseLimit parses a limit in the form 'rate[:burst]'; the burst defaults to 1
+func ParseLimit(s string) (Limit, error) {
+	split := strings.SplitN(s, ":", 2)
+
+	rate, err := strconv.ParseFloat(split[0], 64)
+	if err != nil || rate <= 0 {
+		return Limit{}, fmt.Errorf("rate '%s' must be a positive number", split[0])
+	}
+
+	limit := Limit{Rate: rate, Burst: 1}
+	if len(split) == 2 {
+		burst, err := strconv.Atoi(split[1])
+		if err != nil || burst < 1 {
+			return Limit{}, fmt.Errorf("burst '%s' must be a positive integer", split[1])
+		}
+
+		limit.Burst = burst
+	}
+
+	return limit, nil
+}
+
+// TokenBucket allows Rate requests per second on average, and up to Burst requests at once
+type TokenBucket struct {
+	limit  Limit
+	clock  waitutils.Clock
+	mutex  sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func NewTokenBucket(limit Limit, clock waitutils.Clock) *TokenBucket {
+	return &TokenBucket{
+		limit:  limit,
+		clock:  clock,
+		tokens: float64(limit.Burst),
+		last:   clock.Now(),
+	}
+}
+
+// Reserve takes a token from the bucket and returns how long the caller must wait before using it
+func (t *TokenBucket) Reserve() time.Duration {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	now := t.clock.Now()
+	if elapsed := now.Sub(t.last); elapsed > 0 {
+		t.tokens += elapsed.Seconds() * t.limit.Rate
+		if max := float64(t.limit.Burst); t.tokens > max {
+			t.tokens = max
+		}
+
+		t.last = now
+	}
+
+	// tokens may go negative; each reservation queues behind the ones before it
+	t.tokens--
+	if t.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-t.tokens / t.limit.Rate * float64(time.Second))
+}
+
+// Limiter holds a token bucket per AWS service, and per API action for actions
+// with a limit of their own. Services without a configured limit use the default.
+type Limiter struct {
+	Default Limit
+	Limits  map[string]Limit
+	Clock   waitutils.Clock
+	mutex   sync.Mutex
+	buckets map[string]*TokenBucket
+}
+
+func NewLimiter(defaultLimit Limit, limits map[string]Limit) *Limiter {
+	return &Limiter{
+		Default: defaultLimit,
+		Limits:  limits,
+		Clock:   waitutils.RealClock{},
+	}
+}
+
+// Wait blocks until a call to the specified service and action is allowed
+func (l *Limiter) Wait(service, action string) {
+	key, bucket := l.bucket(service, action)
+	if delay := bucket.Reserve(); delay > 0 {
+		rateLimitWaitSeconds.WithLabelValues(key).Add(delay.Seconds())
+		l.Clock.Sleep(delay)
+	}
+}
+
+func (l *Limiter) bucket(service, action string) (string, *TokenBucket) {
+	key := service
+	limit, ok := l.Limits[service+"."+action]
+	if ok {
+		key = service + "." + action
+	} else if limit, ok = l.Limits[service]; !ok {
+		limit = l.Default
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.buckets == nil {
+		l.buckets = map[string]*TokenBucket{}
+	}
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = NewTokenBucket(limit, l.Clock)
+		l.buckets[key] = bucket
+	}
+
+	return key, bucket
+}
@@ -0,0 +1,103 @@
+package ratelimit
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type fixedClock struct {
+	time  time.Time
+	slept time.Duration
+}
+
+func (c *fixedClock) Now() time.Time {
+	return c.time
+}
+
+func (c *fixedClock) Since(t time.Time) time.Duration {
+	return c.time.Sub(t)
+}
+
+func (c *fixedClock) Sleep(d time.Duration) {
+	c.slept += d
+}
+
+func TestParseLimits(t *testing.T) {
+	limits, err := ParseLimits(" ecs=20:40, logs.DescribeLogStreams=5,,ec2=0.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]Limit{
+		"ecs":                     {Rate: 20, Burst: 40},
+		"logs.DescribeLogStreams": {Rate: 5, Burst: 1},
+		"ec2":                     {Rate: 0.5, Burst: 1},
+	}
+
+	if !reflect.DeepEqual(limits, expected) {
+		t.Errorf("Limits were %v, expected %v", limits, expected)
+	}
+}
+
+func TestParseLimits_errors(t *testing.T) {
+	for _, s := range []string{"ecs", "=5", "ecs=", "ecs=abc", "ecs=-1", "ecs=5:0", "ecs=5:x"} {
+		if _, err := ParseLimits(s); err == nil {
+			t.Errorf("Error was unexpectedly nil for '%s'", s)
+		}
+	}
+}
+
+func TestTokenBucketReserve(t *testing.T) {
+	clock := &fixedClock{time: time.Now()}
+	bucket := NewTokenBucket(Limit{Rate: 10, Burst: 2}, clock)
+
+	expected := []time.Duration{0, 0, time.Millisecond * 100, time.Millisecond * 200}
+	for i, e := range expected {
+		if delay := bucket.Reserve(); delay != e {
+			t.Errorf("Reservation %d: delay was %v, expected %v", i, delay, e)
+		}
+	}
+
+	// after a second the bucket refills, but never beyond the burst
+	clock.time = clock.time.Add(time.Second)
+	expected = []time.Duration{0, 0, time.Millisecond * 100}
+	for i, e := range expected {
+		if delay := bucket.Reserve(); delay != e {
+			t.Errorf("Reservation %d after refill: delay was %v, expected %v", i, delay, e)
+		}
+	}
+}
+
+func TestLimiterWait(t *testing.T) {
+	clock := &fixedClock{time: time.Now()}
+	limiter := NewLimiter(Limit{Rate: 1, Burst: 1}, map[string]Limit{
+		"ecs":                     {Rate: 10, Burst: 1},
+		"logs.DescribeLogStreams": {Rate: 2, Burst: 1},
+	})
+	limiter.Clock = clock
+
+	waits := []struct {
+		Service  string
+		Action   string
+		Expected time.Duration
+	}{
+		{"ecs", "UpdateService", 0},
+		{"ecs", "DescribeServices", time.Millisecond * 100},
+		{"ec2", "DescribeInstances", 0},
+		{"ec2", "DescribeInstances", time.Second},
+		{"logs", "DescribeLogStreams", 0},
+		{"logs", "DescribeLogStreams", time.Millisecond * 500},
+		// actions without their own limit share the service's default bucket
+		{"logs", "GetLogEvents", 0},
+		{"logs", "GetLogEvents", time.Second},
+	}
+
+	for _, w := range waits {
+		clock.slept = 0
+		limiter.Wait(w.Service, w.Action)
+		if clock.slept != w.Expected {
+			t.Errorf("%s.%s: waited %v, expected %v", w.Service, w.Action, clock.slept, w.Expected)
+		}
+	}
+}
@@ -1,30 +1,42 @@
 package startup
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/quintilesims/layer0/api/backend"
+	"github.com/quintilesims/layer0/api/backend/cache"
 	"github.com/quintilesims/layer0/api/backend/ecs"
 	"github.com/quintilesims/layer0/api/logic"
 	"github.com/quintilesims/layer0/api/scheduler"
+	"github.com/quintilesims/layer0/common/aws/acm"
 	"github.com/quintilesims/layer0/common/aws/autoscaling"
+	"github.com/quintilesims/layer0/common/aws/cloudwatch"
 	"github.com/quintilesims/layer0/common/aws/cloudwatchlogs"
 	"github.com/quintilesims/layer0/common/aws/ec2"
 	"github.com/quintilesims/layer0/common/aws/ecs"
 	"github.com/quintilesims/layer0/common/aws/elb"
 	"github.com/quintilesims/layer0/common/aws/iam"
 	"github.com/quintilesims/layer0/common/aws/provider"
+	"github.com/quintilesims/layer0/common/aws/route53"
 	"github.com/quintilesims/layer0/common/aws/s3"
+	"github.com/quintilesims/layer0/common/aws/sts"
 	"github.com/quintilesims/layer0/common/config"
 	"github.com/quintilesims/layer0/common/db/job_store"
 	"github.com/quintilesims/layer0/common/db/tag_store"
 	"github.com/quintilesims/layer0/common/decorators"
+	"github.com/quintilesims/layer0/common/ratelimit"
 	"github.com/quintilesims/layer0/common/waitutils"
 )
 
 func GetBackend(credProvider provider.CredProvider, region string) (*ecsbackend.ECSBackend, error) {
+	retryPolicy, err := decorators.NewRetryPolicyFromConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	s3Provider, err := s3.NewS3(credProvider, region)
 	if err != nil {
 		return nil, err
@@ -50,54 +62,64 @@ func GetBackend(credProvider provider.CredProvider, region string) (*ecsbackend.
 		return nil, err
 	}
 
-	tagStore, err := getNewTagStore()
+	cloudWatchProvider, err := cloudwatch.NewCloudWatch(credProvider, region)
 	if err != nil {
 		return nil, err
 	}
 
-	ec2Provider = wrapEC2(ec2Provider)
-	elbProvider = wrapELB(elbProvider)
-	cloudWatchLogsProvider = wrapCloudWatchLogs(cloudWatchLogsProvider)
+	route53Provider, err := route53.NewRoute53(credProvider, region)
+	if err != nil {
+		return nil, err
+	}
 
-	ecsProvider, err := GetECS(credProvider, region)
+	acmProvider, err := acm.NewACM(credProvider, region)
 	if err != nil {
 		return nil, err
 	}
 
-	autoscalingProvider, err := GetAutoscaling(credProvider, region)
+	ecsProvider, err := ecs.NewECS(credProvider, region)
+	if err != nil {
+		return nil, err
+	}
+
+	autoscalingProvider, err := autoscaling.NewAutoScaling(credProvider, region)
+	if err != nil {
+		return nil, err
+	}
+
+	tagStore, err := getNewTagStore()
 	if err != nil {
 		return nil, err
 	}
 
 	backend := ecsbackend.NewBackend(
 		tagStore,
-		s3Provider,
-		iamProvider,
-		ec2Provider,
-		ecsProvider,
-		elbProvider,
-		autoscalingProvider,
-		cloudWatchLogsProvider)
+		wrapS3(s3Provider, retryPolicy),
+		wrapIAM(iamProvider, retryPolicy),
+		wrapEC2(ec2Provider, retryPolicy),
+		wrapECS(ecsProvider, retryPolicy),
+		wrapELB(elbProvider, retryPolicy),
+		wrapAutoscaling(autoscalingProvider, retryPolicy),
+		wrapCloudWatchLogs(cloudWatchLogsProvider, retryPolicy),
+		wrapCloudWatch(cloudWatchProvider, retryPolicy),
+		wrapRoute53(route53Provider, retryPolicy),
+		wrapACM(acmProvider, retryPolicy))
 
 	return backend, nil
 }
 
-func GetECS(credProvider provider.CredProvider, region string) (ecs.Provider, error) {
-	ecsProvider, err := ecs.NewECS(credProvider, region)
+func GetSTS(credProvider provider.CredProvider, region string) (sts.Provider, error) {
+	retryPolicy, err := decorators.NewRetryPolicyFromConfig()
 	if err != nil {
 		return nil, err
 	}
 
-	return wrapECS(ecsProvider), nil
-}
-
-func GetAutoscaling(credProvider provider.CredProvider, region string) (autoscaling.Provider, error) {
-	autoscalingProvider, err := autoscaling.NewAutoScaling(credProvider, region)
+	stsProvider, err := sts.NewSTS(credProvider, region)
 	if err != nil {
 		return nil, err
 	}
 
-	return wrapAutoscaling(autoscalingProvider), nil
+	return wrapSTS(stsProvider, retryPolicy), nil
 }
 
 func GetLogic(backend *ecsbackend.ECSBackend) (*logic.Logic, error) {
@@ -111,7 +133,12 @@ func GetLogic(backend *ecsbackend.ECSBackend) (*logic.Logic, error) {
 		return nil, err
 	}
 
-	lgc := logic.NewLogic(tagStore, jobStore, backend, nil)
+	cachedBackend, err := getCachedBackend(backend)
+	if err != nil {
+		return nil, err
+	}
+
+	lgc := logic.NewLogic(tagStore, jobStore, cachedBackend, nil)
 
 	deployLogic := logic.NewL0DeployLogic(*lgc)
 	serviceLogic := logic.NewL0ServiceLogic(*lgc)
@@ -126,12 +153,28 @@ func GetLogic(backend *ecsbackend.ECSBackend) (*logic.Logic, error) {
 	return lgc, nil
 }
 
+// getCachedBackend wraps the backend in a cache if LAYER0_BACKEND_CACHE_TTL is set
+func getCachedBackend(b backend.Backend) (backend.Backend, error) {
+	if config.BackendCacheTTL() == "" {
+		return b, nil
+	}
+
+	ttl, err := time.ParseDuration(config.BackendCacheTTL())
+	if err != nil {
+		return nil, fmt.Errorf("Invalid backend cache ttl: %v", err)
+	}
+
+	if ttl <= 0 {
+		return b, nil
+	}
+
+	return cache.NewCachedBackend(b, ttl), nil
+}
+
 func getNewTagStore() (tag_store.TagStore, error) {
 	creds := credentials.NewStaticCredentials(config.AWSAccessKey(), config.AWSSecretKey(), "")
 	session := session.New(config.GetAWSConfig(creds, config.AWSRegion()))
-	if err := sessionTimeDelay(session); err != nil {
-		return nil, err
-	}
+	session.Handlers.Send.PushBack(provider.WaitForRateLimit)
 
 	store := tag_store.NewDynamoTagStore(session, config.DynamoTagTableName())
 
@@ -145,9 +188,7 @@ func getNewTagStore() (tag_store.TagStore, error) {
 func getNewJobStore() (job_store.JobStore, error) {
 	creds := credentials.NewStaticCredentials(config.AWSAccessKey(), config.AWSSecretKey(), "")
 	session := session.New(config.GetAWSConfig(creds, config.AWSRegion()))
-	if err := sessionTimeDelay(session); err != nil {
-		return nil, err
-	}
+	session.Handlers.Send.PushBack(provider.WaitForRateLimit)
 
 	store := job_store.NewDynamoJobStore(session, config.DynamoJobTableName())
 
@@ -158,77 +199,292 @@ func getNewJobStore() (job_store.JobStore, error) {
 	return store, nil
 }
 
-func sessionTimeDelay(session *session.Session) error {
+// GetRateLimiter returns a rate limiter with a token bucket for each AWS service, and for each API action
+// with a limit of its own. Services without a configured limit allow one request per AWSTimeBetweenRequests.
+func GetRateLimiter() (*ratelimit.Limiter, error) {
 	delay, err := time.ParseDuration(config.AWSTimeBetweenRequests())
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	ticker := time.Tick(delay)
-	session.Handlers.Send.PushBack(func(r *request.Request) {
-		<-ticker
-	})
+	if delay <= 0 {
+		return nil, fmt.Errorf("Time between requests must be positive, got '%v'", delay)
+	}
 
-	return nil
+	configured, err := ratelimit.ParseLimits(config.AWSRateLimits())
+	if err != nil {
+		return nil, err
+	}
+
+	limits := map[string]ratelimit.Limit{}
+	for key, limit := range provider.DefaultActionLimits {
+		limits[key] = limit
+	}
+
+	for key, limit := range configured {
+		limits[key] = limit
+	}
+
+	defaultLimit := ratelimit.Limit{Rate: float64(time.Second) / float64(delay), Burst: 1}
+	return ratelimit.NewLimiter(defaultLimit, limits), nil
 }
 
-func wrapECS(e ecs.Provider) ecs.Provider {
-	retry := &decorators.Retry{
-		Clock: waitutils.RealClock{},
+func newRetry(provider string, policy decorators.RetryPolicy) *decorators.Retry {
+	return &decorators.Retry{
+		Clock:    waitutils.RealClock{},
+		Policy:   policy,
+		Provider: provider,
 	}
+}
 
-	wrap := &ecs.ProviderDecorator{
-		Inner:     e,
-		Decorator: retry.CallWithRetries,
+func wrapACM(p acm.Provider, policy decorators.RetryPolicy) acm.Provider {
+	metrics := &decorators.Metrics{
+		Provider: "acm",
+	}
+
+	wrap := &acm.ProviderDecorator{
+		Inner:     p,
+		Decorator: metrics.CallWithMetrics,
+	}
+
+	wrap = &acm.ProviderDecorator{
+		Inner:     wrap,
+		Decorator: decorators.CallWithLogging,
+	}
+
+	wrap = &acm.ProviderDecorator{
+		Inner:     wrap,
+		Decorator: newRetry("acm", policy).CallWithRetries,
 	}
 
 	return wrap
 }
 
-func wrapAutoscaling(a autoscaling.Provider) autoscaling.Provider {
-	retry := &decorators.Retry{
-		Clock: waitutils.RealClock{},
+func wrapAutoscaling(p autoscaling.Provider, policy decorators.RetryPolicy) autoscaling.Provider {
+	metrics := &decorators.Metrics{
+		Provider: "autoscaling",
 	}
 
 	wrap := &autoscaling.ProviderDecorator{
-		Inner:     a,
-		Decorator: retry.CallWithRetries,
+		Inner:     p,
+		Decorator: metrics.CallWithMetrics,
+	}
+
+	wrap = &autoscaling.ProviderDecorator{
+		Inner:     wrap,
+		Decorator: decorators.CallWithLogging,
+	}
+
+	wrap = &autoscaling.ProviderDecorator{
+		Inner:     wrap,
+		Decorator: newRetry("autoscaling", policy).CallWithRetries,
+	}
+
+	return wrap
+}
+
+func wrapCloudWatch(p cloudwatch.Provider, policy decorators.RetryPolicy) cloudwatch.Provider {
+	metrics := &decorators.Metrics{
+		Provider: "cloudwatch",
+	}
+
+	wrap := &cloudwatch.ProviderDecorator{
+		Inner:     p,
+		Decorator: metrics.CallWithMetrics,
+	}
+
+	wrap = &cloudwatch.ProviderDecorator{
+		Inner:     wrap,
+		Decorator: decorators.CallWithLogging,
+	}
+
+	wrap = &cloudwatch.ProviderDecorator{
+		Inner:     wrap,
+		Decorator: newRetry("cloudwatch", policy).CallWithRetries,
 	}
 
 	return wrap
 }
 
-func wrapEC2(e ec2.Provider) ec2.Provider {
+func wrapCloudWatchLogs(p cloudwatchlogs.Provider, policy decorators.RetryPolicy) cloudwatchlogs.Provider {
+	metrics := &decorators.Metrics{
+		Provider: "cloudwatchlogs",
+	}
+
+	wrap := &cloudwatchlogs.ProviderDecorator{
+		Inner:     p,
+		Decorator: metrics.CallWithMetrics,
+	}
+
+	wrap = &cloudwatchlogs.ProviderDecorator{
+		Inner:     wrap,
+		Decorator: decorators.CallWithLogging,
+	}
+
+	wrap = &cloudwatchlogs.ProviderDecorator{
+		Inner:     wrap,
+		Decorator: newRetry("cloudwatchlogs", policy).CallWithRetries,
+	}
+
+	return wrap
+}
+
+func wrapEC2(p ec2.Provider, policy decorators.RetryPolicy) ec2.Provider {
+	metrics := &decorators.Metrics{
+		Provider: "ec2",
+	}
+
 	wrap := &ec2.ProviderDecorator{
-		Inner:     e,
+		Inner:     p,
+		Decorator: metrics.CallWithMetrics,
+	}
+
+	wrap = &ec2.ProviderDecorator{
+		Inner:     wrap,
+		Decorator: decorators.CallWithLogging,
+	}
+
+	wrap = &ec2.ProviderDecorator{
+		Inner:     wrap,
+		Decorator: newRetry("ec2", policy).CallWithRetries,
+	}
+
+	return wrap
+}
+
+func wrapECS(p ecs.Provider, policy decorators.RetryPolicy) ecs.Provider {
+	metrics := &decorators.Metrics{
+		Provider: "ecs",
+	}
+
+	wrap := &ecs.ProviderDecorator{
+		Inner:     p,
+		Decorator: metrics.CallWithMetrics,
+	}
+
+	wrap = &ecs.ProviderDecorator{
+		Inner:     wrap,
 		Decorator: decorators.CallWithLogging,
 	}
 
+	wrap = &ecs.ProviderDecorator{
+		Inner:     wrap,
+		Decorator: newRetry("ecs", policy).CallWithRetries,
+	}
+
 	return wrap
 }
 
-func wrapELB(e elb.Provider) elb.Provider {
+func wrapELB(p elb.Provider, policy decorators.RetryPolicy) elb.Provider {
+	metrics := &decorators.Metrics{
+		Provider: "elb",
+	}
+
 	wrap := &elb.ProviderDecorator{
-		Inner:     e,
+		Inner:     p,
+		Decorator: metrics.CallWithMetrics,
+	}
+
+	wrap = &elb.ProviderDecorator{
+		Inner:     wrap,
 		Decorator: decorators.CallWithLogging,
 	}
 
+	wrap = &elb.ProviderDecorator{
+		Inner:     wrap,
+		Decorator: newRetry("elb", policy).CallWithRetries,
+	}
+
 	return wrap
 }
 
-func wrapCloudWatchLogs(c cloudwatchlogs.Provider) cloudwatchlogs.Provider {
-	wrap := &cloudwatchlogs.ProviderDecorator{
-		Inner:     c,
+func wrapIAM(p iam.Provider, policy decorators.RetryPolicy) iam.Provider {
+	metrics := &decorators.Metrics{
+		Provider: "iam",
+	}
+
+	wrap := &iam.ProviderDecorator{
+		Inner:     p,
+		Decorator: metrics.CallWithMetrics,
+	}
+
+	wrap = &iam.ProviderDecorator{
+		Inner:     wrap,
 		Decorator: decorators.CallWithLogging,
 	}
 
-	retry := &decorators.Retry{
-		Clock: waitutils.RealClock{},
+	wrap = &iam.ProviderDecorator{
+		Inner:     wrap,
+		Decorator: newRetry("iam", policy).CallWithRetries,
 	}
 
-	wrap = &cloudwatchlogs.ProviderDecorator{
+	return wrap
+}
+
+func wrapRoute53(p route53.Provider, policy decorators.RetryPolicy) route53.Provider {
+	metrics := &decorators.Metrics{
+		Provider: "route53",
+	}
+
+	wrap := &route53.ProviderDecorator{
+		Inner:     p,
+		Decorator: metrics.CallWithMetrics,
+	}
+
+	wrap = &route53.ProviderDecorator{
+		Inner:     wrap,
+		Decorator: decorators.CallWithLogging,
+	}
+
+	wrap = &route53.ProviderDecorator{
+		Inner:     wrap,
+		Decorator: newRetry("route53", policy).CallWithRetries,
+	}
+
+	return wrap
+}
+
+func wrapS3(p s3.Provider, policy decorators.RetryPolicy) s3.Provider {
+	metrics := &decorators.Metrics{
+		Provider: "s3",
+	}
+
+	wrap := &s3.ProviderDecorator{
+		Inner:     p,
+		Decorator: metrics.CallWithMetrics,
+	}
+
+	wrap = &s3.ProviderDecorator{
+		Inner:     wrap,
+		Decorator: decorators.CallWithLogging,
+	}
+
+	wrap = &s3.ProviderDecorator{
+		Inner:     wrap,
+		Decorator: newRetry("s3", policy).CallWithRetries,
+	}
+
+	return wrap
+}
+
+func wrapSTS(p sts.Provider, policy decorators.RetryPolicy) sts.Provider {
+	metrics := &decorators.Metrics{
+		Provider: "sts",
+	}
+
+	wrap := &sts.ProviderDecorator{
+		Inner:     p,
+		Decorator: metrics.CallWithMetrics,
+	}
+
+	wrap = &sts.ProviderDecorator{
+		Inner:     wrap,
+		Decorator: decorators.CallWithLogging,
+	}
+
+	wrap = &sts.ProviderDecorator{
 		Inner:     wrap,
-		Decorator: retry.CallWithRetries,
+		Decorator: newRetry("sts", policy).CallWithRetries,
 	}
 
 	return wrap
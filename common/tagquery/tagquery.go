@@ -0,0 +1,314 @@
+package tagquery
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/quintilesims/layer0/common/models"
+)
+
+type Operator string
+
+const (
+	OperatorExists    Operator = "exists"
+	OperatorNotExists Operator = "!exists"
+	OperatorEqual     Operator = "="
+	OperatorNotEqual  Operator = "!="
+	OperatorMatch     Operator = "~"
+	OperatorNotMatch  Operator = "!~"
+	OperatorIn        Operator = "in"
+	OperatorNotIn     Operator = "notin"
+)
+
+// Condition is a single requirement on the tags of an entity.
+// Values of the '=' and '!=' operators may contain glob patterns (e.g. 'pay*').
+type Condition struct {
+	Key      string
+	Operator Operator
+	Values   []string
+	regex    *regexp.Regexp
+}
+
+// Query is a set of condition groups: an entity matches the query if it
+// matches every condition in at least one of the groups
+type Query struct {
+	Groups [][]Condition
+}
+
+// Parse parses a filter expression. Conditions are separated by ',' and must all match;
+// groups of conditions are separated by ';' and at least one group must match. Supported conditions are:
+//
+//	key             the entity has a tag with the key
+//	!key            the entity does not have a tag with the key
+//	key=value       the entity has a tag with the key and a value matching the glob pattern
+//	key!=value      the entity does not have a tag with the key and a value matching the glob pattern
+//	key~regex       the entity has a tag with the key and a value matching the regular expression
+//	key!~regex      the entity does not have a tag with the key and a value matching the regular expression
+//	key in (a,b)    the entity has a tag with the key and one of the values
+//	key notin (a,b) the entity does not have a tag with the key and one of the values
+func Parse(filter string) (*Query, error) {
+	query := &Query{}
+	for _, group := range split(filter, ';') {
+		conditions := []Condition{}
+		for _, expr := range split(group, ',') {
+			if strings.TrimSpace(expr) == "" {
+				continue
+			}
+
+			condition, err := ParseCondition(expr)
+			if err != nil {
+				return nil, err
+			}
+
+			conditions = append(conditions, condition)
+		}
+
+		if len(conditions) > 0 {
+			query.Groups = append(query.Groups, conditions)
+		}
+	}
+
+	return query, nil
+}
+
+// ParseCondition parses a single condition; see Parse for the supported syntax
+func ParseCondition(expr string) (Condition, error) {
+	expr = strings.TrimSpace(expr)
+
+	if match := listExpr.FindStringSubmatch(expr); match != nil {
+		values := []string{}
+		for _, value := range strings.Split(match[3], ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+
+		if len(values) == 0 {
+			return Condition{}, fmt.Errorf("Invalid condition '%s': list of values is empty", expr)
+		}
+
+		return Condition{Key: match[1], Operator: Operator(match[2]), Values: values}, nil
+	}
+
+	// the operator is the first '=', '!=', '~', or '!~' in the expression, so values may contain operators
+	if i := strings.IndexAny(expr, "=~!"); i > 0 {
+		op := Operator(expr[i : i+1])
+		if op == "!" {
+			if i+1 == len(expr) || (expr[i+1] != '=' && expr[i+1] != '~') {
+				return Condition{}, fmt.Errorf("Invalid condition '%s'", expr)
+			}
+
+			op = Operator(expr[i : i+2])
+		}
+
+		key := strings.TrimSpace(expr[:i])
+		value := strings.TrimSpace(expr[i+len(op):])
+		if !validKey(key) {
+			return Condition{}, fmt.Errorf("Invalid condition '%s': key '%s' is invalid", expr, key)
+		}
+
+		condition := Condition{Key: key, Operator: op, Values: []string{value}}
+		switch op {
+		case OperatorEqual, OperatorNotEqual:
+			if _, err := path.Match(value, ""); err != nil {
+				return Condition{}, fmt.Errorf("Invalid condition '%s': %v", expr, err)
+			}
+		case OperatorMatch, OperatorNotMatch:
+			regex, err := regexp.Compile(value)
+			if err != nil {
+				return Condition{}, fmt.Errorf("Invalid condition '%s': %v", expr, err)
+			}
+
+			condition.regex = regex
+		}
+
+		return condition, nil
+	}
+
+	if key := strings.TrimPrefix(expr, "!"); key != expr {
+		if !validKey(strings.TrimSpace(key)) {
+			return Condition{}, fmt.Errorf("Invalid condition '%s'", expr)
+		}
+
+		return Condition{Key: strings.TrimSpace(key), Operator: OperatorNotExists}, nil
+	}
+
+	if !validKey(expr) {
+		return Condition{}, fmt.Errorf("Invalid condition '%s'", expr)
+	}
+
+	return Condition{Key: expr, Operator: OperatorExists}, nil
+}
+
+var listExpr = regexp.MustCompile(`^([^\s=!~(),;]+)\s+(in|notin)\s*\((.*)\)$`)
+
+func validKey(key string) bool {
+	return key != "" && !strings.ContainsAny(key, " \t=!~(),;")
+}
+
+// split splits s on sep, ignoring separators inside of parentheses, brackets, and braces
+func split(s string, sep rune) []string {
+	parts := []string{}
+	depth := 0
+	start := 0
+	for i, r := range s {
+		switch {
+		case r == '(' || r == '[' || r == '{':
+			depth++
+		case (r == ')' || r == ']' || r == '}') && depth > 0:
+			depth--
+		case r == sep && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, s[start:])
+}
+
+// Match returns true if the entity matches the query; an empty query matches every entity
+func (q *Query) Match(ewt models.EntityWithTags) bool {
+	if len(q.Groups) == 0 {
+		return true
+	}
+
+	for _, group := range q.Groups {
+		if matchAll(group, ewt.Tags) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchAll(conditions []Condition, tags models.Tags) bool {
+	for _, condition := range conditions {
+		if !condition.Match(tags) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Match returns true if the tags meet the condition
+func (c Condition) Match(tags models.Tags) bool {
+	tags = tags.WithKey(c.Key)
+
+	switch c.Operator {
+	case OperatorExists:
+		return len(tags) > 0
+	case OperatorNotExists:
+		return len(tags) == 0
+	case OperatorNotEqual, OperatorNotMatch, OperatorNotIn:
+		return !tags.Any(c.matchValue)
+	default:
+		return tags.Any(c.matchValue)
+	}
+}
+
+func (c Condition) matchValue(tag models.Tag) bool {
+	switch c.Operator {
+	case OperatorEqual, OperatorNotEqual:
+		matched, _ := path.Match(c.Values[0], tag.Value)
+		return matched
+	case OperatorMatch, OperatorNotMatch:
+		return c.regex.MatchString(tag.Value)
+	case OperatorIn, OperatorNotIn:
+		for _, value := range c.Values {
+			if tag.Value == value {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Filter returns the entities that match the query
+func (q *Query) Filter(ewts models.EntitiesWithTags) models.EntitiesWithTags {
+	return ewts.RemoveIf(func(ewt models.EntityWithTags) bool {
+		return !q.Match(ewt)
+	})
+}
+
+// Sort sorts the entities by a comma-separated list of tag keys, e.g. 'name,-version'.
+// A '-' prefix sorts by the key in descending order, and the key 'id' sorts by entity id.
+// Values that are both integers are compared numerically; entities are always
+// sorted by id last so the order is stable across requests.
+func Sort(ewts models.EntitiesWithTags, keys string) error {
+	fields := []string{}
+	for _, key := range strings.Split(keys, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+
+		if !validKey(strings.TrimPrefix(key, "-")) {
+			return fmt.Errorf("Invalid sort key '%s'", key)
+		}
+
+		fields = append(fields, key)
+	}
+
+	fields = append(fields, "id")
+	sort.SliceStable(ewts, func(i, j int) bool {
+		for _, field := range fields {
+			key := strings.TrimPrefix(field, "-")
+			cmp := compare(sortValue(ewts[i], key), sortValue(ewts[j], key))
+			if cmp == 0 {
+				continue
+			}
+
+			if field != key {
+				return cmp > 0
+			}
+
+			return cmp < 0
+		}
+
+		return false
+	})
+
+	return nil
+}
+
+func sortValue(ewt *models.EntityWithTags, key string) string {
+	if key == "id" {
+		return ewt.EntityID
+	}
+
+	if tag, ok := ewt.Tags.WithKey(key).First(); ok {
+		return tag.Value
+	}
+
+	return ""
+}
+
+func compare(a, b string) int {
+	if x, err := strconv.Atoi(a); err == nil {
+		if y, err := strconv.Atoi(b); err == nil {
+			return x - y
+		}
+	}
+
+	return strings.Compare(a, b)
+}
+
+// Page returns at most limit entities starting at offset; a limit of 0 returns every remaining entity
+func Page(ewts models.EntitiesWithTags, offset, limit int) models.EntitiesWithTags {
+	if offset >= len(ewts) {
+		return models.EntitiesWithTags{}
+	}
+
+	ewts = ewts[offset:]
+	if limit > 0 && limit < len(ewts) {
+		ewts = ewts[:limit]
+	}
+
+	return ewts
+}
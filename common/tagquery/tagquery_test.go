@@ -0,0 +1,116 @@
+package tagquery
+
+import (
+	"testing"
+
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
+)
+
+func testEntities() models.EntitiesWithTags {
+	newEntity := func(id string, keyValues ...string) *models.EntityWithTags {
+		ewt := &models.EntityWithTags{EntityID: id, EntityType: "service", Tags: models.Tags{}}
+		for i := 0; i < len(keyValues); i += 2 {
+			tag := models.Tag{EntityID: id, EntityType: "service", Key: keyValues[i], Value: keyValues[i+1]}
+			ewt.Tags = append(ewt.Tags, tag)
+		}
+
+		return ewt
+	}
+
+	return models.EntitiesWithTags{
+		newEntity("s1", "name", "api", "team", "payments", "env", "prod", "version", "10"),
+		newEntity("s2", "name", "worker", "team", "payments", "env", "staging", "version", "9"),
+		newEntity("s3", "name", "web", "team", "core", "env", "prod"),
+		newEntity("s4", "name", "batch"),
+	}
+}
+
+func entityIDs(ewts models.EntitiesWithTags) []string {
+	ids := []string{}
+	for _, ewt := range ewts {
+		ids = append(ids, ewt.EntityID)
+	}
+
+	return ids
+}
+
+func TestQueryFilter(t *testing.T) {
+	cases := map[string][]string{
+		"":                               {"s1", "s2", "s3", "s4"},
+		"team=payments":                  {"s1", "s2"},
+		"team=payments,env!=prod":        {"s2"},
+		"env!=prod":                      {"s2", "s4"},
+		"team":                           {"s1", "s2", "s3"},
+		"!team":                          {"s4"},
+		"name=w*":                        {"s2", "s3"},
+		"name~^(api|web)$":               {"s1", "s3"},
+		"name!~^a":                       {"s2", "s3", "s4"},
+		"env in (staging, dev)":          {"s2"},
+		"env notin (prod)":               {"s2", "s4"},
+		"team=core;name=batch":           {"s3", "s4"},
+		"team=payments,env=prod;!env":    {"s1", "s4"},
+		"version~^[0-9]{1,2}$,team=pay*": {"s1", "s2"},
+	}
+
+	for filter, expected := range cases {
+		query, err := Parse(filter)
+		if err != nil {
+			t.Fatalf("%s: %v", filter, err)
+		}
+
+		result := query.Filter(testEntities())
+		testutils.AssertEqual(t, entityIDs(result), expected)
+	}
+}
+
+func TestParse_errors(t *testing.T) {
+	cases := []string{
+		"=value",
+		"key!value",
+		"team=[",
+		"name~(",
+		"env in ()",
+		"bad key=value",
+		"!",
+	}
+
+	for _, filter := range cases {
+		if _, err := Parse(filter); err == nil {
+			t.Errorf("%s: error was unexpectedly nil", filter)
+		}
+	}
+}
+
+func TestSort(t *testing.T) {
+	cases := map[string][]string{
+		"":              {"s1", "s2", "s3", "s4"},
+		"name":          {"s1", "s4", "s3", "s2"},
+		"-name":         {"s2", "s3", "s4", "s1"},
+		"version":       {"s3", "s4", "s2", "s1"},
+		"team,-version": {"s4", "s3", "s1", "s2"},
+		"-id":           {"s4", "s3", "s2", "s1"},
+	}
+
+	for keys, expected := range cases {
+		ewts := testEntities()
+		ewts[0], ewts[3] = ewts[3], ewts[0]
+
+		if err := Sort(ewts, keys); err != nil {
+			t.Fatalf("%s: %v", keys, err)
+		}
+
+		testutils.AssertEqual(t, entityIDs(ewts), expected)
+	}
+
+	if err := Sort(testEntities(), "-"); err == nil {
+		t.Fatal("error was unexpectedly nil")
+	}
+}
+
+func TestPage(t *testing.T) {
+	testutils.AssertEqual(t, entityIDs(Page(testEntities(), 0, 0)), []string{"s1", "s2", "s3", "s4"})
+	testutils.AssertEqual(t, entityIDs(Page(testEntities(), 1, 2)), []string{"s2", "s3"})
+	testutils.AssertEqual(t, entityIDs(Page(testEntities(), 3, 2)), []string{"s4"})
+	testutils.AssertEqual(t, entityIDs(Page(testEntities(), 4, 2)), []string{})
+}
@@ -24,6 +24,8 @@ func GetCLIContext(t *testing.T, args []string, flags map[string]interface{}) *c
 			flagSet.Var(&slice, key, "")
 		case int:
 			flagSet.Int(key, v, "")
+		case float64:
+			flagSet.Float64(key, v, "")
 		default:
 			t.Errorf("Cannot generate CLI context: unknown flag type for '%s'", key)
 		}
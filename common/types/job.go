@@ -11,6 +11,7 @@ const (
 	InProgress
 	Completed
 	Error
+	Cancelled
 )
 
 var jobStatusStrings = []string{
@@ -18,6 +19,7 @@ var jobStatusStrings = []string{
 	"in progress",
 	"completed",
 	"error",
+	"cancelled",
 }
 
 func (jobStatus JobStatus) String() string {
@@ -36,6 +38,10 @@ const (
 	DeleteLoadBalancerJob
 	DeleteTaskJob
 	CreateTaskJob
+	CreateEnvironmentJob
+	CreateLoadBalancerJob
+	CreateServiceJob
+	UpdateServiceJob
 )
 
 var jobTypeStrings = []string{
@@ -44,6 +50,10 @@ var jobTypeStrings = []string{
 	"delete load balancer",
 	"delete task",
 	"create task",
+	"create environment",
+	"create load balancer",
+	"create service",
+	"update service",
 }
 
 func (jobType JobType) String() string {
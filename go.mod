@@ -6,7 +6,7 @@ replace github.com/Sirupsen/logrus v1.5.0 => github.com/sirupsen/logrus v1.0.6
 
 require (
 	github.com/Sirupsen/logrus v1.5.0
-	github.com/aws/aws-sdk-go v1.30.4
+	github.com/aws/aws-sdk-go v1.44.108
 	github.com/blang/semver v3.5.1+incompatible
 	github.com/briandowns/spinner v1.10.0
 	github.com/dghubble/sling v1.3.0
@@ -20,6 +20,8 @@ require (
 	github.com/json-iterator/go v1.1.10 // indirect
 	github.com/onsi/ginkgo v1.14.0 // indirect
 	github.com/opencontainers/runc v0.1.1 // indirect
+	github.com/prometheus/client_golang v1.6.0
+	github.com/prometheus/client_model v0.2.0
 	github.com/quintilesims/sts v0.0.0-20170809211516-82d6e9731e72
 	github.com/quintilesims/tftest v0.0.0-20180108221958-70597d446846
 	github.com/ryanuber/columnize v2.1.0+incompatible
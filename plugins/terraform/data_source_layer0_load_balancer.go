@@ -33,6 +33,10 @@ func dataSourcelayer0LoadBalancer() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"dns_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"cross_zone": {
 				Type:     schema.TypeBool,
 				Computed: true,
@@ -65,6 +69,7 @@ func dataSourcelayer0LoadBalancerRead(d *schema.ResourceData, meta interface{})
 		"name":             loadbalancer.LoadBalancerName,
 		"private":          !loadbalancer.IsPublic,
 		"url":              loadbalancer.URL,
+		"dns_name":         loadbalancer.DNSName,
 		"environment_id":   loadbalancer.EnvironmentID,
 		"environment_name": loadbalancer.EnvironmentName,
 		"cross_zone":       loadbalancer.CrossZone,
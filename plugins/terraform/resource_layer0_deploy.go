@@ -11,6 +11,7 @@ func resourceLayer0Deploy() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceLayer0DeployCreate,
 		Read:   resourceLayer0DeployRead,
+		Update: resourceLayer0DeployUpdate,
 		Delete: resourceLayer0DeployDelete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
@@ -30,6 +31,7 @@ func resourceLayer0Deploy() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"tags": tagsSchema(),
 		},
 	}
 }
@@ -46,6 +48,10 @@ func resourceLayer0DeployCreate(d *schema.ResourceData, meta interface{}) error
 	}
 
 	d.SetId(deploy.DeployID)
+	if err := updateTags(client, d, "deploy"); err != nil {
+		return err
+	}
+
 	return resourceLayer0DeployRead(d, meta)
 }
 
@@ -66,6 +72,7 @@ func resourceLayer0DeployRead(d *schema.ResourceData, meta interface{}) error {
 
 	d.Set("name", deploy.DeployName)
 	d.Set("version", deploy.Version)
+	d.Set("tags", deploy.Tags)
 
 	// do not set content as it fails to properly diff against what's
 	// returned by the Layer0 API
@@ -75,6 +82,16 @@ func resourceLayer0DeployRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+func resourceLayer0DeployUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Layer0Client)
+
+	if err := updateTags(client, d, "deploy"); err != nil {
+		return err
+	}
+
+	return resourceLayer0DeployRead(d, meta)
+}
+
 func resourceLayer0DeployDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*Layer0Client)
 	deployID := d.Id()
@@ -3,7 +3,9 @@ package main
 import (
 	"testing"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
 	"github.com/quintilesims/layer0/common/models"
 )
 
@@ -66,3 +68,58 @@ func TestDeployDelete(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestDeployUpdate_tags(t *testing.T) {
+	ctrl, mockClient, provider := setupUnitTest(t)
+	defer ctrl.Finish()
+
+	mockClient.EXPECT().
+		DeleteTag("deploy", "did", "owner").
+		Return(nil)
+
+	mockClient.EXPECT().
+		CreateTag("deploy", "did", "team", "core").
+		Return(nil)
+
+	mockClient.EXPECT().
+		CreateTag("deploy", "did", "env", "prod").
+		Return(nil)
+
+	mockClient.EXPECT().
+		GetDeploy("did").
+		Return(&models.Deploy{DeployID: "did"}, nil)
+
+	deployResource := provider.ResourcesMap["layer0_deploy"]
+	state := &terraform.InstanceState{
+		ID: "did",
+		Attributes: map[string]string{
+			"name":       "test-dep",
+			"content":    "sample task definition",
+			"tags.%":     "2",
+			"tags.team":  "payments",
+			"tags.owner": "alice",
+		},
+	}
+
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"name":    "test-dep",
+		"content": "sample task definition",
+		"tags": map[string]interface{}{
+			"team": "core",
+			"env":  "prod",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := &Layer0Client{API: mockClient}
+	diff, err := deployResource.Diff(state, terraform.NewResourceConfig(raw), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := deployResource.Apply(state, diff, client); err != nil {
+		t.Fatal(err)
+	}
+}
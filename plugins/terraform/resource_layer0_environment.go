@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/quintilesims/layer0/common/errors"
+	"github.com/quintilesims/layer0/common/models"
 )
 
 func resourceLayer0Environment() *schema.Resource {
@@ -49,6 +50,58 @@ func resourceLayer0Environment() *schema.Resource {
 				ForceNew: true,
 				Computed: true,
 			},
+			"spot_price": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"mixed_instances_policy": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"instance_types": {
+							Type:     schema.TypeList,
+							Required: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"on_demand_base_capacity": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+							Default:  0,
+						},
+						"on_demand_percentage_above_base": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+							Default:  100,
+						},
+					},
+				},
+			},
+			"log_sink": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"address": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
 			"cluster_count": {
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -57,6 +110,7 @@ func resourceLayer0Environment() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"tags": tagsSchema(),
 		},
 	}
 }
@@ -70,13 +124,20 @@ func resourceLayer0EnvironmentCreate(d *schema.ResourceData, meta interface{}) e
 	userData := d.Get("user_data").(string)
 	os := d.Get("os").(string)
 	ami := d.Get("ami").(string)
+	spotPrice := d.Get("spot_price").(string)
+	mixedInstancesPolicy := expandMixedInstancesPolicy(d.Get("mixed_instances_policy"))
+	logSinks := expandLogSinks(d.Get("log_sink"))
 
-	environment, err := client.API.CreateEnvironment(name, size, minCount, []byte(userData), os, ami)
+	environment, err := client.API.CreateEnvironment(name, size, minCount, []byte(userData), os, ami, spotPrice, mixedInstancesPolicy, logSinks)
 	if err != nil {
 		return err
 	}
 
 	d.SetId(environment.EnvironmentID)
+	if err := updateTags(client, d, "environment"); err != nil {
+		return err
+	}
+
 	return resourceLayer0EnvironmentRead(d, meta)
 }
 
@@ -101,6 +162,10 @@ func resourceLayer0EnvironmentRead(d *schema.ResourceData, meta interface{}) err
 	d.Set("security_group_id", environment.SecurityGroupID)
 	d.Set("os", environment.OperatingSystem)
 	d.Set("ami", environment.AMIID)
+	d.Set("spot_price", environment.SpotPrice)
+	d.Set("mixed_instances_policy", flattenMixedInstancesPolicy(environment.MixedInstancesPolicy))
+	d.Set("log_sink", flattenLogSinks(environment.LogSinks))
+	d.Set("tags", environment.Tags)
 
 	return nil
 }
@@ -117,6 +182,10 @@ func resourceLayer0EnvironmentUpdate(d *schema.ResourceData, meta interface{}) e
 		}
 	}
 
+	if err := updateTags(client, d, "environment"); err != nil {
+		return err
+	}
+
 	return resourceLayer0EnvironmentRead(d, meta)
 }
 
@@ -139,3 +208,64 @@ func resourceLayer0EnvironmentDelete(d *schema.ResourceData, meta interface{}) e
 
 	return nil
 }
+
+func expandMixedInstancesPolicy(flattened interface{}) *models.MixedInstancesPolicy {
+	mip := flattened.([]interface{})
+
+	if len(mip) > 0 {
+		policy := mip[0].(map[string]interface{})
+
+		instanceTypes := []string{}
+		for _, instanceType := range policy["instance_types"].([]interface{}) {
+			instanceTypes = append(instanceTypes, instanceType.(string))
+		}
+
+		return &models.MixedInstancesPolicy{
+			InstanceTypes:               instanceTypes,
+			OnDemandBaseCapacity:        policy["on_demand_base_capacity"].(int),
+			OnDemandPercentageAboveBase: policy["on_demand_percentage_above_base"].(int),
+		}
+	}
+
+	return nil
+}
+
+func flattenMixedInstancesPolicy(mixedInstancesPolicy *models.MixedInstancesPolicy) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, 1)
+
+	if mixedInstancesPolicy != nil {
+		policy := make(map[string]interface{})
+		policy["instance_types"] = mixedInstancesPolicy.InstanceTypes
+		policy["on_demand_base_capacity"] = mixedInstancesPolicy.OnDemandBaseCapacity
+		policy["on_demand_percentage_above_base"] = mixedInstancesPolicy.OnDemandPercentageAboveBase
+
+		result = append(result, policy)
+	}
+
+	return result
+}
+
+func expandLogSinks(flattened interface{}) []models.LogSink {
+	var logSinks []models.LogSink
+	for _, raw := range flattened.([]interface{}) {
+		sink := raw.(map[string]interface{})
+		logSinks = append(logSinks, models.LogSink{
+			Type:    sink["type"].(string),
+			Address: sink["address"].(string),
+		})
+	}
+
+	return logSinks
+}
+
+func flattenLogSinks(logSinks []models.LogSink) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(logSinks))
+	for _, logSink := range logSinks {
+		result = append(result, map[string]interface{}{
+			"type":    logSink.Type,
+			"address": logSink.Address,
+		})
+	}
+
+	return result
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/quintilesims/layer0/common/models"
 )
 
 func resourceLayer0EnvironmentLink() *schema.Resource {
@@ -24,6 +25,37 @@ func resourceLayer0EnvironmentLink() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+			"port": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"protocol": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+							Default:  "tcp",
+						},
+						"from_port": {
+							Type:     schema.TypeInt,
+							Required: true,
+							ForceNew: true,
+						},
+						"to_port": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+			"one_way": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
 		},
 	}
 }
@@ -32,8 +64,10 @@ func resourceLayer0EnvironmentLinkCreate(d *schema.ResourceData, meta interface{
 	client := meta.(*Layer0Client)
 	sourceID := d.Get("source").(string)
 	destID := d.Get("dest").(string)
+	ports := expandEnvironmentLinkPorts(d.Get("port"))
+	oneWay := d.Get("one_way").(bool)
 
-	if err := client.API.CreateLink(sourceID, destID); err != nil {
+	if err := client.API.CreateLink(sourceID, destID, ports, oneWay); err != nil {
 		return err
 	}
 
@@ -78,3 +112,17 @@ func resourceLayer0EnvironmentLinkDelete(d *schema.ResourceData, meta interface{
 
 	return nil
 }
+
+func expandEnvironmentLinkPorts(flattened interface{}) []models.EnvironmentLinkPort {
+	var ports []models.EnvironmentLinkPort
+	for _, raw := range flattened.([]interface{}) {
+		port := raw.(map[string]interface{})
+		ports = append(ports, models.EnvironmentLinkPort{
+			Protocol: port["protocol"].(string),
+			FromPort: port["from_port"].(int),
+			ToPort:   port["to_port"].(int),
+		})
+	}
+
+	return ports
+}
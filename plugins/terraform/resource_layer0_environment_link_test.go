@@ -13,7 +13,7 @@ func TestEnvironmentLinkCreate(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockClient.EXPECT().
-		CreateLink("test-env", "test-env2").
+		CreateLink("test-env", "test-env2", nil, false).
 		Return(nil)
 
 	mockClient.EXPECT().
@@ -32,6 +32,40 @@ func TestEnvironmentLinkCreate(t *testing.T) {
 	}
 }
 
+func TestEnvironmentLinkCreate_ports(t *testing.T) {
+	ctrl, mockClient, provider := setupUnitTest(t)
+	defer ctrl.Finish()
+
+	ports := []models.EnvironmentLinkPort{
+		{Protocol: "tcp", FromPort: 5432},
+		{Protocol: "udp", FromPort: 8000, ToPort: 8100},
+	}
+
+	mockClient.EXPECT().
+		CreateLink("test-env", "test-env2", ports, true).
+		Return(nil)
+
+	mockClient.EXPECT().
+		GetEnvironment("test-env").
+		Return(&models.Environment{}, nil)
+
+	environmentResource := provider.ResourcesMap["layer0_environment_link"]
+	d := schema.TestResourceDataRaw(t, environmentResource.Schema, map[string]interface{}{
+		"source": "test-env",
+		"dest":   "test-env2",
+		"port": []interface{}{
+			map[string]interface{}{"from_port": 5432},
+			map[string]interface{}{"protocol": "udp", "from_port": 8000, "to_port": 8100},
+		},
+		"one_way": true,
+	})
+
+	client := &Layer0Client{API: mockClient}
+	if err := environmentResource.Create(d, client); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestEnvironmentLinkRead(t *testing.T) {
 	ctrl, mockClient, provider := setupUnitTest(t)
 	defer ctrl.Finish()
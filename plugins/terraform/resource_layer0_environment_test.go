@@ -7,6 +7,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
 )
 
 func TestEnvironmentCreate_defaults(t *testing.T) {
@@ -14,7 +15,7 @@ func TestEnvironmentCreate_defaults(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockClient.EXPECT().
-		CreateEnvironment("test-env", "m3.medium", 0, []byte(""), "linux", "").
+		CreateEnvironment("test-env", "m3.medium", 0, []byte(""), "linux", "", "", nil, nil).
 		Return(&models.Environment{EnvironmentID: "eid"}, nil)
 
 	mockClient.EXPECT().
@@ -36,8 +37,14 @@ func TestEnvironmentCreate_specifyOptional(t *testing.T) {
 	ctrl, mockClient, provider := setupUnitTest(t)
 	defer ctrl.Finish()
 
+	policy := &models.MixedInstancesPolicy{
+		InstanceTypes:               []string{"m3.large", "m4.large"},
+		OnDemandBaseCapacity:        1,
+		OnDemandPercentageAboveBase: 50,
+	}
+
 	mockClient.EXPECT().
-		CreateEnvironment("test-env", "m3.large", 2, []byte("user data"), "windows", "ami_id").
+		CreateEnvironment("test-env", "m3.large", 2, []byte("user data"), "windows", "ami_id", "0.05", policy, nil).
 		Return(&models.Environment{EnvironmentID: "eid"}, nil)
 
 	mockClient.EXPECT().
@@ -46,18 +53,61 @@ func TestEnvironmentCreate_specifyOptional(t *testing.T) {
 
 	environmentResource := provider.ResourcesMap["layer0_environment"]
 	d := schema.TestResourceDataRaw(t, environmentResource.Schema, map[string]interface{}{
-		"name":      "test-env",
-		"size":      "m3.large",
-		"min_count": 2,
-		"user_data": "user data",
-		"os":        "windows",
-		"ami":       "ami_id",
+		"name":       "test-env",
+		"size":       "m3.large",
+		"min_count":  2,
+		"user_data":  "user data",
+		"os":         "windows",
+		"ami":        "ami_id",
+		"spot_price": "0.05",
+		"mixed_instances_policy": []interface{}{
+			map[string]interface{}{
+				"instance_types":                  []interface{}{"m3.large", "m4.large"},
+				"on_demand_base_capacity":         1,
+				"on_demand_percentage_above_base": 50,
+			},
+		},
+	})
+
+	client := &Layer0Client{API: mockClient}
+	if err := environmentResource.Create(d, client); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEnvironmentCreate_logSinks(t *testing.T) {
+	ctrl, mockClient, provider := setupUnitTest(t)
+	defer ctrl.Finish()
+
+	logSinks := []models.LogSink{
+		{Type: "http", Address: "https://collector.example.com/ingest"},
+	}
+
+	mockClient.EXPECT().
+		CreateEnvironment("test-env", "m3.medium", 0, []byte(""), "linux", "", "", nil, logSinks).
+		Return(&models.Environment{EnvironmentID: "eid"}, nil)
+
+	mockClient.EXPECT().
+		GetEnvironment("eid").
+		Return(&models.Environment{LogSinks: logSinks}, nil)
+
+	environmentResource := provider.ResourcesMap["layer0_environment"]
+	d := schema.TestResourceDataRaw(t, environmentResource.Schema, map[string]interface{}{
+		"name": "test-env",
+		"log_sink": []interface{}{
+			map[string]interface{}{
+				"type":    "http",
+				"address": "https://collector.example.com/ingest",
+			},
+		},
 	})
 
 	client := &Layer0Client{API: mockClient}
 	if err := environmentResource.Create(d, client); err != nil {
 		t.Fatal(err)
 	}
+
+	testutils.AssertEqual(t, d.Get("log_sink.0.address"), "https://collector.example.com/ingest")
 }
 
 func TestEnvironmentRead(t *testing.T) {
@@ -84,7 +134,7 @@ func TestEnvironmentUpdate(t *testing.T) {
 
 	gomock.InOrder(
 		mockClient.EXPECT().
-			CreateEnvironment("test-env", "m3.medium", 0, []byte(""), "linux", "").
+			CreateEnvironment("test-env", "m3.medium", 0, []byte(""), "linux", "", "", nil, nil).
 			Return(&models.Environment{EnvironmentID: "eid"}, nil),
 
 		mockClient.EXPECT().
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform/helper/hashcode"
@@ -41,6 +42,11 @@ func resourceLayer0LoadBalancer() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"dns_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
 			"port": {
 				Type:     schema.TypeSet,
 				Required: true,
@@ -63,6 +69,20 @@ func resourceLayer0LoadBalancer() *schema.Resource {
 							Type:     schema.TypeString,
 							Optional: true,
 						},
+						"certificate_domain": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"source_cidrs": {
+							Type:     schema.TypeList,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Optional: true,
+						},
+						"source_security_groups": {
+							Type:     schema.TypeList,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Optional: true,
+						},
 					},
 				},
 			},
@@ -111,6 +131,7 @@ func resourceLayer0LoadBalancer() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			"tags": tagsSchema(),
 		},
 	}
 }
@@ -125,6 +146,7 @@ func resourceLayer0LoadBalancerCreate(d *schema.ResourceData, meta interface{})
 	healthCheck := expandHealthCheck(d.Get("health_check"))
 	idleTimeout := d.Get("idle_timeout").(int)
 	crossZone := d.Get("cross_zone").(bool)
+	dnsName := d.Get("dns_name").(string)
 
 	if healthCheck == nil {
 		healthCheck = &models.HealthCheck{
@@ -136,12 +158,16 @@ func resourceLayer0LoadBalancerCreate(d *schema.ResourceData, meta interface{})
 		}
 	}
 
-	loadBalancer, err := client.API.CreateLoadBalancer(name, environmentID, *healthCheck, ports, !private, idleTimeout, crossZone)
+	loadBalancer, err := client.API.CreateLoadBalancer(name, environmentID, *healthCheck, ports, !private, idleTimeout, crossZone, dnsName)
 	if err != nil {
 		return err
 	}
 
 	d.SetId(loadBalancer.LoadBalancerID)
+	if err := updateTags(client, d, "load_balancer"); err != nil {
+		return err
+	}
+
 	return resourceLayer0LoadBalancerRead(d, meta)
 }
 
@@ -164,10 +190,12 @@ func resourceLayer0LoadBalancerRead(d *schema.ResourceData, meta interface{}) er
 	d.Set("environment", loadBalancer.EnvironmentID)
 	d.Set("health_check", flattenHealthCheck(loadBalancer.HealthCheck))
 	d.Set("private", !loadBalancer.IsPublic)
-	d.Set("port", flattenPorts(loadBalancer.Ports))
+	d.Set("port", flattenPorts(preserveCertificateDomains(loadBalancer.Ports, d)))
 	d.Set("url", loadBalancer.URL)
+	d.Set("dns_name", loadBalancer.DNSName)
 	d.Set("idle_timeout", loadBalancer.IdleTimeout)
 	d.Set("cross_zone", loadBalancer.CrossZone)
+	d.Set("tags", loadBalancer.Tags)
 
 	return nil
 }
@@ -208,6 +236,10 @@ func resourceLayer0LoadBalancerUpdate(d *schema.ResourceData, meta interface{})
 		}
 	}
 
+	if err := updateTags(client, d, "load_balancer"); err != nil {
+		return err
+	}
+
 	return resourceLayer0LoadBalancerRead(d, meta)
 }
 
@@ -247,6 +279,18 @@ func resourceLayer0PortHash(v interface{}) int {
 		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
 	}
 
+	if v, ok := m["certificate_domain"]; ok && v.(string) != "" {
+		buf.WriteString(fmt.Sprintf("%s-", v.(string)))
+	}
+
+	for _, key := range []string{"source_cidrs", "source_security_groups"} {
+		if v, ok := m[key]; ok && len(v.([]interface{})) > 0 {
+			sources := expandStrings(v)
+			sort.Strings(sources)
+			buf.WriteString(fmt.Sprintf("%s-", strings.Join(sources, ",")))
+		}
+	}
+
 	return hashcode.String(buf.String())
 }
 
@@ -304,12 +348,33 @@ func expandPorts(flattened []interface{}) []models.Port {
 			}
 		}
 
+		if v, ok := data["certificate_domain"]; ok {
+			port.CertificateDomain = v.(string)
+		}
+
+		if v, ok := data["source_cidrs"]; ok {
+			port.SourceCIDRs = expandStrings(v)
+		}
+
+		if v, ok := data["source_security_groups"]; ok {
+			port.SourceSecurityGroups = expandStrings(v)
+		}
+
 		ports = append(ports, port)
 	}
 
 	return ports
 }
 
+func expandStrings(flattened interface{}) []string {
+	var expanded []string
+	for _, v := range flattened.([]interface{}) {
+		expanded = append(expanded, v.(string))
+	}
+
+	return expanded
+}
+
 func flattenPorts(ports []models.Port) []map[string]interface{} {
 	flattened := []map[string]interface{}{}
 
@@ -320,7 +385,17 @@ func flattenPorts(ports []models.Port) []map[string]interface{} {
 			"protocol":       port.Protocol,
 		}
 
-		if port.CertificateARN != "" {
+		if len(port.SourceCIDRs) > 0 {
+			data["source_cidrs"] = port.SourceCIDRs
+		}
+
+		if len(port.SourceSecurityGroups) > 0 {
+			data["source_security_groups"] = port.SourceSecurityGroups
+		}
+
+		if port.CertificateDomain != "" {
+			data["certificate_domain"] = port.CertificateDomain
+		} else if port.CertificateARN != "" {
 			data["certificate"] = port.CertificateARN
 		} else if port.CertificateName != "" {
 			data["certificate"] = port.CertificateName
@@ -331,3 +406,29 @@ func flattenPorts(ports []models.Port) []map[string]interface{} {
 
 	return flattened
 }
+
+// the api only reports a listener's certificate by arn, so ports configured
+// with a certificate domain keep that domain instead of the resolved arn
+func preserveCertificateDomains(ports []models.Port, d *schema.ResourceData) []models.Port {
+	v, ok := d.GetOk("port")
+	if !ok {
+		return ports
+	}
+
+	domains := map[int64]string{}
+	for _, port := range expandPorts(v.(*schema.Set).List()) {
+		if port.CertificateDomain != "" {
+			domains[port.HostPort] = port.CertificateDomain
+		}
+	}
+
+	for i, port := range ports {
+		if domain, ok := domains[port.HostPort]; ok && port.CertificateARN != "" {
+			ports[i].CertificateARN = ""
+			ports[i].CertificateName = ""
+			ports[i].CertificateDomain = domain
+		}
+	}
+
+	return ports
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/testutils"
 )
 
 func TestLoadBalancerCreate_defaults(t *testing.T) {
@@ -22,7 +23,7 @@ func TestLoadBalancerCreate_defaults(t *testing.T) {
 	}
 
 	mockClient.EXPECT().
-		CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, ports, true, 60, true).
+		CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, ports, true, 60, true, "").
 		Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil)
 
 	mockClient.EXPECT().
@@ -67,7 +68,7 @@ func TestLoadBalancerCreate_specifyPorts(t *testing.T) {
 	}
 
 	mockClient.EXPECT().
-		CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, ports, false, 60, true).
+		CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, ports, false, 60, true, "").
 		Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil)
 
 	mockClient.EXPECT().
@@ -93,7 +94,7 @@ func TestLoadBalancerCreate_specifyHealthCheck(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockClient.EXPECT().
-		CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"HTTP:80/admin/healthcheck", 25, 10, 4, 3}, []models.Port{}, true, 60, true).
+		CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"HTTP:80/admin/healthcheck", 25, 10, 4, 3}, []models.Port{}, true, 60, true, "").
 		Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil)
 
 	mockClient.EXPECT().
@@ -124,7 +125,7 @@ func TestLoadBalancerCreate_specifyCrossZone(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockClient.EXPECT().
-		CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, []models.Port{}, true, 60, false).
+		CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, []models.Port{}, true, 60, false, "").
 		Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil)
 
 	mockClient.EXPECT().
@@ -162,13 +163,51 @@ func TestLoadBalancerRead(t *testing.T) {
 	}
 }
 
+func TestLoadBalancerRead_certificateDomain(t *testing.T) {
+	ctrl, mockClient, provider := setupUnitTest(t)
+	defer ctrl.Finish()
+
+	loadBalancer := &models.LoadBalancer{
+		LoadBalancerID: "lbid",
+		Ports: []models.Port{
+			{
+				HostPort:       443,
+				ContainerPort:  80,
+				Protocol:       "https",
+				CertificateARN: "arn:aws:acm:us-west-2:12345:certificate/uuid",
+			},
+		},
+	}
+
+	mockClient.EXPECT().
+		GetLoadBalancer("lbid").
+		Return(loadBalancer, nil)
+
+	ports := []models.Port{
+		{HostPort: 443, ContainerPort: 80, Protocol: "https", CertificateDomain: "example.com"},
+	}
+
+	loadBalancerResource := provider.ResourcesMap["layer0_load_balancer"]
+	d := schema.TestResourceDataRaw(t, loadBalancerResource.Schema, map[string]interface{}{
+		"port": flattenPorts(ports),
+	})
+	d.SetId("lbid")
+
+	client := &Layer0Client{API: mockClient}
+	if err := loadBalancerResource.Read(d, client); err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, expandPorts(d.Get("port").(*schema.Set).List()), ports)
+}
+
 func TestLoadBalancerUpdate_ports(t *testing.T) {
 	ctrl, mockClient, provider := setupUnitTest(t)
 	defer ctrl.Finish()
 
 	gomock.InOrder(
 		mockClient.EXPECT().
-			CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, []models.Port{}, true, 60, true).
+			CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, []models.Port{}, true, 60, true, "").
 			Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil),
 
 		mockClient.EXPECT().
@@ -176,7 +215,7 @@ func TestLoadBalancerUpdate_ports(t *testing.T) {
 			Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil),
 
 		mockClient.EXPECT().
-			UpdateLoadBalancerPorts("lbid", []models.Port{{"", "", 80, 80, "http"}}).
+			UpdateLoadBalancerPorts("lbid", []models.Port{{HostPort: 80, ContainerPort: 80, Protocol: "http"}}).
 			Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil),
 
 		// The Idle Timeout is set when the load balancer is first created
@@ -228,7 +267,7 @@ func TestLoadBalancerUpdate_healthCheck(t *testing.T) {
 
 	gomock.InOrder(
 		mockClient.EXPECT().
-			CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, []models.Port{}, true, 60, true).
+			CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, []models.Port{}, true, 60, true, "").
 			Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil),
 
 		mockClient.EXPECT().
@@ -288,7 +327,7 @@ func TestLoadBalancerUpdate_idleTimeout(t *testing.T) {
 
 	gomock.InOrder(
 		mockClient.EXPECT().
-			CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, []models.Port{}, true, 95, true).
+			CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, []models.Port{}, true, 95, true, "").
 			Return(&models.LoadBalancer{
 				LoadBalancerID: "lbid"}, nil),
 
@@ -339,7 +378,7 @@ func TestLoadBalancerUpdate_crossZone(t *testing.T) {
 
 	gomock.InOrder(
 		mockClient.EXPECT().
-			CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, []models.Port{}, true, 60, true).
+			CreateLoadBalancer("test-lb", "test-env", models.HealthCheck{"TCP:80", 30, 5, 2, 2}, []models.Port{}, true, 60, true, "").
 			Return(&models.LoadBalancer{LoadBalancerID: "lbid"}, nil),
 
 		mockClient.EXPECT().
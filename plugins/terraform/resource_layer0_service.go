@@ -41,6 +41,7 @@ func resourceLayer0Service() *schema.Resource {
 				Optional: true,
 				Default:  1,
 			},
+			"tags": tagsSchema(),
 		},
 	}
 }
@@ -62,6 +63,10 @@ func resourceLayer0ServiceCreate(d *schema.ResourceData, meta interface{}) error
 	// set id first to tell terraform resource has been created
 	d.SetId(service.ServiceID)
 
+	if err := updateTags(client, d, "service"); err != nil {
+		return err
+	}
+
 	if scale != 1 {
 		if _, err := client.API.ScaleService(service.ServiceID, scale); err != nil {
 			return err
@@ -94,6 +99,7 @@ func resourceLayer0ServiceRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("name", service.ServiceName)
 	d.Set("load_balancer", service.LoadBalancerID)
 	d.Set("scale", service.DesiredCount)
+	d.Set("tags", service.Tags)
 
 	for _, deployment := range service.Deployments {
 		if deployment.Status == "PRIMARY" {
@@ -124,6 +130,10 @@ func resourceLayer0ServiceUpdate(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
+	if err := updateTags(client, d, "service"); err != nil {
+		return err
+	}
+
 	if err := waitForDeploymentWithContext(client, serviceID); err != nil {
 		return err
 	}
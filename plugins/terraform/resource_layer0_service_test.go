@@ -46,6 +46,10 @@ func TestServiceCreate_specifyOptional(t *testing.T) {
 		CreateService("test-svc", "test-env", "test-dep", "test-lb").
 		Return(&models.Service{ServiceID: "sid"}, nil)
 
+	mockClient.EXPECT().
+		CreateTag("service", "sid", "team", "payments").
+		Return(nil)
+
 	mockClient.EXPECT().
 		ScaleService("sid", 2).
 		Return(&models.Service{ServiceID: "sid"}, nil)
@@ -65,6 +69,7 @@ func TestServiceCreate_specifyOptional(t *testing.T) {
 		"deploy":        "test-dep",
 		"load_balancer": "test-lb",
 		"scale":         2,
+		"tags":          map[string]interface{}{"team": "payments"},
 	})
 
 	client := &Layer0Client{API: mockClient, StopContext: context.Background()}
@@ -69,6 +69,44 @@ func suppressEquivalentDockerrunDiffs(k, old, new string, d *schema.ResourceData
 	return reflect.DeepEqual(oldDockerrun, newDockerrun)
 }
 
+// tagsSchema is the schema of the user-defined tags on a resource; see updateTags
+func tagsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+// updateTags creates, updates, and deletes the entity's user-defined tags to match the 'tags' attribute
+func updateTags(client *Layer0Client, d *schema.ResourceData, entityType string) error {
+	if !d.HasChange("tags") {
+		return nil
+	}
+
+	o, n := d.GetChange("tags")
+	oldTags := o.(map[string]interface{})
+	newTags := n.(map[string]interface{})
+
+	for key := range oldTags {
+		if _, ok := newTags[key]; !ok {
+			if err := client.API.DeleteTag(entityType, d.Id(), key); err != nil {
+				return err
+			}
+		}
+	}
+
+	for key, value := range newTags {
+		if oldValue, ok := oldTags[key]; !ok || oldValue != value {
+			if err := client.API.CreateTag(entityType, d.Id(), key, value.(string)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 func waitForJobWithContext(client *Layer0Client, jobID string) error {
 	result := make(chan error, 1)
 	go func() { result <- client.API.WaitForJob(jobID, defaultTimeout) }()
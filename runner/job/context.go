@@ -1,6 +1,7 @@
 package job
 
 import (
+	log "github.com/Sirupsen/logrus"
 	"github.com/quintilesims/layer0/api/logic"
 )
 
@@ -12,6 +13,7 @@ type JobContext struct {
 	ServiceLogic      logic.ServiceLogic
 	TaskLogic         logic.TaskLogic
 	EnvironmentLogic  logic.EnvironmentLogic
+	warn              func(error)
 }
 
 func NewJobContext(jobID string, lgc *logic.Logic, request string) *JobContext {
@@ -35,6 +37,7 @@ func (j *JobContext) CreateCopyWithNewRequest(request string) *JobContext {
 		ServiceLogic:      j.ServiceLogic,
 		TaskLogic:         j.TaskLogic,
 		EnvironmentLogic:  j.EnvironmentLogic,
+		warn:              j.warn,
 	}
 }
 
@@ -56,6 +59,15 @@ func (j *JobContext) AddJobMeta(key, val string) error {
 	return j.SetJobMeta(job.Meta)
 }
 
+// Warn logs a non-fatal error and records it on the job's current step
+func (j *JobContext) Warn(err error) {
+	log.Warning(err)
+
+	if j.warn != nil {
+		j.warn(err)
+	}
+}
+
 func (j *JobContext) Request() string {
 	return j.request
 }
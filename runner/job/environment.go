@@ -1,29 +1,65 @@
 package job
 
 import (
+	"encoding/json"
 	"time"
 
 	log "github.com/Sirupsen/logrus"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/types"
 )
 
-var DeleteEnvironmentSteps = []Step{
+func init() {
+	Register(types.DeleteEnvironmentJob, DeleteEnvironmentSteps)
+	Register(types.CreateEnvironmentJob, CreateEnvironmentSteps)
+}
+
+var CreateEnvironmentSteps = []Step{
 	{
-		Name:    "Delete Dependencies",
+		Name:    "Create Environment",
 		Timeout: time.Minute * 15,
-		Action:  Fold(DeleteEnvironmentLoadBalancers, DeleteEnvironmentServices, DeleteEnvironmentTasks),
+		Action:  CreateEnvironment,
+	},
+}
+
+var DeleteEnvironmentSteps = []Step{
+	{
+		Name:      "Delete Dependencies",
+		Timeout:   time.Minute * 15,
+		Action:    Fold(DeleteEnvironmentLoadBalancers, DeleteEnvironmentServices, DeleteEnvironmentTasks),
+		Resumable: true,
 	},
 	{
-		Name:    "Delete Environment",
-		Timeout: time.Minute * 10,
-		Action:  DeleteEnvironment,
+		Name:      "Delete Environment",
+		Timeout:   time.Minute * 10,
+		Action:    DeleteEnvironment,
+		Resumable: true,
 	},
 }
 
+func CreateEnvironment(quit chan bool, context *JobContext) error {
+	var req models.CreateEnvironmentRequest
+	if err := json.Unmarshal([]byte(context.Request()), &req); err != nil {
+		return err
+	}
+
+	// creating an environment is not idempotent, so it is not retried
+	log.Infof("Running Action: CreateEnvironment '%s'", req.EnvironmentName)
+	environment, err := context.EnvironmentLogic.CreateEnvironment(req)
+	if err != nil {
+		return err
+	}
+
+	return runAndRetry(quit, context, time.Second*10, func() error {
+		return context.AddJobMeta("environment_id", environment.EnvironmentID)
+	})
+}
+
 func DeleteEnvironment(quit chan bool, context *JobContext) error {
 	log.Infof("Running Action: DeleteEnvironment")
 	environmentID := context.Request()
 
-	return runAndRetry(quit, time.Second*10, func() error {
+	return runAndRetry(quit, context, time.Second*10, func() error {
 		log.Infof("Running Action: DeleteEnvironment on '%s'", environmentID)
 		return context.EnvironmentLogic.DeleteEnvironment(environmentID)
 	})
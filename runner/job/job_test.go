@@ -1,6 +1,8 @@
 package job
 
 import (
+	"time"
+
 	log "github.com/Sirupsen/logrus"
 )
 
@@ -8,4 +10,5 @@ func init() {
 	log.SetLevel(log.FatalLevel)
 
 	timeMultiplier = 0
+	cancelPollInterval = time.Millisecond
 }
@@ -1,23 +1,58 @@
 package job
 
 import (
+	"encoding/json"
 	"time"
 
 	log "github.com/Sirupsen/logrus"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/types"
 )
 
-var DeleteLoadBalancerSteps = []Step{
+func init() {
+	Register(types.DeleteLoadBalancerJob, DeleteLoadBalancerSteps)
+	Register(types.CreateLoadBalancerJob, CreateLoadBalancerSteps)
+}
+
+var CreateLoadBalancerSteps = []Step{
 	{
-		Name:    "Delete Load Balancer",
+		Name:    "Create Load Balancer",
 		Timeout: time.Minute * 10,
-		Action:  DeleteLoadBalancer,
+		Action:  CreateLoadBalancer,
 	},
 }
 
+var DeleteLoadBalancerSteps = []Step{
+	{
+		Name:      "Delete Load Balancer",
+		Timeout:   time.Minute * 10,
+		Action:    DeleteLoadBalancer,
+		Resumable: true,
+	},
+}
+
+func CreateLoadBalancer(quit chan bool, context *JobContext) error {
+	var req models.CreateLoadBalancerRequest
+	if err := json.Unmarshal([]byte(context.Request()), &req); err != nil {
+		return err
+	}
+
+	// creating a load balancer is not idempotent, so it is not retried
+	log.Infof("Running Action: CreateLoadBalancer '%s'", req.LoadBalancerName)
+	loadBalancer, err := context.LoadBalancerLogic.CreateLoadBalancer(req)
+	if err != nil {
+		return err
+	}
+
+	return runAndRetry(quit, context, time.Second*10, func() error {
+		return context.AddJobMeta("load_balancer_id", loadBalancer.LoadBalancerID)
+	})
+}
+
 func DeleteLoadBalancer(quit chan bool, context *JobContext) error {
 	loadBalancerID := context.Request()
 
-	return runAndRetry(quit, time.Second*10, func() error {
+	return runAndRetry(quit, context, time.Second*10, func() error {
 		log.Infof("Running Action: DeleteLoadBalancer on '%s'", loadBalancerID)
 		return context.LoadBalancerLogic.DeleteLoadBalancer(loadBalancerID)
 	})
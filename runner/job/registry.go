@@ -0,0 +1,29 @@
+package job
+
+import (
+	"fmt"
+
+	"github.com/quintilesims/layer0/common/types"
+)
+
+var registry = map[types.JobType][]Step{}
+
+// Register sets the steps the runner runs for jobs of the specified type.
+// Job types register themselves in the init function of the file that defines their steps.
+func Register(jobType types.JobType, steps []Step) {
+	if _, ok := registry[jobType]; ok {
+		panic(fmt.Sprintf("Job type '%v' is already registered", jobType))
+	}
+
+	registry[jobType] = steps
+}
+
+// GetSteps returns the steps registered for the specified job type
+func GetSteps(jobType types.JobType) ([]Step, error) {
+	steps, ok := registry[jobType]
+	if !ok {
+		return nil, fmt.Errorf("Unknown job type '%v'!", int64(jobType))
+	}
+
+	return steps, nil
+}
@@ -2,6 +2,7 @@ package job
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	log "github.com/Sirupsen/logrus"
@@ -13,15 +14,25 @@ import (
 const (
 	JOB_LOAD_ATTEMPTS       = 10
 	JOB_LOAD_SLEEP_INTERVAL = time.Second * 5
+	MAX_STEP_WARNINGS       = 10
 )
 
 var timeMultiplier time.Duration = 1
 
+// how often a running step checks if its job has been cancelled
+var cancelPollInterval = time.Second * 10
+
+var errJobCancelled = fmt.Errorf("Job was cancelled")
+
 type JobRunner struct {
-	Logic   *logic.Logic
-	Context *JobContext
-	Steps   []Step
-	jobID   string
+	Logic      *logic.Logic
+	Context    *JobContext
+	Steps      []Step
+	jobID      string
+	mutex      sync.Mutex
+	progress   []models.JobStep
+	current    int
+	checkpoint int
 }
 
 func NewJobRunner(logic *logic.Logic, jobID string) *JobRunner {
@@ -31,8 +42,19 @@ func NewJobRunner(logic *logic.Logic, jobID string) *JobRunner {
 	}
 }
 
+// MarkStatus sets the job's status unless the job has already finished or was cancelled,
+// so a cancel that lands while the last step is running is not overwritten
 func (j *JobRunner) MarkStatus(status types.JobStatus) error {
-	return j.Logic.JobStore.UpdateJobStatus(j.jobID, status)
+	updated, err := j.Logic.JobStore.UpdateUnfinishedJobStatus(j.jobID, status)
+	if err != nil {
+		return err
+	}
+
+	if !updated {
+		log.Infof("Not marking job '%s' as %s because it has already finished or was cancelled", j.jobID, status)
+	}
+
+	return nil
 }
 
 func (j *JobRunner) Load() error {
@@ -43,22 +65,18 @@ func (j *JobRunner) Load() error {
 		return err
 	}
 
-	switch types.JobType(job.JobType) {
-	case types.DeleteEnvironmentJob:
-		j.Steps = DeleteEnvironmentSteps
-	case types.DeleteLoadBalancerJob:
-		j.Steps = DeleteLoadBalancerSteps
-	case types.DeleteServiceJob:
-		j.Steps = DeleteServiceSteps
-	case types.DeleteTaskJob:
-		j.Steps = DeleteTaskSteps
-	case types.CreateTaskJob:
-		j.Steps = CreateTaskSteps
-	default:
-		return fmt.Errorf("Unknown job type '%v'!", job.JobType)
+	steps, err := GetSteps(types.JobType(job.JobType))
+	if err != nil {
+		return err
 	}
 
+	j.Steps = steps
 	j.Context = NewJobContext(j.jobID, j.Logic, job.Request)
+	j.Context.warn = j.addWarning
+
+	// resume from the last completed step if a previous runner for this job stopped
+	j.checkpoint = int(job.Checkpoint)
+	j.progress = job.Steps
 	return nil
 }
 
@@ -80,15 +98,51 @@ func (j *JobRunner) tryLoadJob() (*models.Job, error) {
 	return nil, err
 }
 
+// IsCancelled returns true if the job's status has been set to Cancelled
+func (j *JobRunner) IsCancelled() bool {
+	job, err := j.Logic.JobStore.SelectByID(j.jobID)
+	if err != nil {
+		log.Warningf("Failed to check if job '%s' was cancelled: %v", j.jobID, err)
+		return false
+	}
+
+	return types.JobStatus(job.JobStatus) == types.Cancelled
+}
+
 func (j *JobRunner) Run() error {
+	if j.IsCancelled() {
+		log.Infof("Job '%s' was cancelled before it started", j.jobID)
+		return nil
+	}
+
 	if err := j.MarkStatus(types.InProgress); err != nil {
 		return err
 	}
 
-	for _, step := range j.Steps {
+	j.startProgress()
+	for i, step := range j.Steps {
+		if i < j.checkpoint {
+			log.Infof("Skipping step '%s' which completed before the job was resumed", step.Name)
+			continue
+		}
+
+		if j.IsCancelled() {
+			log.Infof("Job '%s' was cancelled before step '%s'", j.jobID, step.Name)
+			return nil
+		}
+
 		log.Infof("Running step '%s'", step.Name)
 
-		if err := j.runStep(step, j.Context); err != nil {
+		j.startStep(i)
+		err := j.runStep(step, j.Context)
+		j.finishStep(i, err)
+
+		if err != nil {
+			if err == errJobCancelled {
+				log.Infof("Job '%s' was cancelled during step '%s'", j.jobID, step.Name)
+				return nil
+			}
+
 			log.Errorf("Error on step '%s': %v", step.Name, err)
 
 			if err := j.MarkStatus(types.Error); err != nil {
@@ -97,24 +151,118 @@ func (j *JobRunner) Run() error {
 
 			return fmt.Errorf("Error on step '%s': %v", step.Name, err)
 		}
+
+		if err := j.Logic.JobStore.SetJobCheckpoint(j.jobID, i+1); err != nil {
+			log.Warningf("Failed to save checkpoint for job '%s': %v", j.jobID, err)
+		}
 	}
 
 	return j.MarkStatus(types.Completed)
 }
 
 func (j *JobRunner) runStep(step Step, context *JobContext) error {
-	var err error
 	quitc := make(chan bool)
 	stepc := make(chan error)
 	go func() { stepc <- step.Action(quitc, context) }()
 
-	select {
-	case err = <-stepc:
-	case <-time.After(step.Timeout):
-		close(quitc)
-		<-stepc
-		err = fmt.Errorf("Timeout reached after %v", step.Timeout)
+	ticker := time.NewTicker(cancelPollInterval)
+	defer ticker.Stop()
+
+	timeout := time.After(step.Timeout)
+	for {
+		select {
+		case err := <-stepc:
+			return err
+		case <-timeout:
+			close(quitc)
+			<-stepc
+			return fmt.Errorf("Timeout reached after %v", step.Timeout)
+		case <-ticker.C:
+			if j.IsCancelled() {
+				close(quitc)
+				<-stepc
+				return errJobCancelled
+			}
+		}
+	}
+}
+
+func (j *JobRunner) startProgress() {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	previous := j.progress
+	j.progress = make([]models.JobStep, len(j.Steps))
+	for i, step := range j.Steps {
+		j.progress[i] = models.JobStep{Name: step.Name, Resumable: step.Resumable}
+
+		// keep the progress of steps completed before the job was resumed
+		if i < j.checkpoint && i < len(previous) {
+			j.progress[i] = previous[i]
+		}
 	}
 
-	return err
+	j.current = -1
+	j.saveProgress()
+}
+
+func (j *JobRunner) startStep(i int) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	j.current = i
+	j.progress[i].TimeStarted = time.Now()
+	j.saveProgress()
+}
+
+func (j *JobRunner) finishStep(i int, err error) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	j.progress[i].TimeFinished = time.Now()
+
+	// the current step stays set if the job stopped on this step
+	if err != nil {
+		j.progress[i].Error = err.Error()
+	} else {
+		j.current = -1
+	}
+
+	j.saveProgress()
+}
+
+// addWarning records a non-fatal error on the current step.
+// Only the most recent MAX_STEP_WARNINGS are kept so long-running retries do not grow the job without bound.
+func (j *JobRunner) addWarning(err error) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	if j.current < 0 || j.current >= len(j.progress) {
+		return
+	}
+
+	step := &j.progress[j.current]
+	warnings := append(step.Warnings, err.Error())
+	if len(warnings) > MAX_STEP_WARNINGS {
+		warnings = warnings[len(warnings)-MAX_STEP_WARNINGS:]
+	}
+
+	step.Warnings = warnings
+	j.saveProgress()
+}
+
+// saveProgress writes the step progress to the job store; the caller must hold the mutex.
+// Failing to save progress is logged but does not fail the job.
+func (j *JobRunner) saveProgress() {
+	var currentStep string
+	if j.current >= 0 {
+		currentStep = j.progress[j.current].Name
+	}
+
+	steps := make([]models.JobStep, len(j.progress))
+	copy(steps, j.progress)
+
+	if err := j.Logic.JobStore.SetJobSteps(j.jobID, currentStep, steps); err != nil {
+		log.Warningf("Failed to save progress for job '%s': %v", j.jobID, err)
+	}
 }
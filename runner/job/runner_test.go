@@ -7,6 +7,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/quintilesims/layer0/api/logic"
+	"github.com/quintilesims/layer0/common/db/job_store"
 	"github.com/quintilesims/layer0/common/db/job_store/mock_job_store"
 	"github.com/quintilesims/layer0/common/models"
 	"github.com/quintilesims/layer0/common/testutils"
@@ -16,7 +17,21 @@ import (
 func getStubbedLogic(ctrl *gomock.Controller) *logic.Logic {
 	mockJobStore := mock_job_store.NewMockJobStore(ctrl)
 	mockJobStore.EXPECT().
-		UpdateJobStatus(gomock.Any(), gomock.Any()).
+		UpdateUnfinishedJobStatus(gomock.Any(), gomock.Any()).
+		Return(true, nil).
+		AnyTimes()
+
+	mockJobStore.EXPECT().
+		SetJobSteps(gomock.Any(), gomock.Any(), gomock.Any()).
+		AnyTimes()
+
+	mockJobStore.EXPECT().
+		SetJobCheckpoint(gomock.Any(), gomock.Any()).
+		AnyTimes()
+
+	mockJobStore.EXPECT().
+		SelectByID(gomock.Any()).
+		Return(&models.Job{JobStatus: int64(types.InProgress)}, nil).
 		AnyTimes()
 
 	return logic.NewLogic(nil, mockJobStore, nil, nil)
@@ -101,6 +116,54 @@ func TestRunnerLoad(t *testing.T) {
 	testutils.RunTests(t, testCases)
 }
 
+func TestRunnerLoad_registeredSteps(t *testing.T) {
+	jobTypes := []types.JobType{
+		types.DeleteEnvironmentJob,
+		types.DeleteServiceJob,
+		types.DeleteLoadBalancerJob,
+		types.DeleteTaskJob,
+		types.CreateTaskJob,
+		types.CreateEnvironmentJob,
+		types.CreateLoadBalancerJob,
+		types.CreateServiceJob,
+		types.UpdateServiceJob,
+	}
+
+	for _, jobType := range jobTypes {
+		ctrl := gomock.NewController(t)
+		mockJobStore := mock_job_store.NewMockJobStore(ctrl)
+		mockJobStore.EXPECT().
+			SelectByID("some_job_id").
+			Return(&models.Job{JobID: "some_job_id", JobType: int64(jobType)}, nil)
+
+		runner := NewJobRunner(logic.NewLogic(nil, mockJobStore, nil, nil), "some_job_id")
+		if err := runner.Load(); err != nil {
+			t.Fatalf("%v: %v", jobType, err)
+		}
+
+		if len(runner.Steps) == 0 {
+			t.Errorf("%v: no steps were loaded", jobType)
+		}
+
+		ctrl.Finish()
+	}
+}
+
+func TestRunnerLoad_unknownJobType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockJobStore := mock_job_store.NewMockJobStore(ctrl)
+	mockJobStore.EXPECT().
+		SelectByID("some_job_id").
+		Return(&models.Job{JobID: "some_job_id", JobType: 0}, nil)
+
+	runner := NewJobRunner(logic.NewLogic(nil, mockJobStore, nil, nil), "some_job_id")
+	if err := runner.Load(); err == nil {
+		t.Fatal("Error was nil!")
+	}
+}
+
 func TestRunnerRun_StepExecution(t *testing.T) {
 	testCases := []testutils.TestCase{
 		{
@@ -198,10 +261,22 @@ func TestRunnerRun_JobStateManagement(t *testing.T) {
 			Name: "Should mark status to InProgress at start of Run",
 			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
 				mockJobStore := mock_job_store.NewMockJobStore(ctrl)
+				mockJobStore.EXPECT().
+					SelectByID(gomock.Any()).
+					Return(&models.Job{JobStatus: int64(types.InProgress)}, nil).
+					AnyTimes()
+
+				mockJobStore.EXPECT().
+					SetJobSteps(gomock.Any(), gomock.Any(), gomock.Any()).
+					AnyTimes()
+
+				mockJobStore.EXPECT().
+					SetJobCheckpoint(gomock.Any(), gomock.Any()).
+					AnyTimes()
 
 				gomock.InOrder(
-					mockJobStore.EXPECT().UpdateJobStatus("some_job_id", types.InProgress),
-					mockJobStore.EXPECT().UpdateJobStatus(gomock.Any(), gomock.Not(types.InProgress)).AnyTimes(),
+					mockJobStore.EXPECT().UpdateUnfinishedJobStatus("some_job_id", types.InProgress).Return(true, nil),
+					mockJobStore.EXPECT().UpdateUnfinishedJobStatus(gomock.Any(), gomock.Not(types.InProgress)).Return(true, nil).AnyTimes(),
 				)
 
 				mockLogic := logic.NewLogic(nil, mockJobStore, nil, nil)
@@ -216,10 +291,22 @@ func TestRunnerRun_JobStateManagement(t *testing.T) {
 			Name: "Should mark status to Completed at end of Run without errors",
 			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
 				mockJobStore := mock_job_store.NewMockJobStore(ctrl)
+				mockJobStore.EXPECT().
+					SelectByID(gomock.Any()).
+					Return(&models.Job{JobStatus: int64(types.InProgress)}, nil).
+					AnyTimes()
+
+				mockJobStore.EXPECT().
+					SetJobSteps(gomock.Any(), gomock.Any(), gomock.Any()).
+					AnyTimes()
+
+				mockJobStore.EXPECT().
+					SetJobCheckpoint(gomock.Any(), gomock.Any()).
+					AnyTimes()
 
 				gomock.InOrder(
-					mockJobStore.EXPECT().UpdateJobStatus(gomock.Any(), gomock.Not(types.Completed)).AnyTimes(),
-					mockJobStore.EXPECT().UpdateJobStatus("some_job_id", types.Completed),
+					mockJobStore.EXPECT().UpdateUnfinishedJobStatus(gomock.Any(), gomock.Not(types.Completed)).Return(true, nil).AnyTimes(),
+					mockJobStore.EXPECT().UpdateUnfinishedJobStatus("some_job_id", types.Completed).Return(true, nil),
 				)
 
 				mockLogic := logic.NewLogic(nil, mockJobStore, nil, nil)
@@ -234,10 +321,22 @@ func TestRunnerRun_JobStateManagement(t *testing.T) {
 			Name: "Should mark status to Error at the end of Run with errors",
 			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
 				mockJobStore := mock_job_store.NewMockJobStore(ctrl)
+				mockJobStore.EXPECT().
+					SelectByID(gomock.Any()).
+					Return(&models.Job{JobStatus: int64(types.InProgress)}, nil).
+					AnyTimes()
+
+				mockJobStore.EXPECT().
+					SetJobSteps(gomock.Any(), gomock.Any(), gomock.Any()).
+					AnyTimes()
+
+				mockJobStore.EXPECT().
+					SetJobCheckpoint(gomock.Any(), gomock.Any()).
+					AnyTimes()
 
 				gomock.InOrder(
-					mockJobStore.EXPECT().UpdateJobStatus(gomock.Any(), gomock.Not(types.Error)).AnyTimes(),
-					mockJobStore.EXPECT().UpdateJobStatus("some_job_id", types.Error),
+					mockJobStore.EXPECT().UpdateUnfinishedJobStatus(gomock.Any(), gomock.Not(types.Error)).Return(true, nil).AnyTimes(),
+					mockJobStore.EXPECT().UpdateUnfinishedJobStatus("some_job_id", types.Error).Return(true, nil),
 				)
 
 				mockLogic := logic.NewLogic(nil, mockJobStore, nil, nil)
@@ -255,3 +354,242 @@ func TestRunnerRun_JobStateManagement(t *testing.T) {
 
 	testutils.RunTests(t, testCases)
 }
+
+func TestRunnerRun_Cancellation(t *testing.T) {
+	testCases := []testutils.TestCase{
+		{
+			Name: "Should not run steps if the job was cancelled before it started",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockJobStore := mock_job_store.NewMockJobStore(ctrl)
+				mockJobStore.EXPECT().
+					SelectByID("some_job_id").
+					Return(&models.Job{JobStatus: int64(types.Cancelled)}, nil)
+
+				mockLogic := logic.NewLogic(nil, mockJobStore, nil, nil)
+				runner := NewJobRunner(mockLogic, "some_job_id")
+				runner.Steps = []Step{stepWithError()}
+
+				return runner
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				runner := target.(*JobRunner)
+
+				if err := runner.Run(); err != nil {
+					reporter.Error(err)
+				}
+			},
+		},
+		{
+			Name: "Should close quit channel and keep Cancelled status when cancelled during a step",
+			Setup: func(reporter *testutils.Reporter, ctrl *gomock.Controller) interface{} {
+				mockJobStore := mock_job_store.NewMockJobStore(ctrl)
+				mockJobStore.EXPECT().
+					UpdateUnfinishedJobStatus("some_job_id", types.InProgress).
+					Return(true, nil)
+
+				mockJobStore.EXPECT().
+					SetJobSteps("some_job_id", gomock.Any(), gomock.Any()).
+					AnyTimes()
+
+				mockJobStore.EXPECT().
+					SetJobCheckpoint("some_job_id", gomock.Any()).
+					AnyTimes()
+
+				gomock.InOrder(
+					mockJobStore.EXPECT().
+						SelectByID("some_job_id").
+						Return(&models.Job{JobStatus: int64(types.InProgress)}, nil).
+						Times(2),
+					mockJobStore.EXPECT().
+						SelectByID("some_job_id").
+						Return(&models.Job{JobStatus: int64(types.Cancelled)}, nil).
+						AnyTimes(),
+				)
+
+				mockLogic := logic.NewLogic(nil, mockJobStore, nil, nil)
+				runner := NewJobRunner(mockLogic, "some_job_id")
+				runner.Context = NewJobContext("some_job_id", mockLogic, "")
+
+				runner.Steps = []Step{
+					{
+						Name:    "long step",
+						Timeout: time.Minute,
+						Action: func(quit chan bool, c *JobContext) error {
+							return runAndRetry(quit, c, time.Minute, func() error {
+								return fmt.Errorf("some error")
+							})
+						},
+					},
+					stepWithError(),
+				}
+
+				return runner
+			},
+			Run: func(reporter *testutils.Reporter, target interface{}) {
+				runner := target.(*JobRunner)
+
+				if err := runner.Run(); err != nil {
+					reporter.Error(err)
+				}
+			},
+		},
+	}
+
+	testutils.RunTests(t, testCases)
+}
+
+func TestRunnerRun_cancelledDuringLastStep(t *testing.T) {
+	jobStore := job_store.NewMemoryJobStore()
+	if err := jobStore.Insert(&models.Job{JobID: "some_job_id", JobStatus: int64(types.Pending)}); err != nil {
+		t.Fatal(err)
+	}
+
+	runner := NewJobRunner(logic.NewLogic(nil, jobStore, nil, nil), "some_job_id")
+	runner.Context = NewJobContext("some_job_id", runner.Logic, "")
+	runner.Steps = []Step{
+		{
+			Name:    "step1",
+			Timeout: time.Second * 1,
+			Action: func(chan bool, *JobContext) error {
+				// the cancel lands after the step's last check for it
+				return jobStore.UpdateJobStatus("some_job_id", types.Cancelled)
+			},
+		},
+	}
+
+	if err := runner.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	job, err := jobStore.SelectByID("some_job_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, types.JobStatus(job.JobStatus), types.Cancelled)
+}
+
+func TestRunnerRun_StepProgress(t *testing.T) {
+	jobStore := job_store.NewMemoryJobStore()
+	if err := jobStore.Insert(&models.Job{JobID: "some_job_id", JobStatus: int64(types.Pending)}); err != nil {
+		t.Fatal(err)
+	}
+
+	runner := NewJobRunner(logic.NewLogic(nil, jobStore, nil, nil), "some_job_id")
+	runner.Context = NewJobContext("some_job_id", runner.Logic, "")
+	runner.Context.warn = runner.addWarning
+
+	runner.Steps = []Step{
+		{
+			Name:      "step1",
+			Timeout:   time.Second * 1,
+			Action:    func(chan bool, *JobContext) error { return nil },
+			Resumable: true,
+		},
+		{
+			Name:    "step2",
+			Timeout: time.Second * 1,
+			Action: func(quit chan bool, c *JobContext) error {
+				for i := 0; i < MAX_STEP_WARNINGS+1; i++ {
+					c.Warn(fmt.Errorf("warning %d", i))
+				}
+
+				return fmt.Errorf("some error")
+			},
+		},
+		{
+			Name:    "step3",
+			Timeout: time.Second * 1,
+			Action:  func(chan bool, *JobContext) error { return nil },
+		},
+	}
+
+	if err := runner.Run(); err == nil {
+		t.Fatal("Error was nil!")
+	}
+
+	job, err := jobStore.SelectByID("some_job_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, types.JobStatus(job.JobStatus), types.Error)
+	testutils.AssertEqual(t, job.CurrentStep, "step2")
+	testutils.AssertEqual(t, len(job.Steps), 3)
+
+	step1 := job.Steps[0]
+	testutils.AssertEqual(t, step1.Name, "step1")
+	testutils.AssertEqual(t, step1.Error, "")
+	testutils.AssertEqual(t, step1.Resumable, true)
+	if step1.TimeStarted.IsZero() || step1.TimeFinished.Before(step1.TimeStarted) {
+		t.Errorf("step1 has invalid times: %v - %v", step1.TimeStarted, step1.TimeFinished)
+	}
+
+	step2 := job.Steps[1]
+	testutils.AssertEqual(t, step2.Error, "some error")
+	testutils.AssertEqual(t, step2.Resumable, false)
+	testutils.AssertEqual(t, len(step2.Warnings), MAX_STEP_WARNINGS)
+	testutils.AssertEqual(t, step2.Warnings[0], "warning 1")
+
+	step3 := job.Steps[2]
+	testutils.AssertEqual(t, step3.TimeStarted.IsZero(), true)
+}
+
+func TestRunnerRun_resumesFromCheckpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	started := time.Now().Add(-time.Hour)
+	previous := []models.JobStep{
+		{Name: "step1", TimeStarted: started, TimeFinished: started.Add(time.Minute)},
+		{Name: "step2", TimeStarted: started.Add(time.Minute)},
+	}
+
+	jobStore := job_store.NewMemoryJobStore()
+	job := &models.Job{
+		JobID:      "some_job_id",
+		JobType:    int64(types.DeleteTaskJob),
+		JobStatus:  int64(types.InProgress),
+		Checkpoint: 1,
+		Steps:      previous,
+	}
+
+	if err := jobStore.Insert(job); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := testutils.NewRecorder(ctrl)
+	recorder.EXPECT().Call("step2")
+
+	runner := NewJobRunner(logic.NewLogic(nil, jobStore, nil, nil), "some_job_id")
+	if err := runner.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	runner.Steps = []Step{
+		{
+			Name:    "step1",
+			Timeout: time.Second * 1,
+			Action: func(chan bool, *JobContext) error {
+				recorder.Call("step1")
+				return nil
+			},
+		},
+		{
+			Name:    "step2",
+			Timeout: time.Second * 1,
+			Action: func(chan bool, *JobContext) error {
+				recorder.Call("step2")
+				return nil
+			},
+		},
+	}
+
+	if err := runner.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.AssertEqual(t, types.JobStatus(job.JobStatus), types.Completed)
+	testutils.AssertEqual(t, job.Checkpoint, int64(2))
+	testutils.AssertEqual(t, job.Steps[0], previous[0])
+}
@@ -1,24 +1,82 @@
 package job
 
 import (
+	"encoding/json"
 	"time"
 
 	log "github.com/Sirupsen/logrus"
+	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/types"
 )
 
-var DeleteServiceSteps = []Step{
+func init() {
+	Register(types.DeleteServiceJob, DeleteServiceSteps)
+	Register(types.CreateServiceJob, CreateServiceSteps)
+	Register(types.UpdateServiceJob, UpdateServiceSteps)
+}
+
+var CreateServiceSteps = []Step{
 	{
-		Name:    "Delete Service",
+		Name:    "Create Service",
 		Timeout: time.Minute * 10,
-		Action:  DeleteService,
+		Action:  CreateService,
+	},
+}
+
+var UpdateServiceSteps = []Step{
+	{
+		Name:      "Update Service",
+		Timeout:   time.Minute * 10,
+		Action:    UpdateService,
+		Resumable: true,
+	},
+}
+
+var DeleteServiceSteps = []Step{
+	{
+		Name:      "Delete Service",
+		Timeout:   time.Minute * 10,
+		Action:    DeleteService,
+		Resumable: true,
 	},
 }
 
 func DeleteService(quit chan bool, context *JobContext) error {
 	serviceID := context.Request()
 
-	return runAndRetry(quit, time.Second*10, func() error {
+	return runAndRetry(quit, context, time.Second*10, func() error {
 		log.Infof("Running Action: DeleteService on '%s'", serviceID)
 		return context.ServiceLogic.DeleteService(serviceID)
 	})
 }
+
+func CreateService(quit chan bool, context *JobContext) error {
+	var req models.CreateServiceRequest
+	if err := json.Unmarshal([]byte(context.Request()), &req); err != nil {
+		return err
+	}
+
+	// creating a service is not idempotent, so it is not retried
+	log.Infof("Running Action: CreateService '%s'", req.ServiceName)
+	service, err := context.ServiceLogic.CreateService(req)
+	if err != nil {
+		return err
+	}
+
+	return runAndRetry(quit, context, time.Second*10, func() error {
+		return context.AddJobMeta("service_id", service.ServiceID)
+	})
+}
+
+func UpdateService(quit chan bool, context *JobContext) error {
+	var req models.UpdateServiceJobRequest
+	if err := json.Unmarshal([]byte(context.Request()), &req); err != nil {
+		return err
+	}
+
+	return runAndRetry(quit, context, time.Second*10, func() error {
+		log.Infof("Running Action: UpdateService on '%s'", req.ServiceID)
+		_, err := context.ServiceLogic.UpdateService(req.ServiceID, models.UpdateServiceRequest{DeployID: req.DeployID})
+		return err
+	})
+}
@@ -5,7 +5,6 @@ import (
 	"sync"
 	"time"
 
-	log "github.com/Sirupsen/logrus"
 	"github.com/quintilesims/layer0/common/errors"
 )
 
@@ -15,6 +14,9 @@ type Step struct {
 	Name    string
 	Timeout time.Duration
 	Action  Action
+	// Resumable steps are safe to run again if their runner stops part way through.
+	// Steps that create resources are not, since running them again creates duplicates.
+	Resumable bool
 }
 
 // Fold takes a slice of Actions and runs them async
@@ -39,15 +41,19 @@ func Fold(actions ...Action) Action {
 	}
 }
 
-func runAndRetry(quit chan bool, interval time.Duration, fn func() error) error {
+func runAndRetry(quit chan bool, context *JobContext, interval time.Duration, fn func() error) error {
 	for {
 		select {
 		default:
 			if err := fn(); err != nil {
-				// todo: track errors and return them when quit is called
-				log.Warning(err)
-				time.Sleep(interval)
-				continue
+				context.Warn(err)
+
+				select {
+				case <-time.After(interval):
+					continue
+				case <-quit:
+					return fmt.Errorf("Quit signalled")
+				}
 			}
 
 			return nil
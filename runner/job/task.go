@@ -7,13 +7,20 @@ import (
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/quintilesims/layer0/common/models"
+	"github.com/quintilesims/layer0/common/types"
 )
 
+func init() {
+	Register(types.DeleteTaskJob, DeleteTaskSteps)
+	Register(types.CreateTaskJob, CreateTaskSteps)
+}
+
 var DeleteTaskSteps = []Step{
 	{
-		Name:    "Delete Task",
-		Timeout: time.Minute * 10,
-		Action:  DeleteTask,
+		Name:      "Delete Task",
+		Timeout:   time.Minute * 10,
+		Action:    DeleteTask,
+		Resumable: true,
 	},
 }
 
@@ -28,7 +35,7 @@ var CreateTaskSteps = []Step{
 func DeleteTask(quit chan bool, context *JobContext) error {
 	taskID := context.Request()
 
-	return runAndRetry(quit, time.Second*10, func() error {
+	return runAndRetry(quit, context, time.Second*10, func() error {
 		log.Infof("Running Action: DeleteTask on '%s'", taskID)
 		return context.TaskLogic.DeleteTask(taskID)
 	})
@@ -40,7 +47,7 @@ func CreateTask(quit chan bool, context *JobContext) error {
 		return err
 	}
 
-	if err := runAndRetry(quit, time.Second*10, func() error {
+	if err := runAndRetry(quit, context, time.Second*10, func() error {
 		log.Infof("Running Action: CreateTask '%s'", createTaskRequest.TaskName)
 		taskID, err := context.TaskLogic.CreateTask(createTaskRequest)
 		if err != nil {
@@ -48,7 +55,7 @@ func CreateTask(quit chan bool, context *JobContext) error {
 			return err
 		}
 
-		return runAndRetry(quit, time.Second*10, func() error {
+		return runAndRetry(quit, context, time.Second*10, func() error {
 			key := fmt.Sprintf("task_id")
 			return context.AddJobMeta(key, taskID)
 		})
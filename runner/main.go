@@ -3,7 +3,6 @@ package main
 import (
 	"os"
 	"strings"
-	"time"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/quintilesims/layer0/common/aws/provider"
@@ -92,19 +91,19 @@ func Run(c *cli.Context) {
 	}
 
 	credProvider := provider.NewExplicitCredProvider(c.String("access_key"), c.String("secret_key"))
-	backend, err := startup.GetBackend(credProvider, c.String("region"))
+
+	rateLimiter, err := startup.GetRateLimiter()
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	rateLimit, err := time.ParseDuration(config.AWSTimeBetweenRequests())
+	provider.SetRateLimiter(rateLimiter)
+
+	backend, err := startup.GetBackend(credProvider, c.String("region"))
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	provider.ResetRateLimiter(rateLimit)
-	defer provider.StopRateLimiter()
-
 	logic, err := startup.GetLogic(backend)
 	if err != nil {
 		log.Fatal(err)
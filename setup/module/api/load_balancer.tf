@@ -65,7 +65,7 @@ resource "aws_elb" "api" {
     healthy_threshold   = 2
     unhealthy_threshold = 2
     timeout             = 5
-    target              = "HTTP:80/health"
+    target              = "HTTP:80/health/ping"
     interval            = 6
   }
 }
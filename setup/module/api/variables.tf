@@ -16,6 +16,11 @@ variable "ssh_key_pair" {}
 
 variable "dockercfg" {}
 
+variable "route53_hosted_zone_id" {
+  description = "optional - id of the hosted zone load balancer dns records are created in"
+  default     = ""
+}
+
 variable "tags" {
   description = "A map of tags to add to all resources"
   default     = {}
@@ -23,13 +28,16 @@ variable "tags" {
 
 variable "group_policies" {
   default = [
+    "acm",
     "autoscaling",
+    "cloudwatch",
     "dynamodb",
     "ec2",
     "ecs",
     "elb",
     "iam",
     "logs",
+    "route53",
     "s3",
   ]
 }
@@ -34,6 +34,8 @@ module "api" {
   ssh_key_pair = "${var.ssh_key_pair}"
   dockercfg    = "${var.dockercfg}"
 
+  route53_hosted_zone_id = "${var.route53_hosted_zone_id}"
+
   tags {
     "layer0" = "${var.name}"
   }
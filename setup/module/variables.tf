@@ -16,6 +16,11 @@ variable "username" {}
 
 variable "password" {}
 
+variable "route53_hosted_zone_id" {
+  description = "optional - id of the hosted zone load balancer dns records are created in"
+  default     = ""
+}
+
 variable "vpc_id" {
   description = "optional - use an empty string to provision a new vpc"
   type        = "string"
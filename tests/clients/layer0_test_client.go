@@ -34,7 +34,7 @@ func (l *Layer0TestClient) CreateTask(taskName, environmentID, deployID string,
 }
 
 func (l *Layer0TestClient) CreateEnvironment(name string) *models.Environment {
-	environment, err := l.Client.CreateEnvironment(name, "m3.medium", 0, nil, "linux", "")
+	environment, err := l.Client.CreateEnvironment(name, "m3.medium", 0, nil, "linux", "", "", nil, nil)
 	if err != nil {
 		l.T.Fatal(err)
 	}
@@ -62,7 +62,7 @@ func (l *Layer0TestClient) CreateLoadBalancer(name, environmentID string) *model
 
 	ports := []models.Port{{HostPort: 80, ContainerPort: 80, Protocol: "http"}}
 
-	loadBalancer, err := l.Client.CreateLoadBalancer(name, environmentID, hc, ports, true)
+	loadBalancer, err := l.Client.CreateLoadBalancer(name, environmentID, hc, ports, true, 60, true, "")
 	if err != nil {
 		l.T.Fatal(err)
 	}
@@ -179,7 +179,7 @@ func (l *Layer0TestClient) ScaleService(id string, scale int) *models.Service {
 }
 
 func (l *Layer0TestClient) CreateLink(id1, id2 string) {
-	if err := l.Client.CreateLink(id1, id2); err != nil {
+	if err := l.Client.CreateLink(id1, id2, nil, false); err != nil {
 		l.T.Fatal(err)
 	}
 }